@@ -141,4 +141,22 @@ describe('CrashDialog', () => {
       expect(buttons.length).toBe(2);
     });
   });
+
+  describe('crash loop', () => {
+    it('offers the report instead of enabling logging', () => {
+      const { getByText, queryByText } = render(CrashDialog, { props: { visible: true, crashLoop: true } });
+      expect(getByText('Bericht kopieren')).toBeTruthy();
+      expect(queryByText('Logging aktivieren')).toBeNull();
+    });
+
+    it('dispatches copyReport on the button and on Enter', async () => {
+      const { getByText, component } = render(CrashDialog, { props: { visible: true, crashLoop: true } });
+      const handler = vi.fn();
+      component.$on('copyReport', handler);
+
+      await fireEvent.click(getByText('Bericht kopieren'));
+      await fireEvent.keyDown(window, { key: 'Enter' });
+      expect(handler).toHaveBeenCalledTimes(2);
+    });
+  });
 });
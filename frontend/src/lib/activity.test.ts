@@ -0,0 +1,27 @@
+import { describe, it, expect } from 'vitest';
+import { tabActivity, activityLabel } from './activity';
+
+describe('tabActivity', () => {
+  it('is idle for a tab without panes or activity', () => {
+    expect(tabActivity([])).toBe('idle');
+    expect(tabActivity(['idle', 'idle'])).toBe('idle');
+  });
+
+  it('ranks needsInput over done over active over idle', () => {
+    expect(tabActivity(['idle', 'active'])).toBe('active');
+    expect(tabActivity(['active', 'done', 'idle'])).toBe('done');
+    expect(tabActivity(['done', 'needsInput', 'active'])).toBe('needsInput');
+  });
+
+  it('treats a password prompt like any other input request', () => {
+    expect(tabActivity(['done', 'passwordInput'])).toBe('passwordInput');
+    expect(tabActivity(['needsInput', 'passwordInput'])).toBe('needsInput');
+  });
+});
+
+describe('activityLabel', () => {
+  it('has no tooltip while idle', () => {
+    expect(activityLabel('idle')).toBe('');
+    expect(activityLabel('needsInput')).toContain('Eingabe');
+  });
+});
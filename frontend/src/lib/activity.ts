@@ -0,0 +1,30 @@
+/**
+ * Pane activity as reported by the backend (terminal:activity), and its
+ * aggregation into the indicator a tab shows for its panes.
+ */
+
+export type PaneActivity = 'idle' | 'active' | 'done' | 'needsInput' | 'passwordInput';
+
+// Higher wins: a pane waiting for the user outranks a finished one, which
+// outranks one that is still working.
+const PRIORITY: Record<PaneActivity, number> = { idle: 0, active: 1, done: 2, needsInput: 3, passwordInput: 3 };
+
+/** The activity a tab shows: the highest-priority state among its panes. */
+export function tabActivity(activities: PaneActivity[]): PaneActivity {
+  let best: PaneActivity = 'idle';
+  for (const a of activities) {
+    if (PRIORITY[a] > PRIORITY[best]) best = a;
+  }
+  return best;
+}
+
+/** German tooltip for a tab's activity. */
+export function activityLabel(a: PaneActivity): string {
+  switch (a) {
+    case 'needsInput': return 'Ein Pane wartet auf Eingabe';
+    case 'passwordInput': return 'Ein Pane wartet auf ein Passwort';
+    case 'done': return 'Ein Pane ist fertig';
+    case 'active': return 'Läuft';
+    default: return '';
+  }
+}
@@ -1,5 +1,8 @@
+import { get } from 'svelte/store';
 import { ClipboardGetText, ClipboardSetText } from '../../wailsjs/runtime/runtime';
 import { encodeForPty } from './claude';
+import { planPaste, pasteConfirmText, type PasteSafety } from './paste';
+import { config } from '../stores/config';
 import * as App from '../../wailsjs/go/backend/App';
 import type { Terminal } from '@xterm/xterm';
 
@@ -11,16 +14,42 @@ function bracketForPaste(text: string, terminal: Terminal | null): string {
   return text;
 }
 
+/**
+ * Paste text into a PTY session, applying the configured paste safety:
+ * trailing newlines may be stripped, and risky pastes need a confirm.
+ */
+export function pasteText(sessionId: number, text: string, terminal: Terminal | null = null): void {
+  const cfg = get(config);
+  const bracketed = !!terminal?.modes?.bracketedPasteMode;
+  const plan = planPaste(text, bracketed, (cfg.paste_safety ?? 'off') as PasteSafety, !!cfg.paste_warn_dangerous);
+  if (!plan.text) return;
+  if (plan.warnings.length > 0 && !confirm(pasteConfirmText(plan.warnings))) return;
+  App.PasteToSession(sessionId, encodeForPty(bracketForPaste(plan.text, terminal)));
+}
+
 /** Read clipboard and write its content to the given PTY session. */
 export async function pasteToSession(sessionId: number, terminal: Terminal | null = null): Promise<void> {
   try {
     const text = await ClipboardGetText();
-    if (text) App.WriteToSession(sessionId, encodeForPty(bracketForPaste(text, terminal)));
+    if (text) pasteText(sessionId, text, terminal);
   } catch (err) {
     console.error('[clipboard] paste failed:', err);
   }
 }
 
+/**
+ * Save the image in the clipboard (e.g. a screenshot) as a file and type its
+ * path into the session, so Claude can read it. Errors, such as no image on
+ * the clipboard, are shown to the user.
+ */
+export async function pasteImageToSession(sessionId: number): Promise<void> {
+  try {
+    await App.PasteClipboardImageToSession(sessionId);
+  } catch (err: any) {
+    alert(`Bild konnte nicht eingefügt werden: ${err?.message || err}`);
+  }
+}
+
 /** Copy the current terminal selection to clipboard, return true if copied. */
 export function copySelection(terminal: Terminal): boolean {
   if (terminal.hasSelection()) {
@@ -31,6 +60,16 @@ export function copySelection(terminal: Terminal): boolean {
   return false;
 }
 
+/** Copy a session's scrollback and screen as plain text (see App.ExportSession). */
+export async function copySessionOutput(sessionId: number): Promise<void> {
+  try {
+    const text = await App.ExportSession(sessionId, false);
+    if (text) await ClipboardSetText(text);
+  } catch (err) {
+    console.error('[clipboard] export failed:', err);
+  }
+}
+
 /** Encode and write arbitrary text to a PTY session. */
 export function writeTextToSession(sessionId: number, text: string): void {
   App.WriteToSession(sessionId, encodeForPty(text));
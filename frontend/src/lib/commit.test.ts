@@ -0,0 +1,31 @@
+import { describe, it, expect } from 'vitest';
+import { commitSeverity } from './commit';
+
+const esc = { minutes: 30, warning: 2, danger: 3 };
+
+describe('commitSeverity', () => {
+  it('is empty outside a repository', () => {
+    expect(commitSeverity(-1, esc)).toBe('');
+  });
+
+  it('escalates at 1x, the warning and the danger factor', () => {
+    expect(commitSeverity(0, esc)).toBe('ok');
+    expect(commitSeverity(29, esc)).toBe('ok');
+    expect(commitSeverity(30, esc)).toBe('info');
+    expect(commitSeverity(59, esc)).toBe('info');
+    expect(commitSeverity(60, esc)).toBe('warning');
+    expect(commitSeverity(89, esc)).toBe('warning');
+    expect(commitSeverity(90, esc)).toBe('danger');
+    expect(commitSeverity(600, esc)).toBe('danger');
+  });
+
+  it('honours custom factors', () => {
+    const quick = { minutes: 10, warning: 1.5, danger: 1.5 };
+    expect(commitSeverity(14, quick)).toBe('info');
+    expect(commitSeverity(15, quick)).toBe('danger');
+  });
+
+  it('never escalates with the reminder disabled', () => {
+    expect(commitSeverity(10000, { ...esc, minutes: 0 })).toBe('ok');
+  });
+});
@@ -0,0 +1,27 @@
+/**
+ * Commit reminder: how urgent the footer's "last commit" badge is, as a
+ * multiple of commit_reminder_minutes.
+ */
+
+/** '' without a repository, 'ok' below the reminder, then escalating. */
+export type CommitSeverity = '' | 'ok' | 'info' | 'warning' | 'danger';
+
+export interface CommitEscalation {
+  minutes: number; // commit_reminder_minutes; 0 disables the reminder
+  warning: number; // factor of minutes
+  danger: number;
+}
+
+/**
+ * Severity for a commit ageMinutes old (-1 = no repository): info once the
+ * reminder is due, warning and danger at their multiples of it.
+ */
+export function commitSeverity(ageMinutes: number, esc: CommitEscalation): CommitSeverity {
+  if (ageMinutes < 0) return '';
+  if (esc.minutes <= 0) return 'ok';
+  const ratio = ageMinutes / esc.minutes;
+  if (ratio >= esc.danger) return 'danger';
+  if (ratio >= esc.warning) return 'warning';
+  if (ratio >= 1) return 'info';
+  return 'ok';
+}
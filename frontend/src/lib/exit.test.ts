@@ -0,0 +1,23 @@
+import { describe, it, expect } from 'vitest';
+import { isCrash, exitMessage, type PaneExit } from './exit';
+
+const exit = (reason: PaneExit['reason'], code = 0, signal = ''): PaneExit => ({ reason, code, signal });
+
+describe('isCrash', () => {
+  it('flags nonzero exits and signals only', () => {
+    expect(isCrash(exit('error', 2))).toBe(true);
+    expect(isCrash(exit('signaled', -1, 'SIGSEGV'))).toBe(true);
+    expect(isCrash(exit('normal'))).toBe(false);
+    expect(isCrash(exit('killed'))).toBe(false);
+    expect(isCrash(null)).toBe(false);
+  });
+});
+
+describe('exitMessage', () => {
+  it('names the code or signal', () => {
+    expect(exitMessage(exit('error', 2))).toBe('Prozess beendet (Exit-Code 2)');
+    expect(exitMessage(exit('signaled', -1, 'SIGSEGV'))).toBe('Prozess abgestürzt (SIGSEGV)');
+    expect(exitMessage(exit('normal'))).toBe('Prozess beendet');
+    expect(exitMessage(null)).toBe('Prozess beendet');
+  });
+});
@@ -0,0 +1,31 @@
+/**
+ * How a pane's process ended, as reported by the backend's terminal:exit
+ * event (id, code, reason, signal).
+ */
+
+export type ExitReason = 'normal' | 'error' | 'killed' | 'signaled';
+
+export interface PaneExit {
+  reason: ExitReason;
+  code: number;
+  signal: string; // e.g. "SIGSEGV"; only for 'signaled'
+}
+
+/** Whether the process ended on its own in a way worth a notification. */
+export function isCrash(exit: PaneExit | null): boolean {
+  return exit?.reason === 'error' || exit?.reason === 'signaled';
+}
+
+/** Overlay text for an exited pane. */
+export function exitMessage(exit: PaneExit | null): string {
+  switch (exit?.reason) {
+    case 'error':
+      return `Prozess beendet (Exit-Code ${exit.code})`;
+    case 'signaled':
+      return `Prozess abgestürzt (${exit.signal || 'Signal'})`;
+    case 'killed':
+      return 'Prozess gestoppt';
+    default:
+      return 'Prozess beendet';
+  }
+}
@@ -0,0 +1,72 @@
+import { describe, it, expect } from 'vitest';
+import { treeEntries, rootEntries, withExpanded, restoreExpanded, type TreeEntry } from './filetree';
+
+const dir = (path: string): TreeEntry => ({ name: path.split('/').pop()!, path, isDir: true });
+const file = (path: string): TreeEntry => ({ name: path.split('/').pop()!, path, isDir: false });
+
+const listing: Record<string, TreeEntry[]> = {
+  '/p': [dir('/p/src'), dir('/p/docs'), file('/p/README.md')],
+  '/p/src': [dir('/p/src/lib'), file('/p/src/main.ts')],
+  '/p/src/lib': [file('/p/src/lib/a.ts')],
+  '/p/docs': [file('/p/docs/x.md')],
+};
+const listDir = async (path: string) => listing[path] ?? null;
+
+describe('withExpanded', () => {
+  it('adds and removes paths without duplicates', () => {
+    let open = withExpanded([], '/p/src', true);
+    open = withExpanded(open, '/p/src', true);
+    expect(open).toEqual(['/p/src']);
+    expect(withExpanded(open, '/p/src', false)).toEqual([]);
+  });
+});
+
+describe('restoreExpanded', () => {
+  it('keeps nested folders open across a refresh', async () => {
+    const expanded = new Set(['/p/src', '/p/src/lib']);
+    const entries = treeEntries(await listDir('/p'));
+    await restoreExpanded(entries, expanded, listDir);
+
+    const src = entries.find((e) => e.path === '/p/src')!;
+    expect(src.expanded).toBe(true);
+    expect(src.children!.find((e) => e.path === '/p/src/lib')!.expanded).toBe(true);
+    expect(entries.find((e) => e.path === '/p/docs')!.expanded).toBe(false);
+
+    // A refresh rebuilds the nodes; the same paths open again
+    const refreshed = treeEntries(await listDir('/p'));
+    await restoreExpanded(refreshed, expanded, listDir);
+    expect(refreshed.find((e) => e.path === '/p/src')!.children!.map((e) => e.path)).toEqual(['/p/src/lib', '/p/src/main.ts']);
+  });
+
+  it('does not open children of a collapsed folder', async () => {
+    const entries = treeEntries(await listDir('/p'));
+    await restoreExpanded(entries, new Set(['/p/src/lib']), listDir);
+    expect(entries.find((e) => e.path === '/p/src')!.loaded).toBe(false);
+  });
+
+  it('skips folders that are gone or fail to list', async () => {
+    const failing = async (path: string) => {
+      if (path === '/p/docs') throw new Error('EACCES');
+      return listDir(path);
+    };
+    const entries = treeEntries(await listDir('/p'));
+    await restoreExpanded(entries, new Set(['/p/gone', '/p/docs']), failing);
+    expect(entries.every((e) => !e.expanded)).toBe(true);
+  });
+});
+
+describe('rootEntries', () => {
+  it('names each root folder by its last path element', () => {
+    const roots = rootEntries(['/p', '/work/api/', 'C:\\code\\web']);
+    expect(roots.map((r) => r.name)).toEqual(['p', 'api', 'web']);
+    expect(roots.every((r) => r.isDir && !r.expanded && !r.loaded)).toBe(true);
+  });
+
+  it('restores the open folders of every root', async () => {
+    const roots = rootEntries(['/p', '/p/docs']);
+    await restoreExpanded(roots, new Set(['/p', '/p/src', '/p/docs']), listDir);
+    expect(roots.map((r) => r.expanded)).toEqual([true, true]);
+    expect(roots[0].children!.find((e) => e.path === '/p/src')!.expanded).toBe(true);
+    expect(roots[1].children!.map((e) => e.path)).toEqual(['/p/docs/x.md']);
+  });
+});
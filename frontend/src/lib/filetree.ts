@@ -0,0 +1,67 @@
+/**
+ * Sidebar file tree state. Open folders are tracked by absolute path, not
+ * by position, so a refresh or a changed directory listing keeps what the
+ * user opened.
+ */
+
+export interface TreeEntry {
+  name: string;
+  path: string;
+  isDir: boolean;
+  gitStatus?: string;
+  more?: number;
+  expanded?: boolean;
+  children?: TreeEntry[];
+  loaded?: boolean;
+}
+
+/** A path as typed into a shell: quoted if it contains spaces. */
+export function shellPath(path: string): string {
+  return path.includes(' ') ? `"${path}"` : path;
+}
+
+export type ListDir = (path: string) => Promise<TreeEntry[] | null>;
+
+/** Fresh, collapsed tree nodes for a directory listing. */
+export function treeEntries(list: TreeEntry[] | null): TreeEntry[] {
+  return (list || []).map((e) => ({ ...e, expanded: false, children: [], loaded: false }));
+}
+
+/**
+ * Collapsed top-level nodes for the sidebar's root folders, used once
+ * workspace folders add roots beside the tab directory.
+ */
+export function rootEntries(roots: string[]): TreeEntry[] {
+  return roots.map((path) => {
+    const parts = path.replace(/[\\/]+$/, '').split(/[\\/]/);
+    const name = parts[parts.length - 1] || path;
+    return { name, path, isDir: true, expanded: false, children: [], loaded: false };
+  });
+}
+
+/** Add (open) or remove (closed) a folder from the list of open paths. */
+export function withExpanded(expanded: string[], path: string, open: boolean): string[] {
+  const rest = expanded.filter((p) => p !== path);
+  return open ? [...rest, path] : rest;
+}
+
+/**
+ * Re-open the folders in expanded, loading their children on demand.
+ * Folders that no longer exist are skipped; a folder that fails to list
+ * stays closed.
+ */
+export async function restoreExpanded(entries: TreeEntry[], expanded: ReadonlySet<string>, listDir: ListDir): Promise<void> {
+  for (const entry of entries) {
+    if (!entry.isDir || !expanded.has(entry.path)) continue;
+    if (!entry.loaded) {
+      try {
+        entry.children = treeEntries(await listDir(entry.path));
+        entry.loaded = true;
+      } catch {
+        continue;
+      }
+    }
+    entry.expanded = true;
+    await restoreExpanded(entry.children || [], expanded, listDir);
+  }
+}
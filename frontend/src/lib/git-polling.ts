@@ -1,10 +1,34 @@
 import * as App from '../../wailsjs/go/backend/App';
 
-export async function fetchBranch(dir: string): Promise<string> {
+export interface GitSummary {
+  branch: string;
+  modified: number;
+  added: number;
+  deleted: number;
+  untracked: number;
+  ahead: number;
+  behind: number;
+}
+
+export const EMPTY_GIT_SUMMARY: GitSummary = {
+  branch: '', modified: 0, added: 0, deleted: 0, untracked: 0, ahead: 0, behind: 0,
+};
+
+/** Branch, changed-file counts and upstream distance (cached briefly by the backend). */
+export async function fetchGitSummary(dir: string): Promise<GitSummary> {
+  try {
+    return { ...EMPTY_GIT_SUMMARY, ...(await App.GetGitSummary(dir || '.')) };
+  } catch {
+    return EMPTY_GIT_SUMMARY;
+  }
+}
+
+/** Current working directory of a pane's process; falls back to the given dir. */
+export async function fetchPaneDir(sessionId: number, fallback: string): Promise<string> {
   try {
-    return await App.GetGitBranch(dir || '.');
+    return (await App.GetSessionDir(sessionId)) || fallback;
   } catch {
-    return '';
+    return fallback;
   }
 }
 
@@ -0,0 +1,19 @@
+import { describe, it, expect } from 'vitest';
+import { isGitHubTimeout, githubErrorMessage } from './github';
+
+describe('githubErrorMessage', () => {
+  it('recognizes timeouts from the backend', () => {
+    expect(isGitHubTimeout('github timed out')).toBe(true);
+    expect(githubErrorMessage('github timed out')).toContain('Zeitüberschreitung');
+  });
+
+  it('passes other failures through', () => {
+    expect(isGitHubTimeout('gh issue: no git remotes found')).toBe(false);
+    expect(githubErrorMessage('gh issue: no git remotes found')).toBe('GitHub-Fehler: gh issue: no git remotes found');
+    expect(githubErrorMessage(new Error('boom'))).toBe('GitHub-Fehler: boom');
+  });
+
+  it('falls back for unknown rejections', () => {
+    expect(githubErrorMessage(undefined)).toBe('GitHub-Anfrage fehlgeschlagen');
+  });
+});
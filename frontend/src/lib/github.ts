@@ -0,0 +1,26 @@
+/**
+ * Error texts for failed gh calls. The backend rejects with "github timed
+ * out" when gh exceeds github_timeout_seconds, and with gh's own message
+ * otherwise.
+ */
+
+const TIMEOUT_MARKER = 'github timed out';
+
+/** Whether a rejected backend call was a gh timeout. */
+export function isGitHubTimeout(err: unknown): boolean {
+  return errorText(err).includes(TIMEOUT_MARKER);
+}
+
+/** German message for a failed gh call, distinguishing timeouts. */
+export function githubErrorMessage(err: unknown): string {
+  if (isGitHubTimeout(err)) return 'GitHub antwortet nicht (Zeitüberschreitung)';
+  const text = errorText(err);
+  return text ? `GitHub-Fehler: ${text}` : 'GitHub-Anfrage fehlgeschlagen';
+}
+
+// Wails rejects with the Go error string; other callers may pass an Error
+function errorText(err: unknown): string {
+  if (typeof err === 'string') return err;
+  if (err instanceof Error) return err.message;
+  return '';
+}
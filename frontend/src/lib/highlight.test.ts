@@ -0,0 +1,27 @@
+import { describe, it, expect } from 'vitest';
+
+import { toSpans, scanStart } from './highlight';
+
+describe('toSpans', () => {
+  it('pairs up start and end columns', () => {
+    expect(toSpans([3, 8, 13, 16])).toEqual([{ x: 3, width: 5 }, { x: 13, width: 3 }]);
+  });
+
+  it('handles lines without matches', () => {
+    expect(toSpans(null)).toEqual([]);
+    expect(toSpans([])).toEqual([]);
+    expect(toSpans([4, 4])).toEqual([]);
+  });
+});
+
+describe('scanStart', () => {
+  it('scans the whole buffer without an end marker', () => {
+    expect(scanStart(null, 50)).toBe(0);
+    expect(scanStart(-1, 50)).toBe(0);
+  });
+
+  it('re-scans from the previous end or the viewport, whichever is earlier', () => {
+    expect(scanStart(40, 50)).toBe(40);
+    expect(scanStart(70, 50)).toBe(50);
+  });
+});
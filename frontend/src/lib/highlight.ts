@@ -0,0 +1,147 @@
+/**
+ * Persistent output highlight for a pane: every match of the pane's pattern
+ * gets a decoration, and new output is re-scanned as it arrives. Matching
+ * runs in the backend (Go RE2, linear time), so a pattern typed into the
+ * search bar can never hang the UI with catastrophic backtracking.
+ */
+import type { Terminal, IDecoration, IMarker } from '@xterm/xterm';
+import * as App from '../../wailsjs/go/backend/App';
+
+const RESCAN_DELAY = 150; // ms; batches the scans of streaming output
+const HIGHLIGHT_BG = '#b58900';
+const HIGHLIGHT_FG = '#000000';
+
+export interface Span {
+  x: number;
+  width: number;
+}
+
+/** Turn the backend's flat [start, end) column pairs into decoration spans. */
+export function toSpans(cols: number[] | null | undefined): Span[] {
+  const spans: Span[] = [];
+  if (!cols) return spans;
+  for (let i = 0; i + 1 < cols.length; i += 2) {
+    if (cols[i + 1] > cols[i]) spans.push({ x: cols[i], width: cols[i + 1] - cols[i] });
+  }
+  return spans;
+}
+
+/**
+ * First buffer line to re-scan. Lines from the previous scan's end onward
+ * are new, and anything in the viewport may have been redrawn. markLine is
+ * -1 when the end marker was trimmed out of the scrollback.
+ */
+export function scanStart(markLine: number | null, baseY: number): number {
+  if (markLine === null || markLine < 0) return 0;
+  return Math.min(markLine, baseY);
+}
+
+interface MarkedLine {
+  marker: IMarker;
+  decorations: IDecoration[];
+}
+
+export class OutputHighlighter {
+  private marked: MarkedLine[] = [];
+  private end: IMarker | null = null;
+  private timer: ReturnType<typeof setTimeout> | null = null;
+  private active = false;
+  private scanning = false;
+  private pending = false;
+  private generation = 0; // bumped by set(); stale scans drop their results
+
+  constructor(private term: Terminal, private sessionId: number) {}
+
+  /** Set the pattern ('' clears). Resolves to '' or the backend's error. */
+  async set(pattern: string): Promise<string> {
+    const err = await App.SetSessionHighlight(this.sessionId, pattern);
+    if (err) return err;
+    this.generation++;
+    this.clear();
+    this.active = pattern !== '';
+    if (this.active) this.rescan(0);
+    return '';
+  }
+
+  /** Call after output was written; the re-scan is batched. */
+  schedule() {
+    if (!this.active || this.timer) return;
+    this.timer = setTimeout(() => {
+      this.timer = null;
+      this.rescan(scanStart(this.end ? this.end.line : null, this.term.buffer.active.baseY));
+    }, RESCAN_DELAY);
+  }
+
+  dispose() {
+    this.active = false;
+    this.clear();
+  }
+
+  private clear() {
+    if (this.timer) clearTimeout(this.timer);
+    this.timer = null;
+    this.unmark(0);
+    this.end?.dispose();
+    this.end = null;
+  }
+
+  /** Remove the decorations from buffer line `from` on. */
+  private unmark(from: number) {
+    this.marked = this.marked.filter((m) => {
+      if (!m.marker.isDisposed && m.marker.line < from) return true;
+      m.decorations.forEach((d) => d.dispose());
+      m.marker.dispose();
+      return false;
+    });
+  }
+
+  private async rescan(from: number) {
+    if (this.scanning) {
+      // One scan at a time; the follow-up covers whatever arrived meanwhile.
+      this.pending = true;
+      return;
+    }
+    this.scanning = true;
+    try {
+      await this.scan(from);
+    } finally {
+      this.scanning = false;
+    }
+    if (this.pending) {
+      this.pending = false;
+      this.schedule();
+    }
+  }
+
+  private async scan(from: number) {
+    const buf = this.term.buffer.active;
+    const lines: string[] = [];
+    for (let y = from; y < buf.length; y++) {
+      lines.push(buf.getLine(y)?.translateToString(false) ?? '');
+    }
+    if (lines.length === 0) return;
+    const generation = this.generation;
+    const matches = await App.MatchSessionHighlight(this.sessionId, lines);
+    if (!this.active || generation !== this.generation || !matches) return;
+
+    // Marker offsets count from the cursor line as it is now, after the await.
+    const cursorLine = buf.baseY + buf.cursorY;
+    this.unmark(from);
+    matches.forEach((cols, i) => {
+      const spans = toSpans(cols);
+      if (spans.length === 0) return;
+      const marker = this.term.registerMarker(from + i - cursorLine);
+      if (!marker) return;
+      const decorations: IDecoration[] = [];
+      for (const { x, width } of spans) {
+        const d = this.term.registerDecoration({
+          marker, x, width, backgroundColor: HIGHLIGHT_BG, foregroundColor: HIGHLIGHT_FG, layer: 'top',
+        });
+        if (d) decorations.push(d);
+      }
+      this.marked.push({ marker, decorations });
+    });
+    this.end?.dispose();
+    this.end = this.term.registerMarker(from + lines.length - 1 - cursorLine) ?? null;
+  }
+}
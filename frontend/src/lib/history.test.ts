@@ -0,0 +1,24 @@
+import { describe, it, expect } from 'vitest';
+import { historyEntries, commandInput } from './history';
+
+describe('historyEntries', () => {
+  it('lists the newest command first and drops older duplicates', () => {
+    expect(historyEntries(['ls', 'make', 'ls', 'git status'], '')).toEqual(['git status', 'ls', 'make']);
+  });
+
+  it('filters case-insensitively', () => {
+    expect(historyEntries(['git status', 'make', 'Git log'], 'git')).toEqual(['Git log', 'git status']);
+    expect(historyEntries(['make'], 'npm')).toEqual([]);
+  });
+});
+
+describe('commandInput', () => {
+  it('adds Enter only when running', () => {
+    expect(commandInput('ls -la', true)).toBe('ls -la\r');
+    expect(commandInput('ls -la', false)).toBe('ls -la');
+  });
+
+  it('sends multi-line commands line by line', () => {
+    expect(commandInput('for f in *\ndo echo $f\ndone', true)).toBe('for f in *\rdo echo $f\rdone\r');
+  });
+});
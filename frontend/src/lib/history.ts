@@ -0,0 +1,24 @@
+/**
+ * Command history of shell panes, captured by the backend from OSC 133
+ * prompt marks (GetCommandHistory, oldest first).
+ */
+
+/** Newest-first entries without duplicates, filtered by a case-insensitive substring. */
+export function historyEntries(commands: string[], query: string): string[] {
+  const q = query.trim().toLowerCase();
+  const seen = new Set<string>();
+  const out: string[] = [];
+  for (let i = commands.length - 1; i >= 0; i--) {
+    const cmd = commands[i];
+    if (seen.has(cmd)) continue;
+    seen.add(cmd);
+    if (!q || cmd.toLowerCase().includes(q)) out.push(cmd);
+  }
+  return out;
+}
+
+/** Text to send to the PTY: the command with Enter if it should run right away. */
+export function commandInput(cmd: string, run: boolean): string {
+  const text = cmd.replace(/\r?\n/g, '\r');
+  return run ? text + '\r' : text;
+}
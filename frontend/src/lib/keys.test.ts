@@ -0,0 +1,52 @@
+import { describe, it, expect } from 'vitest';
+import { modifiedKeySequence } from './keys';
+
+function key(spec: string): KeyboardEvent {
+  const parts = spec.split('+');
+  const k = parts.pop()!;
+  return {
+    key: k,
+    ctrlKey: parts.includes('ctrl'),
+    altKey: parts.includes('alt'),
+    shiftKey: parts.includes('shift'),
+    metaKey: parts.includes('meta'),
+  } as KeyboardEvent;
+}
+
+describe('modifiedKeySequence', () => {
+  const cases: [string, string][] = [
+    ['ctrl+ArrowUp', '\x1b[1;5A'],
+    ['ctrl+ArrowDown', '\x1b[1;5B'],
+    ['ctrl+ArrowRight', '\x1b[1;5C'],
+    ['ctrl+ArrowLeft', '\x1b[1;5D'],
+    ['alt+ArrowUp', '\x1b[1;3A'],
+    ['alt+ArrowDown', '\x1b[1;3B'],
+    ['alt+ArrowRight', '\x1b[1;3C'],
+    ['alt+ArrowLeft', '\x1b[1;3D'],
+    ['ctrl+Home', '\x1b[1;5H'],
+    ['ctrl+End', '\x1b[1;5F'],
+    ['alt+Home', '\x1b[1;3H'],
+    ['alt+End', '\x1b[1;3F'],
+    ['ctrl+Delete', '\x1b[3;5~'],
+    ['alt+Delete', '\x1b[3;3~'],
+    ['ctrl+shift+ArrowLeft', '\x1b[1;6D'],
+    ['ctrl+alt+ArrowRight', '\x1b[1;7C'],
+    ['ctrl+alt+shift+End', '\x1b[1;8F'],
+  ];
+  for (const [spec, want] of cases) {
+    it(`encodes ${spec}`, () => {
+      expect(modifiedKeySequence(key(spec))).toBe(want);
+    });
+  }
+
+  it('leaves unmodified, shift-only and meta keys to xterm.js', () => {
+    expect(modifiedKeySequence(key('ArrowLeft'))).toBeNull();
+    expect(modifiedKeySequence(key('shift+ArrowLeft'))).toBeNull();
+    expect(modifiedKeySequence(key('meta+ctrl+ArrowLeft'))).toBeNull();
+  });
+
+  it('ignores other keys', () => {
+    expect(modifiedKeySequence(key('ctrl+a'))).toBeNull();
+    expect(modifiedKeySequence(key('alt+PageUp'))).toBeNull();
+  });
+});
@@ -0,0 +1,29 @@
+/**
+ * Key encodings xterm.js gets wrong for line editors. It rewrites
+ * Alt+Left/Right to Ctrl's sequence (or ESC b / ESC f on macOS), so
+ * readline and Claude Code cannot tell the two apart.
+ */
+
+/** Final byte of the CSI 1;<mod> form for cursor and Home/End keys. */
+const CSI_FINAL: Record<string, string> = {
+  ArrowUp: 'A',
+  ArrowDown: 'B',
+  ArrowRight: 'C',
+  ArrowLeft: 'D',
+  Home: 'H',
+  End: 'F',
+};
+
+/**
+ * The xterm sequence for a navigation key held with Ctrl and/or Alt, e.g.
+ * Ctrl+Left → ESC[1;5D, Alt+Left → ESC[1;3D, Ctrl+Delete → ESC[3;5~.
+ * Shift adds to the modifier as usual. Returns null for unmodified keys,
+ * Shift-only combinations and anything with Meta, which xterm.js handles.
+ */
+export function modifiedKeySequence(e: KeyboardEvent): string | null {
+  if (e.metaKey || (!e.ctrlKey && !e.altKey)) return null;
+  const mod = 1 + (e.shiftKey ? 1 : 0) + (e.altKey ? 2 : 0) + (e.ctrlKey ? 4 : 0);
+  if (e.key === 'Delete') return `\x1b[3;${mod}~`;
+  const final = CSI_FINAL[e.key];
+  return final ? `\x1b[1;${mod}${final}` : null;
+}
@@ -0,0 +1,19 @@
+import { describe, it, expect } from 'vitest';
+import { parseEnvLines } from './launch';
+
+describe('parseEnvLines', () => {
+  it('parses one variable per line and keeps "=" in values', () => {
+    expect(parseEnvLines('NO_COLOR=1\nANTHROPIC_API_KEY=sk-a=b')).toEqual({
+      NO_COLOR: '1',
+      ANTHROPIC_API_KEY: 'sk-a=b',
+    });
+  });
+
+  it('skips blank lines, comments and invalid names', () => {
+    expect(parseEnvLines('\n# comment\n=x\nNO EQUALS\nBAD NAME=1\n  TERM=dumb  \n')).toEqual({ TERM: 'dumb' });
+  });
+
+  it('lets later lines override earlier ones', () => {
+    expect(parseEnvLines('A=1\nA=2')).toEqual({ A: '2' });
+  });
+});
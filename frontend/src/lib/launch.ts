@@ -19,6 +19,25 @@ export function buildIssuePrompt(issue: IssueContext): string {
   return text;
 }
 
+/**
+ * Parse "KEY=value" lines from the launch dialog into per-pane environment
+ * overrides. Blank lines, "#" comments and lines without a valid name are
+ * skipped; the value keeps everything after the first "=".
+ */
+export function parseEnvLines(text: string): Record<string, string> {
+  const env: Record<string, string> = {};
+  for (const raw of text.split('\n')) {
+    const line = raw.trim();
+    if (!line || line.startsWith('#')) continue;
+    const eq = line.indexOf('=');
+    if (eq <= 0) continue;
+    const name = line.slice(0, eq).trim();
+    if (/\s/.test(name)) continue;
+    env[name] = line.slice(eq + 1);
+  }
+  return env;
+}
+
 export interface BranchConflict {
   currentBranch: string;
   currentIssueNumber: number;
@@ -67,8 +86,9 @@ export async function setupIssueBranch(
   const branchInfo = await App.IsOnIssueBranch(sessionDir, issue.number);
   const isDefaultBranch = ['main', 'master', 'develop'].includes(branchInfo.branch_name);
 
-  if (!isDefaultBranch && !branchInfo.is_same_issue) {
-    const dirty = !(await App.HasCleanWorkingTree(sessionDir));
+  // Uncommitted changes need a decision too (stash, stay or worktree)
+  const dirty = !branchInfo.is_same_issue && !(await App.HasCleanWorkingTree(sessionDir));
+  if (dirty || (!isDefaultBranch && !branchInfo.is_same_issue)) {
     result.conflict = {
       currentBranch: branchInfo.branch_name,
       currentIssueNumber: branchInfo.issue_number,
@@ -94,7 +114,25 @@ export async function setupIssueBranch(
 }
 
 /**
- * Resolve a branch conflict after the user chose an action.
+ * After a stash-and-switch, offer to carry the stashed changes over to the
+ * issue branch. Declining keeps them in `git stash list`.
+ */
+async function offerStashPop(dir: string, branch: string, ref: string): Promise<void> {
+  const take = confirm(
+    `Deine Änderungen wurden vor dem Wechsel zu ${branch} gestasht.\n\n` +
+    'Jetzt auf diesem Branch wieder anwenden? (Abbrechen lässt sie im Stash.)',
+  );
+  if (!take) return;
+  try {
+    await App.PopIssueStash(dir, ref);
+  } catch (err: any) {
+    alert(`Stash konnte nicht angewendet werden:\n${err?.message || String(err)}\n\nEr bleibt in "git stash list" erhalten.`);
+  }
+}
+
+/**
+ * Resolve a branch conflict after the user chose an action. "switch" stashes
+ * uncommitted changes first.
  */
 export async function resolveBranchConflict(
   action: 'switch' | 'stay' | 'worktree',
@@ -105,7 +143,9 @@ export async function resolveBranchConflict(
 
   if (action === 'switch') {
     try {
-      result.issueBranch = await App.GetOrCreateIssueBranch(sessionDir, issue.number, issue.title);
+      const switched = await App.GetOrCreateIssueBranchAutoStash(sessionDir, issue.number, issue.title);
+      result.issueBranch = switched.branch;
+      if (switched.stashed) await offerStashPop(sessionDir, switched.branch, switched.stash_ref);
     } catch (err: any) {
       const msg = err?.message || String(err);
       if (!confirm(`Branch-Wechsel fehlgeschlagen:\n${msg}\n\nTrotzdem ohne Branch starten?`)) {
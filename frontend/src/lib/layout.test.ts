@@ -0,0 +1,92 @@
+import { describe, it, expect } from 'vitest';
+import {
+  splitPane, removePane, paneIds, layoutRects, gridRects, gridColumns,
+  appendPane, syncLayout, layoutFromGrid, type LayoutNode,
+} from './layout';
+
+const p = (paneId: string): LayoutNode => ({ type: 'pane', paneId });
+
+describe('splitPane', () => {
+  it('puts the new pane right of or below the target', () => {
+    const h = splitPane(p('a'), 'a', 'b', 'h');
+    expect(h).toEqual({ type: 'split', dir: 'h', a: p('a'), b: p('b') });
+    expect(layoutRects(h).get('b')).toEqual({ x: 0.5, y: 0, w: 0.5, h: 1 });
+
+    const v = splitPane(p('a'), 'a', 'b', 'v');
+    expect(layoutRects(v).get('b')).toEqual({ x: 0, y: 0.5, w: 1, h: 0.5 });
+  });
+
+  it('splits nested panes only inside their own half', () => {
+    // a | b, then b split below into c, then c split right into d
+    let tree = splitPane(p('a'), 'a', 'b', 'h');
+    tree = splitPane(tree, 'b', 'c', 'v');
+    tree = splitPane(tree, 'c', 'd', 'h');
+    expect(paneIds(tree)).toEqual(['a', 'b', 'c', 'd']);
+
+    const rects = layoutRects(tree);
+    expect(rects.get('a')).toEqual({ x: 0, y: 0, w: 0.5, h: 1 });
+    expect(rects.get('b')).toEqual({ x: 0.5, y: 0, w: 0.5, h: 0.5 });
+    expect(rects.get('c')).toEqual({ x: 0.5, y: 0.5, w: 0.25, h: 0.5 });
+    expect(rects.get('d')).toEqual({ x: 0.75, y: 0.5, w: 0.25, h: 0.5 });
+  });
+
+  it('leaves the tree alone for an unknown target', () => {
+    const tree = splitPane(p('a'), 'a', 'b', 'h');
+    expect(splitPane(tree, 'zz', 'c', 'v')).toBe(tree);
+  });
+});
+
+describe('removePane', () => {
+  it('lets the sibling take over the freed space', () => {
+    let tree = splitPane(p('a'), 'a', 'b', 'h');
+    tree = splitPane(tree, 'b', 'c', 'v');
+    const left = removePane(tree, 'b')!;
+    expect(paneIds(left)).toEqual(['a', 'c']);
+    expect(layoutRects(left).get('c')).toEqual({ x: 0.5, y: 0, w: 0.5, h: 1 });
+  });
+
+  it('empties the tree with the last pane', () => {
+    expect(removePane(p('a'), 'a')).toBeNull();
+  });
+});
+
+describe('gridRects', () => {
+  it('matches the automatic grid', () => {
+    expect(gridColumns(1)).toBe(1);
+    expect(gridColumns(4)).toBe(2);
+    expect(gridColumns(10)).toBe(3);
+    const rects = gridRects(['a', 'b', 'c']);
+    expect(rects.get('a')).toEqual({ x: 0, y: 0, w: 0.5, h: 0.5 });
+    expect(rects.get('c')).toEqual({ x: 0, y: 0.5, w: 0.5, h: 0.5 });
+  });
+});
+
+describe('appendPane', () => {
+  it('splits the largest pane along its longer side', () => {
+    let tree = splitPane(p('a'), 'a', 'b', 'h');
+    tree = splitPane(tree, 'b', 'c', 'v');
+    tree = appendPane(tree, 'd');
+    // a (half the width, full height) is split below
+    expect(layoutRects(tree).get('d')).toEqual({ x: 0, y: 0.5, w: 0.5, h: 0.5 });
+  });
+});
+
+describe('syncLayout', () => {
+  it('drops closed panes and appends new ones', () => {
+    const tree = splitPane(p('a'), 'a', 'b', 'v');
+    expect(paneIds(syncLayout(tree, ['b', 'c']))).toEqual(['b', 'c']);
+    expect(syncLayout(tree, [])).toBeNull();
+    expect(syncLayout(null, ['x'])).toEqual(p('x'));
+  });
+});
+
+describe('layoutFromGrid', () => {
+  it('keeps the grid positions of a full grid', () => {
+    const ids = ['a', 'b', 'c', 'd'];
+    expect(layoutRects(layoutFromGrid(ids)!)).toEqual(gridRects(ids));
+  });
+
+  it('is null without panes', () => {
+    expect(layoutFromGrid([])).toBeNull();
+  });
+});
@@ -0,0 +1,152 @@
+/**
+ * Per-tab pane layout: a binary split tree built by the split_horizontal /
+ * split_vertical commands. A tab without a tree uses the automatic grid.
+ */
+
+/** 'h' places the halves side by side, 'v' stacks them (tmux -h / -v). */
+export type SplitDir = 'h' | 'v';
+
+export type LayoutNode =
+  | { type: 'pane'; paneId: string }
+  | { type: 'split'; dir: SplitDir; a: LayoutNode; b: LayoutNode };
+
+/** Position of a pane as fractions (0..1) of the pane area. */
+export interface Rect {
+  x: number;
+  y: number;
+  w: number;
+  h: number;
+}
+
+export const FULL_RECT: Rect = { x: 0, y: 0, w: 1, h: 1 };
+
+function leaf(paneId: string): LayoutNode {
+  return { type: 'pane', paneId };
+}
+
+/** Pane ids in reading order (left/top half first). */
+export function paneIds(node: LayoutNode | null): string[] {
+  if (!node) return [];
+  if (node.type === 'pane') return [node.paneId];
+  return [...paneIds(node.a), ...paneIds(node.b)];
+}
+
+/**
+ * Split the pane targetId in direction dir and put newId in the new right
+ * (h) or bottom (v) half. Returns the tree unchanged when targetId is not
+ * in it.
+ */
+export function splitPane(node: LayoutNode, targetId: string, newId: string, dir: SplitDir): LayoutNode {
+  if (node.type === 'pane') {
+    if (node.paneId !== targetId) return node;
+    return { type: 'split', dir, a: node, b: leaf(newId) };
+  }
+  const a = splitPane(node.a, targetId, newId, dir);
+  if (a !== node.a) return { ...node, a };
+  const b = splitPane(node.b, targetId, newId, dir);
+  if (b !== node.b) return { ...node, b };
+  return node;
+}
+
+/** Remove paneId; its sibling takes over the parent's space. Null when the tree empties. */
+export function removePane(node: LayoutNode, paneId: string): LayoutNode | null {
+  if (node.type === 'pane') return node.paneId === paneId ? null : node;
+  const a = removePane(node.a, paneId);
+  const b = removePane(node.b, paneId);
+  if (!a) return b;
+  if (!b) return a;
+  if (a === node.a && b === node.b) return node;
+  return { ...node, a, b };
+}
+
+/** Fractional rects of every pane in the tree, halves split evenly. */
+export function layoutRects(node: LayoutNode, rect: Rect = FULL_RECT, out: Map<string, Rect> = new Map()): Map<string, Rect> {
+  if (node.type === 'pane') {
+    out.set(node.paneId, rect);
+    return out;
+  }
+  if (node.dir === 'h') {
+    const w = rect.w / 2;
+    layoutRects(node.a, { ...rect, w }, out);
+    layoutRects(node.b, { ...rect, x: rect.x + w, w }, out);
+  } else {
+    const h = rect.h / 2;
+    layoutRects(node.a, { ...rect, h }, out);
+    layoutRects(node.b, { ...rect, y: rect.y + h, h }, out);
+  }
+  return out;
+}
+
+/** Columns of the automatic grid: square-ish, at most 3 wide. */
+export function gridColumns(count: number): number {
+  return Math.max(1, Math.min(Math.ceil(Math.sqrt(count)), 3));
+}
+
+/** Rects of the automatic grid, filling rows left to right. */
+export function gridRects(ids: string[], cols = gridColumns(ids.length)): Map<string, Rect> {
+  const out = new Map<string, Rect>();
+  const rows = Math.max(1, Math.ceil(ids.length / cols));
+  ids.forEach((id, i) => {
+    out.set(id, {
+      x: (i % cols) / cols,
+      y: Math.floor(i / cols) / rows,
+      w: 1 / cols,
+      h: 1 / rows,
+    });
+  });
+  return out;
+}
+
+/**
+ * Add a pane that was not placed by a split command: the largest pane is
+ * split along its longer side, so the tree stays roughly balanced.
+ */
+export function appendPane(node: LayoutNode, newId: string): LayoutNode {
+  let best = '';
+  let bestArea = -1;
+  let bestDir: SplitDir = 'h';
+  for (const [id, r] of layoutRects(node)) {
+    // Strictly larger wins, so ties go to the first pane in reading order
+    if (r.w * r.h > bestArea) {
+      best = id;
+      bestArea = r.w * r.h;
+      bestDir = r.w >= r.h ? 'h' : 'v';
+    }
+  }
+  return splitPane(node, best, newId, bestDir);
+}
+
+/** Bring the tree in line with ids: drop panes that are gone, append new ones. */
+export function syncLayout(node: LayoutNode | null, ids: string[]): LayoutNode | null {
+  let tree = node;
+  for (const id of paneIds(node)) {
+    if (!ids.includes(id) && tree) tree = removePane(tree, id);
+  }
+  const present = new Set(paneIds(tree));
+  for (const id of ids) {
+    if (present.has(id)) continue;
+    tree = tree ? appendPane(tree, id) : leaf(id);
+  }
+  return tree;
+}
+
+/**
+ * Start a tree from the automatic grid so the first split does not move
+ * the other panes around: each grid row becomes a chain of 'h' splits and
+ * the rows are chained with 'v' splits. Uneven chains are not exact, the
+ * halves are equal, but the reading order is kept.
+ */
+export function layoutFromGrid(ids: string[], cols = gridColumns(ids.length)): LayoutNode | null {
+  if (ids.length === 0) return null;
+  const rows: LayoutNode[] = [];
+  for (let i = 0; i < ids.length; i += cols) {
+    rows.push(chain(ids.slice(i, i + cols).map(leaf), 'h'));
+  }
+  return chain(rows, 'v');
+}
+
+function chain(nodes: LayoutNode[], dir: SplitDir): LayoutNode {
+  if (nodes.length === 1) return nodes[0];
+  const mid = Math.ceil(nodes.length / 2);
+  return { type: 'split', dir, a: chain(nodes.slice(0, mid), dir), b: chain(nodes.slice(mid), dir) };
+}
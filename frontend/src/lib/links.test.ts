@@ -1,5 +1,5 @@
 import { describe, it, expect } from 'vitest';
-import { LINK_REGEX, LOCALHOST_REGEX, isUrl } from './links';
+import { LINK_REGEX, LOCALHOST_REGEX, isUrl, findFileRef } from './links';
 
 describe('LINK_REGEX', () => {
   const match = (text: string) => {
@@ -118,3 +118,23 @@ describe('isUrl', () => {
     expect(isUrl('src/foo.ts:42')).toBe(false);
   });
 });
+
+describe('findFileRef', () => {
+  const refs = [
+    { text: 'internal/app/model.go:123:5', path: '/repo/internal/app/model.go', line: 123, exists: true },
+    { text: 'main.go:7', path: '/repo/main.go', line: 7, exists: false },
+  ];
+
+  it('prefers an exact match', () => {
+    expect(findFileRef(refs, 'main.go:7')?.line).toBe(7);
+  });
+
+  it('matches a link cut differently than the backend reference', () => {
+    expect(findFileRef(refs, 'app/model.go:123:5')?.line).toBe(123);
+  });
+
+  it('returns null without a match', () => {
+    expect(findFileRef(refs, 'other.go:1')).toBeNull();
+    expect(findFileRef(null, 'main.go:7')).toBeNull();
+  });
+});
@@ -18,6 +18,26 @@ export function isUrl(uri: string): boolean {
   return /^https?:\/\//.test(uri);
 }
 
+/** A file reference as returned by App.GetFileRefs. */
+export interface FileRefLike {
+  text: string;
+  path: string;
+  line: number;
+  exists: boolean;
+}
+
+/**
+ * Pick the backend reference for a clicked link text. The frontend regex
+ * may cut a path differently, so a reference ending in the text (or the
+ * other way round) counts when there is no exact match.
+ */
+export function findFileRef<T extends FileRefLike>(refs: T[] | null | undefined, text: string): T | null {
+  if (!refs || !text) return null;
+  return refs.find(r => r.text === text)
+    ?? refs.find(r => r.text.endsWith(text) || text.endsWith(r.text))
+    ?? null;
+}
+
 export type LinkHandler = (event: MouseEvent, uri: string) => void;
 
 export function createWebLinksAddon(handler: LinkHandler): WebLinksAddon {
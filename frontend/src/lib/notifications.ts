@@ -3,8 +3,11 @@ import * as App from '../../wailsjs/go/backend/App';
 const recentNotifications = new Map<string, number>();
 const DEDUP_WINDOW_MS = 5000;
 
-/** Send a native OS notification via the Go backend (deduplicated). */
-export function sendNotification(title: string, body: string) {
+/**
+ * Send a native OS notification via the Go backend (deduplicated).
+ * `target` ("session/<id>" or "issue/<n>") names the pane a click selects.
+ */
+export function sendNotification(title: string, body: string, target = '') {
   const key = `${title}\0${body}`;
   const now = Date.now();
   const last = recentNotifications.get(key);
@@ -16,5 +19,5 @@ export function sendNotification(title: string, body: string) {
       if (now - t >= DEDUP_WINDOW_MS) recentNotifications.delete(k);
     }
   }
-  App.SendNotification(title, body);
+  App.SendNotification(title, body, target);
 }
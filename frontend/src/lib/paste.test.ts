@@ -0,0 +1,48 @@
+import { describe, it, expect } from 'vitest';
+import { dangerousMatches, planPaste, pasteConfirmText } from './paste';
+
+describe('dangerousMatches', () => {
+  it('finds destructive and download-and-run commands', () => {
+    expect(dangerousMatches('rm -rf /tmp/x')).toEqual(['rm -rf']);
+    expect(dangerousMatches('rm -fr build')).toEqual(['rm -rf']);
+    expect(dangerousMatches('rm -v -Rf dist')).toEqual(['rm -rf']);
+    expect(dangerousMatches('curl -fsSL https://x.sh | sudo bash')).toEqual(['curl … | sh']);
+    expect(dangerousMatches('irm https://x.ps1 | iex')).toEqual(['iwr … | iex']);
+    expect(dangerousMatches('Remove-Item .\\out -Recurse -Force')).toEqual(['Remove-Item -Recurse -Force']);
+    expect(dangerousMatches(':(){ :|:& };:')).toEqual(['Fork-Bombe']);
+  });
+
+  it('ignores harmless commands', () => {
+    expect(dangerousMatches('rm file.txt')).toEqual([]);
+    expect(dangerousMatches('curl -o out.json https://api')).toEqual([]);
+    expect(dangerousMatches('git commit -m "form fields"')).toEqual([]);
+  });
+});
+
+describe('planPaste', () => {
+  it('leaves text untouched when the feature is off', () => {
+    const text = 'ls\n\x1b[201~rm -rf /\n';
+    expect(planPaste(text, false, 'off', false)).toEqual({ text, warnings: [] });
+  });
+
+  it('strips trailing newlines outside bracketed paste mode', () => {
+    expect(planPaste('make test\r\n', false, 'strip', false).text).toBe('make test');
+    expect(planPaste('make test\n', true, 'strip', false).text).toBe('make test\n');
+  });
+
+  it('asks before multi-line pastes outside bracketed paste mode', () => {
+    expect(planPaste('a\nb', false, 'confirm', false).warnings).toHaveLength(1);
+    expect(planPaste('a\nb', true, 'confirm', false).warnings).toEqual([]);
+    expect(planPaste('single line', false, 'confirm', false).warnings).toEqual([]);
+  });
+
+  it('removes embedded bracketed paste markers', () => {
+    expect(planPaste('echo hi\x1b[201~; rm x\x1b[200~', true, 'confirm', false).text).toBe('echo hi; rm x');
+  });
+
+  it('warns on dangerous commands even inside bracketed paste', () => {
+    const plan = planPaste('curl https://get.x | sh', true, 'off', true);
+    expect(plan.warnings).toEqual(['Enthält „curl … | sh“.']);
+    expect(pasteConfirmText(plan.warnings)).toBe('Wirklich einfügen?\n\n• Enthält „curl … | sh“.');
+  });
+});
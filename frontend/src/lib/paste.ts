@@ -0,0 +1,60 @@
+/**
+ * Paste safety (config: paste_safety, paste_warn_dangerous).
+ *
+ * Without bracketed paste mode a shell runs every pasted line as soon as it
+ * sees the line break, so a copied trailing newline executes the command
+ * before it can be read. This module decides what actually gets pasted and
+ * what to ask the user first; clipboard.ts applies it.
+ */
+
+export type PasteSafety = 'off' | 'strip' | 'confirm';
+
+/** Commands worth a second look before they run, with the label shown. */
+const DANGEROUS: [RegExp, string][] = [
+  [/\brm\s+(-\w*\s+)*-\w*(r\w*f|f\w*r)\b/i, 'rm -rf'],
+  [/\b(curl|wget)\b[^|\n]*\|\s*(sudo\s+)?(ba|z|da)?sh\b/, 'curl … | sh'],
+  [/\b(iwr|irm|Invoke-WebRequest|Invoke-RestMethod)\b[^|\n]*\|\s*(iex|Invoke-Expression)\b/i, 'iwr … | iex'],
+  [/\bRemove-Item\b[^\n]*-Recurse\b[^\n]*-Force\b/i, 'Remove-Item -Recurse -Force'],
+  [/\bmkfs(\.\w+)?\b/, 'mkfs'],
+  [/\bdd\b[^\n]*\bof=\/dev\//, 'dd of=/dev/…'],
+  [/\bchmod\s+-R\s+777\s+\/(\s|$)/, 'chmod -R 777 /'],
+  [/:\(\)\s*\{\s*:\s*\|\s*:\s*&\s*\}\s*;\s*:/, 'Fork-Bombe'],
+];
+
+/** Labels of the dangerous commands found in text, in table order. */
+export function dangerousMatches(text: string): string[] {
+  return DANGEROUS.filter(([re]) => re.test(text)).map(([, label]) => label);
+}
+
+export interface PastePlan {
+  text: string;       // what to send to the pane
+  warnings: string[]; // reasons to confirm first; empty = paste right away
+}
+
+/**
+ * Decide how to paste text into a pane. `bracketed` is the pane's
+ * bracketed paste mode, in which the shell waits for Enter anyway.
+ * Embedded paste start/end markers are removed so the text cannot end
+ * bracketed paste early and run the rest.
+ */
+export function planPaste(text: string, bracketed: boolean, safety: PasteSafety, warnDangerous: boolean): PastePlan {
+  if (safety === 'off' && !warnDangerous) return { text, warnings: [] };
+
+  let out = text.replace(/\x1b\[20[01]~/g, '');
+  const warnings: string[] = [];
+  if (!bracketed && safety === 'strip') {
+    out = out.replace(/[\r\n]+$/, '');
+  }
+  if (!bracketed && safety === 'confirm' && /[\r\n]/.test(out)) {
+    warnings.push('Der Text enthält Zeilenumbrüche – jede Zeile wird sofort ausgeführt.');
+  }
+  if (warnDangerous) {
+    for (const label of dangerousMatches(out)) warnings.push(`Enthält „${label}“.`);
+  }
+  return { text: out, warnings };
+}
+
+/** Text of the confirm dialog for a plan with warnings. */
+export function pasteConfirmText(warnings: string[]): string {
+  return `Wirklich einfügen?\n\n${warnings.map((w) => `• ${w}`).join('\n')}`;
+}
@@ -0,0 +1,26 @@
+import { describe, it, expect } from 'vitest';
+import { tabProgress, progressLabel, NO_PROGRESS } from './progress';
+
+describe('tabProgress', () => {
+  it('is empty without reports', () => {
+    expect(tabProgress([])).toEqual(NO_PROGRESS);
+    expect(tabProgress([NO_PROGRESS, NO_PROGRESS])).toEqual(NO_PROGRESS);
+  });
+
+  it('prefers errors over running and busy panes', () => {
+    const running = { state: 'normal' as const, percent: 40 };
+    const failed = { state: 'error' as const, percent: 70 };
+    const busy = { state: 'indeterminate' as const, percent: 0 };
+    expect(tabProgress([busy, running, failed])).toBe(failed);
+    expect(tabProgress([busy, running])).toBe(running);
+  });
+});
+
+describe('progressLabel', () => {
+  it('describes each state', () => {
+    expect(progressLabel({ state: 'normal', percent: 42 })).toBe('Fortschritt: 42%');
+    expect(progressLabel({ state: 'error', percent: 10 })).toBe('Fehler bei 10%');
+    expect(progressLabel({ state: 'indeterminate', percent: 0 })).toBe('In Arbeit…');
+    expect(progressLabel(NO_PROGRESS)).toBe('');
+  });
+});
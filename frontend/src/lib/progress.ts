@@ -0,0 +1,36 @@
+/**
+ * Taskbar-style progress reported by programs via OSC 9;4 (winget, some
+ * installers and build tools), forwarded by the backend as terminal:progress.
+ */
+
+export type ProgressState = 'none' | 'normal' | 'error' | 'indeterminate' | 'paused';
+
+export interface PaneProgress {
+  state: ProgressState;
+  percent: number; // 0-100; unused for indeterminate
+}
+
+export const NO_PROGRESS: PaneProgress = { state: 'none', percent: 0 };
+
+// Higher wins when a tab has several panes reporting progress
+const PRIORITY: Record<ProgressState, number> = { none: 0, indeterminate: 1, normal: 2, paused: 3, error: 4 };
+
+/** The progress a tab shows for its panes: errors first, then paused, running, busy. */
+export function tabProgress(progresses: PaneProgress[]): PaneProgress {
+  let best = NO_PROGRESS;
+  for (const p of progresses) {
+    if (PRIORITY[p.state] > PRIORITY[best.state]) best = p;
+  }
+  return best;
+}
+
+/** German tooltip for a progress report. */
+export function progressLabel(p: PaneProgress): string {
+  switch (p.state) {
+    case 'normal': return `Fortschritt: ${p.percent}%`;
+    case 'error': return `Fehler bei ${p.percent}%`;
+    case 'paused': return `Pausiert bei ${p.percent}%`;
+    case 'indeterminate': return 'In Arbeit…';
+    default: return '';
+  }
+}
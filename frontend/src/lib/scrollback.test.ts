@@ -0,0 +1,41 @@
+import { describe, it, expect } from 'vitest';
+import { tailText, historyData, stashHistory, takeHistory, registerScrollback, unregisterScrollback, captureScrollback } from './scrollback';
+
+describe('tailText', () => {
+  it('drops trailing blank lines and keeps the newest lines', () => {
+    expect(tailText(['a', 'b', 'c', '', '  '], 2)).toBe('b\nc');
+  });
+
+  it('returns empty text for an empty buffer', () => {
+    expect(tailText(['', ''], 10)).toBe('');
+  });
+});
+
+describe('historyData', () => {
+  it('converts line breaks and ends on a fresh line', () => {
+    expect(historyData('$ ls\nmain.go')).toBe('$ ls\r\nmain.go\r\n');
+  });
+
+  it('removes control characters so nothing is interpreted', () => {
+    expect(historyData('\x1b[31mred\ttab\x07')).toBe('[31mred\ttab\r\n');
+  });
+
+  it('is empty for empty text', () => {
+    expect(historyData('')).toBe('');
+  });
+});
+
+describe('history registry', () => {
+  it('hands stashed text out once', () => {
+    stashHistory(7, 'old output');
+    expect(takeHistory(7)).toBe('old output');
+    expect(takeHistory(7)).toBe('');
+  });
+
+  it('reads scrollback only while a pane is registered', () => {
+    registerScrollback(3, () => 'buffer');
+    expect(captureScrollback(3)).toBe('buffer');
+    unregisterScrollback(3);
+    expect(captureScrollback(3)).toBe('');
+  });
+});
@@ -0,0 +1,56 @@
+/**
+ * Scrollback persistence for shell panes (config: restore_scrollback).
+ *
+ * Only xterm.js keeps the full scrollback, so each TerminalPane registers a
+ * reader for its buffer and the session saver pulls the text from here. On
+ * restore the saved text is parked per session until the pane mounts and
+ * writes it above the new shell's output.
+ */
+
+/** Lines captured per pane; the backend trims further by size. */
+export const SCROLLBACK_LINES = 1000;
+
+const readers = new Map<number, () => string>();
+const pending = new Map<number, string>();
+
+export function registerScrollback(sessionId: number, read: () => string): void {
+  readers.set(sessionId, read);
+}
+
+export function unregisterScrollback(sessionId: number): void {
+  readers.delete(sessionId);
+}
+
+/** Current scrollback text of a session, or '' if its pane is not mounted. */
+export function captureScrollback(sessionId: number): string {
+  return readers.get(sessionId)?.() ?? '';
+}
+
+/** Park restored text until the pane of sessionId mounts. */
+export function stashHistory(sessionId: number, text: string): void {
+  if (text) pending.set(sessionId, text);
+}
+
+/** Take (and forget) the restored text for sessionId. */
+export function takeHistory(sessionId: number): string {
+  const text = pending.get(sessionId) ?? '';
+  pending.delete(sessionId);
+  return text;
+}
+
+/** Join buffer lines, dropping trailing blank ones and keeping the newest max. */
+export function tailText(lines: string[], max: number): string {
+  let end = lines.length;
+  while (end > 0 && lines[end - 1].trim() === '') end--;
+  return lines.slice(Math.max(0, end - max), end).join('\n');
+}
+
+/**
+ * What xterm should be fed for restored text. Mirrors the backend's
+ * terminal.HistoryBytes so both screens continue below the same text:
+ * control characters removed, CRLF line breaks, one trailing line break.
+ */
+export function historyData(text: string): string {
+  const clean = text.replace(/[\x00-\x08\x0a-\x1f\x7f\x80-\x9f]/g, (c) => (c === '\n' ? c : ''));
+  return clean ? clean.replace(/\n/g, '\r\n') + '\r\n' : '';
+}
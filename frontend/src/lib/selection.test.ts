@@ -0,0 +1,42 @@
+import { describe, it, expect } from 'vitest';
+import { startSelection, moveHead, selectionRange } from './selection';
+
+describe('keyboard selection', () => {
+  it('starts as a single cell at the cursor', () => {
+    const sel = startSelection(3, 10);
+    expect(selectionRange(sel, 80)).toEqual({ col: 3, row: 10, length: 1 });
+  });
+
+  it('extends to the right and wraps to the next line', () => {
+    let sel = startSelection(78, 0);
+    sel = moveHead(sel, 'ArrowRight', 80, 5)!;
+    sel = moveHead(sel, 'ArrowRight', 80, 5)!;
+    expect(sel.head).toEqual({ col: 0, row: 1 });
+    expect(selectionRange(sel, 80)).toEqual({ col: 78, row: 0, length: 3 });
+  });
+
+  it('selects backwards from the anchor', () => {
+    let sel = startSelection(5, 2);
+    sel = moveHead(sel, 'ArrowUp', 80, 5)!;
+    expect(selectionRange(sel, 80)).toEqual({ col: 5, row: 1, length: 81 });
+  });
+
+  it('clamps at the buffer edges', () => {
+    let sel = startSelection(0, 0);
+    sel = moveHead(sel, 'ArrowLeft', 80, 5)!;
+    sel = moveHead(sel, 'ArrowUp', 80, 5)!;
+    expect(sel.head).toEqual({ col: 0, row: 0 });
+    sel = moveHead(startSelection(79, 5), 'ArrowRight', 80, 5)!;
+    expect(sel.head).toEqual({ col: 79, row: 5 });
+  });
+
+  it('jumps to line start and end', () => {
+    const sel = startSelection(10, 3);
+    expect(moveHead(sel, 'Home', 80, 5)!.head).toEqual({ col: 0, row: 3 });
+    expect(moveHead(sel, 'End', 80, 5)!.head).toEqual({ col: 79, row: 3 });
+  });
+
+  it('ignores keys that do not navigate', () => {
+    expect(moveHead(startSelection(0, 0), 'a', 80, 5)).toBeNull();
+  });
+});
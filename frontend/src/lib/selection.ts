@@ -0,0 +1,67 @@
+/**
+ * Keyboard selection mode for a terminal pane: the selection is anchored at
+ * the cursor and the arrow keys move its other end (the head). Positions use
+ * absolute buffer lines so the selection survives scrolling.
+ */
+
+export interface CellPos {
+  col: number;
+  row: number; // absolute buffer line (0 = oldest scrollback line)
+}
+
+export interface KeyboardSelection {
+  anchor: CellPos;
+  head: CellPos;
+}
+
+/** Linear range in the form xterm's Terminal.select(column, row, length) expects. */
+export interface SelectionRange {
+  col: number;
+  row: number;
+  length: number;
+}
+
+export function startSelection(col: number, row: number): KeyboardSelection {
+  return { anchor: { col, row }, head: { col, row } };
+}
+
+/**
+ * Move the head for a navigation key. Left/Right wrap across lines,
+ * Home/End jump within the line. Returns null for keys that do not move it.
+ */
+export function moveHead(sel: KeyboardSelection, key: string, cols: number, lastRow: number): KeyboardSelection | null {
+  let { col, row } = sel.head;
+  switch (key) {
+    case 'ArrowLeft':
+      if (col > 0) col--;
+      else if (row > 0) { row--; col = cols - 1; }
+      break;
+    case 'ArrowRight':
+      if (col < cols - 1) col++;
+      else if (row < lastRow) { row++; col = 0; }
+      break;
+    case 'ArrowUp':
+      row = Math.max(0, row - 1);
+      break;
+    case 'ArrowDown':
+      row = Math.min(lastRow, row + 1);
+      break;
+    case 'Home':
+      col = 0;
+      break;
+    case 'End':
+      col = cols - 1;
+      break;
+    default:
+      return null;
+  }
+  return { anchor: sel.anchor, head: { col, row } };
+}
+
+/** Convert a selection into a linear range, including both end cells. */
+export function selectionRange(sel: KeyboardSelection, cols: number): SelectionRange {
+  const a = sel.anchor.row * cols + sel.anchor.col;
+  const h = sel.head.row * cols + sel.head.col;
+  const start = Math.min(a, h);
+  return { col: start % cols, row: Math.floor(start / cols), length: Math.abs(h - a) + 1 };
+}
@@ -1,72 +1,152 @@
+import { get } from 'svelte/store';
 import { tabStore } from '../stores/tabs';
+import { config as appConfig } from '../stores/config';
+import { captureScrollback, stashHistory } from './scrollback';
+import { sidebarMemory, sidebarMemoryFor } from '../stores/sidebar';
 import { INDEX_TO_MODE, MODE_TO_INDEX, buildClaudeArgv } from './claude';
 import * as App from '../../wailsjs/go/backend/App';
+import type { config } from '../../wailsjs/go/models';
 
 /** Restore saved tabs/panes from the backend session file. */
 export async function restoreSession(claudePath: string): Promise<boolean> {
   try {
     const saved = await App.LoadTabs();
     if (!saved || !saved.tabs || saved.tabs.length === 0) return false;
+    await restoreState(saved, claudePath);
+    return true;
+  } catch (err) {
+    console.error('[restoreSession]', err);
+    return false;
+  }
+}
 
-    for (const savedTab of saved.tabs) {
-      const tabId = tabStore.addTab(savedTab.name, savedTab.dir);
-      for (const savedPane of savedTab.panes) {
-        const mode = INDEX_TO_MODE[savedPane.mode] || 'shell';
-        const argv = buildClaudeArgv(mode, savedPane.model || '', claudePath);
-        try {
-          const sessionId = await App.CreateSession(argv, savedTab.dir || '', 24, 80);
-          if (sessionId > 0) {
-            const issueNum = (savedPane as any).issue_number || 0;
-            const issueBranch = (savedPane as any).issue_branch || '';
-            const paneId = tabStore.addPane(tabId, sessionId, savedPane.name, mode, savedPane.model || '', issueNum || null, '', issueBranch);
-            const zd = (savedPane as any).zoom_delta || 0;
-            if (zd !== 0) {
-              tabStore.setZoomDelta(tabId, paneId, zd);
-            }
-            if (issueNum) App.LinkSessionIssue(sessionId, issueNum, '', issueBranch, savedTab.dir || '');
+/** Recreate the tabs and panes described by a saved session state. */
+async function restoreState(saved: config.SessionState, claudePath: string): Promise<void> {
+  const offset = tabStore.getState().tabs.length;
+  // Before the tabs exist, so the sidebar finds its tree state on first load
+  if (saved.sidebar) sidebarMemory.update((m) => ({ ...saved.sidebar, ...m }));
+  for (const savedTab of saved.tabs) {
+    const tabId = tabStore.addTab(savedTab.name, savedTab.dir);
+    let maximizedPaneId = '';
+    for (const savedPane of savedTab.panes) {
+      const mode = INDEX_TO_MODE[savedPane.mode] || 'shell';
+      const customArgv = savedPane.argv || [];
+      const paneDir = savedPane.dir || '';
+      const paneEnv = savedPane.env || {};
+      const argv = customArgv.length > 0 ? customArgv : buildClaudeArgv(mode, savedPane.model || '', claudePath);
+      try {
+        const history = savedPane.scrollback || '';
+        const sessionId = await App.CreateSessionWithHistory(argv, paneDir || savedTab.dir || '', 24, 80, paneEnv, history);
+        if (sessionId > 0) {
+          stashHistory(sessionId, history);
+          const issueNum = (savedPane as any).issue_number || 0;
+          const issueBranch = (savedPane as any).issue_branch || '';
+          const paneId = tabStore.addPane(tabId, sessionId, savedPane.name, mode, savedPane.model || '', issueNum || null, '', issueBranch);
+          if (customArgv.length > 0 || paneDir || Object.keys(paneEnv).length > 0) {
+            tabStore.setPaneCommand(tabId, paneId, customArgv, paneDir, paneEnv);
+          }
+          const zd = (savedPane as any).zoom_delta || 0;
+          if (zd !== 0) {
+            tabStore.setZoomDelta(tabId, paneId, zd);
+          }
+          if (savedPane.name_manual) {
+            tabStore.renamePane(tabId, paneId, savedPane.name);
+            App.SetPaneName(sessionId, savedPane.name);
           }
-        } catch (err) {
-          console.error('[restoreSession] failed to create session:', err);
+          if (savedPane.pinned) tabStore.togglePinned(tabId, paneId);
+          if (savedPane.maximized) maximizedPaneId = paneId;
+          if (issueNum) App.LinkSessionIssue(sessionId, issueNum, '', issueBranch, savedTab.dir || '');
         }
+      } catch (err) {
+        console.error('[restoreSession] failed to create session:', err);
       }
-      // Restore focused pane (addPane always focuses the last-added pane)
-      if (savedTab.focus_idx >= 0) {
-        const curState = tabStore.getState();
-        const tab = curState.tabs.find(t => t.id === tabId);
-        if (tab && savedTab.focus_idx < tab.panes.length) {
-          tabStore.focusPane(tabId, tab.panes[savedTab.focus_idx].id);
-        }
+    }
+    // Restore focused pane (addPane always focuses the last-added pane)
+    if (savedTab.focus_idx >= 0) {
+      const curState = tabStore.getState();
+      const tab = curState.tabs.find(t => t.id === tabId);
+      if (tab && savedTab.focus_idx < tab.panes.length) {
+        tabStore.focusPane(tabId, tab.panes[savedTab.focus_idx].id);
       }
     }
+    // Restore zoom last: adding panes clears it
+    if (maximizedPaneId) tabStore.toggleMaximize(tabId, maximizedPaneId);
+  }
 
-    const state = tabStore.getState();
-    if (saved.active_tab >= 0 && saved.active_tab < state.tabs.length) {
-      tabStore.setActiveTab(state.tabs[saved.active_tab].id);
-    }
-    return true;
-  } catch (err) {
-    console.error('[restoreSession]', err);
-    return false;
+  const state = tabStore.getState();
+  const active = offset + saved.active_tab;
+  if (saved.active_tab >= 0 && active < state.tabs.length) {
+    tabStore.setActiveTab(state.tabs[active].id);
   }
 }
 
-/** Persist current tab/pane layout to the backend session file. */
-export function saveSession(): void {
+/**
+ * Snapshot the current tab/pane layout in the backend's SessionState shape.
+ * With withScrollback, shell panes also carry their recent output
+ * (restore_scrollback).
+ */
+function buildSessionState(withScrollback = false): config.SessionState | null {
+  const keepOutput = withScrollback && !!get(appConfig).restore_scrollback;
   const state = tabStore.getState();
-  if (!state.tabs.length) return;
+  if (!state.tabs.length) return null;
   const activeIdx = state.tabs.findIndex((t) => t.id === state.activeTabId);
-  const tabs = state.tabs.map((tab) => ({
-    name: tab.name,
-    dir: tab.dir,
-    focus_idx: tab.panes.findIndex((p) => p.focused),
-    panes: tab.panes.map((pane) => ({
-      name: pane.name,
-      mode: MODE_TO_INDEX[pane.mode] ?? 0,
-      model: pane.model || '',
-      issue_number: pane.issueNumber || 0,
-      issue_branch: pane.issueBranch || '',
-      zoom_delta: pane.zoomDelta || 0,
-    })),
-  }));
-  App.SaveTabs({ active_tab: Math.max(activeIdx, 0), tabs } as any);
+  const tabs = state.tabs.map((tab) => {
+    // Mirrors have no process of their own and are not restored
+    const panes = tab.panes.filter((p) => p.mirrorOf === null);
+    return {
+      name: tab.name,
+      dir: tab.dir,
+      focus_idx: panes.findIndex((p) => p.focused),
+      panes: panes.map((pane) => ({
+        name: pane.name,
+        name_manual: pane.nameManual || undefined,
+        pinned: pane.pinned || undefined,
+        mode: MODE_TO_INDEX[pane.mode] ?? 0,
+        model: pane.model || '',
+        issue_number: pane.issueNumber || 0,
+        issue_branch: pane.issueBranch || '',
+        zoom_delta: pane.zoomDelta || 0,
+        argv: pane.argv?.length ? pane.argv : undefined,
+        dir: pane.dir || undefined,
+        env: Object.keys(pane.env ?? {}).length ? pane.env : undefined,
+        maximized: pane.id === tab.maximizedPaneId || undefined,
+        scrollback: keepOutput && pane.mode === 'shell' ? captureScrollback(pane.sessionId) || undefined : undefined,
+      })),
+    };
+  });
+  const sidebar = sidebarMemoryFor([...new Set(state.tabs.map((t) => t.dir))]);
+  return { active_tab: Math.max(activeIdx, 0), tabs, sidebar } as any;
+}
+
+/** Persist current tab/pane layout to the backend session file. */
+export function saveSession(): void {
+  const state = buildSessionState(true);
+  if (state) App.SaveTabs(state);
+}
+
+/** Close a tab with the sessions of its panes; pinned panes move on (tabStore.closeTab). */
+export function closeTab(tabId: string): void {
+  for (const pane of tabStore.closeTab(tabId)) App.CloseSession(pane.sessionId);
+}
+
+/** Save the current tab/pane layout under a name. */
+export async function saveLayout(name: string): Promise<void> {
+  const state = buildSessionState();
+  if (!state) throw new Error('Keine Tabs zum Speichern');
+  await App.SaveLayout(name, state);
+}
+
+/**
+ * Replace all open tabs with a named layout. The saved tabs are created
+ * first; the previous tabs and their sessions are closed afterwards so the
+ * window is never left empty if the layout fails to load.
+ */
+export async function loadLayout(name: string, claudePath: string): Promise<void> {
+  const layout = await App.LoadLayout(name);
+  const previous = tabStore.getState().tabs;
+  await restoreState(layout, claudePath);
+  tabStore.removeTabs(previous.map((t) => t.id));
+  for (const tab of previous) {
+    for (const pane of tab.panes) App.CloseSession(pane.sessionId);
+  }
 }
@@ -0,0 +1,201 @@
+import { describe, it, expect, vi } from 'vitest';
+import { createGlobalKeyHandler, defaultLaunchMode, keySpec, buildKeymap, isAppShortcut, ctrlCAction, isQuitKey, favoriteSlot, DOUBLE_CTRL_C } from './shortcuts';
+import type { ShortcutCallbacks } from './shortcuts';
+
+function makeCallbacks(defaultLaunch: string | undefined, overrides: Partial<ShortcutCallbacks> = {}): ShortcutCallbacks {
+  return {
+    onNewPane: vi.fn(),
+    onLaunchPane: vi.fn(),
+    getDefaultLaunch: () => defaultLaunch,
+    getKeybindings: () => undefined,
+    onNewTab: vi.fn(),
+    onCloseTab: vi.fn(),
+    onToggleSidebar: vi.fn(),
+    onToggleMaximize: vi.fn(),
+    onFocusPane: vi.fn(),
+    onInsertFavorite: vi.fn(),
+    onOpenIssues: vi.fn(),
+    onRestartPane: vi.fn(),
+    onCycleTheme: vi.fn(),
+    onSplitPane: vi.fn(),
+    canAddPane: () => true,
+    getQuitBinding: () => '',
+    onQuit: vi.fn(),
+    ...overrides,
+  };
+}
+
+function keydown(key: string, shift = false, mods: KeyboardEventInit = {}): KeyboardEvent {
+  return new KeyboardEvent('keydown', { key, ctrlKey: true, shiftKey: shift, ...mods });
+}
+
+describe('defaultLaunchMode', () => {
+  it('maps config values to pane modes', () => {
+    expect(defaultLaunchMode('shell')).toBe('shell');
+    expect(defaultLaunchMode('claude')).toBe('claude');
+    expect(defaultLaunchMode('yolo')).toBe('claude-yolo');
+  });
+
+  it('returns null for dialog and unknown values', () => {
+    expect(defaultLaunchMode('dialog')).toBeNull();
+    expect(defaultLaunchMode('')).toBeNull();
+    expect(defaultLaunchMode(undefined)).toBeNull();
+  });
+});
+
+describe('new pane shortcuts', () => {
+  it('opens the dialog on Ctrl+N by default', () => {
+    const cb = makeCallbacks('dialog');
+    createGlobalKeyHandler(cb)(keydown('n'));
+    expect(cb.onNewPane).toHaveBeenCalledOnce();
+    expect(cb.onLaunchPane).not.toHaveBeenCalled();
+  });
+
+  it('launches a Claude pane directly with default_launch=claude', () => {
+    const cb = makeCallbacks('claude');
+    createGlobalKeyHandler(cb)(keydown('n'));
+    expect(cb.onLaunchPane).toHaveBeenCalledWith('claude');
+    expect(cb.onNewPane).not.toHaveBeenCalled();
+  });
+
+  it('still opens the chooser on Ctrl+Shift+N with default_launch=claude', () => {
+    const cb = makeCallbacks('claude');
+    createGlobalKeyHandler(cb)(keydown('N', true));
+    expect(cb.onNewPane).toHaveBeenCalledOnce();
+    expect(cb.onLaunchPane).not.toHaveBeenCalled();
+  });
+
+  it('does nothing when the tab is full', () => {
+    const cb = makeCallbacks('claude', { canAddPane: () => false });
+    const handler = createGlobalKeyHandler(cb);
+    handler(keydown('n'));
+    handler(keydown('N', true));
+    handler(keydown('E', true));
+    expect(cb.onLaunchPane).not.toHaveBeenCalled();
+    expect(cb.onNewPane).not.toHaveBeenCalled();
+    expect(cb.onSplitPane).not.toHaveBeenCalled();
+  });
+
+  it('splits the focused pane right or down', () => {
+    const cb = makeCallbacks('dialog');
+    const handler = createGlobalKeyHandler(cb);
+    handler(keydown('E', true));
+    expect(cb.onSplitPane).toHaveBeenLastCalledWith('h');
+    handler(keydown('O', true));
+    expect(cb.onSplitPane).toHaveBeenLastCalledWith('v');
+    expect(cb.onNewPane).not.toHaveBeenCalled();
+  });
+});
+
+describe('keySpec', () => {
+  it('orders modifiers canonically and lowercases the key', () => {
+    expect(keySpec(keydown('N', true))).toBe('ctrl+shift+n');
+    expect(keySpec(keydown('t', false, { ctrlKey: false, altKey: true }))).toBe('alt+t');
+    expect(keySpec(keydown(' '))).toBe('ctrl+space');
+  });
+});
+
+describe('configurable keybindings', () => {
+  it('falls back to defaults for unmapped actions', () => {
+    const keymap = buildKeymap({ new_tab: 'alt+t' });
+    expect(keymap.get('alt+t')).toBe('new_tab');
+    expect(keymap.get('ctrl+t')).toBeUndefined();
+    expect(keymap.get('ctrl+w')).toBe('close_tab');
+  });
+
+  it('dispatches remapped actions and ignores the old key', () => {
+    const cb = makeCallbacks('dialog', { getKeybindings: () => ({ new_tab: 'alt+t' }) });
+    const handler = createGlobalKeyHandler(cb);
+    handler(keydown('t'));
+    expect(cb.onNewTab).not.toHaveBeenCalled();
+    handler(keydown('t', false, { ctrlKey: false, altKey: true }));
+    expect(cb.onNewTab).toHaveBeenCalledOnce();
+  });
+
+  it('restarts the focused pane on Ctrl+Shift+R', () => {
+    const cb = makeCallbacks('dialog');
+    createGlobalKeyHandler(cb)(keydown('R', true));
+    expect(cb.onRestartPane).toHaveBeenCalledOnce();
+  });
+
+  it('cycles the theme on Ctrl+Shift+T without opening a tab', () => {
+    const cb = makeCallbacks('dialog');
+    createGlobalKeyHandler(cb)(keydown('T', true));
+    expect(cb.onCycleTheme).toHaveBeenCalledOnce();
+    expect(cb.onNewTab).not.toHaveBeenCalled();
+  });
+
+  it('keeps remapped shortcuts out of the terminal but not search', () => {
+    const bindings = { toggle_sidebar: 'alt+b' };
+    expect(isAppShortcut(keydown('b', false, { ctrlKey: false, altKey: true }), bindings)).toBe(true);
+    expect(isAppShortcut(keydown('b'), bindings)).toBe(false);
+    expect(isAppShortcut(keydown('f'), bindings)).toBe(false);
+    expect(isAppShortcut(keydown(' ', true), bindings)).toBe(false);
+    expect(isAppShortcut(keydown('3'), bindings)).toBe(true);
+  });
+});
+
+describe('quit gesture', () => {
+  const opts = (quitBinding: string, interceptCtrlC = true, hasSelection = false) => ({ quitBinding, interceptCtrlC, hasSelection });
+
+  it('passes every Ctrl+C to the pane when quitting by key is off', () => {
+    const press = { last: 0 };
+    expect(ctrlCAction(opts(''), press, 1000)).toBe('pass');
+    expect(ctrlCAction(opts(''), press, 1100)).toBe('pass');
+  });
+
+  it('quits on the second of two quick Ctrl+C presses, passing the first', () => {
+    const press = { last: 0 };
+    expect(ctrlCAction(opts(DOUBLE_CTRL_C), press, 1000)).toBe('pass');
+    expect(ctrlCAction(opts(DOUBLE_CTRL_C), press, 1400)).toBe('quit');
+  });
+
+  it('does not quit when the presses are too far apart', () => {
+    const press = { last: 0 };
+    expect(ctrlCAction(opts(DOUBLE_CTRL_C), press, 1000)).toBe('pass');
+    expect(ctrlCAction(opts(DOUBLE_CTRL_C), press, 1600)).toBe('pass');
+    expect(ctrlCAction(opts(DOUBLE_CTRL_C), press, 1900)).toBe('quit');
+  });
+
+  it('copies a selection instead, which also breaks a double press', () => {
+    const press = { last: 0 };
+    expect(ctrlCAction(opts(DOUBLE_CTRL_C), press, 1000)).toBe('pass');
+    expect(ctrlCAction(opts(DOUBLE_CTRL_C, true, true), press, 1100)).toBe('copy');
+    expect(ctrlCAction(opts(DOUBLE_CTRL_C), press, 1200)).toBe('pass');
+  });
+
+  it('forwards everything with intercept_ctrl_c off', () => {
+    const press = { last: 0 };
+    expect(ctrlCAction(opts(DOUBLE_CTRL_C, false, true), press, 1000)).toBe('pass');
+    expect(ctrlCAction(opts(DOUBLE_CTRL_C, false), press, 1100)).toBe('pass');
+  });
+
+  it('quits on a dedicated key from anywhere in the app', () => {
+    expect(isQuitKey(keydown('q'), 'ctrl+q')).toBe(true);
+    expect(isQuitKey(keydown('q'), '')).toBe(false);
+    expect(isQuitKey(keydown('c'), DOUBLE_CTRL_C)).toBe(false);
+
+    const cb = makeCallbacks('dialog', { getQuitBinding: () => 'ctrl+q' });
+    createGlobalKeyHandler(cb)(keydown('q'));
+    expect(cb.onQuit).toHaveBeenCalledOnce();
+  });
+});
+
+describe('favorite shortcuts', () => {
+  const alt = (code: string, key: string, mods: KeyboardEventInit = {}) =>
+    new KeyboardEvent('keydown', { key, code, altKey: true, ...mods });
+
+  it('maps Alt+1-9 to favorite slots by physical key', () => {
+    expect(favoriteSlot(alt('Digit1', '1'))).toBe(0);
+    expect(favoriteSlot(alt('Digit9', '»'))).toBe(8); // layout changes e.key
+    expect(favoriteSlot(alt('Digit0', '0'))).toBe(-1);
+    expect(favoriteSlot(alt('Digit2', '2', { ctrlKey: true }))).toBe(-1);
+  });
+
+  it('inserts the favorite and keeps the key from the pane', () => {
+    const cb = makeCallbacks('dialog');
+    createGlobalKeyHandler(cb)(alt('Digit3', '3'));
+    expect(cb.onInsertFavorite).toHaveBeenCalledWith(2);
+    expect(isAppShortcut(alt('Digit3', '3'))).toBe(true);
+  });
+});
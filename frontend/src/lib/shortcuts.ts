@@ -1,52 +1,240 @@
+import type { PaneMode } from '../stores/tabs';
+import type { SplitDir } from './layout';
+
+export type ShortcutAction =
+  | 'new_pane'
+  | 'launch_dialog'
+  | 'new_tab'
+  | 'close_tab'
+  | 'toggle_sidebar'
+  | 'toggle_maximize'
+  | 'open_issues'
+  | 'restart_pane'
+  | 'cycle_theme'
+  | 'search'
+  | 'select_mode'
+  | 'command_history'
+  | 'toggle_readonly'
+  | 'toggle_pin'
+  | 'split_horizontal'
+  | 'split_vertical';
+
+/** Built-in bindings; mirrors defaultKeybindings in internal/config. */
+export const DEFAULT_KEYBINDINGS: Record<ShortcutAction, string> = {
+  new_pane: 'ctrl+n',
+  launch_dialog: 'ctrl+shift+n',
+  new_tab: 'ctrl+t',
+  close_tab: 'ctrl+w',
+  toggle_sidebar: 'ctrl+b',
+  toggle_maximize: 'ctrl+z',
+  open_issues: 'ctrl+i',
+  restart_pane: 'ctrl+shift+r',
+  cycle_theme: 'ctrl+shift+t',
+  search: 'ctrl+f',
+  select_mode: 'ctrl+shift+space',
+  command_history: 'ctrl+shift+h',
+  toggle_readonly: 'ctrl+shift+l',
+  toggle_pin: 'ctrl+shift+k',
+  split_horizontal: 'ctrl+shift+e',
+  split_vertical: 'ctrl+shift+o',
+};
+
+/** Actions handled by the focused terminal pane rather than the app. */
+const PANE_ACTIONS: ReadonlySet<ShortcutAction> = new Set(['search', 'select_mode', 'command_history', 'toggle_readonly', 'toggle_pin']);
+
 export interface ShortcutCallbacks {
   onNewPane: () => void;
+  onLaunchPane: (mode: PaneMode) => void;
+  getDefaultLaunch: () => string | undefined;
+  getKeybindings: () => Record<string, string> | undefined;
   onNewTab: () => void;
   onCloseTab: () => void;
   onToggleSidebar: () => void;
   onToggleMaximize: () => void;
   onFocusPane: (index: number) => void;
+  onInsertFavorite: (index: number) => void;
   onOpenIssues: () => void;
+  onRestartPane: () => void;
+  onCycleTheme: () => void;
+  onSplitPane: (dir: SplitDir) => void;
   canAddPane: () => boolean;
+  getQuitBinding: () => string | undefined;
+  onQuit: () => void;
+}
+
+/** quit_binding value for quitting with two quick Ctrl+C presses; mirrors config.DoubleCtrlC. */
+export const DOUBLE_CTRL_C = 'double_ctrl_c';
+/** Longest gap between the two presses of a double Ctrl+C. */
+export const DOUBLE_CTRL_C_MS = 500;
+
+export interface CtrlCOptions {
+  quitBinding: string; // quit_binding config value
+  interceptCtrlC: boolean; // intercept_ctrl_c config value
+  hasSelection: boolean;
+}
+
+/** Time of the previous Ctrl+C that counts towards a double press (0 = none). */
+export interface DoublePress {
+  last: number;
+}
+
+/**
+ * What a terminal pane does with Ctrl+C: copy the selection, quit the app
+ * (second press of a double Ctrl+C) or pass it to the PTY. The first press
+ * of a double Ctrl+C is passed on, so an interrupt is never swallowed, and
+ * with intercept_ctrl_c off every press goes to the PTY.
+ */
+export function ctrlCAction(opts: CtrlCOptions, press: DoublePress, now: number): 'copy' | 'quit' | 'pass' {
+  if (!opts.interceptCtrlC) return 'pass';
+  if (opts.hasSelection) {
+    press.last = 0;
+    return 'copy';
+  }
+  if (opts.quitBinding !== DOUBLE_CTRL_C) return 'pass';
+  if (press.last > 0 && now - press.last <= DOUBLE_CTRL_C_MS) {
+    press.last = 0;
+    return 'quit';
+  }
+  press.last = now;
+  return 'pass';
+}
+
+/** Whether e is the dedicated quit key (quit_binding set to a key spec). */
+export function isQuitKey(e: KeyboardEvent, quitBinding: string | undefined): boolean {
+  return !!quitBinding && quitBinding !== DOUBLE_CTRL_C && keySpec(e) === quitBinding;
+}
+
+/** Map the default_launch config value to a pane mode (null = show dialog). */
+export function defaultLaunchMode(value: string | undefined): PaneMode | null {
+  switch (value) {
+    case 'shell': return 'shell';
+    case 'claude': return 'claude';
+    case 'yolo': return 'claude-yolo';
+    default: return null;
+  }
+}
+
+/** Normalised key spec for an event, e.g. "ctrl+shift+n". */
+export function keySpec(e: KeyboardEvent): string {
+  const parts: string[] = [];
+  if (e.ctrlKey) parts.push('ctrl');
+  if (e.altKey) parts.push('alt');
+  if (e.shiftKey) parts.push('shift');
+  if (e.metaKey) parts.push('meta');
+  parts.push(e.key === ' ' ? 'space' : e.key.toLowerCase());
+  return parts.join('+');
+}
+
+/** Build a key spec → action lookup, falling back to defaults for unmapped actions. */
+export function buildKeymap(bindings?: Record<string, string>): Map<string, ShortcutAction> {
+  const keymap = new Map<string, ShortcutAction>();
+  for (const action of Object.keys(DEFAULT_KEYBINDINGS) as ShortcutAction[]) {
+    const spec = (bindings?.[action] || DEFAULT_KEYBINDINGS[action]).toLowerCase();
+    if (!keymap.has(spec)) keymap.set(spec, action);
+  }
+  return keymap;
+}
+
+/** Return the application action bound to this key event, if any. */
+export function matchShortcut(e: KeyboardEvent, bindings?: Record<string, string>): ShortcutAction | null {
+  return buildKeymap(bindings).get(keySpec(e)) ?? null;
+}
+
+/**
+ * Favorite slot (0-8) for Alt+1-9, which types that favorite's path into
+ * the focused pane; -1 for other keys. Uses the physical key, since Alt
+ * changes e.key on some layouts.
+ */
+export function favoriteSlot(e: KeyboardEvent): number {
+  if (!e.altKey || e.ctrlKey || e.shiftKey || e.metaKey) return -1;
+  const m = /^Digit([1-9])$/.exec(e.code);
+  return m ? parseInt(m[1]) - 1 : -1;
+}
+
+/** Whether a terminal pane should let this key through to the app instead of the PTY. */
+export function isAppShortcut(e: KeyboardEvent, bindings?: Record<string, string>): boolean {
+  const action = matchShortcut(e, bindings);
+  if (action && !PANE_ACTIONS.has(action)) return true;
+  if (favoriteSlot(e) >= 0) return true;
+  return e.ctrlKey && !e.altKey && !e.shiftKey && e.key >= '1' && e.key <= '9';
 }
 
 /** Create a global keydown handler for the application shortcuts. */
 export function createGlobalKeyHandler(cb: ShortcutCallbacks): (e: KeyboardEvent) => void {
   return (e: KeyboardEvent) => {
-    if (!e.ctrlKey) return;
-
-    switch (e.key) {
-      case 'n':
+    if (isQuitKey(e, cb.getQuitBinding())) {
+      e.preventDefault();
+      cb.onQuit();
+      return;
+    }
+    const action = matchShortcut(e, cb.getKeybindings());
+    switch (action) {
+      case 'new_pane': {
+        e.preventDefault();
+        if (!cb.canAddPane()) return;
+        const mode = defaultLaunchMode(cb.getDefaultLaunch());
+        if (mode) cb.onLaunchPane(mode);
+        else cb.onNewPane();
+        return;
+      }
+      case 'launch_dialog':
+        // Always opens the launch dialog, regardless of default_launch
         e.preventDefault();
         if (cb.canAddPane()) cb.onNewPane();
         return;
-      case 't':
+      case 'new_tab':
         e.preventDefault();
         cb.onNewTab();
         return;
-      case 'w':
+      case 'close_tab':
         e.preventDefault();
         cb.onCloseTab();
         return;
-      case 'b':
+      case 'toggle_sidebar':
         e.preventDefault();
         cb.onToggleSidebar();
         return;
-      case 'z':
+      case 'toggle_maximize':
         e.preventDefault();
         cb.onToggleMaximize();
         return;
-      case 'i':
+      case 'open_issues':
         e.preventDefault();
         cb.onOpenIssues();
         return;
-      case 'f':
-        return; // let terminal pane handle search
+      case 'restart_pane':
+        e.preventDefault();
+        cb.onRestartPane();
+        return;
+      case 'cycle_theme':
+        e.preventDefault();
+        cb.onCycleTheme();
+        return;
+      case 'split_horizontal':
+      case 'split_vertical':
+        e.preventDefault();
+        if (cb.canAddPane()) cb.onSplitPane(action === 'split_horizontal' ? 'h' : 'v');
+        return;
+      case 'search':
+      case 'select_mode':
+      case 'command_history':
+      case 'toggle_readonly':
+      case 'toggle_pin':
+        return; // handled by the terminal pane
     }
 
     // Ctrl+1-9 → focus pane by index
-    if (e.key >= '1' && e.key <= '9') {
+    if (e.ctrlKey && !e.altKey && !e.shiftKey && e.key >= '1' && e.key <= '9') {
       e.preventDefault();
       cb.onFocusPane(parseInt(e.key) - 1);
+      return;
+    }
+
+    // Alt+1-9 → type the path of favorite N into the focused pane
+    const slot = favoriteSlot(e);
+    if (slot >= 0) {
+      e.preventDefault();
+      cb.onInsertFavorite(slot);
     }
   };
 }
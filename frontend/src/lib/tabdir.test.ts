@@ -0,0 +1,38 @@
+import { describe, it, expect } from 'vitest';
+import { newTabDir, tabNameForDir } from './tabdir';
+
+describe('newTabDir', () => {
+  const dirs = { tab: '/work/app', pane: '/work/app/web', fixed: '/home/me' };
+
+  it('asks with the dialog by default', () => {
+    expect(newTabDir('dialog', dirs)).toBeNull();
+    expect(newTabDir(undefined, dirs)).toBeNull();
+  });
+
+  it('uses the directory the mode names', () => {
+    expect(newTabDir('inherit-tab', dirs)).toBe('/work/app');
+    expect(newTabDir('inherit-pane-cwd', dirs)).toBe('/work/app/web');
+    expect(newTabDir('fixed', dirs)).toBe('/home/me');
+  });
+
+  it('falls back to the tab directory without a pane cwd', () => {
+    expect(newTabDir('inherit-pane-cwd', { tab: '/work/app', pane: '' })).toBe('/work/app');
+  });
+
+  it('falls back to the dialog when no directory is known', () => {
+    expect(newTabDir('inherit-tab', {})).toBeNull();
+    expect(newTabDir('inherit-pane-cwd', {})).toBeNull();
+  });
+});
+
+describe('tabNameForDir', () => {
+  it('takes the last path component', () => {
+    expect(tabNameForDir('/work/app/web')).toBe('web');
+    expect(tabNameForDir('C:\\Users\\me\\repo')).toBe('repo');
+    expect(tabNameForDir('/work/app/')).toBe('app');
+  });
+
+  it('has a name for the root', () => {
+    expect(tabNameForDir('/')).toBe('Projekt');
+  });
+});
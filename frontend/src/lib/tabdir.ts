@@ -0,0 +1,35 @@
+/**
+ * Where Ctrl+T opens a new tab (config: new_tab_dir_mode).
+ */
+
+export interface TabDirCandidates {
+  /** Directory of the active tab. */
+  tab?: string;
+  /** Current directory of the focused pane (OSC 7 or process cwd). */
+  pane?: string;
+  /** default_dir, or the app's working directory. */
+  fixed?: string;
+}
+
+/**
+ * Directory for a new tab, or null to ask with the project dialog. Modes
+ * fall back along pane cwd → tab dir, and to the dialog when nothing is
+ * known (e.g. no tab is open yet).
+ */
+export function newTabDir(mode: string | undefined, dirs: TabDirCandidates): string | null {
+  switch (mode) {
+    case 'inherit-tab':
+      return dirs.tab || null;
+    case 'inherit-pane-cwd':
+      return dirs.pane || dirs.tab || null;
+    case 'fixed':
+      return dirs.fixed || null;
+    default:
+      return null;
+  }
+}
+
+/** Tab name for a directory: its last path component. */
+export function tabNameForDir(dir: string): string {
+  return dir.replace(/\\/g, '/').replace(/\/+$/, '').split('/').pop() || 'Projekt';
+}
@@ -0,0 +1,34 @@
+import { describe, it, expect } from 'vitest';
+import { getTerminalTheme, ANSI_COLOR_KEYS } from './terminal';
+
+describe('getTerminalTheme', () => {
+  it('uses the theme palette without overrides', () => {
+    expect(getTerminalTheme('nord').red).toBe(getTerminalTheme('nord', {}).red);
+  });
+
+  it('overrides ANSI colors by position', () => {
+    const theme = getTerminalTheme('nord', { nord: ['#000000', '', '#00ff00'] });
+    const base = getTerminalTheme('nord');
+    expect(theme.black).toBe('#000000');
+    expect(theme.red).toBe(base.red);
+    expect(theme.green).toBe('#00ff00');
+    expect(theme.background).toBe(base.background);
+  });
+
+  it('maps the 16th entry to bright white', () => {
+    const palette = Array(16).fill('');
+    palette[15] = '#ffffff';
+    expect(getTerminalTheme('dark', { dark: palette }).brightWhite).toBe('#ffffff');
+    expect(ANSI_COLOR_KEYS).toHaveLength(16);
+  });
+
+  it('does not change the shared theme', () => {
+    const before = getTerminalTheme('dracula').black;
+    getTerminalTheme('dracula', { dracula: ['#123456'] });
+    expect(getTerminalTheme('dracula').black).toBe(before);
+  });
+
+  it('ignores palettes of other themes', () => {
+    expect(getTerminalTheme('light', { nord: ['#000000'] }).black).toBe(getTerminalTheme('light').black);
+  });
+});
@@ -2,6 +2,7 @@ import { Terminal } from '@xterm/xterm';
 import { FitAddon } from '@xterm/addon-fit';
 import { SearchAddon } from '@xterm/addon-search';
 import { createWebLinksAddon, registerFileLinkProvider, type LinkHandler } from './links';
+import { getCustomTheme } from '../stores/theme';
 
 /** Curated list of monospace fonts. Order = priority for fallback chain. */
 export const MONOSPACE_FONTS = [
@@ -51,6 +52,7 @@ const baseOptions: Partial<import('@xterm/xterm').ITerminalOptions> = {
   cursorBlink: true,
   cursorStyle: 'block',
   scrollback: 10000,
+  scrollOnUserInput: true, // typing snaps the view back to the prompt
   allowProposedApi: true,
 };
 
@@ -168,17 +170,56 @@ const terminalThemes: Record<string, import('@xterm/xterm').ITheme> = {
   },
 };
 
+/**
+ * Returns the number of pages to scroll the viewport for Shift+PageUp (-1)
+ * and Shift+PageDown (+1), or 0 if the key should go to the terminal.
+ */
+export function scrollPagesForKey(e: KeyboardEvent): number {
+  if (!e.shiftKey || e.ctrlKey || e.altKey || e.metaKey) return 0;
+  if (e.key === 'PageUp') return -1;
+  if (e.key === 'PageDown') return 1;
+  return 0;
+}
+
+/**
+ * Size of one cell in CSS pixels, rounded, for programs that ask for pixel
+ * geometry (image protocols). xterm.js has no public API for it yet, so
+ * this reads the renderer's dimensions and returns null if they moved.
+ */
+export function cellPixelSize(terminal: Terminal): { width: number; height: number } | null {
+  const cell = (terminal as any)._core?._renderService?.dimensions?.css?.cell;
+  if (!cell?.width || !cell?.height) return null;
+  return { width: Math.round(cell.width), height: Math.round(cell.height) };
+}
+
+/** Whether the viewport is scrolled up into the scrollback. */
+export function isScrolledUp(terminal: Terminal): boolean {
+  const buf = terminal.buffer.active;
+  return buf.viewportY < buf.baseY;
+}
+
+/**
+ * Whether the program in the terminal enabled mouse tracking (CSI ?1000h,
+ * ?1002h, ?1003h or X10 ?9h). xterm.js then encodes clicks, drags and wheel
+ * events in the requested mode and protocol (X10 or SGR ?1006h) and sends
+ * them to the PTY; otherwise the wheel scrolls the local scrollback.
+ */
+export function mouseTrackingActive(terminal: Terminal): boolean {
+  return terminal.modes.mouseTrackingMode !== 'none';
+}
+
 export function createTerminal(
   theme: string = 'dark',
   linkHandler?: LinkHandler,
   fontFamily?: string,
   fontSize?: number,
+  palettes?: Record<string, string[]>,
 ): TerminalInstance {
   const terminal = new Terminal({
     ...baseOptions,
     fontFamily: buildFontFamily(fontFamily || ''),
     fontSize: fontSize || 10,
-    theme: terminalThemes[theme] || terminalThemes.dark,
+    theme: getTerminalTheme(theme, palettes),
   });
 
   const fitAddon = new FitAddon();
@@ -187,6 +228,17 @@ export function createTerminal(
   const searchAddon = new SearchAddon();
   terminal.loadAddon(searchAddon);
 
+  // The backend screen answers device status, cursor position and device
+  // attribute queries (CSI 5n / 6n, CSI c, CSI > c) as soon as the output
+  // arrives. Swallow them here so the program does not get a second, later
+  // reply from xterm.js.
+  const isDefault = (params: (number | number[])[]) => params.length <= 1 && !params[0];
+  const queryDisposables = [
+    terminal.parser.registerCsiHandler({ final: 'n' }, (params) => params[0] === 5 || params[0] === 6),
+    terminal.parser.registerCsiHandler({ final: 'c' }, isDefault),
+    terminal.parser.registerCsiHandler({ prefix: '>', final: 'c' }, isDefault),
+  ];
+
   let fileLinkDisposable: { dispose(): void } | undefined;
   if (linkHandler) {
     terminal.loadAddon(createWebLinksAddon(linkHandler));
@@ -199,6 +251,7 @@ export function createTerminal(
     searchAddon,
     dispose: () => {
       fileLinkDisposable?.dispose();
+      queryDisposables.forEach((d) => d.dispose());
       searchAddon.dispose();
       fitAddon.dispose();
       terminal.dispose();
@@ -206,6 +259,36 @@ export function createTerminal(
   };
 }
 
-export function getTerminalTheme(theme: string): import('@xterm/xterm').ITheme {
-  return terminalThemes[theme] || terminalThemes.dark;
+/** ITheme keys of the 16 ANSI colors, in palette order (ansi_palettes). */
+export const ANSI_COLOR_KEYS = [
+  'black', 'red', 'green', 'yellow', 'blue', 'magenta', 'cyan', 'white',
+  'brightBlack', 'brightRed', 'brightGreen', 'brightYellow',
+  'brightBlue', 'brightMagenta', 'brightCyan', 'brightWhite',
+] as const;
+
+/**
+ * Terminal colors for an app theme. palettes (config: ansi_palettes) can
+ * override the theme's ANSI colors by position; empty entries keep them.
+ */
+export function getTerminalTheme(theme: string, palettes?: Record<string, string[]>): import('@xterm/xterm').ITheme {
+  const colors = { ...baseTerminalTheme(theme) };
+  (palettes?.[theme] ?? []).forEach((color, i) => {
+    if (color && i < ANSI_COLOR_KEYS.length) colors[ANSI_COLOR_KEYS[i]] = color;
+  });
+  return colors;
+}
+
+function baseTerminalTheme(theme: string): import('@xterm/xterm').ITheme {
+  if (terminalThemes[theme]) return terminalThemes[theme];
+  // Custom themes only define UI colors: keep the dark ANSI palette and
+  // take background, foreground and selection from the theme.
+  const custom = getCustomTheme(theme);
+  if (!custom) return terminalThemes.dark;
+  return {
+    ...terminalThemes.dark,
+    background: custom.paneBg,
+    foreground: custom.fg,
+    cursor: custom.fg,
+    selectionBackground: custom.bgTertiary.length === 7 ? custom.bgTertiary + '80' : custom.bgTertiary,
+  };
 }
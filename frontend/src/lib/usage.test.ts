@@ -0,0 +1,40 @@
+import { describe, it, expect } from 'vitest';
+import { formatBytes, formatDuration, uptimeLabel, usageLabel, NO_STATS, NO_USAGE } from './usage';
+
+describe('formatBytes', () => {
+  it('picks MB or GB with sensible precision', () => {
+    expect(formatBytes(3.5 * (1 << 20))).toBe('3.5 MB');
+    expect(formatBytes(148.4 * (1 << 20))).toBe('148 MB');
+    expect(formatBytes(1.25 * (1 << 30))).toBe('1.3 GB');
+  });
+});
+
+describe('usageLabel', () => {
+  it('is empty before the first sample', () => {
+    expect(usageLabel(NO_USAGE)).toBe('');
+  });
+
+  it('rounds the CPU share and allows more than one core', () => {
+    expect(usageLabel({ cpu_percent: 12.4, rss_bytes: 148 * (1 << 20) })).toBe('CPU 12% · 148 MB');
+    expect(usageLabel({ cpu_percent: 203.6, rss_bytes: 2 * (1 << 30) })).toBe('CPU 204% · 2.0 GB');
+  });
+});
+
+describe('formatDuration', () => {
+  it('uses seconds, minutes or hours with padded minutes', () => {
+    expect(formatDuration(45)).toBe('45s');
+    expect(formatDuration(12 * 60 + 30)).toBe('12m');
+    expect(formatDuration(2 * 3600 + 5 * 60)).toBe('2h 05m');
+    expect(formatDuration(-3)).toBe('0s');
+  });
+});
+
+describe('uptimeLabel', () => {
+  it('is empty for panes without a process', () => {
+    expect(uptimeLabel(NO_STATS)).toBe('');
+  });
+
+  it('shows the uptime', () => {
+    expect(uptimeLabel({ uptime_seconds: 3700, idle_seconds: 60 })).toBe('läuft 1h 01m');
+  });
+});
@@ -0,0 +1,67 @@
+/**
+ * CPU and memory use of the focused pane's process tree, sampled by the
+ * backend scan loop, and the pane's uptime; both are shown in the footer.
+ */
+import * as App from '../../wailsjs/go/backend/App';
+
+export interface SessionUsage {
+  cpu_percent: number; // share of one core; above 100 when several are busy
+  rss_bytes: number;
+}
+
+export const NO_USAGE: SessionUsage = { cpu_percent: 0, rss_bytes: 0 };
+
+// CPU share from which the footer highlights a possibly runaway process
+export const HOT_CPU_PERCENT = 90;
+
+export async function fetchSessionUsage(sessionId: number): Promise<SessionUsage> {
+  try {
+    return { ...NO_USAGE, ...(await App.GetSessionUsage(sessionId)) };
+  } catch {
+    return NO_USAGE;
+  }
+}
+
+export interface SessionStats {
+  uptime_seconds: number; // since the pane's process was started
+  idle_seconds: number; // since its last output
+}
+
+export const NO_STATS: SessionStats = { uptime_seconds: 0, idle_seconds: 0 };
+
+export async function fetchSessionStats(sessionId: number): Promise<SessionStats> {
+  try {
+    return { ...NO_STATS, ...(await App.GetSessionStats(sessionId)) };
+  } catch {
+    return NO_STATS;
+  }
+}
+
+/** Human-readable size, e.g. "148 MB" or "1.2 GB". */
+export function formatBytes(bytes: number): string {
+  const mb = bytes / (1 << 20);
+  if (mb >= 1024) return `${(mb / 1024).toFixed(1)} GB`;
+  if (mb >= 10) return `${Math.round(mb)} MB`;
+  return `${mb.toFixed(1)} MB`;
+}
+
+/** Footer text like "CPU 12% · 148 MB"; empty until the first sample. */
+export function usageLabel(u: SessionUsage): string {
+  if (u.rss_bytes <= 0) return '';
+  return `CPU ${Math.round(u.cpu_percent)}% · ${formatBytes(u.rss_bytes)}`;
+}
+
+/** Short duration like "45s", "12m" or "2h 05m". */
+export function formatDuration(seconds: number): string {
+  const s = Math.max(0, Math.floor(seconds));
+  if (s < 60) return `${s}s`;
+  const m = Math.floor(s / 60);
+  if (m < 60) return `${m}m`;
+  return `${Math.floor(m / 60)}h ${String(m % 60).padStart(2, '0')}m`;
+}
+
+/** Footer text like "läuft 2h 05m"; empty for panes without a process. */
+export function uptimeLabel(s: SessionStats): string {
+  if (s.uptime_seconds <= 0) return '';
+  return `läuft ${formatDuration(s.uptime_seconds)}`;
+}
@@ -10,6 +10,15 @@ export interface CommandEntry {
   text: string;
 }
 
+export interface LaunchProfile {
+  label: string;
+  argv: string[];
+  dir?: string;
+  mode: string; // 'shell' | 'claude' | 'yolo'
+  startup_command?: string;
+  env?: Record<string, string>; // extra environment, overrides inherited vars
+}
+
 export interface AudioConfig {
   enabled?: boolean;
   volume: number;
@@ -25,20 +34,37 @@ export interface AppConfig {
   theme: string;
   terminal_color: string;
   max_panes_per_tab: number;
+  max_sessions?: number; // open terminals across all tabs
   sidebar_width: number;
   claude_command: string;
   claude_models: ModelEntry[];
   commit_reminder_minutes: number;
+  commit_reminder_warning_factor?: number;
+  commit_reminder_danger_factor?: number;
+  commit_reminder_notify?: boolean;
   restore_session?: boolean;
+  restore_scrollback?: boolean;
   logging_enabled?: boolean;
   use_worktrees?: boolean;
   commands: CommandEntry[];
   audio: AudioConfig;
   localhost_auto_open: string;
   sidebar_pinned: boolean;
+  sidebar_ignore?: string[];
+  sidebar_show_hidden?: boolean;
   font_family: string;
   font_size: number;
   favorites: Record<string, string[]>;
+  workspace_folders?: string[];
+  default_launch?: string;
+  keybindings?: Record<string, string>;
+  quit_binding?: string; // "" | "double_ctrl_c" | key spec such as "ctrl+q"
+  intercept_ctrl_c?: boolean; // Ctrl+C copies a selection; false = always sent to the pane
+  launch_profiles?: LaunchProfile[];
+  startup_command?: string; // typed into new shell panes
+  paste_safety?: string; // "off" | "strip" | "confirm"
+  paste_warn_dangerous?: boolean; // confirm pastes with rm -rf, curl | sh, ...
+  custom_themes?: Record<string, Record<string, string>>; // name → snake_case color key → hex
 }
 
 export const config = writable<AppConfig>({
@@ -47,6 +73,7 @@ export const config = writable<AppConfig>({
   theme: 'dark',
   terminal_color: '#39ff14',
   max_panes_per_tab: 12,
+  max_sessions: 50,
   sidebar_width: 30,
   claude_command: 'claude',
   claude_models: [
@@ -56,6 +83,8 @@ export const config = writable<AppConfig>({
     { label: 'Haiku 4.5', id: 'claude-haiku-4-5-20251001' },
   ],
   commit_reminder_minutes: 30,
+  commit_reminder_warning_factor: 2,
+  commit_reminder_danger_factor: 3,
   commands: [
     { name: 'Commit & Push', text: "git add -A && git commit -m 'update' && git push" },
   ],
@@ -72,4 +101,5 @@ export const config = writable<AppConfig>({
   font_family: '',
   font_size: 10,
   favorites: {},
+  default_launch: 'dialog',
 });
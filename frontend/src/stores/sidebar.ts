@@ -0,0 +1,42 @@
+import { writable, get } from 'svelte/store';
+import { withExpanded } from '../lib/filetree';
+
+/** What the file tree remembers for one root directory (config.SidebarState). */
+export interface SidebarMemory {
+  expanded?: string[];
+  selected?: string;
+  scroll?: number;
+}
+
+/** File tree state per tab directory; saved with the session. */
+export const sidebarMemory = writable<Record<string, SidebarMemory>>({});
+
+function update(dir: string, fn: (m: SidebarMemory) => SidebarMemory) {
+  sidebarMemory.update((all) => ({ ...all, [dir]: fn(all[dir] || {}) }));
+}
+
+export function rememberExpanded(dir: string, path: string, open: boolean) {
+  update(dir, (m) => ({ ...m, expanded: withExpanded(m.expanded || [], path, open) }));
+}
+
+export function rememberSelected(dir: string, path: string) {
+  update(dir, (m) => ({ ...m, selected: path }));
+}
+
+export function rememberScroll(dir: string, scroll: number) {
+  update(dir, (m) => ({ ...m, scroll: Math.round(scroll) }));
+}
+
+export function recall(dir: string): SidebarMemory {
+  return get(sidebarMemory)[dir] || {};
+}
+
+/** The memory for the given directories only, e.g. those of open tabs. */
+export function sidebarMemoryFor(dirs: string[]): Record<string, SidebarMemory> {
+  const all = get(sidebarMemory);
+  const out: Record<string, SidebarMemory> = {};
+  for (const dir of dirs) {
+    if (all[dir]) out[dir] = all[dir];
+  }
+  return out;
+}
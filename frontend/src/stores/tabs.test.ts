@@ -1,6 +1,7 @@
 import { describe, it, expect, beforeEach } from 'vitest';
 import { get } from 'svelte/store';
-import { tabStore, activeTab, allTabs } from './tabs';
+import { tabStore, activeTab, allTabs, paneTitle, MAX_PANES_PER_TAB } from './tabs';
+import { layoutRects, paneIds } from '../lib/layout';
 
 // Note: tabStore uses internal counters that persist across tests.
 // We work with that by testing behavior rather than exact IDs.
@@ -77,6 +78,62 @@ describe('tabStore', () => {
       expect(state.activeTabId).not.toBe(id2);
       expect(state.tabs.find((t) => t.id === state.activeTabId)).toBeDefined();
     });
+
+    it('moves pinned panes to the next tab and returns the others', () => {
+      const id1 = tabStore.addTab('Server');
+      const id2 = tabStore.addTab('Work');
+      const server = tabStore.addPane(id1, 101, 'dev server', 'shell', '');
+      const scratch = tabStore.addPane(id1, 102, 'scratch', 'shell', '');
+      tabStore.addPane(id2, 103, 'editor', 'shell', '');
+      tabStore.togglePinned(id1, server);
+
+      const closed = tabStore.closeTab(id1);
+      expect(closed.map((p) => p.id)).toEqual([scratch]);
+      const work = tabStore.getState().tabs.find((t) => t.id === id2)!;
+      expect(work.panes.map((p) => p.sessionId)).toEqual([103, 101]);
+      expect(work.panes.filter((p) => p.focused)).toHaveLength(1);
+    });
+
+    it('moves pinned panes that do not fit into a new background tab', () => {
+      const id1 = tabStore.addTab('Servers');
+      const id2 = tabStore.addTab('Full');
+      const a = tabStore.addPane(id1, 201, 'api', 'shell', '');
+      const b = tabStore.addPane(id1, 202, 'web', 'shell', '');
+      tabStore.togglePinned(id1, a);
+      tabStore.togglePinned(id1, b);
+      for (let i = 0; i < MAX_PANES_PER_TAB - 1; i++) {
+        tabStore.addPane(id2, 300 + i, `p${i}`, 'shell', '');
+      }
+      tabStore.setActiveTab(id2);
+
+      tabStore.closeTab(id1);
+      const state = tabStore.getState();
+      const full = state.tabs.find((t) => t.id === id2)!;
+      expect(full.panes).toHaveLength(MAX_PANES_PER_TAB);
+      expect(full.panes.at(-1)!.sessionId).toBe(201);
+      const spill = state.tabs.find((t) => t.panes.some((p) => p.sessionId === 202))!;
+      expect(spill.id).not.toBe(id2);
+      expect(spill.name).toBe('Servers');
+      expect(spill.focusedPaneId).toBe(b);
+      expect(state.activeTabId).toBe(id2);
+    });
+  });
+
+  describe('removeTabs', () => {
+    it('removes tabs even if none would remain', () => {
+      const before = tabStore.getState().tabs.map((t) => t.id);
+      const a = tabStore.addTab('RemoveA');
+      const b = tabStore.addTab('RemoveB');
+      tabStore.removeTabs([...before, a]);
+
+      const state = tabStore.getState();
+      expect(state.tabs.map((t) => t.id)).toEqual([b]);
+      expect(state.activeTabId).toBe(b);
+
+      tabStore.removeTabs([b]);
+      expect(tabStore.getState().tabs).toHaveLength(0);
+      expect(tabStore.getState().activeTabId).toBe('');
+    });
   });
 
   describe('setActiveTab', () => {
@@ -138,7 +195,7 @@ describe('tabStore', () => {
       expect(pane!.running).toBe(true);
       expect(pane!.activity).toBe('idle');
       expect(pane!.cost).toBe('');
-      expect(pane!.maximized).toBe(false);
+      expect(tab!.maximizedPaneId).toBe('');
     });
 
     it('focuses the new pane and unfocuses others', () => {
@@ -198,21 +255,100 @@ describe('tabStore', () => {
     });
   });
 
+  describe('placeSplit', () => {
+    it('puts the new pane next to the target and keeps the grid order', () => {
+      const tabId = tabStore.addTab('SplitTest');
+      const p1 = tabStore.addPane(tabId, 1, 'P1', 'shell', '');
+      const p2 = tabStore.addPane(tabId, 2, 'P2', 'shell', '');
+      const p3 = tabStore.addPane(tabId, 3, 'P3', 'shell', '');
+
+      tabStore.placeSplit(tabId, p1, p3, 'v');
+      const tab = tabStore.getState().tabs.find((t) => t.id === tabId)!;
+      const rects = layoutRects(tab.layout!);
+      expect(rects.get(p1)).toEqual({ x: 0, y: 0, w: 0.5, h: 0.5 });
+      expect(rects.get(p3)).toEqual({ x: 0, y: 0.5, w: 0.5, h: 0.5 });
+      expect(rects.get(p2)).toEqual({ x: 0.5, y: 0, w: 0.5, h: 1 });
+    });
+
+    it('tracks later panes and drops the tree at one pane', () => {
+      const tabId = tabStore.addTab('SplitCloseTest');
+      const p1 = tabStore.addPane(tabId, 1, 'P1', 'shell', '');
+      const p2 = tabStore.addPane(tabId, 2, 'P2', 'shell', '');
+      tabStore.placeSplit(tabId, p1, p2, 'h');
+      const p3 = tabStore.addPane(tabId, 3, 'P3', 'shell', '');
+
+      let tab = tabStore.getState().tabs.find((t) => t.id === tabId)!;
+      expect(paneIds(tab.layout)).toEqual([p1, p3, p2]);
+
+      tabStore.closePane(tabId, p3);
+      tab = tabStore.getState().tabs.find((t) => t.id === tabId)!;
+      expect(paneIds(tab.layout)).toEqual([p1, p2]);
+
+      tabStore.closePane(tabId, p2);
+      tab = tabStore.getState().tabs.find((t) => t.id === tabId)!;
+      expect(tab.layout).toBeNull();
+    });
+  });
+
   describe('toggleMaximize', () => {
-    it('toggles pane maximized state', () => {
+    it('toggles the tab zoom for a pane', () => {
       const tabId = tabStore.addTab('MaxTest');
       const paneId = tabStore.addPane(tabId, 1, 'P1', 'shell', '');
 
       let tab = tabStore.getState().tabs.find((t) => t.id === tabId);
-      expect(tab!.panes[0].maximized).toBe(false);
+      expect(tab!.maximizedPaneId).toBe('');
 
       tabStore.toggleMaximize(tabId, paneId);
       tab = tabStore.getState().tabs.find((t) => t.id === tabId);
-      expect(tab!.panes[0].maximized).toBe(true);
+      expect(tab!.maximizedPaneId).toBe(paneId);
 
       tabStore.toggleMaximize(tabId, paneId);
       tab = tabStore.getState().tabs.find((t) => t.id === tabId);
-      expect(tab!.panes[0].maximized).toBe(false);
+      expect(tab!.maximizedPaneId).toBe('');
+    });
+
+    it('keeps at most one zoomed pane per tab', () => {
+      const tabId = tabStore.addTab('MaxOneTest');
+      const p1 = tabStore.addPane(tabId, 1, 'P1', 'shell', '');
+      const p2 = tabStore.addPane(tabId, 2, 'P2', 'shell', '');
+
+      tabStore.toggleMaximize(tabId, p1);
+      tabStore.toggleMaximize(tabId, p2);
+      const tab = tabStore.getState().tabs.find((t) => t.id === tabId);
+      expect(tab!.maximizedPaneId).toBe(p2);
+    });
+
+    it('remembers zoom per tab across tab switches', () => {
+      const tabA = tabStore.addTab('ZoomA');
+      const a1 = tabStore.addPane(tabA, 1, 'A1', 'shell', '');
+      tabStore.addPane(tabA, 2, 'A2', 'shell', '');
+      const tabB = tabStore.addTab('ZoomB');
+      tabStore.addPane(tabB, 3, 'B1', 'shell', '');
+      tabStore.addPane(tabB, 4, 'B2', 'shell', '');
+
+      tabStore.setActiveTab(tabA);
+      tabStore.toggleMaximize(tabA, a1);
+      tabStore.setActiveTab(tabB);
+      tabStore.setActiveTab(tabA);
+
+      const tabs = tabStore.getState().tabs;
+      expect(tabs.find((t) => t.id === tabA)!.maximizedPaneId).toBe(a1);
+      expect(tabs.find((t) => t.id === tabB)!.maximizedPaneId).toBe('');
+    });
+
+    it('moves the zoom with focus and clears it when the pane closes', () => {
+      const tabId = tabStore.addTab('ZoomFocusTest');
+      const p1 = tabStore.addPane(tabId, 1, 'P1', 'shell', '');
+      const p2 = tabStore.addPane(tabId, 2, 'P2', 'shell', '');
+
+      tabStore.toggleMaximize(tabId, p2);
+      tabStore.focusPane(tabId, p1);
+      let tab = tabStore.getState().tabs.find((t) => t.id === tabId);
+      expect(tab!.maximizedPaneId).toBe(p1);
+
+      tabStore.closePane(tabId, p1);
+      tab = tabStore.getState().tabs.find((t) => t.id === tabId);
+      expect(tab!.maximizedPaneId).toBe('');
     });
   });
 
@@ -254,6 +390,19 @@ describe('tabStore', () => {
       const pane = tab!.panes.find((p) => p.sessionId === 888);
       expect(pane!.cost).toBe('$0.50');
     });
+
+    it('keeps the command result until the pane leaves done', () => {
+      const tabId = tabStore.addTab('ResultTest');
+      tabStore.addPane(tabId, 889, 'Shell', 'shell', '');
+
+      tabStore.updateActivity(889, 'done', '', '$ ', 'fail');
+      tabStore.updateActivity(889, 'done', '$0.10');
+      const pane = () => tabStore.getState().tabs.find((t) => t.id === tabId)!.panes[0];
+      expect(pane().result).toBe('fail');
+
+      tabStore.updateActivity(889, 'active', '');
+      expect(pane().result).toBe('');
+    });
   });
 
   describe('markExited', () => {
@@ -267,6 +416,40 @@ describe('tabStore', () => {
       const pane = tab!.panes.find((p) => p.sessionId === 555);
       expect(pane!.running).toBe(false);
     });
+
+    it('keeps the exit reason until the pane runs again', () => {
+      const tabId = tabStore.addTab('ExitReasonTest');
+      tabStore.addPane(tabId, 556, 'Shell', 'shell', '');
+
+      tabStore.markExited(556, { reason: 'signaled', code: -1, signal: 'SIGSEGV' });
+      let pane = tabStore.getState().tabs.find((t) => t.id === tabId)!.panes[0];
+      expect(pane.exit?.signal).toBe('SIGSEGV');
+
+      tabStore.markRunning(556);
+      pane = tabStore.getState().tabs.find((t) => t.id === tabId)!.panes[0];
+      expect(pane.exit).toBeNull();
+    });
+  });
+
+  describe('markFailed', () => {
+    it('stops the pane and keeps the reason until it runs again', () => {
+      const tabId = tabStore.addTab('FailTest');
+      tabStore.addPane(tabId, 557, 'Shell', 'shell', '');
+
+      tabStore.markFailed(557, 'PTY read failed while the process is still running: broken pipe');
+      let pane = tabStore.getState().tabs.find((t) => t.id === tabId)!.panes[0];
+      expect(pane.running).toBe(false);
+      expect(pane.exit).toBeNull();
+      expect(pane.error).toContain('broken pipe');
+
+      tabStore.markExited(557, { reason: 'killed', code: -1, signal: '' });
+      pane = tabStore.getState().tabs.find((t) => t.id === tabId)!.panes[0];
+      expect(pane.error).toContain('broken pipe');
+
+      tabStore.markRunning(557);
+      pane = tabStore.getState().tabs.find((t) => t.id === tabId)!.panes[0];
+      expect(pane.error).toBe('');
+    });
   });
 
   describe('renamePane', () => {
@@ -279,6 +462,63 @@ describe('tabStore', () => {
       const tab = tabStore.getState().tabs.find((t) => t.id === tabId);
       const pane = tab!.panes.find((p) => p.id === paneId);
       expect(pane!.name).toBe('New Name');
+      expect(pane!.nameManual).toBe(true);
+    });
+
+    it('returns to automatic titles for an empty name', () => {
+      const tabId = tabStore.addTab('RenameTest');
+      const paneId = tabStore.addPane(tabId, 2, 'Shell', 'shell', '');
+      tabStore.renamePane(tabId, paneId, 'Build');
+
+      tabStore.renamePane(tabId, paneId, '');
+
+      const pane = tabStore.getState().tabs.find((t) => t.id === tabId)!.panes[0];
+      expect(pane.name).toBe('Build');
+      expect(pane.nameManual).toBe(false);
+    });
+  });
+
+  describe('updateTitle', () => {
+    it('sets the display title of the pane with that session', () => {
+      const tabId = tabStore.addTab('TitleTest');
+      const paneId = tabStore.addPane(tabId, 777, 'Shell', 'shell', '');
+
+      tabStore.updateTitle(777, 'vim main.go');
+
+      const pane = tabStore.getState().tabs.find((t) => t.id === tabId)!.panes.find((p) => p.id === paneId)!;
+      expect(pane.title).toBe('vim main.go');
+      expect(paneTitle(pane)).toBe('vim main.go');
+      tabStore.updateTitle(777, '');
+      expect(paneTitle(tabStore.getState().tabs.find((t) => t.id === tabId)!.panes[0])).toBe('Shell');
+    });
+  });
+
+  describe('updateProgress', () => {
+    it('stores the report on the pane with that session', () => {
+      const tabId = tabStore.addTab('ProgressTest');
+      const paneId = tabStore.addPane(tabId, 778, 'winget', 'shell', '');
+      const find = () => tabStore.getState().tabs.find((t) => t.id === tabId)!.panes.find((p) => p.id === paneId)!;
+      expect(find().progress.state).toBe('none');
+
+      tabStore.updateProgress(778, { state: 'normal', percent: 55 });
+      expect(find().progress).toEqual({ state: 'normal', percent: 55 });
+    });
+  });
+
+  describe('setPaneCommand', () => {
+    it('stores a custom command and working dir', () => {
+      const tabId = tabStore.addTab('ProfileTest');
+      const paneId = tabStore.addPane(tabId, 1, 'Run tests', 'shell', '');
+
+      const pane0 = tabStore.getState().tabs.find((t) => t.id === tabId)!.panes[0];
+      expect(pane0.argv).toEqual([]);
+      expect(pane0.dir).toBe('');
+
+      tabStore.setPaneCommand(tabId, paneId, ['npm', 'test'], '/srv/app');
+
+      const pane = tabStore.getState().tabs.find((t) => t.id === tabId)!.panes.find((p) => p.id === paneId);
+      expect(pane!.argv).toEqual(['npm', 'test']);
+      expect(pane!.dir).toBe('/srv/app');
     });
   });
 
@@ -1,4 +1,8 @@
 import { writable, derived, get } from 'svelte/store';
+import { NO_PROGRESS, type PaneProgress } from '../lib/progress';
+import type { PaneExit } from '../lib/exit';
+import type { PaneActivity } from '../lib/activity';
+import { appendPane, layoutFromGrid, removePane, splitPane, syncLayout, type LayoutNode, type SplitDir } from '../lib/layout';
 
 export type PaneMode = 'shell' | 'claude' | 'claude-yolo';
 
@@ -6,18 +10,30 @@ export interface Pane {
   id: string;
   sessionId: number;
   name: string;
+  nameManual: boolean; // name was chosen by the user (rename), not a default
+  title: string;       // display title from the backend (OSC or manual); empty = name
   mode: PaneMode;
   model: string;
   focused: boolean;
-  activity: 'idle' | 'active' | 'done' | 'needsInput';
+  activity: PaneActivity; // passwordInput: typed input is a secret
+  activityReason: string; // prompt or question on screen behind activity; empty while idle/active
+  result: '' | 'pass' | 'fail'; // outcome of the last finished command, read from its output
   cost: string;
   running: boolean;
-  maximized: boolean;
+  exit: PaneExit | null; // how the process ended; null while running
+  error: string; // why the terminal stopped working (terminal:failed); '' while fine
   issueNumber: number | null;
   issueTitle: string;
   issueBranch: string;
   worktreePath: string;
   zoomDelta: number;
+  argv: string[]; // custom command (launch profile); empty = derived from mode
+  dir: string;    // working dir override; empty = tab dir
+  env: Record<string, string>; // per-pane environment overrides
+  progress: PaneProgress; // OSC 9;4 progress report
+  mirrorOf: number | null; // source session of a read-only mirror pane
+  readOnly: boolean; // locked against typed input (toggle_readonly)
+  pinned: boolean;   // moves to the next tab instead of closing with its tab (toggle_pin)
 }
 
 export interface Tab {
@@ -26,8 +42,13 @@ export interface Tab {
   dir: string;
   panes: Pane[];
   focusedPaneId: string;
+  maximizedPaneId: string; // zoomed pane of this tab; empty = grid
+  layout: LayoutNode | null; // split tree from split commands; null = automatic grid
 }
 
+/** Most panes a tab can hold. */
+export const MAX_PANES_PER_TAB = 10;
+
 function createTabStore() {
   const { subscribe, update, set } = writable<{
     tabs: Tab[];
@@ -53,6 +74,8 @@ function createTabStore() {
           dir: dir || '',
           panes: [],
           focusedPaneId: '',
+          maximizedPaneId: '',
+          layout: null,
         });
         state.activeTabId = id;
         return state;
@@ -60,14 +83,58 @@ function createTabStore() {
       return id;
     },
 
-    closeTab(tabId: string) {
+    /**
+     * Close a tab (never the last one). Pinned panes move to the tab that
+     * takes its place; those that do not fit there (MAX_PANES_PER_TAB) get
+     * a new background tab in the closed tab's position. The other panes
+     * are returned so the caller can close their sessions.
+     */
+    closeTab(tabId: string): Pane[] {
+      let closed: Pane[] = [];
       update((state) => {
         if (state.tabs.length <= 1) return state;
         const idx = state.tabs.findIndex((t) => t.id === tabId);
         if (idx === -1) return state;
-        state.tabs.splice(idx, 1);
-        if (state.activeTabId === tabId) {
-          state.activeTabId = state.tabs[Math.min(idx, state.tabs.length - 1)].id;
+        const [tab] = state.tabs.splice(idx, 1);
+        const next = state.tabs[Math.min(idx, state.tabs.length - 1)];
+        closed = tab.panes.filter((p) => !p.pinned);
+        const pinned = tab.panes.filter((p) => p.pinned);
+        pinned.forEach((p) => (p.focused = false));
+        const room = Math.max(0, MAX_PANES_PER_TAB - next.panes.length);
+        const moved = pinned.slice(0, room);
+        if (moved.length > 0) {
+          next.panes.push(...moved);
+          if (next.layout) next.layout = syncLayout(next.layout, next.panes.map((p) => p.id));
+          if (!next.focusedPaneId) {
+            moved[0].focused = true;
+            next.focusedPaneId = moved[0].id;
+          }
+        }
+        const spill = pinned.slice(room);
+        if (spill.length > 0) {
+          spill[0].focused = true;
+          state.tabs.splice(idx, 0, {
+            id: `tab-${nextTabNum++}`,
+            name: tab.name,
+            dir: tab.dir,
+            panes: spill,
+            focusedPaneId: spill[0].id,
+            maximizedPaneId: '',
+            layout: null,
+          });
+        }
+        if (state.activeTabId === tabId) state.activeTabId = next.id;
+        return state;
+      });
+      return closed;
+    },
+
+    /** Remove the given tabs without the last-tab guard of closeTab. */
+    removeTabs(tabIds: string[]) {
+      update((state) => {
+        state.tabs = state.tabs.filter((t) => !tabIds.includes(t.id));
+        if (!state.tabs.some((t) => t.id === state.activeTabId)) {
+          state.activeTabId = state.tabs[0]?.id ?? '';
         }
         return state;
       });
@@ -103,7 +170,10 @@ function createTabStore() {
         const tab = state.tabs.find((t) => t.id === tabId);
         if (!tab) return state;
         const pane = tab.panes.find((p) => p.id === paneId);
-        if (pane) pane.name = name;
+        if (!pane) return state;
+        // An empty name drops the manual name so OSC titles show again
+        if (name) pane.name = name;
+        pane.nameManual = name !== '';
         return state;
       });
     },
@@ -119,20 +189,34 @@ function createTabStore() {
           id: paneId,
           sessionId,
           name,
+          nameManual: false,
+          title: '',
           mode,
           model,
           focused: true,
           activity: 'idle',
+          activityReason: '',
+          result: '',
           cost: '',
           running: true,
-          maximized: false,
+          exit: null,
+          error: '',
           issueNumber: issueNumber ?? null,
           issueTitle: issueTitle ?? '',
           issueBranch: issueBranch ?? '',
           worktreePath: worktreePath ?? '',
           zoomDelta: 0,
+          argv: [],
+          dir: '',
+          env: {},
+          progress: NO_PROGRESS,
+          mirrorOf: null,
+          readOnly: false,
+          pinned: false,
         });
         tab.focusedPaneId = paneId;
+        tab.maximizedPaneId = ''; // show the new pane in the grid
+        if (tab.layout) tab.layout = appendPane(tab.layout, paneId);
         return state;
       });
       return paneId;
@@ -145,6 +229,9 @@ function createTabStore() {
         const idx = tab.panes.findIndex((p) => p.id === paneId);
         if (idx === -1) return state;
         tab.panes.splice(idx, 1);
+        if (tab.maximizedPaneId === paneId) tab.maximizedPaneId = '';
+        // A single pane fills the tab either way; later panes use the grid again
+        if (tab.layout) tab.layout = tab.panes.length > 1 ? removePane(tab.layout, paneId) : null;
         if (tab.focusedPaneId === paneId && tab.panes.length > 0) {
           const newIdx = Math.min(idx, tab.panes.length - 1);
           tab.panes.forEach((p) => (p.focused = false));
@@ -161,16 +248,78 @@ function createTabStore() {
         if (!tab) return state;
         tab.panes.forEach((p) => (p.focused = p.id === paneId));
         tab.focusedPaneId = paneId;
+        // A zoomed tab keeps showing the focused pane
+        if (tab.maximizedPaneId) tab.maximizedPaneId = paneId;
+        return state;
+      });
+    },
+
+    /**
+     * Move newPaneId next to targetPaneId: right of it for 'h', below it for
+     * 'v'. The first split turns the tab's automatic grid into a split tree
+     * that keeps the other panes where they were.
+     */
+    placeSplit(tabId: string, targetPaneId: string, newPaneId: string, dir: SplitDir) {
+      update((state) => {
+        const tab = state.tabs.find((t) => t.id === tabId);
+        if (!tab || targetPaneId === newPaneId) return state;
+        const ids = tab.panes.map((p) => p.id);
+        if (!ids.includes(targetPaneId) || !ids.includes(newPaneId)) return state;
+        const others = ids.filter((id) => id !== newPaneId);
+        const base = tab.layout ? removePane(tab.layout, newPaneId) : layoutFromGrid(others);
+        const tree = syncLayout(base, others);
+        if (tree) tab.layout = splitPane(tree, targetPaneId, newPaneId, dir);
         return state;
       });
     },
 
     toggleMaximize(tabId: string, paneId: string) {
+      update((state) => {
+        const tab = state.tabs.find((t) => t.id === tabId);
+        if (!tab) return state;
+        if (!tab.panes.some((p) => p.id === paneId)) return state;
+        tab.maximizedPaneId = tab.maximizedPaneId === paneId ? '' : paneId;
+        return state;
+      });
+    },
+
+    setPaneMirror(tabId: string, paneId: string, sourceSessionId: number) {
+      update((state) => {
+        const tab = state.tabs.find((t) => t.id === tabId);
+        const pane = tab?.panes.find((p) => p.id === paneId);
+        if (pane) pane.mirrorOf = sourceSessionId;
+        return state;
+      });
+    },
+
+    toggleReadOnly(tabId: string, paneId: string) {
+      update((state) => {
+        const tab = state.tabs.find((t) => t.id === tabId);
+        const pane = tab?.panes.find((p) => p.id === paneId);
+        if (pane) pane.readOnly = !pane.readOnly;
+        return state;
+      });
+    },
+
+    togglePinned(tabId: string, paneId: string) {
+      update((state) => {
+        const tab = state.tabs.find((t) => t.id === tabId);
+        const pane = tab?.panes.find((p) => p.id === paneId);
+        if (pane) pane.pinned = !pane.pinned;
+        return state;
+      });
+    },
+
+    setPaneCommand(tabId: string, paneId: string, argv: string[], dir: string, env: Record<string, string> = {}) {
       update((state) => {
         const tab = state.tabs.find((t) => t.id === tabId);
         if (!tab) return state;
         const pane = tab.panes.find((p) => p.id === paneId);
-        if (pane) pane.maximized = !pane.maximized;
+        if (pane) {
+          pane.argv = argv;
+          pane.dir = dir;
+          pane.env = env;
+        }
         return state;
       });
     },
@@ -185,12 +334,41 @@ function createTabStore() {
       });
     },
 
-    updateActivity(sessionId: number, activity: string, cost: string) {
+    updateTitle(sessionId: number, title: string) {
+      update((state) => {
+        for (const tab of state.tabs) {
+          const pane = tab.panes.find((p) => p.sessionId === sessionId);
+          if (pane) {
+            pane.title = title;
+            return state;
+          }
+        }
+        return state;
+      });
+    },
+
+    updateProgress(sessionId: number, progress: PaneProgress) {
+      update((state) => {
+        for (const tab of state.tabs) {
+          const pane = tab.panes.find((p) => p.sessionId === sessionId);
+          if (pane) {
+            pane.progress = progress;
+            return state;
+          }
+        }
+        return state;
+      });
+    },
+
+    updateActivity(sessionId: number, activity: string, cost: string, reason = '', result = '') {
       update((state) => {
         for (const tab of state.tabs) {
           for (const pane of tab.panes) {
             if (pane.sessionId === sessionId) {
               pane.activity = activity as Pane['activity'];
+              pane.activityReason = reason;
+              // Cost-only events while done carry no result; keep the last one
+              if (result || activity !== 'done') pane.result = result as Pane['result'];
               if (cost) pane.cost = cost;
               return state;
             }
@@ -200,12 +378,45 @@ function createTabStore() {
       });
     },
 
-    markExited(sessionId: number) {
+    markExited(sessionId: number, exit: PaneExit | null = null) {
+      update((state) => {
+        for (const tab of state.tabs) {
+          for (const pane of tab.panes) {
+            if (pane.sessionId === sessionId) {
+              pane.running = false;
+              pane.exit = exit;
+              return state;
+            }
+          }
+        }
+        return state;
+      });
+    },
+
+    /** The pane's terminal broke (PTY gone while the process may live on); error says why. */
+    markFailed(sessionId: number, error: string) {
       update((state) => {
         for (const tab of state.tabs) {
           for (const pane of tab.panes) {
             if (pane.sessionId === sessionId) {
               pane.running = false;
+              pane.error = error;
+              return state;
+            }
+          }
+        }
+        return state;
+      });
+    },
+
+    markRunning(sessionId: number) {
+      update((state) => {
+        for (const tab of state.tabs) {
+          for (const pane of tab.panes) {
+            if (pane.sessionId === sessionId) {
+              pane.running = true;
+              pane.exit = null;
+              pane.error = '';
               return state;
             }
           }
@@ -227,6 +438,11 @@ function createTabStore() {
 
 export const tabStore = createTabStore();
 
+/** The name to show for a pane: the backend title if any, else its name. */
+export function paneTitle(pane: Pane): string {
+  return pane.title || pane.name;
+}
+
 export const activeTab = derived(tabStore, ($state) =>
   $state.tabs.find((t) => t.id === $state.activeTabId)
 );
@@ -1,6 +1,6 @@
 import { describe, it, expect, beforeEach, vi } from 'vitest';
 import { get } from 'svelte/store';
-import { currentTheme, themeColors, applyTheme, applyAccentColor } from './theme';
+import { currentTheme, themeColors, applyTheme, applyAccentColor, registerCustomThemes, customThemeNames, getCustomTheme, nextTheme } from './theme';
 import type { ThemeName } from './theme';
 
 // Mock document.documentElement for applyTheme/applyAccentColor
@@ -172,3 +172,46 @@ describe('theme store', () => {
     });
   });
 });
+
+describe('custom themes', () => {
+  it('registers custom themes with dark fallbacks', () => {
+    registerCustomThemes({ tokyonight: { bg: '#1a1b26', pane_bg: '#16161e' } });
+    expect(get(customThemeNames)).toEqual(['tokyonight']);
+
+    currentTheme.set('tokyonight');
+    const colors = get(themeColors);
+    expect(colors.bg).toBe('#1a1b26');
+    expect(colors.paneBg).toBe('#16161e');
+    expect(colors.accent).toBe('#cba6f7'); // dark fallback
+  });
+
+  it('does not let custom themes replace built-ins', () => {
+    registerCustomThemes({ dark: { bg: '#000000' } });
+    currentTheme.set('dark');
+    expect(get(themeColors).bg).toBe('#1e1e2e');
+    expect(get(customThemeNames)).toEqual([]);
+  });
+
+  it('replaces previously registered themes and falls back for unknown names', () => {
+    registerCustomThemes({ tokyonight: { bg: '#1a1b26' } });
+    registerCustomThemes({ gruvbox: { bg: '#282828' } });
+    expect(getCustomTheme('tokyonight')).toBeUndefined();
+    expect(getCustomTheme('gruvbox')?.bg).toBe('#282828');
+
+    applyTheme('tokyonight');
+    expect(get(currentTheme)).toBe('dark');
+  });
+});
+
+describe('nextTheme', () => {
+  const names = ['dark', 'light', 'tokyonight'];
+
+  it('advances and wraps around', () => {
+    expect(nextTheme('dark', names)).toBe('light');
+    expect(nextTheme('tokyonight', names)).toBe('dark');
+  });
+
+  it('starts from the first theme for unknown names', () => {
+    expect(nextTheme('removed', names)).toBe('dark');
+  });
+});
@@ -1,10 +1,18 @@
 import { writable, derived } from 'svelte/store';
 
-export type ThemeName = 'dark' | 'light' | 'dracula' | 'nord' | 'solarized';
+export type BuiltinThemeName = 'dark' | 'light' | 'dracula' | 'nord' | 'solarized';
+
+/** A built-in theme or the name of a custom theme from config.custom_themes. */
+export type ThemeName = BuiltinThemeName | (string & {});
+
+export const BUILTIN_THEMES: BuiltinThemeName[] = ['dark', 'light', 'dracula', 'nord', 'solarized'];
 
 export const currentTheme = writable<ThemeName>('dark');
 
-interface ThemeColors {
+/** Names of the custom themes currently registered, in config order. */
+export const customThemeNames = writable<string[]>([]);
+
+export interface ThemeColors {
   bg: string;
   bgSecondary: string;
   bgTertiary: string;
@@ -27,7 +35,7 @@ interface ThemeColors {
   footerBg: string;
 }
 
-const themes: Record<ThemeName, ThemeColors> = {
+const themes: Record<string, ThemeColors> = {
   dark: {
     bg: '#1e1e2e',
     bgSecondary: '#181825',
@@ -140,9 +148,44 @@ const themes: Record<ThemeName, ThemeColors> = {
   },
 };
 
-export const themeColors = derived(currentTheme, ($theme) => themes[$theme]);
+export const themeColors = derived(currentTheme, ($theme) => themes[$theme] || themes.dark);
+
+/** Colors of a custom theme, or undefined for built-in and unknown names. */
+export function getCustomTheme(name: string): ThemeColors | undefined {
+  return (BUILTIN_THEMES as string[]).includes(name) ? undefined : themes[name];
+}
+
+/**
+ * Register the custom themes from the config (snake_case keys, as sent by
+ * the backend), replacing any previously registered ones. Missing colors
+ * fall back to the dark theme; the backend has already validated them.
+ */
+export function registerCustomThemes(custom?: Record<string, object>) {
+  for (const name of Object.keys(themes)) {
+    if (!(BUILTIN_THEMES as string[]).includes(name)) delete themes[name];
+  }
+  const names: string[] = [];
+  for (const [name, palette] of Object.entries(custom || {})) {
+    if ((BUILTIN_THEMES as string[]).includes(name)) continue;
+    const colors = { ...themes.dark };
+    for (const key of Object.keys(colors) as (keyof ThemeColors)[]) {
+      const value = (palette as Record<string, string> | undefined)?.[camelToSnake(key)];
+      if (value) colors[key] = value;
+    }
+    themes[name] = colors;
+    names.push(name);
+  }
+  customThemeNames.set(names.sort());
+}
+
+/** The theme after current in names, wrapping around (first if current is unknown). */
+export function nextTheme(current: string, names: string[]): string {
+  const idx = names.indexOf(current);
+  return names[(idx + 1) % names.length] ?? 'dark';
+}
 
 export function applyTheme(theme: ThemeName, accentColor?: string) {
+  if (!themes[theme]) theme = 'dark';
   currentTheme.set(theme);
   const colors = themes[theme];
   const root = document.documentElement;
@@ -175,3 +218,7 @@ function adjustBrightness(hex: string, amount: number): string {
 function camelToKebab(str: string): string {
   return str.replace(/([a-z])([A-Z])/g, '$1-$2').toLowerCase();
 }
+
+function camelToSnake(str: string): string {
+  return str.replace(/([a-z])([A-Z])/g, '$1_$2').toLowerCase();
+}
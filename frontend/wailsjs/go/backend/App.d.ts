@@ -9,6 +9,12 @@ export function AddIssueComment(arg1:string,arg2:number,arg3:string):Promise<voi
 
 export function AddToQueue(arg1:number,arg2:string):Promise<backend.QueueItem>;
 
+export function AddWorkspaceFolder(arg1:string):Promise<void>;
+
+export function AttachMirror(arg1:number):Promise<void>;
+
+export function AttachSessionToIssue(arg1:number):Promise<void>;
+
 export function BrowseForAudioFile():Promise<string>;
 
 export function BrowseForClaude():Promise<string>;
@@ -29,7 +35,9 @@ export function CreateDirectory(arg1:string):Promise<string>;
 
 export function CreateIssue(arg1:string,arg2:string,arg3:string,arg4:Array<string>):Promise<backend.Issue>;
 
-export function CreateSession(arg1:Array<string>,arg2:string,arg3:number,arg4:number):Promise<number>;
+export function CreateSession(arg1:Array<string>,arg2:string,arg3:number,arg4:number,arg5:Record<string, string>):Promise<number>;
+
+export function CreateSessionWithHistory(arg1:Array<string>,arg2:string,arg3:number,arg4:number,arg5:Record<string, string>,arg6:string):Promise<number>;
 
 export function CreateWorktree(arg1:string,arg2:number,arg3:string):Promise<backend.WorktreeInfo>;
 
@@ -39,16 +47,32 @@ export function DisableLogging():Promise<void>;
 
 export function EnableLogging(arg1:boolean):Promise<string>;
 
+export function ExportSession(arg1:number,arg2:boolean):Promise<string>;
+
+export function ExportSessionToFile(arg1:number,arg2:boolean):Promise<string>;
+
+export function FilterSessionLines(arg1:number):Promise<Array<string>>;
+
 export function GetAppVersion():Promise<string>;
 
+export function GetCommandHistory(arg1:number):Promise<Array<string>>;
+
 export function GetConfig():Promise<config.Config>;
 
+export function GetCrashReport():Promise<string>;
+
 export function GetFavorites(arg1:string):Promise<Array<string>>;
 
+export function GetFileRefs(arg1:number):Promise<Array<backend.FileRef>>;
+
 export function GetGitBranch(arg1:string):Promise<string>;
 
 export function GetGitFileStatuses(arg1:string):Promise<Record<string, string>>;
 
+export function GetGitSummary(arg1:string):Promise<backend.GitSummary>;
+
+export function GetImageThumbnail(arg1:string,arg2:number):Promise<string>;
+
 export function GetIssueDetail(arg1:string,arg2:number):Promise<backend.IssueDetail>;
 
 export function GetIssueLabels(arg1:string):Promise<Array<backend.IssueLabel>>;
@@ -63,14 +87,36 @@ export function GetMergeConflicts(arg1:string):Promise<backend.MergeConflictInfo
 
 export function GetOrCreateIssueBranch(arg1:string,arg2:number,arg3:string):Promise<string>;
 
+export function GetOrCreateIssueBranchAutoStash(arg1:string,arg2:number,arg3:string):Promise<backend.IssueBranchResult>;
+
 export function GetQueue(arg1:number):Promise<Array<backend.QueueItem>>;
 
 export function GetResolvedClaudePath():Promise<string>;
 
+export function GetScreenDiff(arg1:number):Promise<backend.ScreenDiff>;
+
+export function GetScreenSnapshot(arg1:number):Promise<backend.ScreenSnapshot>;
+
+export function GetScrollbackStats():Promise<backend.ScrollbackStats>;
+
+export function GetSessionDir(arg1:number):Promise<string>;
+
+export function GetSessionError(arg1:number):Promise<string>;
+
 export function GetSessionIssue(arg1:number):Promise<number>;
 
+export function GetSessionStats(arg1:number):Promise<backend.SessionStats>;
+
+export function GetSessionUsage(arg1:number):Promise<backend.SessionUsage>;
+
+export function GetUnhandledSequences(arg1:number):Promise<Record<string, number>>;
+
 export function GetWorkingDir():Promise<string>;
 
+export function GetWorkspaceFolders():Promise<Array<string>>;
+
+export function GrepFiles(arg1:string,arg2:string,arg3:backend.GrepOptions):Promise<Array<backend.GrepHit>>;
+
 export function HasCleanWorkingTree(arg1:string):Promise<boolean>;
 
 export function IsClaudeDetected():Promise<boolean>;
@@ -79,40 +125,90 @@ export function IsGitRepo(arg1:string):Promise<boolean>;
 
 export function IsOnIssueBranch(arg1:string,arg2:number):Promise<backend.IssueBranchInfo>;
 
+export function KeepSessionOpen(arg1:number):Promise<void>;
+
 export function LinkSessionIssue(arg1:number,arg2:number,arg3:string,arg4:string,arg5:string):Promise<void>;
 
 export function ListDirectory(arg1:string):Promise<Array<backend.FileEntry>>;
 
+export function ListLayouts():Promise<Array<string>>;
+
 export function ListWorktrees(arg1:string):Promise<Array<backend.WorktreeInfo>>;
 
+export function LoadLayout(arg1:string):Promise<config.SessionState>;
+
 export function LoadTabs():Promise<config.SessionState>;
 
+export function MatchSessionHighlight(arg1:number,arg2:Array<string>):Promise<Array<Array<number>>>;
+
+export function MirrorSession(arg1:number):Promise<number>;
+
 export function OpenFileInEditor(arg1:string):Promise<string>;
 
 export function OpenLogDir():Promise<void>;
 
+export function PasteClipboardImageToSession(arg1:number):Promise<string>;
+
+export function PasteToSession(arg1:number,arg2:string):Promise<void>;
+
+export function PopIssueStash(arg1:string,arg2:string):Promise<void>;
+
+export function PruneFavorites(arg1:string):Promise<number>;
+
+export function QuickCommit(arg1:string,arg2:string,arg3:boolean):Promise<string>;
+
 export function ReadFile(arg1:string):Promise<backend.FileContent>;
 
+export function RefreshIssue(arg1:string,arg2:number):Promise<backend.IssueDetail>;
+
 export function RemoveFavorite(arg1:string,arg2:string):Promise<void>;
 
 export function RemoveFromQueue(arg1:number,arg2:number):Promise<void>;
 
+export function RemoveWorkspaceFolder(arg1:string):Promise<void>;
+
 export function RemoveWorktree(arg1:string,arg2:number):Promise<void>;
 
 export function ResizeSession(arg1:number,arg2:number,arg3:number):Promise<void>;
 
+export function RestartSession(arg1:number):Promise<void>;
+
+export function RunStartupCommand(arg1:number,arg2:string):Promise<void>;
+
 export function SaveConfig(arg1:config.Config):Promise<void>;
 
+export function SaveLayout(arg1:string,arg2:config.SessionState):Promise<void>;
+
 export function SaveTabs(arg1:config.SessionState):Promise<void>;
 
 export function SearchFiles(arg1:string,arg2:string):Promise<Array<backend.FileEntry>>;
 
 export function SelectDirectory(arg1:string):Promise<string>;
 
-export function SendNotification(arg1:string,arg2:string):Promise<void>;
+export function SendNotification(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function SetCellSize(arg1:number,arg2:number,arg3:number):Promise<void>;
+
+export function SetPaneName(arg1:number,arg2:string):Promise<void>;
+
+export function SetSessionFocus(arg1:number,arg2:boolean):Promise<void>;
+
+export function SetSessionHighlight(arg1:number,arg2:string):Promise<string>;
+
+export function SetSessionPinned(arg1:number,arg2:boolean):Promise<void>;
+
+export function SetSessionReadOnly(arg1:number,arg2:boolean):Promise<void>;
+
+export function SetSessionYolo(arg1:number,arg2:boolean):Promise<void>;
+
+export function SetTheme(arg1:string):Promise<void>;
 
 export function UpdateIssue(arg1:string,arg2:number,arg3:string,arg4:string,arg5:string):Promise<void>;
 
 export function ValidateClaudePath(arg1:string):Promise<boolean>;
 
+export function WatchSidebarDirs(arg1:Array<string>):Promise<void>;
+
+export function WriteKeyToSession(arg1:number,arg2:string,arg3:string):Promise<void>;
+
 export function WriteToSession(arg1:number,arg2:string):Promise<void>;
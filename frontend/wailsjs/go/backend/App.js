@@ -14,6 +14,18 @@ export function AddToQueue(arg1, arg2) {
   return window['go']['backend']['App']['AddToQueue'](arg1, arg2);
 }
 
+export function AddWorkspaceFolder(arg1) {
+  return window['go']['backend']['App']['AddWorkspaceFolder'](arg1);
+}
+
+export function AttachMirror(arg1) {
+  return window['go']['backend']['App']['AttachMirror'](arg1);
+}
+
+export function AttachSessionToIssue(arg1) {
+  return window['go']['backend']['App']['AttachSessionToIssue'](arg1);
+}
+
 export function BrowseForAudioFile() {
   return window['go']['backend']['App']['BrowseForAudioFile']();
 }
@@ -54,8 +66,12 @@ export function CreateIssue(arg1, arg2, arg3, arg4) {
   return window['go']['backend']['App']['CreateIssue'](arg1, arg2, arg3, arg4);
 }
 
-export function CreateSession(arg1, arg2, arg3, arg4) {
-  return window['go']['backend']['App']['CreateSession'](arg1, arg2, arg3, arg4);
+export function CreateSession(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['backend']['App']['CreateSession'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function CreateSessionWithHistory(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['backend']['App']['CreateSessionWithHistory'](arg1, arg2, arg3, arg4, arg5, arg6);
 }
 
 export function CreateWorktree(arg1, arg2, arg3) {
@@ -74,18 +90,42 @@ export function EnableLogging(arg1) {
   return window['go']['backend']['App']['EnableLogging'](arg1);
 }
 
+export function ExportSession(arg1, arg2) {
+  return window['go']['backend']['App']['ExportSession'](arg1, arg2);
+}
+
+export function ExportSessionToFile(arg1, arg2) {
+  return window['go']['backend']['App']['ExportSessionToFile'](arg1, arg2);
+}
+
+export function FilterSessionLines(arg1) {
+  return window['go']['backend']['App']['FilterSessionLines'](arg1);
+}
+
 export function GetAppVersion() {
   return window['go']['backend']['App']['GetAppVersion']();
 }
 
+export function GetCommandHistory(arg1) {
+  return window['go']['backend']['App']['GetCommandHistory'](arg1);
+}
+
 export function GetConfig() {
   return window['go']['backend']['App']['GetConfig']();
 }
 
+export function GetCrashReport() {
+  return window['go']['backend']['App']['GetCrashReport']();
+}
+
 export function GetFavorites(arg1) {
   return window['go']['backend']['App']['GetFavorites'](arg1);
 }
 
+export function GetFileRefs(arg1) {
+  return window['go']['backend']['App']['GetFileRefs'](arg1);
+}
+
 export function GetGitBranch(arg1) {
   return window['go']['backend']['App']['GetGitBranch'](arg1);
 }
@@ -94,6 +134,14 @@ export function GetGitFileStatuses(arg1) {
   return window['go']['backend']['App']['GetGitFileStatuses'](arg1);
 }
 
+export function GetGitSummary(arg1) {
+  return window['go']['backend']['App']['GetGitSummary'](arg1);
+}
+
+export function GetImageThumbnail(arg1, arg2) {
+  return window['go']['backend']['App']['GetImageThumbnail'](arg1, arg2);
+}
+
 export function GetIssueDetail(arg1, arg2) {
   return window['go']['backend']['App']['GetIssueDetail'](arg1, arg2);
 }
@@ -122,6 +170,10 @@ export function GetOrCreateIssueBranch(arg1, arg2, arg3) {
   return window['go']['backend']['App']['GetOrCreateIssueBranch'](arg1, arg2, arg3);
 }
 
+export function GetOrCreateIssueBranchAutoStash(arg1, arg2, arg3) {
+  return window['go']['backend']['App']['GetOrCreateIssueBranchAutoStash'](arg1, arg2, arg3);
+}
+
 export function GetQueue(arg1) {
   return window['go']['backend']['App']['GetQueue'](arg1);
 }
@@ -130,14 +182,54 @@ export function GetResolvedClaudePath() {
   return window['go']['backend']['App']['GetResolvedClaudePath']();
 }
 
+export function GetScreenDiff(arg1) {
+  return window['go']['backend']['App']['GetScreenDiff'](arg1);
+}
+
+export function GetScreenSnapshot(arg1) {
+  return window['go']['backend']['App']['GetScreenSnapshot'](arg1);
+}
+
+export function GetScrollbackStats() {
+  return window['go']['backend']['App']['GetScrollbackStats']();
+}
+
+export function GetSessionDir(arg1) {
+  return window['go']['backend']['App']['GetSessionDir'](arg1);
+}
+
+export function GetSessionError(arg1) {
+  return window['go']['backend']['App']['GetSessionError'](arg1);
+}
+
 export function GetSessionIssue(arg1) {
   return window['go']['backend']['App']['GetSessionIssue'](arg1);
 }
 
+export function GetSessionStats(arg1) {
+  return window['go']['backend']['App']['GetSessionStats'](arg1);
+}
+
+export function GetSessionUsage(arg1) {
+  return window['go']['backend']['App']['GetSessionUsage'](arg1);
+}
+
+export function GetUnhandledSequences(arg1) {
+  return window['go']['backend']['App']['GetUnhandledSequences'](arg1);
+}
+
 export function GetWorkingDir() {
   return window['go']['backend']['App']['GetWorkingDir']();
 }
 
+export function GetWorkspaceFolders() {
+  return window['go']['backend']['App']['GetWorkspaceFolders']();
+}
+
+export function GrepFiles(arg1, arg2, arg3) {
+  return window['go']['backend']['App']['GrepFiles'](arg1, arg2, arg3);
+}
+
 export function HasCleanWorkingTree(arg1) {
   return window['go']['backend']['App']['HasCleanWorkingTree'](arg1);
 }
@@ -154,6 +246,10 @@ export function IsOnIssueBranch(arg1, arg2) {
   return window['go']['backend']['App']['IsOnIssueBranch'](arg1, arg2);
 }
 
+export function KeepSessionOpen(arg1) {
+  return window['go']['backend']['App']['KeepSessionOpen'](arg1);
+}
+
 export function LinkSessionIssue(arg1, arg2, arg3, arg4, arg5) {
   return window['go']['backend']['App']['LinkSessionIssue'](arg1, arg2, arg3, arg4, arg5);
 }
@@ -162,14 +258,30 @@ export function ListDirectory(arg1) {
   return window['go']['backend']['App']['ListDirectory'](arg1);
 }
 
+export function ListLayouts() {
+  return window['go']['backend']['App']['ListLayouts']();
+}
+
 export function ListWorktrees(arg1) {
   return window['go']['backend']['App']['ListWorktrees'](arg1);
 }
 
+export function LoadLayout(arg1) {
+  return window['go']['backend']['App']['LoadLayout'](arg1);
+}
+
 export function LoadTabs() {
   return window['go']['backend']['App']['LoadTabs']();
 }
 
+export function MatchSessionHighlight(arg1, arg2) {
+  return window['go']['backend']['App']['MatchSessionHighlight'](arg1, arg2);
+}
+
+export function MirrorSession(arg1) {
+  return window['go']['backend']['App']['MirrorSession'](arg1);
+}
+
 export function OpenFileInEditor(arg1) {
   return window['go']['backend']['App']['OpenFileInEditor'](arg1);
 }
@@ -178,10 +290,34 @@ export function OpenLogDir() {
   return window['go']['backend']['App']['OpenLogDir']();
 }
 
+export function PasteClipboardImageToSession(arg1) {
+  return window['go']['backend']['App']['PasteClipboardImageToSession'](arg1);
+}
+
+export function PasteToSession(arg1, arg2) {
+  return window['go']['backend']['App']['PasteToSession'](arg1, arg2);
+}
+
+export function PopIssueStash(arg1, arg2) {
+  return window['go']['backend']['App']['PopIssueStash'](arg1, arg2);
+}
+
+export function PruneFavorites(arg1) {
+  return window['go']['backend']['App']['PruneFavorites'](arg1);
+}
+
+export function QuickCommit(arg1, arg2, arg3) {
+  return window['go']['backend']['App']['QuickCommit'](arg1, arg2, arg3);
+}
+
 export function ReadFile(arg1) {
   return window['go']['backend']['App']['ReadFile'](arg1);
 }
 
+export function RefreshIssue(arg1, arg2) {
+  return window['go']['backend']['App']['RefreshIssue'](arg1, arg2);
+}
+
 export function RemoveFavorite(arg1, arg2) {
   return window['go']['backend']['App']['RemoveFavorite'](arg1, arg2);
 }
@@ -190,6 +326,10 @@ export function RemoveFromQueue(arg1, arg2) {
   return window['go']['backend']['App']['RemoveFromQueue'](arg1, arg2);
 }
 
+export function RemoveWorkspaceFolder(arg1) {
+  return window['go']['backend']['App']['RemoveWorkspaceFolder'](arg1);
+}
+
 export function RemoveWorktree(arg1, arg2) {
   return window['go']['backend']['App']['RemoveWorktree'](arg1, arg2);
 }
@@ -198,10 +338,22 @@ export function ResizeSession(arg1, arg2, arg3) {
   return window['go']['backend']['App']['ResizeSession'](arg1, arg2, arg3);
 }
 
+export function RestartSession(arg1) {
+  return window['go']['backend']['App']['RestartSession'](arg1);
+}
+
+export function RunStartupCommand(arg1, arg2) {
+  return window['go']['backend']['App']['RunStartupCommand'](arg1, arg2);
+}
+
 export function SaveConfig(arg1) {
   return window['go']['backend']['App']['SaveConfig'](arg1);
 }
 
+export function SaveLayout(arg1, arg2) {
+  return window['go']['backend']['App']['SaveLayout'](arg1, arg2);
+}
+
 export function SaveTabs(arg1) {
   return window['go']['backend']['App']['SaveTabs'](arg1);
 }
@@ -214,8 +366,40 @@ export function SelectDirectory(arg1) {
   return window['go']['backend']['App']['SelectDirectory'](arg1);
 }
 
-export function SendNotification(arg1, arg2) {
-  return window['go']['backend']['App']['SendNotification'](arg1, arg2);
+export function SendNotification(arg1, arg2, arg3) {
+  return window['go']['backend']['App']['SendNotification'](arg1, arg2, arg3);
+}
+
+export function SetCellSize(arg1, arg2, arg3) {
+  return window['go']['backend']['App']['SetCellSize'](arg1, arg2, arg3);
+}
+
+export function SetPaneName(arg1, arg2) {
+  return window['go']['backend']['App']['SetPaneName'](arg1, arg2);
+}
+
+export function SetSessionFocus(arg1, arg2) {
+  return window['go']['backend']['App']['SetSessionFocus'](arg1, arg2);
+}
+
+export function SetSessionHighlight(arg1, arg2) {
+  return window['go']['backend']['App']['SetSessionHighlight'](arg1, arg2);
+}
+
+export function SetSessionPinned(arg1, arg2) {
+  return window['go']['backend']['App']['SetSessionPinned'](arg1, arg2);
+}
+
+export function SetSessionReadOnly(arg1, arg2) {
+  return window['go']['backend']['App']['SetSessionReadOnly'](arg1, arg2);
+}
+
+export function SetSessionYolo(arg1, arg2) {
+  return window['go']['backend']['App']['SetSessionYolo'](arg1, arg2);
+}
+
+export function SetTheme(arg1) {
+  return window['go']['backend']['App']['SetTheme'](arg1);
 }
 
 export function UpdateIssue(arg1, arg2, arg3, arg4, arg5) {
@@ -226,6 +410,14 @@ export function ValidateClaudePath(arg1) {
   return window['go']['backend']['App']['ValidateClaudePath'](arg1);
 }
 
+export function WatchSidebarDirs(arg1) {
+  return window['go']['backend']['App']['WatchSidebarDirs'](arg1);
+}
+
+export function WriteKeyToSession(arg1, arg2, arg3) {
+  return window['go']['backend']['App']['WriteKeyToSession'](arg1, arg2, arg3);
+}
+
 export function WriteToSession(arg1, arg2) {
   return window['go']['backend']['App']['WriteToSession'](arg1, arg2);
 }
@@ -1,5 +1,39 @@
 export namespace backend {
 	
+	export class CellUpdate {
+	    row: number;
+	    col: number;
+	    cell: SnapshotCell;
+	
+	    static createFrom(source: any = {}) {
+	        return new CellUpdate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.row = source["row"];
+	        this.col = source["col"];
+	        this.cell = this.convertValues(source["cell"], SnapshotCell);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class ClaudeDetectResult {
 	    path: string;
 	    source: string;
@@ -42,6 +76,8 @@ export namespace backend {
 	    name: string;
 	    path: string;
 	    isDir: boolean;
+	    gitStatus?: string;
+	    more?: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new FileEntry(source);
@@ -52,12 +88,93 @@ export namespace backend {
 	        this.name = source["name"];
 	        this.path = source["path"];
 	        this.isDir = source["isDir"];
+	        this.gitStatus = source["gitStatus"];
+	        this.more = source["more"];
+	    }
+	}
+	export class FileRef {
+	    row: number;
+	    col: number;
+	    text: string;
+	    path: string;
+	    line: number;
+	    column: number;
+	    exists: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileRef(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.row = source["row"];
+	        this.col = source["col"];
+	        this.text = source["text"];
+	        this.path = source["path"];
+	        this.line = source["line"];
+	        this.column = source["column"];
+	        this.exists = source["exists"];
+	    }
+	}
+	export class GitSummary {
+	    branch: string;
+	    modified: number;
+	    added: number;
+	    deleted: number;
+	    untracked: number;
+	    ahead: number;
+	    behind: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.branch = source["branch"];
+	        this.modified = source["modified"];
+	        this.added = source["added"];
+	        this.deleted = source["deleted"];
+	        this.untracked = source["untracked"];
+	        this.ahead = source["ahead"];
+	        this.behind = source["behind"];
+	    }
+	}
+	export class GrepHit {
+	    path: string;
+	    line: number;
+	    text: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GrepHit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.line = source["line"];
+	        this.text = source["text"];
+	    }
+	}
+	export class GrepOptions {
+	    ignoreCase: boolean;
+	    regex: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new GrepOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ignoreCase = source["ignoreCase"];
+	        this.regex = source["regex"];
 	    }
 	}
 	export class HealthInfo {
 	    crash_detected: boolean;
 	    logging_enabled: boolean;
 	    logging_auto: boolean;
+	    crash_report: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new HealthInfo(source);
@@ -68,6 +185,7 @@ export namespace backend {
 	        this.crash_detected = source["crash_detected"];
 	        this.logging_enabled = source["logging_enabled"];
 	        this.logging_auto = source["logging_auto"];
+	        this.crash_report = source["crash_report"];
 	    }
 	}
 	export class Issue {
@@ -118,6 +236,24 @@ export namespace backend {
 	        this.is_same_issue = source["is_same_issue"];
 	    }
 	}
+	export class IssueBranchResult {
+	    branch: string;
+	    stashed: boolean;
+	    stash_ref: string;
+	    stash_message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new IssueBranchResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.branch = source["branch"];
+	        this.stashed = source["stashed"];
+	        this.stash_ref = source["stash_ref"];
+	        this.stash_message = source["stash_message"];
+	    }
+	}
 	export class IssueComment {
 	    author: string;
 	    body: string;
@@ -230,6 +366,144 @@ export namespace backend {
 	        this.status = source["status"];
 	    }
 	}
+	export class ScreenDiff {
+	    cursor_row: number;
+	    cursor_col: number;
+	    changes: CellUpdate[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ScreenDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.cursor_row = source["cursor_row"];
+	        this.cursor_col = source["cursor_col"];
+	        this.changes = this.convertValues(source["changes"], CellUpdate);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ScreenSnapshot {
+	    rows: number;
+	    cols: number;
+	    cursor_row: number;
+	    cursor_col: number;
+	    cells: SnapshotCell[][];
+	    truncated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScreenSnapshot(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rows = source["rows"];
+	        this.cols = source["cols"];
+	        this.cursor_row = source["cursor_row"];
+	        this.cursor_col = source["cursor_col"];
+	        this.cells = this.convertValues(source["cells"], SnapshotCell);
+	        this.truncated = source["truncated"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ScrollbackStats {
+	    rows: number;
+	    bytes: number;
+	    budget_bytes: number;
+	    evicted_rows: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScrollbackStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rows = source["rows"];
+	        this.bytes = source["bytes"];
+	        this.budget_bytes = source["budget_bytes"];
+	        this.evicted_rows = source["evicted_rows"];
+	    }
+	}
+	export class SessionStats {
+	    uptime_seconds: number;
+	    idle_seconds: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.uptime_seconds = source["uptime_seconds"];
+	        this.idle_seconds = source["idle_seconds"];
+	    }
+	}
+	export class SessionUsage {
+	    cpu_percent: number;
+	    rss_bytes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.cpu_percent = source["cpu_percent"];
+	        this.rss_bytes = source["rss_bytes"];
+	    }
+	}
+	export class SnapshotCell {
+	    char: string;
+	    fg: number;
+	    bg: number;
+	    attrs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SnapshotCell(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.char = source["char"];
+	        this.fg = source["fg"];
+	        this.bg = source["bg"];
+	        this.attrs = source["attrs"];
+	    }
+	}
 	export class UpdateInfo {
 	    currentVersion: string;
 	    latestVersion: string;
@@ -325,6 +599,28 @@ export namespace config {
 	        this.include_cost_in_report = source["include_cost_in_report"];
 	    }
 	}
+	export class LaunchProfile {
+	    label: string;
+	    argv: string[];
+	    dir?: string;
+	    mode: string;
+	    startup_command?: string;
+	    env?: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new LaunchProfile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.label = source["label"];
+	        this.argv = source["argv"];
+	        this.dir = source["dir"];
+	        this.mode = source["mode"];
+	        this.startup_command = source["startup_command"];
+	        this.env = source["env"];
+	    }
+	}
 	export class ModelEntry {
 	    label: string;
 	    id: string;
@@ -339,28 +635,83 @@ export namespace config {
 	        this.id = source["id"];
 	    }
 	}
+	export class ResultPatterns {
+	    pass: string[];
+	    fail: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ResultPatterns(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.pass = source["pass"];
+	        this.fail = source["fail"];
+	    }
+	}
 	export class Config {
 	    default_shell: string;
+	    default_shell_args: string[];
 	    default_dir: string;
 	    theme: string;
 	    terminal_color: string;
 	    max_panes_per_tab: number;
+	    max_sessions: number;
+	    idle_timeout_minutes: number;
 	    sidebar_width: number;
 	    claude_command: string;
 	    claude_models: ModelEntry[];
 	    commit_reminder_minutes: number;
+	    commit_reminder_warning_factor: number;
+	    commit_reminder_danger_factor: number;
+	    commit_reminder_notify: boolean;
 	    restore_session?: boolean;
+	    restore_scrollback: boolean;
 	    logging_enabled: boolean;
+	    log_input: boolean;
+	    crash_report_screens: boolean;
 	    auto_branch_on_issue?: boolean;
 	    use_worktrees?: boolean;
 	    issue_tracking: IssueTracking;
+	    issue_cache_seconds: number;
+	    github_timeout_seconds: number;
 	    commands: CommandEntry[];
 	    audio: AudioSettings;
 	    localhost_auto_open: string;
 	    sidebar_pinned: boolean;
+	    sidebar_ignore: string[];
+	    sidebar_show_hidden: boolean;
+	    sidebar_watch?: boolean;
 	    favorites?: Record<string, Array<string>>;
+	    workspace_folders: string[];
 	    font_family: string;
 	    font_size: number;
+	    monochrome: boolean;
+	    output_coalesce_ms: number;
+	    output_line_flush?: boolean;
+	    output_chunk_limit_kb: number;
+	    output_throttle_ms: number;
+	    scan_interval_min_ms: number;
+	    scan_interval_max_ms: number;
+	    throttle_claude_spinner: boolean;
+	    reflow_on_resize?: boolean;
+	    default_launch: string;
+	    new_tab_dir_mode: string;
+	    auto_approve: string[];
+	    approval_menu_patterns: string[];
+	    result_patterns: ResultPatterns;
+	    paste_safety: string;
+	    paste_warn_dangerous: boolean;
+	    keybindings: Record<string, string>;
+	    quit_binding: string;
+	    intercept_ctrl_c?: boolean;
+	    launch_profiles: LaunchProfile[];
+	    custom_themes?: Record<string, ThemeColors>;
+	    ansi_palettes?: Record<string, Array<string>>;
+	    scrollback_memory_budget_mb: number;
+	    startup_command: string;
+	    control_port: number;
+	    control_api: string[];
 	
 	    static createFrom(source: any = {}) {
 	        return new Config(source);
@@ -369,26 +720,67 @@ export namespace config {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.default_shell = source["default_shell"];
+	        this.default_shell_args = source["default_shell_args"];
 	        this.default_dir = source["default_dir"];
 	        this.theme = source["theme"];
 	        this.terminal_color = source["terminal_color"];
 	        this.max_panes_per_tab = source["max_panes_per_tab"];
+	        this.max_sessions = source["max_sessions"];
+	        this.idle_timeout_minutes = source["idle_timeout_minutes"];
 	        this.sidebar_width = source["sidebar_width"];
 	        this.claude_command = source["claude_command"];
 	        this.claude_models = this.convertValues(source["claude_models"], ModelEntry);
 	        this.commit_reminder_minutes = source["commit_reminder_minutes"];
+	        this.commit_reminder_warning_factor = source["commit_reminder_warning_factor"];
+	        this.commit_reminder_danger_factor = source["commit_reminder_danger_factor"];
+	        this.commit_reminder_notify = source["commit_reminder_notify"];
 	        this.restore_session = source["restore_session"];
+	        this.restore_scrollback = source["restore_scrollback"];
 	        this.logging_enabled = source["logging_enabled"];
+	        this.log_input = source["log_input"];
+	        this.crash_report_screens = source["crash_report_screens"];
 	        this.auto_branch_on_issue = source["auto_branch_on_issue"];
 	        this.use_worktrees = source["use_worktrees"];
 	        this.issue_tracking = this.convertValues(source["issue_tracking"], IssueTracking);
+	        this.issue_cache_seconds = source["issue_cache_seconds"];
+	        this.github_timeout_seconds = source["github_timeout_seconds"];
 	        this.commands = this.convertValues(source["commands"], CommandEntry);
 	        this.audio = this.convertValues(source["audio"], AudioSettings);
 	        this.localhost_auto_open = source["localhost_auto_open"];
 	        this.sidebar_pinned = source["sidebar_pinned"];
+	        this.sidebar_ignore = source["sidebar_ignore"];
+	        this.sidebar_show_hidden = source["sidebar_show_hidden"];
+	        this.sidebar_watch = source["sidebar_watch"];
 	        this.favorites = source["favorites"];
+	        this.workspace_folders = source["workspace_folders"];
 	        this.font_family = source["font_family"];
 	        this.font_size = source["font_size"];
+	        this.monochrome = source["monochrome"];
+	        this.output_coalesce_ms = source["output_coalesce_ms"];
+	        this.output_line_flush = source["output_line_flush"];
+	        this.output_chunk_limit_kb = source["output_chunk_limit_kb"];
+	        this.output_throttle_ms = source["output_throttle_ms"];
+	        this.scan_interval_min_ms = source["scan_interval_min_ms"];
+	        this.scan_interval_max_ms = source["scan_interval_max_ms"];
+	        this.throttle_claude_spinner = source["throttle_claude_spinner"];
+	        this.reflow_on_resize = source["reflow_on_resize"];
+	        this.default_launch = source["default_launch"];
+	        this.new_tab_dir_mode = source["new_tab_dir_mode"];
+	        this.auto_approve = source["auto_approve"];
+	        this.approval_menu_patterns = source["approval_menu_patterns"];
+	        this.result_patterns = this.convertValues(source["result_patterns"], ResultPatterns);
+	        this.paste_safety = source["paste_safety"];
+	        this.paste_warn_dangerous = source["paste_warn_dangerous"];
+	        this.keybindings = source["keybindings"];
+	        this.quit_binding = source["quit_binding"];
+	        this.intercept_ctrl_c = source["intercept_ctrl_c"];
+	        this.launch_profiles = this.convertValues(source["launch_profiles"], LaunchProfile);
+	        this.custom_themes = this.convertValues(source["custom_themes"], ThemeColors, true);
+	        this.ansi_palettes = source["ansi_palettes"];
+	        this.scrollback_memory_budget_mb = source["scrollback_memory_budget_mb"];
+	        this.startup_command = source["startup_command"];
+	        this.control_port = source["control_port"];
+	        this.control_api = source["control_api"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -418,6 +810,13 @@ export namespace config {
 	    issue_number?: number;
 	    issue_branch?: string;
 	    zoom_delta?: number;
+	    argv?: string[];
+	    dir?: string;
+	    maximized?: boolean;
+	    env?: Record<string, string>;
+	    name_manual?: boolean;
+	    pinned?: boolean;
+	    scrollback?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new SavedPane(source);
@@ -431,6 +830,13 @@ export namespace config {
 	        this.issue_number = source["issue_number"];
 	        this.issue_branch = source["issue_branch"];
 	        this.zoom_delta = source["zoom_delta"];
+	        this.argv = source["argv"];
+	        this.dir = source["dir"];
+	        this.maximized = source["maximized"];
+	        this.env = source["env"];
+	        this.name_manual = source["name_manual"];
+	        this.pinned = source["pinned"];
+	        this.scrollback = source["scrollback"];
 	    }
 	}
 	export class SavedTab {
@@ -469,9 +875,26 @@ export namespace config {
 		    return a;
 		}
 	}
+	export class SidebarState {
+	    expanded?: string[];
+	    selected?: string;
+	    scroll?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SidebarState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.expanded = source["expanded"];
+	        this.selected = source["selected"];
+	        this.scroll = source["scroll"];
+	    }
+	}
 	export class SessionState {
 	    active_tab: number;
 	    tabs: SavedTab[];
+	    sidebar?: Record<string, SidebarState>;
 	
 	    static createFrom(source: any = {}) {
 	        return new SessionState(source);
@@ -481,6 +904,7 @@ export namespace config {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.active_tab = source["active_tab"];
 	        this.tabs = this.convertValues(source["tabs"], SavedTab);
+	        this.sidebar = this.convertValues(source["sidebar"], SidebarState, true);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -502,5 +926,55 @@ export namespace config {
 		}
 	}
 
+	export class ThemeColors {
+	    bg: string;
+	    bg_secondary: string;
+	    bg_tertiary: string;
+	    fg: string;
+	    fg_muted: string;
+	    accent: string;
+	    accent_hover: string;
+	    border: string;
+	    border_focused: string;
+	    success: string;
+	    warning: string;
+	    error: string;
+	    tab_bg: string;
+	    tab_active_bg: string;
+	    tab_active_fg: string;
+	    pane_bg: string;
+	    pane_border: string;
+	    pane_border_focused: string;
+	    toolbar_bg: string;
+	    footer_bg: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ThemeColors(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.bg = source["bg"];
+	        this.bg_secondary = source["bg_secondary"];
+	        this.bg_tertiary = source["bg_tertiary"];
+	        this.fg = source["fg"];
+	        this.fg_muted = source["fg_muted"];
+	        this.accent = source["accent"];
+	        this.accent_hover = source["accent_hover"];
+	        this.border = source["border"];
+	        this.border_focused = source["border_focused"];
+	        this.success = source["success"];
+	        this.warning = source["warning"];
+	        this.error = source["error"];
+	        this.tab_bg = source["tab_bg"];
+	        this.tab_active_bg = source["tab_active_bg"];
+	        this.tab_active_fg = source["tab_active_fg"];
+	        this.pane_bg = source["pane_bg"];
+	        this.pane_border = source["pane_border"];
+	        this.pane_border_focused = source["pane_border_focused"];
+	        this.toolbar_bg = source["toolbar_bg"];
+	        this.footer_bg = source["footer_bg"];
+	    }
+	}
 }
 
@@ -4,17 +4,21 @@ package backend
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
 	"log"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/patrick-goecommerce/Multiterminal-UI/internal/config"
 	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
+// closeTimeout is how long a closing session may take to exit on its own
+// (saving shell history, flushing files) before it is killed.
+const closeTimeout = 2 * time.Second
+
 // sessionIssue tracks which GitHub issue a session is working on.
 type sessionIssue struct {
 	Number int
@@ -26,26 +30,54 @@ type sessionIssue struct {
 // App is the main Wails application struct. All exported methods are
 // automatically available to the frontend via generated TypeScript bindings.
 type App struct {
-	ctx           context.Context
-	cfg           config.Config
-	health        config.HealthState
-	sessions      map[int]*terminal.Session
-	queues        map[int]*sessionQueue
-	sessionIssues map[int]*sessionIssue // issue linked to each session
-	mu                sync.Mutex
-	nextID            int
-	cancelAll         context.CancelFunc
+	ctx                context.Context
+	cfg                config.Config // read via currentConfig, replaced via updateConfig
+	cfgMu              sync.RWMutex  // guards cfg; the config watcher swaps it at any time
+	cfgSaveMu          sync.Mutex    // orders updateConfig's writes to disk
+	health             config.HealthState
+	sessions           map[int]*terminal.Session
+	queues             map[int]*sessionQueue
+	sessionIssues      map[int]*sessionIssue // issue linked to each session
+	yoloSessions       map[int]bool          // panes launched in YOLO mode (auto_approve)
+	pendingMirrors     map[int]bool          // mirrors waiting for AttachMirror
+	pinnedSessions     map[int]bool          // pinned panes, exempt from idle_timeout_minutes
+	idleWarned         map[int]bool          // panes sent terminal:idle-warning (closeIdleShells)
+	mu                 sync.Mutex
+	nextID             int
+	starting           int             // sessions past the max_sessions check, not yet stored
+	appCtx             context.Context // cancelled by Shutdown via cancelAll
+	cancelAll          context.CancelFunc
 	resolvedClaudePath string
 	claudeDetected     bool
+	scanWake           chan struct{}          // output arrived; see wakeScan
+	issues             issueCache             // GetIssueDetail results
+	gitSummaries       gitSummaryCache        // GetGitSummary results
+	gitStatuses        gitStatusCache         // file statuses per repository for the sidebar
+	sidebarDirs        []string               // folders the sidebar shows; see WatchSidebarDirs
+	scrollbackEvicted  int                    // scrollback rows dropped for scrollback_memory_budget_mb
+	focusedSession     int                    // pane that last gained focus; its usage is sampled
+	resizes            map[int]*pendingResize // ResizeSession calls waiting to settle
+	crashReport        string                 // written at startup after a crash loop (GetCrashReport)
+	controlToken       string                 // control API requests must send it; see startFocusListener
+	pipedInput         string                 // `mtui --stdin` input for the first user-opened pane; taken once
 }
 
 // NewApp creates a new App instance with the given configuration.
-func NewApp(cfg config.Config) *App {
+// pipedInput is text read from stdin (mtui --stdin) that is typed into the
+// first pane the user opens; "" for none.
+func NewApp(cfg config.Config, pipedInput string) *App {
 	return &App{
-		cfg:           cfg,
-		sessions:      make(map[int]*terminal.Session),
-		queues:        make(map[int]*sessionQueue),
-		sessionIssues: make(map[int]*sessionIssue),
+		cfg:            cfg,
+		pipedInput:     pipedInput,
+		sessions:       make(map[int]*terminal.Session),
+		queues:         make(map[int]*sessionQueue),
+		sessionIssues:  make(map[int]*sessionIssue),
+		yoloSessions:   make(map[int]bool),
+		pendingMirrors: make(map[int]bool),
+		pinnedSessions: make(map[int]bool),
+		idleWarned:     make(map[int]bool),
+		resizes:        make(map[int]*pendingResize),
+		scanWake:       make(chan struct{}, 1),
 	}
 }
 
@@ -57,14 +89,26 @@ func (a *App) Startup(ctx context.Context) {
 	a.health = config.LoadHealth()
 	config.MarkStarting(&a.health)
 	_ = config.SaveHealth(a.health)
+	a.crashReport = a.prepareCrashReport(time.Now())
 
 	// Resolve Claude CLI path before anything else needs it
 	a.resolveClaudeOnStartup()
 
 	// Start periodic scanner for activity and token detection
 	scanCtx, cancel := context.WithCancel(ctx)
+	a.appCtx = scanCtx
 	a.cancelAll = cancel
 	go a.scanLoop(scanCtx)
+	go a.snapshotLoop(scanCtx, config.HasRepeatedCrashes(&a.health))
+
+	// Reload the config file when it is edited externally
+	go a.watchConfig(scanCtx)
+
+	// Refresh the sidebar when files appear in or vanish from its folders
+	go a.watchSidebar(scanCtx)
+
+	// Remove old images pasted from the clipboard
+	go a.clipboardImageLoop(scanCtx)
 
 	// Start focus listener and register custom protocol for notification clicks
 	a.startFocusListener()
@@ -83,16 +127,26 @@ func (a *App) Shutdown(ctx context.Context) {
 	}
 	a.mu.Unlock()
 
+	// Stop all sessions in parallel so shutdown waits at most one timeout
+	var wg sync.WaitGroup
 	for _, s := range sessions {
-		s.Close()
+		wg.Add(1)
+		go func(s *terminal.Session) {
+			defer wg.Done()
+			s.CloseGraceful(closeTimeout)
+		}(s)
 	}
+	wg.Wait()
+	_ = os.Remove(crashSnapshotPath())
 
 	// Mark clean shutdown and auto-disable logging if stable
 	config.MarkCleanShutdown(&a.health)
 	if config.ShouldAutoDisableLogging(&a.health) {
 		config.DisableAutoLogging(&a.health)
-		a.cfg.LoggingEnabled = false
-		_ = config.Save(a.cfg)
+		_ = a.updateConfig(func(cfg *config.Config) bool {
+			cfg.LoggingEnabled = false
+			return true
+		})
 		log.Println("[Shutdown] Auto-logging disabled after 3 clean shutdowns")
 	}
 	_ = config.SaveHealth(a.health)
@@ -108,12 +162,35 @@ type SessionInfo struct {
 }
 
 // CreateSession spawns a new PTY session and starts streaming its output
-// to the frontend. Returns the session ID.
-func (a *App) CreateSession(argv []string, dir string, rows int, cols int) int {
+// to the frontend. env holds per-pane variables that override the inherited
+// environment (including TERM). Returns the session ID.
+func (a *App) CreateSession(argv []string, dir string, rows int, cols int, env map[string]string) int {
+	return a.createSession(argv, dir, rows, cols, env, "", true)
+}
+
+// createSession implements CreateSession; history is saved output that is
+// shown above the new process (see CreateSessionWithHistory). Only panes
+// the user opens (prefill) may receive the `mtui --stdin` text; restored
+// and scripted panes never take it.
+func (a *App) createSession(argv []string, dir string, rows int, cols int, env map[string]string, history string, prefill bool) int {
+	cfg := a.currentConfig()
 	a.mu.Lock()
+	if max := cfg.MaxSessions; max > 0 && a.processCount()+a.starting >= max {
+		a.mu.Unlock()
+		errMsg := fmt.Sprintf("Session limit reached: %d terminals are open (max_sessions)", max)
+		log.Printf("[CreateSession] %s", errMsg)
+		a.emitSessionError(0, errMsg)
+		return -1
+	}
 	a.nextID++
+	a.starting++
 	id := a.nextID
 	a.mu.Unlock()
+	defer func() {
+		a.mu.Lock()
+		a.starting--
+		a.mu.Unlock()
+	}()
 
 	if dir == "" {
 		dir, _ = os.Getwd()
@@ -125,18 +202,22 @@ func (a *App) CreateSession(argv []string, dir string, rows int, cols int) int {
 		cols = 80
 	}
 
-	log.Printf("[CreateSession] id=%d argv=%v dir=%q rows=%d cols=%d", id, argv, dir, rows, cols)
+	log.Printf("[CreateSession] id=%d argv=%v dir=%q rows=%d cols=%d env=%d vars", id, argv, dir, rows, cols, len(env))
 
 	// Use configured default shell when no command specified
-	if len(argv) == 0 && a.cfg.DefaultShell != "" {
-		argv = []string{a.cfg.DefaultShell}
+	if len(argv) == 0 {
+		var err error
+		if argv, err = cfg.LaunchShellArgv(); err != nil {
+			log.Printf("[CreateSession] default shell unusable (%v), using the system shell", err)
+		}
 	}
 
 	sess := terminal.NewSession(id, rows, cols)
-	if err := sess.Start(argv, dir, nil); err != nil {
+	sess.RestoreHistory(history)
+	if err := sess.Start(argv, dir, sessionEnv(cfg.ShouldUseMonochrome(), env)); err != nil {
 		errMsg := fmt.Sprintf("Session start failed: %v", err)
 		log.Printf("[CreateSession] ERROR: %s", errMsg)
-		runtime.EventsEmit(a.ctx, "terminal:error", id, errMsg)
+		a.emitSessionError(id, errMsg)
 		return -1
 	}
 	log.Printf("[CreateSession] session %d started successfully", id)
@@ -145,44 +226,16 @@ func (a *App) CreateSession(argv []string, dir string, rows int, cols int) int {
 	a.sessions[id] = sess
 	a.mu.Unlock()
 
-	// Stream PTY output to frontend
-	go a.streamOutput(id, sess)
-
-	// Watch for process exit
-	go a.watchExit(id, sess)
-
-	return id
-}
-
-// WriteToSession sends raw input data (base64-encoded) to a session's PTY.
-func (a *App) WriteToSession(id int, b64data string) {
-	a.mu.Lock()
-	sess := a.sessions[id]
-	a.mu.Unlock()
-	if sess == nil {
-		return
-	}
-	data, err := base64.StdEncoding.DecodeString(b64data)
-	if err != nil {
-		return
-	}
-	sess.Write(data)
-}
-
-// ResizeSession updates the PTY and screen buffer dimensions.
-func (a *App) ResizeSession(id int, rows int, cols int) {
-	a.mu.Lock()
-	sess := a.sessions[id]
-	a.mu.Unlock()
-	if sess == nil {
-		return
+	a.startStreaming(id, sess, argv)
+	if prefill {
+		a.prefillPipedInput(id, sess)
 	}
-	sess.Resize(rows, cols)
+	return id
 }
 
 // CloseSession terminates a session and removes it.
 // The session is closed asynchronously but removed from the map only
-// after Close() completes, ensuring streamOutput drains all buffered
+// after the session has closed, ensuring streamOutput drains all buffered
 // data before the session is gone.
 func (a *App) CloseSession(id int) {
 	a.mu.Lock()
@@ -195,63 +248,25 @@ func (a *App) CloseSession(id int) {
 	a.reportIssueProgress(id, progressClose, a.getSessionCost(id))
 
 	go func() {
-		sess.Close() // blocks until process exits and readLoop closes RawOutputCh
+		sess.CloseGraceful(closeTimeout) // blocks until the process exits and readLoop ends the subscriptions
 		a.mu.Lock()
 		delete(a.sessions, id)
 		delete(a.queues, id)
 		delete(a.sessionIssues, id)
+		delete(a.yoloSessions, id)
+		delete(a.pendingMirrors, id)
+		delete(a.pinnedSessions, id)
+		delete(a.idleWarned, id)
 		a.mu.Unlock()
 		// Clean up per-session activity tracking to prevent memory leak
 		cleanupActivityTracking(id)
 	}()
 }
 
-// GetConfig returns the current application configuration.
-func (a *App) GetConfig() config.Config {
-	return a.cfg
-}
-
-// SaveConfig saves the given config to disk and updates the in-memory copy.
-func (a *App) SaveConfig(cfg config.Config) error {
-	log.Printf("[SaveConfig] theme=%q terminal_color=%q", cfg.Theme, cfg.TerminalColor)
-	a.cfg = cfg
-	if err := config.Save(cfg); err != nil {
-		log.Printf("[SaveConfig] error: %v", err)
-		return fmt.Errorf("config save failed: %w", err)
-	}
-	// Re-detect Claude path in case claude_command changed
-	a.resolveClaudeOnStartup()
-	return nil
-}
-
-// SaveTabs persists the current tab/pane layout to disk so it can be
-// restored on next startup.
-func (a *App) SaveTabs(state config.SessionState) {
-	log.Printf("[SaveTabs] saving %d tabs", len(state.Tabs))
-	if err := config.SaveSession(state); err != nil {
-		log.Printf("[SaveTabs] error: %v", err)
-	}
-}
-
-// LoadTabs returns the previously saved tab/pane layout, or nil.
-func (a *App) LoadTabs() *config.SessionState {
-	if !a.cfg.ShouldRestoreSession() {
-		log.Printf("[LoadTabs] restore_session disabled")
-		return nil
-	}
-	state := config.LoadSession()
-	if state == nil {
-		log.Printf("[LoadTabs] no saved session found")
-	} else {
-		log.Printf("[LoadTabs] loaded %d tabs", len(state.Tabs))
-	}
-	return state
-}
-
 // GetWorkingDir returns the effective working directory (from config or cwd).
 func (a *App) GetWorkingDir() string {
-	if a.cfg.DefaultDir != "" {
-		return a.cfg.DefaultDir
+	if dir := a.currentConfig().DefaultDir; dir != "" {
+		return dir
 	}
 	dir, _ := os.Getwd()
 	return dir
@@ -272,4 +287,3 @@ func (a *App) SelectDirectory(startDir string) string {
 	}
 	return dir
 }
-
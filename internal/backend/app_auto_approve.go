@@ -0,0 +1,70 @@
+package backend
+
+import (
+	"log"
+	"regexp"
+)
+
+// promptAnswerer is the part of a terminal.Session that autoApprove needs.
+type promptAnswerer interface {
+	NeedsInputLine() string
+	Write(p []byte) (int, error)
+}
+
+// SetSessionYolo records whether a session runs in YOLO mode. Only such
+// sessions are considered for auto_approve; the frontend calls this for
+// every pane it shows in YOLO mode.
+func (a *App) SetSessionYolo(id int, yolo bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if yolo {
+		a.yoloSessions[id] = true
+	} else {
+		delete(a.yoloSessions, id)
+	}
+}
+
+// autoApprove answers the confirmation prompt of session id with "y" when
+// the session runs in YOLO mode and the prompt line matches one of the
+// auto_approve patterns. Every answer is logged for auditing.
+func (a *App) autoApprove(id int, sess promptAnswerer) {
+	a.mu.Lock()
+	yolo := a.yoloSessions[id]
+	a.mu.Unlock()
+	patterns := a.currentConfig().AutoApprove
+	if !yolo || len(patterns) == 0 {
+		return
+	}
+	line := sess.NeedsInputLine()
+	pattern := matchAutoApprove(patterns, line)
+	if pattern == "" {
+		return
+	}
+	if _, err := sess.Write([]byte("y\r")); err != nil {
+		log.Printf("[auto-approve] session %d: answering %q failed: %v", id, line, err)
+		return
+	}
+	log.Printf("[auto-approve] session %d: answered %q with y (pattern %q)", id, line, pattern)
+}
+
+// matchAutoApprove returns the first pattern that matches line, or "" if
+// none does. Empty lines and patterns never match, and patterns that do
+// not compile are skipped.
+func matchAutoApprove(patterns []string, line string) string {
+	if line == "" {
+		return ""
+	}
+	for _, p := range patterns {
+		if p == "" {
+			continue
+		}
+		re, err := regexp.Compile(p)
+		if err != nil {
+			continue
+		}
+		if re.MatchString(line) {
+			return p
+		}
+	}
+	return ""
+}
@@ -0,0 +1,99 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/config"
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+// fakePrompt is a promptAnswerer that shows line and records writes.
+type fakePrompt struct {
+	line   string
+	writes []string
+}
+
+func (f *fakePrompt) NeedsInputLine() string { return f.line }
+func (f *fakePrompt) Write(p []byte) (int, error) {
+	f.writes = append(f.writes, string(p))
+	return len(p), nil
+}
+
+func newAutoApproveApp(patterns ...string) *App {
+	cfg := config.DefaultConfig()
+	cfg.AutoApprove = patterns
+	return &App{cfg: cfg, yoloSessions: make(map[int]bool)}
+}
+
+func TestAutoApprove_AnswersMatchingPromptInYoloPane(t *testing.T) {
+	app := newAutoApproveApp(`^Run npm test\?`)
+	app.SetSessionYolo(1, true)
+	sess := &fakePrompt{line: "Run npm test? [y/N]"}
+
+	app.autoApprove(1, sess)
+
+	if len(sess.writes) != 1 || sess.writes[0] != "y\r" {
+		t.Errorf("writes = %q, want one \"y\\r\"", sess.writes)
+	}
+}
+
+func TestAutoApprove_NeverAnswersNonMatchingPrompt(t *testing.T) {
+	app := newAutoApproveApp(`^Run npm test\?`)
+	app.SetSessionYolo(1, true)
+	for _, line := range []string{
+		"Delete all files? [y/N]",
+		"Do you want to run rm -rf /? (y/n)",
+		"Please run npm test? [y/N]", // anchored pattern must not match mid-line
+		"",
+	} {
+		sess := &fakePrompt{line: line}
+		app.autoApprove(1, sess)
+		if len(sess.writes) != 0 {
+			t.Errorf("prompt %q was answered with %q", line, sess.writes)
+		}
+	}
+}
+
+func TestAutoApprove_OnlyYoloPanes(t *testing.T) {
+	app := newAutoApproveApp(`Run npm test`)
+	sess := &fakePrompt{line: "Run npm test? [y/N]"}
+
+	app.autoApprove(1, sess) // never marked as YOLO
+	app.SetSessionYolo(2, true)
+	app.SetSessionYolo(2, false)
+	app.autoApprove(2, sess)
+
+	if len(sess.writes) != 0 {
+		t.Errorf("non-YOLO pane was answered: %q", sess.writes)
+	}
+}
+
+func TestAutoApprove_OffWithoutPatterns(t *testing.T) {
+	app := newAutoApproveApp()
+	app.SetSessionYolo(1, true)
+	sess := &fakePrompt{line: "Allow? [Y/n]"}
+
+	app.autoApprove(1, sess)
+
+	if len(sess.writes) != 0 {
+		t.Errorf("answered without any auto_approve pattern: %q", sess.writes)
+	}
+}
+
+func TestMatchAutoApprove_SkipsBrokenAndEmptyPatterns(t *testing.T) {
+	patterns := []string{"", "(unclosed", "Allow"}
+	if got := matchAutoApprove(patterns, "Allow edit? [Y/n]"); got != "Allow" {
+		t.Errorf("matchAutoApprove = %q, want %q", got, "Allow")
+	}
+	if got := matchAutoApprove([]string{""}, "Anything? [Y/n]"); got != "" {
+		t.Errorf("empty pattern matched: %q", got)
+	}
+}
+
+func TestAutoApprove_UsesSessionPromptLine(t *testing.T) {
+	sess := terminal.NewSession(1, 5, 80)
+	sess.Screen.Write([]byte("output\r\nRun npm test? [y/N] "))
+	if got := matchAutoApprove([]string{`^Run npm test\?`}, sess.NeedsInputLine()); got == "" {
+		t.Errorf("NeedsInputLine %q did not match", sess.NeedsInputLine())
+	}
+}
@@ -22,7 +22,7 @@ type ClaudeDetectResult struct {
 // DetectClaudePath tries to locate the Claude CLI binary.
 // Priority: absolute config path → PATH lookup → known install locations.
 func (a *App) DetectClaudePath() ClaudeDetectResult {
-	cmd := a.cfg.ClaudeCommand
+	cmd := a.currentConfig().ClaudeCommand
 	if cmd == "" {
 		cmd = "claude"
 	}
@@ -102,7 +102,7 @@ func (a *App) resolveClaudeOnStartup() {
 		a.claudeDetected = true
 		log.Printf("[ClaudeDetect] found via %s: %s", result.Source, result.Path)
 	} else {
-		a.resolvedClaudePath = a.cfg.ClaudeCommand
+		a.resolvedClaudePath = a.currentConfig().ClaudeCommand
 		if a.resolvedClaudePath == "" {
 			a.resolvedClaudePath = "claude"
 		}
@@ -0,0 +1,142 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Pasted clipboard images are kept for clipboardImageMaxAge, so the pane
+// can still read the file a while after its path was typed, and removed by
+// a sweep every clipboardImageSweep.
+const (
+	clipboardImageMaxAge = 24 * time.Hour
+	clipboardImageSweep  = time.Hour
+)
+
+// errNoClipboardImage is returned when the clipboard holds no image.
+var errNoClipboardImage = errors.New("no image in the clipboard")
+
+// readClipboardImage returns the clipboard image as PNG
+// (clipboardImagePNG); tests replace it.
+var readClipboardImage = clipboardImagePNG
+
+// clipboardImageDir returns the folder pasted images are saved in. Like the
+// crash snapshot it lives in the per-user cache directory, so other
+// accounts on the machine cannot read the screenshots.
+var clipboardImageDir = func() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "Multiterminal", "clipboard")
+}
+
+// PasteClipboardImageToSession saves the image in the clipboard as a PNG
+// file and types its path into session id, quoted like a path inserted from
+// the sidebar, so Claude can read a screenshot. It returns the file's path.
+func (a *App) PasteClipboardImageToSession(id int) (string, error) {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	a.mu.Unlock()
+	if sess == nil {
+		return "", fmt.Errorf("session %d not found", id)
+	}
+	if sess.ReadOnly() {
+		return "", fmt.Errorf("session %d is read-only", id)
+	}
+	data, err := readClipboardImage()
+	if err != nil {
+		return "", err
+	}
+	if _, _, err := image.DecodeConfig(bytes.NewReader(data)); err != nil {
+		return "", errNoClipboardImage
+	}
+	path, err := saveClipboardImage(clipboardImageDir(), data, time.Now())
+	if err != nil {
+		return "", err
+	}
+	if !a.writeBytes(id, []byte(shellPath(path)+" "), "paste", "") {
+		os.Remove(path)
+		return "", fmt.Errorf("session %d is read-only", id)
+	}
+	return path, nil
+}
+
+// saveClipboardImage writes a PNG to a new file in dir, named after now.
+func saveClipboardImage(dir string, data []byte, now time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("saving the clipboard image failed: %w", err)
+	}
+	f, err := os.CreateTemp(dir, "clipboard-"+now.Format("20060102-150405")+"-*.png")
+	if err != nil {
+		return "", fmt.Errorf("saving the clipboard image failed: %w", err)
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("saving the clipboard image failed: %w", err)
+	}
+	return f.Name(), nil
+}
+
+// cleanClipboardImages removes pasted images in dir older than maxAge and
+// returns how many it removed.
+func cleanClipboardImages(dir string, maxAge time.Duration, now time.Time) int {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0
+	}
+	removed := 0
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, "clipboard-") || filepath.Ext(name) != ".png" {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil || now.Sub(info.ModTime()) < maxAge {
+			continue
+		}
+		if os.Remove(filepath.Join(dir, name)) == nil {
+			removed++
+		}
+	}
+	return removed
+}
+
+// clipboardImageLoop removes old pasted images at startup and then every
+// clipboardImageSweep until ctx is cancelled.
+func (a *App) clipboardImageLoop(ctx context.Context) {
+	t := time.NewTicker(clipboardImageSweep)
+	defer t.Stop()
+	now := time.Now()
+	for {
+		if n := cleanClipboardImages(clipboardImageDir(), clipboardImageMaxAge, now); n > 0 {
+			log.Printf("[clipboard] removed %d old pasted images", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case now = <-t.C:
+		}
+	}
+}
+
+// shellPath returns path as typed into a shell: quoted if it contains
+// spaces, like shellPath in the frontend's filetree.ts.
+func shellPath(path string) string {
+	if strings.Contains(path, " ") {
+		return `"` + path + `"`
+	}
+	return path
+}
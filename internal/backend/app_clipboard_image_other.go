@@ -0,0 +1,41 @@
+//go:build !windows
+
+package backend
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// clipboardImagePNG reads the clipboard image with the platform's tools:
+// osascript on macOS, wl-paste (Wayland) or xclip (X11) on Linux.
+func clipboardImagePNG() ([]byte, error) {
+	if runtime.GOOS == "darwin" {
+		// Prints «data PNGf89504E47…», or fails without a PNG on the clipboard
+		out, err := exec.Command("osascript", "-e", "the clipboard as «class PNGf»").Output()
+		if err != nil {
+			return nil, errNoClipboardImage
+		}
+		s := strings.TrimSpace(string(out))
+		s = strings.TrimSuffix(strings.TrimPrefix(s, "«data PNGf"), "»")
+		return hex.DecodeString(s)
+	}
+
+	name, args := "xclip", []string{"-selection", "clipboard", "-target", "image/png", "-out"}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		name, args = "wl-paste", []string{"--no-newline", "--type", "image/png"}
+	}
+	out, err := exec.Command(name, args...).Output()
+	if errors.Is(err, exec.ErrNotFound) {
+		return nil, fmt.Errorf("pasting images needs %s", name)
+	}
+	if err != nil || len(out) == 0 {
+		return nil, errNoClipboardImage
+	}
+	return out, nil
+}
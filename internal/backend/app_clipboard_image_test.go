@@ -0,0 +1,94 @@
+package backend
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+// stubClipboard makes the clipboard hold data and pasted images go to a
+// temp dir, which it returns.
+func stubClipboard(t *testing.T, data []byte) string {
+	dir := t.TempDir()
+	oldRead, oldDir := readClipboardImage, clipboardImageDir
+	readClipboardImage = func() ([]byte, error) { return data, nil }
+	clipboardImageDir = func() string { return dir }
+	t.Cleanup(func() { readClipboardImage, clipboardImageDir = oldRead, oldDir })
+	return dir
+}
+
+func TestPasteClipboardImageToSession(t *testing.T) {
+	var img bytes.Buffer
+	png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 4, 3)))
+	dir := stubClipboard(t, img.Bytes())
+	a := newTestApp()
+	a.sessions[1] = terminal.NewSession(1, 5, 20)
+
+	path, err := a.PasteClipboardImageToSession(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Dir(path) != dir || filepath.Ext(path) != ".png" {
+		t.Errorf("saved to %q, want a .png in %q", path, dir)
+	}
+	if data, _ := os.ReadFile(path); !bytes.Equal(data, img.Bytes()) {
+		t.Error("saved file differs from the clipboard image")
+	}
+	if _, err := a.PasteClipboardImageToSession(99); err == nil {
+		t.Error("pasting into an unknown session succeeded")
+	}
+	a.sessions[1].SetReadOnly(true)
+	if _, err := a.PasteClipboardImageToSession(1); err == nil {
+		t.Error("pasting into a read-only session succeeded")
+	}
+}
+
+func TestPasteClipboardImageToSession_NoImage(t *testing.T) {
+	dir := stubClipboard(t, []byte("just some text"))
+	a := newTestApp()
+	a.sessions[1] = terminal.NewSession(1, 5, 20)
+
+	if _, err := a.PasteClipboardImageToSession(1); !errors.Is(err, errNoClipboardImage) {
+		t.Errorf("err = %v, want errNoClipboardImage", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("%d files saved without an image", len(entries))
+	}
+}
+
+func TestCleanClipboardImages(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	old, _ := saveClipboardImage(dir, []byte("old"), now)
+	fresh, _ := saveClipboardImage(dir, []byte("fresh"), now)
+	other := filepath.Join(dir, "notes.png")
+	os.WriteFile(other, nil, 0o600)
+	for _, p := range []string{old, other} {
+		os.Chtimes(p, now.Add(-2*clipboardImageMaxAge), now.Add(-2*clipboardImageMaxAge))
+	}
+
+	if n := cleanClipboardImages(dir, clipboardImageMaxAge, now); n != 1 {
+		t.Errorf("removed %d images, want 1", n)
+	}
+	for p, want := range map[string]bool{old: false, fresh: true, other: true} {
+		if _, err := os.Stat(p); (err == nil) != want {
+			t.Errorf("%s exists = %v, want %v", filepath.Base(p), err == nil, want)
+		}
+	}
+}
+
+func TestShellPath(t *testing.T) {
+	if got := shellPath("/tmp/a.png"); got != "/tmp/a.png" {
+		t.Errorf("shellPath = %q", got)
+	}
+	if got := shellPath(`C:\Users\Jo Doe\a.png`); got != `"C:\Users\Jo Doe\a.png"` {
+		t.Errorf("shellPath with a space = %q", got)
+	}
+}
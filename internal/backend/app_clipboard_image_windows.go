@@ -0,0 +1,36 @@
+//go:build windows
+
+package backend
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// clipboardImageScript prints the clipboard image as base64 PNG, or nothing
+// if the clipboard holds no image.
+const clipboardImageScript = `Add-Type -AssemblyName System.Windows.Forms, System.Drawing
+$img = [Windows.Forms.Clipboard]::GetImage()
+if ($img) {
+  $ms = New-Object IO.MemoryStream
+  $img.Save($ms, [Drawing.Imaging.ImageFormat]::Png)
+  [Console]::Out.Write([Convert]::ToBase64String($ms.ToArray()))
+}`
+
+// clipboardImagePNG reads the clipboard image through PowerShell. The
+// clipboard API needs a single-threaded apartment, hence -STA.
+func clipboardImagePNG() ([]byte, error) {
+	cmd := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-STA", "-Command", clipboardImageScript)
+	hideConsole(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("reading the clipboard failed: %w", err)
+	}
+	encoded := strings.TrimSpace(string(out))
+	if encoded == "" {
+		return nil, errNoClipboardImage
+	}
+	return base64.StdEncoding.DecodeString(encoded)
+}
@@ -0,0 +1,14 @@
+package backend
+
+// GetCommandHistory returns the commands run in a shell pane, oldest first.
+// They are captured from OSC 133 prompt marks, so the list stays empty for
+// shells without semantic prompt integration.
+func (a *App) GetCommandHistory(id int) []string {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	a.mu.Unlock()
+	if sess == nil {
+		return nil
+	}
+	return sess.CommandHistory()
+}
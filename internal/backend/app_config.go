@@ -0,0 +1,65 @@
+package backend
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/config"
+)
+
+// GetConfig returns the current application configuration.
+func (a *App) GetConfig() config.Config {
+	return a.currentConfig()
+}
+
+// currentConfig returns a copy of the configuration. cfg is only ever
+// replaced as a whole, never changed in place, so the copy (including its
+// maps and slices) stays valid while another goroutine installs a new one.
+func (a *App) currentConfig() config.Config {
+	a.cfgMu.RLock()
+	defer a.cfgMu.RUnlock()
+	return a.cfg
+}
+
+// setConfig installs cfg without saving it, e.g. after a reload from disk.
+func (a *App) setConfig(cfg config.Config) {
+	a.cfgMu.Lock()
+	a.cfg = cfg
+	a.cfgMu.Unlock()
+}
+
+// updateConfig applies fn to a copy of the configuration and, if fn
+// reports a change, installs the copy and saves it to disk. fn must
+// replace maps and slices it changes rather than modify them in place.
+// The disk write happens after cfgMu is released, so readers of the
+// config (output streams, the scan loop) never wait for it; cfgSaveMu
+// keeps concurrent updates from saving an older config last.
+func (a *App) updateConfig(fn func(cfg *config.Config) bool) error {
+	a.cfgSaveMu.Lock()
+	defer a.cfgSaveMu.Unlock()
+	a.cfgMu.Lock()
+	cfg := a.cfg
+	if !fn(&cfg) {
+		a.cfgMu.Unlock()
+		return nil
+	}
+	a.cfg = cfg
+	a.cfgMu.Unlock()
+	return config.Save(cfg)
+}
+
+// SaveConfig saves the given config to disk and updates the in-memory copy.
+func (a *App) SaveConfig(cfg config.Config) error {
+	log.Printf("[SaveConfig] theme=%q terminal_color=%q", cfg.Theme, cfg.TerminalColor)
+	err := a.updateConfig(func(c *config.Config) bool {
+		*c = cfg
+		return true
+	})
+	if err != nil {
+		log.Printf("[SaveConfig] error: %v", err)
+		return fmt.Errorf("config save failed: %w", err)
+	}
+	// Re-detect Claude path in case claude_command changed
+	a.resolveClaudeOnStartup()
+	return nil
+}
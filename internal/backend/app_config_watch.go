@@ -0,0 +1,102 @@
+package backend
+
+import (
+	"context"
+	"log"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/config"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// configPollInterval is how often the config file is checked for changes.
+// A change is only applied once the file has been stable for one full
+// interval, which also debounces editors that write in several steps.
+const configPollInterval = 500 * time.Millisecond
+
+// fileStamp identifies a version of a file by size and modification time.
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+// statStamp returns the current stamp of path (zero if it does not exist).
+func statStamp(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{size: info.Size(), modTime: info.ModTime()}
+}
+
+// watchConfig polls the config file and reloads it after external edits.
+// Polling keeps the watcher dependency-free and behaves the same on all
+// platforms and editors (including atomic rename-on-save).
+func (a *App) watchConfig(ctx context.Context) {
+	path := config.Path()
+	if path == "" {
+		return
+	}
+	ticker := time.NewTicker(configPollInterval)
+	defer ticker.Stop()
+
+	applied := statStamp(path)
+	pending := applied
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		cur := statStamp(path)
+		if cur == applied {
+			continue
+		}
+		if cur != pending {
+			// Still changing — wait for the next tick
+			pending = cur
+			continue
+		}
+		applied = cur
+		a.reloadConfig()
+	}
+}
+
+// reloadConfig re-reads the config file and applies it if it parses and
+// differs from the current config. On errors the current config is kept.
+//
+// Applied live: theme, custom themes, keybindings, terminal colour, fonts,
+// default_launch, launch profiles, output coalescing and throttling,
+// approval_menu_patterns, idle_timeout_minutes and claude_command.
+// Running sessions keep their shell and working directory; default_shell,
+// default_shell_args and default_dir only affect panes opened after the
+// reload.
+func (a *App) reloadConfig() {
+	cfg, err := config.Reload()
+	if err != nil {
+		log.Printf("[reloadConfig] keeping current config: %v", err)
+		runtime.EventsEmit(a.ctx, "config:error", err.Error())
+		return
+	}
+	old := a.currentConfig()
+	if reflect.DeepEqual(cfg, old) {
+		return // e.g. our own SaveConfig write
+	}
+	claudeChanged := cfg.ClaudeCommand != old.ClaudeCommand
+	a.setConfig(cfg)
+	if claudeChanged {
+		a.resolveClaudeOnStartup()
+	}
+	menus := a.menuPatterns()
+	a.mu.Lock()
+	for _, sess := range a.sessions {
+		sess.SetOutputThrottle(a.outputThrottle())
+		sess.SetMenuPatterns(menus)
+		sess.Screen.SetMonochrome(cfg.ShouldUseMonochrome())
+	}
+	a.mu.Unlock()
+	log.Printf("[reloadConfig] config reloaded (theme=%q)", cfg.Theme)
+	runtime.EventsEmit(a.ctx, "config:reloaded", cfg)
+}
@@ -0,0 +1,182 @@
+package backend
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	// controlMaxLine bounds one request line (send carries the text inline).
+	controlMaxLine = 1 << 20
+	// controlIdle closes control connections that stay silent this long.
+	controlIdle = 5 * time.Minute
+)
+
+// controlRequest is one line of the control API, e.g.
+// {"cmd":"send","token":"…","id":1,"text":"make test\n"}.
+type controlRequest struct {
+	Cmd   string   `json:"cmd"`
+	Token string   `json:"token"`          // contents of the control-token file
+	ID    int      `json:"id,omitempty"`   // send: target session
+	Text  string   `json:"text,omitempty"` // send: input, written as typed
+	Dir   string   `json:"dir,omitempty"`  // new_pane: working directory
+	Argv  []string `json:"argv,omitempty"` // new_pane: command; empty = default shell
+	Name  string   `json:"name,omitempty"` // new_pane: pane name
+}
+
+// controlResponse answers one request on its own line.
+type controlResponse struct {
+	OK       bool          `json:"ok"`
+	Error    string        `json:"error,omitempty"`
+	ID       int           `json:"id,omitempty"` // new_pane: the new session
+	Sessions []SessionInfo `json:"sessions,omitempty"`
+}
+
+// ControlPane announces a session created through the control API; the
+// frontend shows it as a pane in the active tab.
+type ControlPane struct {
+	ID   int      `json:"id"`
+	Name string   `json:"name"`
+	Dir  string   `json:"dir"`
+	Argv []string `json:"argv"`
+}
+
+// serveControl answers line-delimited JSON requests on conn until the
+// client closes it or stays idle for controlIdle. r holds the bytes the
+// listener already peeked at.
+func (a *App) serveControl(conn net.Conn, r *bufio.Reader) {
+	defer conn.Close()
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 4096), controlMaxLine)
+	enc := json.NewEncoder(conn)
+	for {
+		_ = conn.SetReadDeadline(time.Now().Add(controlIdle))
+		if !sc.Scan() {
+			return
+		}
+		resp := a.handleControl(sc.Bytes())
+		_ = conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+		if err := enc.Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+// handleControl runs one request. Requests without the token are refused,
+// so other local users cannot use the port, and so are commands missing
+// from control_api, so it only does what the user opted into.
+func (a *App) handleControl(line []byte) controlResponse {
+	var req controlRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		return controlResponse{Error: "invalid request: " + err.Error()}
+	}
+	if a.controlToken == "" || subtle.ConstantTimeCompare([]byte(req.Token), []byte(a.controlToken)) != 1 {
+		return controlResponse{Error: "missing or wrong token (see control-token)"}
+	}
+	if !slices.Contains(a.currentConfig().ControlAPI, req.Cmd) {
+		return controlResponse{Error: fmt.Sprintf("command %q is not enabled (control_api)", req.Cmd)}
+	}
+	log.Printf("[control] %s", req.Cmd)
+	switch req.Cmd {
+	case "list_sessions":
+		return controlResponse{OK: true, Sessions: a.listSessions()}
+	case "send":
+		return a.controlSend(req)
+	case "new_pane":
+		return a.controlNewPane(req)
+	}
+	return controlResponse{Error: fmt.Sprintf("unknown command %q", req.Cmd)}
+}
+
+func (a *App) controlSend(req controlRequest) controlResponse {
+	a.mu.Lock()
+	_, ok := a.sessions[req.ID]
+	a.mu.Unlock()
+	if !ok {
+		return controlResponse{Error: fmt.Sprintf("no session %d", req.ID)}
+	}
+	if !a.writeBytes(req.ID, []byte(req.Text), "api", "") {
+		return controlResponse{Error: fmt.Sprintf("session %d is read-only", req.ID)}
+	}
+	return controlResponse{OK: true}
+}
+
+func (a *App) controlNewPane(req controlRequest) controlResponse {
+	id := a.createSession(req.Argv, req.Dir, 24, 80, nil, "", false)
+	if id <= 0 {
+		return controlResponse{Error: "could not start the session"}
+	}
+	name := req.Name
+	if name == "" {
+		name = "API"
+	}
+	runtime.EventsEmit(a.ctx, "control:pane", ControlPane{ID: id, Name: name, Dir: req.Dir, Argv: req.Argv})
+	return controlResponse{OK: true, ID: id}
+}
+
+// listSessions describes all sessions, ordered by ID.
+func (a *App) listSessions() []SessionInfo {
+	a.mu.Lock()
+	ids := make([]int, 0, len(a.sessions))
+	for id := range a.sessions {
+		ids = append(ids, id)
+	}
+	a.mu.Unlock()
+	slices.Sort(ids)
+
+	infos := make([]SessionInfo, 0, len(ids))
+	for _, id := range ids {
+		a.mu.Lock()
+		sess := a.sessions[id]
+		a.mu.Unlock()
+		if sess == nil {
+			continue
+		}
+		title, _ := sess.DisplayTitle()
+		info := SessionInfo{ID: id, Name: title, Running: sess.IsRunning()}
+		if !info.Running {
+			info.ExitCode = sess.ExitCode
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// controlTokenPath is the file holding the control API token, readable only
+// by the user, e.g. ~/.config/Multiterminal/control-token.
+func controlTokenPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "Multiterminal", "control-token")
+}
+
+// writeControlToken writes a new random token to path and returns it. Each
+// start gets a new one, so a token that leaked stops working.
+func writeControlToken(path string) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(b)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+	if err := writeFileAtomic(path, token+"\n"); err != nil {
+		return "", err
+	}
+	return token, nil
+}
@@ -0,0 +1,140 @@
+package backend
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+func TestHandleControl_Allowlist(t *testing.T) {
+	a := newTestApp()
+	a.controlToken = "secret"
+	if resp := a.handleControl([]byte(`{"token":"secret","cmd":"list_sessions"}`)); resp.OK || !strings.Contains(resp.Error, "not enabled") {
+		t.Errorf("disabled API answered %+v", resp)
+	}
+
+	a.cfg.ControlAPI = []string{"list_sessions"}
+	if resp := a.handleControl([]byte(`{"token":"secret","cmd":"list_sessions"}`)); !resp.OK {
+		t.Errorf("enabled command refused: %+v", resp)
+	}
+	if resp := a.handleControl([]byte(`{"token":"secret","cmd":"send","id":1,"text":"x"}`)); resp.OK {
+		t.Errorf("send ran without being enabled: %+v", resp)
+	}
+	if resp := a.handleControl([]byte(`{"token":"secret","cmd":`)); resp.OK || !strings.Contains(resp.Error, "invalid request") {
+		t.Errorf("malformed line answered %+v", resp)
+	}
+}
+
+func TestHandleControl_ListAndSend(t *testing.T) {
+	a := newTestApp()
+	a.controlToken = "secret"
+	a.cfg.ControlAPI = []string{"list_sessions", "send"}
+	a.sessions[2] = terminal.NewSession(2, 5, 20)
+	a.sessions[1] = terminal.NewSession(1, 5, 20)
+	a.sessions[1].SetManualName("server")
+
+	resp := a.handleControl([]byte(`{"token":"secret","cmd":"list_sessions"}`))
+	if len(resp.Sessions) != 2 || resp.Sessions[0].ID != 1 || resp.Sessions[0].Name != "server" || resp.Sessions[1].ID != 2 {
+		t.Errorf("list_sessions = %+v, want sessions 1 (server) and 2", resp.Sessions)
+	}
+
+	if resp := a.handleControl([]byte(`{"token":"secret","cmd":"send","id":9,"text":"ls\n"}`)); resp.OK {
+		t.Errorf("send to a missing session answered %+v", resp)
+	}
+	if resp := a.handleControl([]byte(`{"token":"secret","cmd":"send","id":1,"text":"ls\n"}`)); !resp.OK {
+		t.Errorf("send refused: %+v", resp)
+	}
+	a.sessions[1].SetReadOnly(true)
+	if resp := a.handleControl([]byte(`{"token":"secret","cmd":"send","id":1,"text":"ls\n"}`)); resp.OK || !strings.Contains(resp.Error, "read-only") {
+		t.Errorf("send to a locked pane answered %+v", resp)
+	}
+}
+
+func TestServeControl_LineDelimited(t *testing.T) {
+	a := newTestApp()
+	a.controlToken = "secret"
+	a.cfg.ControlAPI = []string{"list_sessions"}
+	client, server := net.Pipe()
+	go a.serveControl(server, bufio.NewReader(server))
+	defer client.Close()
+
+	go client.Write([]byte("{\"token\":\"secret\",\"cmd\":\"list_sessions\"}\n{\"token\":\"secret\",\"cmd\":\"new_pane\"}\n"))
+	r := bufio.NewReader(client)
+	for i, wantOK := range []bool{true, false} {
+		line, err := r.ReadBytes('\n')
+		if err != nil {
+			t.Fatalf("response %d: %v", i, err)
+		}
+		var resp controlResponse
+		if err := json.Unmarshal(line, &resp); err != nil {
+			t.Fatalf("response %d %q: %v", i, line, err)
+		}
+		if resp.OK != wantOK {
+			t.Errorf("response %d = %+v, want ok=%v", i, resp, wantOK)
+		}
+	}
+}
+
+func TestHandleControl_Token(t *testing.T) {
+	a := newTestApp()
+	a.cfg.ControlAPI = []string{"list_sessions"}
+	for _, line := range []string{`{"cmd":"list_sessions"}`, `{"token":"","cmd":"list_sessions"}`} {
+		if resp := a.handleControl([]byte(line)); resp.OK {
+			t.Errorf("%s answered without a token file: %+v", line, resp)
+		}
+	}
+
+	a.controlToken = "secret"
+	for _, line := range []string{`{"cmd":"list_sessions"}`, `{"token":"secre","cmd":"list_sessions"}`} {
+		if resp := a.handleControl([]byte(line)); resp.OK || !strings.Contains(resp.Error, "token") {
+			t.Errorf("%s answered %+v", line, resp)
+		}
+	}
+	if resp := a.handleControl([]byte(`{"token":"secret","cmd":"list_sessions"}`)); !resp.OK {
+		t.Errorf("request with the token refused: %+v", resp)
+	}
+}
+
+func TestWriteControlToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Multiterminal", "control-token")
+	first, err := writeControlToken(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if len(first) != 64 || strings.TrimSpace(string(data)) != first {
+		t.Errorf("token %q, file %q", first, data)
+	}
+	if info, err := os.Stat(path); err == nil && runtime.GOOS != "windows" && info.Mode().Perm() != 0o600 {
+		t.Errorf("token file mode = %o, want 600", info.Mode().Perm())
+	}
+	if second, _ := writeControlToken(path); second == first {
+		t.Error("a new start kept the old token")
+	}
+}
+
+func TestServeConn_SilentClientBlocksNoOne(t *testing.T) {
+	a := newTestApp()
+	a.controlToken = "secret"
+	a.cfg.ControlAPI = []string{"list_sessions"}
+	idle, idleServer := net.Pipe()
+	defer idle.Close()
+	go a.serveConn(idleServer) // never writes
+
+	client, server := net.Pipe()
+	defer client.Close()
+	go a.serveConn(server)
+	go client.Write([]byte("{\"token\":\"secret\",\"cmd\":\"list_sessions\"}\n"))
+	client.SetReadDeadline(time.Now().Add(500 * time.Millisecond))
+	if line, err := bufio.NewReader(client).ReadBytes('\n'); err != nil || !strings.Contains(string(line), `"ok":true`) {
+		t.Errorf("second client got %q, %v while the first stayed silent", line, err)
+	}
+}
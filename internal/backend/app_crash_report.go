@@ -0,0 +1,165 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/config"
+)
+
+const (
+	// crashSnapshotInterval is how often the screens are saved while the
+	// app runs, so a crash leaves their recent state behind.
+	crashSnapshotInterval = 30 * time.Second
+	// crashSnapshotLines is the number of output lines kept per session.
+	crashSnapshotLines = 40
+)
+
+// crashSnapshotPath is where the running app keeps its latest snapshot.
+// It lives in the per-user cache directory, not next to the executable,
+// so other accounts on the machine cannot read it. A clean shutdown
+// removes it.
+func crashSnapshotPath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return filepath.Join(logDir(), "crash-snapshot.txt")
+	}
+	dir = filepath.Join(dir, "Multiterminal")
+	_ = os.MkdirAll(dir, 0o700)
+	return filepath.Join(dir, "crash-snapshot.txt")
+}
+
+// snapshotLoop saves a crash snapshot every crashSnapshotInterval until
+// ctx is cancelled. Snapshots are only taken while they can help: after
+// repeated crashes (crashed) or while logging is on.
+func (a *App) snapshotLoop(ctx context.Context, crashed bool) {
+	t := time.NewTicker(crashSnapshotInterval)
+	defer t.Stop()
+	path := crashSnapshotPath()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.C:
+			cfg := a.currentConfig()
+			if !crashed && !cfg.LoggingEnabled {
+				continue
+			}
+			if err := writeFileAtomic(path, a.crashSnapshot(now, cfg.CrashReportScreens)); err != nil {
+				log.Printf("[crashSnapshot] %v", err)
+			}
+		}
+	}
+}
+
+// crashSnapshot lists every session, ordered by ID. With screens, the
+// last crashSnapshotLines lines of each session's output are included;
+// otherwise they are left out, as they may show tokens or private output.
+func (a *App) crashSnapshot(now time.Time, screens bool) string {
+	a.mu.Lock()
+	ids := make([]int, 0, len(a.sessions))
+	for id := range a.sessions {
+		ids = append(ids, id)
+	}
+	a.mu.Unlock()
+	slices.Sort(ids)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Snapshot taken %s, %d session(s)\n", now.Format(time.RFC3339), len(ids))
+	if !screens {
+		b.WriteString("Screen contents omitted (crash_report_screens: false)\n")
+	}
+	for _, id := range ids {
+		a.mu.Lock()
+		sess := a.sessions[id]
+		a.mu.Unlock()
+		if sess == nil {
+			continue
+		}
+		title, _ := sess.DisplayTitle()
+		state := "running"
+		if !sess.IsRunning() {
+			state = "exited"
+		}
+		fmt.Fprintf(&b, "\n=== session %d %q (%s, argv %q)\n", id, title, state, sess.Argv())
+		if screens {
+			b.WriteString(lastLines(sess.Screen.Export(false), crashSnapshotLines))
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// lastLines returns the final n lines of text with trailing blank lines
+// dropped.
+func lastLines(text string, n int) string {
+	lines := strings.Split(strings.TrimRight(text, "\n "), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// writeFileAtomic replaces path via a temporary file, so a crash while
+// writing never leaves half a snapshot. The file is readable only by the
+// user.
+func writeFileAtomic(path, content string) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// prepareCrashReport runs at startup. After a crash loop it writes a
+// report with the last snapshot and the config next to the log files,
+// and turns on logging without waiting for the user. It returns the
+// report text, or "" when there was no crash loop.
+func (a *App) prepareCrashReport(now time.Time) string {
+	if !config.HasCrashLoop(&a.health) {
+		return ""
+	}
+	snapshot, err := os.ReadFile(crashSnapshotPath())
+	if err != nil {
+		snapshot = []byte("No snapshot found; the app may have crashed within its first 30 seconds.\n")
+	}
+	cfg := a.currentConfig()
+	report := buildCrashReport(a.health, cfg, string(snapshot), now)
+	path := filepath.Join(logDir(), fmt.Sprintf("multiterminal-crash-%s.txt", now.Format("2006-01-02-150405")))
+	if err := os.WriteFile(path, []byte(report), 0o600); err != nil {
+		log.Printf("[crashReport] write %s: %v", path, err)
+	} else {
+		log.Printf("[crashReport] %d dirty shutdowns in a row, report -> %s", config.CrashLoopRuns, path)
+	}
+	if !cfg.LoggingEnabled {
+		a.EnableLogging(true)
+	}
+	return report
+}
+
+// buildCrashReport assembles the report text.
+func buildCrashReport(h config.HealthState, cfg config.Config, snapshot string, now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Multiterminal crash report\nVersion: %s\nCreated: %s\n", Version, now.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Last %d sessions ended without a clean shutdown.\nShutdown history (oldest first, true = clean): %v\n",
+		config.CrashLoopRuns, h.Shutdowns)
+	b.WriteString("\n--- Screens before the last crash ---\n")
+	b.WriteString(snapshot)
+	b.WriteString("\n--- Config ---\n")
+	b.WriteString(config.ReportYAML(cfg))
+	return b.String()
+}
+
+// GetCrashReport returns the report written at startup after a crash
+// loop, for the user to attach to a bug report. "" when there was none.
+func (a *App) GetCrashReport() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.crashReport
+}
@@ -0,0 +1,70 @@
+package backend
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/config"
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+func TestLastLines(t *testing.T) {
+	text := "a\nb\nc\nd\n\n\n"
+	if got := lastLines(text, 2); got != "c\nd" {
+		t.Errorf("lastLines(2) = %q, want trailing blank lines dropped and %q", got, "c\nd")
+	}
+	if got := lastLines("one", 5); got != "one" {
+		t.Errorf("lastLines(short) = %q", got)
+	}
+}
+
+func TestCrashSnapshot_ListsSessions(t *testing.T) {
+	a := newTestApp()
+	sess := terminal.NewSession(3, 5, 40)
+	sess.Screen.Write([]byte("panic: runtime error\r\ngoroutine 1 [running]"))
+	sess.SetManualName("server")
+	a.sessions[3] = sess
+
+	out := a.crashSnapshot(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), true)
+	for _, want := range []string{"1 session(s)", `=== session 3 "server"`, "panic: runtime error", "goroutine 1 [running]"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("snapshot lacks %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestCrashSnapshot_OmitsScreensByDefault(t *testing.T) {
+	a := newTestApp()
+	sess := terminal.NewSession(3, 5, 40)
+	sess.Screen.Write([]byte("export TOKEN=ghp_secret"))
+	a.sessions[3] = sess
+
+	out := a.crashSnapshot(time.Now(), false)
+	if strings.Contains(out, "ghp_secret") {
+		t.Errorf("snapshot contains screen text:\n%s", out)
+	}
+	if !strings.Contains(out, "=== session 3") || !strings.Contains(out, "omitted") {
+		t.Errorf("snapshot should still list the session and say the screens are omitted:\n%s", out)
+	}
+}
+
+func TestBuildCrashReport(t *testing.T) {
+	h := config.HealthState{Shutdowns: []bool{true, false, false, false, false}}
+	report := buildCrashReport(h, config.DefaultConfig(), "=== session 1\nlast words\n", time.Now())
+	for _, want := range []string{"crash report", "[true false false false false]", "last words", "--- Config ---", "theme: dark"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report lacks %q:\n%s", want, report)
+		}
+	}
+}
+
+func TestPrepareCrashReport_NoCrashLoop(t *testing.T) {
+	a := &App{health: config.HealthState{Shutdowns: []bool{false, true, false, false}}}
+	if got := a.prepareCrashReport(time.Now()); got != "" {
+		t.Errorf("report written without a crash loop:\n%s", got)
+	}
+	if a.CheckHealth().CrashReport {
+		t.Error("CheckHealth reports a crash report that does not exist")
+	}
+}
@@ -0,0 +1,14 @@
+package backend
+
+// GetUnhandledSequences returns the escape sequences the Go-side parser
+// dropped for a session, for rendering bug triage. Returns nil if the
+// session does not exist.
+func (a *App) GetUnhandledSequences(id int) map[string]int {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	a.mu.Unlock()
+	if sess == nil {
+		return nil
+	}
+	return sess.UnhandledSequences()
+}
@@ -0,0 +1,102 @@
+package backend
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	wailsrt "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// exportMaxBytes bounds ExportSession output. The screen keeps at most
+// terminal.ScrollbackRows rows above the visible ones, so this only bites
+// on very wide panes or heavily styled ANSI output.
+const exportMaxBytes = 1 << 20
+
+// issueCommentMaxBytes stays below GitHub's 65536-character comment limit
+// with room for the surrounding markup.
+const issueCommentMaxBytes = 60000
+
+// ExportSession returns a pane's scrollback and screen as text, one
+// logical line per line. With includeAnsi the colours are kept as SGR
+// sequences. Output over exportMaxBytes keeps the newest lines. Unknown
+// sessions give "".
+func (a *App) ExportSession(id int, includeAnsi bool) string {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	a.mu.Unlock()
+	if sess == nil {
+		return ""
+	}
+	return tailLines(sess.Screen.Export(includeAnsi), exportMaxBytes)
+}
+
+// ExportSessionToFile asks for a file name and writes ExportSession output
+// to it. It returns the chosen path, or "" when the dialog was cancelled.
+func (a *App) ExportSessionToFile(id int, includeAnsi bool) (string, error) {
+	name := fmt.Sprintf("terminal-%d.txt", id)
+	if includeAnsi {
+		name = fmt.Sprintf("terminal-%d.ans", id)
+	}
+	path, err := wailsrt.SaveFileDialog(a.ctx, wailsrt.SaveDialogOptions{
+		Title:           "Terminal-Ausgabe speichern",
+		DefaultFilename: name,
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+	text := a.ExportSession(id, includeAnsi)
+	if err := os.WriteFile(path, []byte(text+"\n"), 0o644); err != nil {
+		log.Printf("[ExportSessionToFile] write %s: %v", path, err)
+		return "", err
+	}
+	return path, nil
+}
+
+// AttachSessionToIssue posts a pane's plain-text output as a comment on
+// the issue linked to it (LinkSessionIssue). Long output keeps its newest
+// lines.
+func (a *App) AttachSessionToIssue(id int) error {
+	a.mu.Lock()
+	si := a.sessionIssues[id]
+	a.mu.Unlock()
+	if si == nil || si.Number == 0 || si.Dir == "" {
+		return fmt.Errorf("session %d has no linked issue", id)
+	}
+	text := a.ExportSession(id, false)
+	if text == "" {
+		return fmt.Errorf("no output to attach")
+	}
+	return a.AddIssueComment(si.Dir, si.Number, formatOutputComment(text))
+}
+
+// formatOutputComment wraps terminal output in a collapsed code block.
+func formatOutputComment(text string) string {
+	text = tailLines(text, issueCommentMaxBytes)
+	// A fence longer than any backtick run in the output cannot be closed early
+	fence := "```"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+	return "**Multiterminal Terminal-Ausgabe**\n\n<details><summary>Ausgabe anzeigen</summary>\n\n" +
+		fence + "\n" + text + "\n" + fence + "\n</details>"
+}
+
+// tailLines cuts text to at most max bytes by dropping whole lines from
+// the start, marking the cut with a note line.
+func tailLines(text string, max int) string {
+	if len(text) <= max {
+		return text
+	}
+	const note = "[… ältere Ausgabe gekürzt]\n"
+	cut := text[len(text)-max+len(note):]
+	if i := strings.IndexByte(cut, '\n'); i >= 0 {
+		cut = cut[i+1:]
+	}
+	for len(cut) > 0 && !utf8.RuneStart(cut[0]) {
+		cut = cut[1:]
+	}
+	return note + cut
+}
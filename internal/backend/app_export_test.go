@@ -0,0 +1,65 @@
+package backend
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+func TestExportSession(t *testing.T) {
+	a := newTestApp()
+	sess := terminal.NewSession(1, 2, 10)
+	sess.Screen.Write([]byte("first\r\n\x1b[32msecond\x1b[0m\r\nthird"))
+	a.sessions[1] = sess
+
+	if got := a.ExportSession(1, false); got != "first\nsecond\nthird" {
+		t.Errorf("plain export = %q", got)
+	}
+	if got := a.ExportSession(1, true); !strings.Contains(got, "\x1b[0;32msecond") {
+		t.Errorf("ANSI export = %q, want the green SGR kept", got)
+	}
+	if got := a.ExportSession(99, false); got != "" {
+		t.Errorf("unknown session = %q, want empty", got)
+	}
+}
+
+func TestAttachSessionToIssue_RequiresLinkedIssue(t *testing.T) {
+	a := newTestApp()
+	a.sessions[1] = terminal.NewSession(1, 2, 10)
+	if err := a.AttachSessionToIssue(1); err == nil {
+		t.Error("want an error for a session without a linked issue")
+	}
+}
+
+func TestTailLines(t *testing.T) {
+	text := strings.Repeat("0123456789\n", 100) + "end"
+	if got := tailLines(text, len(text)); got != text {
+		t.Error("text within the limit should be unchanged")
+	}
+	got := tailLines(text, 200)
+	if len(got) > 200 {
+		t.Errorf("len = %d, want <= 200", len(got))
+	}
+	if !strings.HasPrefix(got, "[… ältere Ausgabe gekürzt]\n0123456789\n") || !strings.HasSuffix(got, "\nend") {
+		t.Errorf("tailLines = %q, want the note then whole newest lines", got)
+	}
+}
+
+func TestTailLines_LongLineKeepsValidUTF8(t *testing.T) {
+	got := tailLines(strings.Repeat("ä", 500), 101)
+	if !utf8.ValidString(got) {
+		t.Errorf("tailLines split a rune: %q", got)
+	}
+}
+
+func TestFormatOutputComment(t *testing.T) {
+	body := formatOutputComment("go test\n```inner```")
+	if !strings.Contains(body, "````\ngo test\n```inner```\n````") {
+		t.Errorf("body = %q, want a fence longer than the backticks in the output", body)
+	}
+	if long := formatOutputComment(strings.Repeat("x\n", 40000)); len(long) > 65536 {
+		t.Errorf("comment length = %d, over GitHub's limit", len(long))
+	}
+}
@@ -2,6 +2,9 @@ package backend
 
 import (
 	"log"
+	"maps"
+	"os"
+	"slices"
 
 	"github.com/patrick-goecommerce/Multiterminal-UI/internal/config"
 )
@@ -11,15 +14,11 @@ func (a *App) GetFavorites(dir string) []string {
 	if dir == "" {
 		return nil
 	}
-	a.mu.Lock()
-	defer a.mu.Unlock()
-	src := a.cfg.Favorites[dir]
+	src := a.currentConfig().Favorites[dir]
 	if len(src) == 0 {
 		return nil
 	}
-	result := make([]string, len(src))
-	copy(result, src)
-	return result
+	return slices.Clone(src)
 }
 
 // AddFavorite adds a path to the favorites for the given directory
@@ -28,23 +27,19 @@ func (a *App) AddFavorite(dir string, path string) error {
 	if dir == "" || path == "" {
 		return nil
 	}
-	a.mu.Lock()
-	defer a.mu.Unlock()
-
-	if a.cfg.Favorites == nil {
-		a.cfg.Favorites = make(map[string][]string)
-	}
-
-	// Check for duplicates
-	for _, f := range a.cfg.Favorites[dir] {
-		if f == path {
-			return nil
+	return a.updateConfig(func(cfg *config.Config) bool {
+		favs := cfg.Favorites[dir]
+		if slices.Contains(favs, path) {
+			return false
 		}
-	}
-
-	a.cfg.Favorites[dir] = append(a.cfg.Favorites[dir], path)
-	log.Printf("[AddFavorite] dir=%q path=%q total=%d", dir, path, len(a.cfg.Favorites[dir]))
-	return config.Save(a.cfg)
+		cfg.Favorites = maps.Clone(cfg.Favorites)
+		if cfg.Favorites == nil {
+			cfg.Favorites = make(map[string][]string)
+		}
+		cfg.Favorites[dir] = append(slices.Clip(favs), path)
+		log.Printf("[AddFavorite] dir=%q path=%q total=%d", dir, path, len(cfg.Favorites[dir]))
+		return true
+	})
 }
 
 // RemoveFavorite removes a path from the favorites for the given directory
@@ -53,31 +48,58 @@ func (a *App) RemoveFavorite(dir string, path string) error {
 	if dir == "" || path == "" {
 		return nil
 	}
-	a.mu.Lock()
-	defer a.mu.Unlock()
-
-	if a.cfg.Favorites == nil {
-		return nil
-	}
+	return a.updateConfig(func(cfg *config.Config) bool {
+		favs := cfg.Favorites[dir]
+		i := slices.Index(favs, path)
+		if i < 0 {
+			return false
+		}
+		cfg.Favorites = maps.Clone(cfg.Favorites)
+		// Clean up empty entries
+		if len(favs) == 1 {
+			delete(cfg.Favorites, dir)
+		} else {
+			cfg.Favorites[dir] = slices.Delete(slices.Clone(favs), i, i+1)
+		}
+		log.Printf("[RemoveFavorite] dir=%q path=%q", dir, path)
+		return true
+	})
+}
 
-	favs := a.cfg.Favorites[dir]
-	found := false
-	for i, f := range favs {
-		if f == path {
-			a.cfg.Favorites[dir] = append(favs[:i], favs[i+1:]...)
-			found = true
-			break
+// PruneFavorites removes the favorites of dir whose files no longer exist
+// and persists the config if any were removed. It returns how many were
+// removed. The sidebar calls it before listing the favorites.
+func (a *App) PruneFavorites(dir string) int {
+	var missing []string
+	for _, path := range a.currentConfig().Favorites[dir] {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			missing = append(missing, path)
 		}
 	}
-	if !found {
-		return nil
+	if len(missing) == 0 {
+		return 0
 	}
-
-	// Clean up empty entries
-	if len(a.cfg.Favorites[dir]) == 0 {
-		delete(a.cfg.Favorites, dir)
+	removed := 0
+	err := a.updateConfig(func(cfg *config.Config) bool {
+		favs := cfg.Favorites[dir]
+		kept := slices.DeleteFunc(slices.Clone(favs), func(p string) bool {
+			return slices.Contains(missing, p)
+		})
+		removed = len(favs) - len(kept)
+		if removed == 0 {
+			return false
+		}
+		cfg.Favorites = maps.Clone(cfg.Favorites)
+		if len(kept) == 0 {
+			delete(cfg.Favorites, dir)
+		} else {
+			cfg.Favorites[dir] = kept
+		}
+		log.Printf("[PruneFavorites] dir=%q removed=%d", dir, removed)
+		return true
+	})
+	if err != nil {
+		log.Printf("[PruneFavorites] save failed: %v", err)
 	}
-
-	log.Printf("[RemoveFavorite] dir=%q path=%q", dir, path)
-	return config.Save(a.cfg)
+	return removed
 }
@@ -1,6 +1,14 @@
 package backend
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/config"
+)
 
 func TestGetFavorites_EmptyDir(t *testing.T) {
 	a := newTestApp()
@@ -112,3 +120,58 @@ func TestAddFavorite_EmptyArgs(t *testing.T) {
 		t.Fatal("empty path should return nil, not error")
 	}
 }
+
+// The config watcher swaps cfg while sessions and bound methods read it;
+// run with -race.
+func TestConfig_ConcurrentReloadAndFavorites(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	a := newTestApp()
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			a.setConfig(config.DefaultConfig())
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			_ = a.AddFavorite("/project", "/project/main.go")
+			_ = a.RemoveFavorite("/project", "/project/main.go")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			_ = a.GetFavorites("/project")
+			_ = a.outputChunkLimit()
+		}
+	}()
+	wg.Wait()
+}
+
+func TestPruneFavorites_DropsMissingFiles(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	dir := t.TempDir()
+	kept := filepath.Join(dir, "main.go")
+	gone := filepath.Join(dir, "deleted.go")
+	os.WriteFile(kept, []byte("package main"), 0644)
+
+	a := newTestApp()
+	a.cfg.Favorites = map[string][]string{dir: {gone, kept}}
+	if n := a.PruneFavorites(dir); n != 1 {
+		t.Fatalf("PruneFavorites = %d, want 1", n)
+	}
+	if got := a.GetFavorites(dir); !reflect.DeepEqual(got, []string{kept}) {
+		t.Errorf("favorites = %q, want %q", got, []string{kept})
+	}
+	if n := a.PruneFavorites(dir); n != 0 {
+		t.Errorf("second PruneFavorites = %d, want 0", n)
+	}
+}
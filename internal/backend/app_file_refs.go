@@ -0,0 +1,62 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileRef is a "path:line[:col]" reference on a session's screen with its
+// path resolved against the pane's working directory.
+type FileRef struct {
+	Row    int    `json:"row"` // screen row and cell column, 0-based
+	Col    int    `json:"col"`
+	Text   string `json:"text"` // as printed
+	Path   string `json:"path"` // absolute when the pane's directory is known
+	Line   int    `json:"line"`
+	Column int    `json:"column"` // 0 when not given
+	Exists bool   `json:"exists"`
+}
+
+// GetFileRefs returns the file references compiler, linter and test output
+// left on the visible screen of a session, so the frontend can open them.
+// Relative paths are resolved against the pane's current directory (see
+// GetSessionDir), "~/" against the home directory.
+func (a *App) GetFileRefs(id int) []FileRef {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	a.mu.Unlock()
+	if sess == nil {
+		return nil
+	}
+	found := sess.Screen.FileRefs()
+	if len(found) == 0 {
+		return nil
+	}
+	dir := a.GetSessionDir(id)
+	refs := make([]FileRef, 0, len(found))
+	for _, f := range found {
+		path := resolveRefPath(f.Path, dir)
+		_, err := os.Stat(path)
+		refs = append(refs, FileRef{
+			Row: f.Row, Col: f.Col, Text: f.Text,
+			Path: path, Line: f.Line, Column: f.Column,
+			Exists: err == nil && filepath.IsAbs(path),
+		})
+	}
+	return refs
+}
+
+// resolveRefPath makes path absolute using dir; it stays relative when dir
+// is unknown.
+func resolveRefPath(path, dir string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	if filepath.IsAbs(path) || dir == "" {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(dir, path)
+}
@@ -0,0 +1,66 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+func TestGetFileRefs_ResolvesAgainstPaneDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "pkg"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "pkg", "a.go"), []byte("package pkg\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	a := newTestApp()
+	sess := terminal.NewSession(1, 5, 60)
+	// OSC 7 tells the session its directory, as a shell integration would
+	urlPath := filepath.ToSlash(dir)
+	if !strings.HasPrefix(urlPath, "/") {
+		urlPath = "/" + urlPath // C:/... on Windows
+	}
+	sess.Screen.Write([]byte("\x1b]7;file://localhost" + urlPath + "\x07"))
+	sess.Screen.Write([]byte("pkg/a.go:3:1: bad\r\nmissing.go:9: gone\r\n"))
+	a.sessions[1] = sess
+
+	refs := a.GetFileRefs(1)
+	if len(refs) != 2 {
+		t.Fatalf("refs = %+v, want 2", refs)
+	}
+	if want := filepath.Join(dir, "pkg", "a.go"); refs[0].Path != want || !refs[0].Exists {
+		t.Errorf("ref 0 = %+v, want existing %s", refs[0], want)
+	}
+	if refs[0].Line != 3 || refs[0].Column != 1 || refs[0].Row != 0 {
+		t.Errorf("ref 0 position = %+v", refs[0])
+	}
+	if refs[1].Exists || refs[1].Path != filepath.Join(dir, "missing.go") {
+		t.Errorf("ref 1 = %+v, want missing file in %s", refs[1], dir)
+	}
+}
+
+func TestGetFileRefs_UnknownSession(t *testing.T) {
+	if refs := newTestApp().GetFileRefs(7); refs != nil {
+		t.Errorf("refs = %+v, want nil", refs)
+	}
+}
+
+func TestResolveRefPath(t *testing.T) {
+	abs := filepath.Join(t.TempDir(), "x.go")
+	if got := resolveRefPath(abs, "/elsewhere"); got != abs {
+		t.Errorf("absolute path changed: %q", got)
+	}
+	if got := resolveRefPath("x.go", ""); got != "x.go" {
+		t.Errorf("without dir = %q, want relative x.go", got)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		if got := resolveRefPath("~/x.go", "/d"); got != filepath.Join(home, "x.go") {
+			t.Errorf("~/x.go = %q", got)
+		}
+	}
+}
@@ -7,17 +7,28 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 )
 
 // FileEntry represents a file or directory in the sidebar.
+// GitStatus uses the same codes as GetGitFileStatuses ("" = unchanged);
+// directories containing changes are marked "M". An entry with More > 0 is
+// a sentinel standing for that many entries cut off by maxDirEntries.
 type FileEntry struct {
-	Name  string `json:"name"`
-	Path  string `json:"path"`
-	IsDir bool   `json:"isDir"`
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	IsDir     bool   `json:"isDir"`
+	GitStatus string `json:"gitStatus,omitempty"`
+	More      int    `json:"more,omitempty"`
 }
 
+// maxDirEntries caps how many children ListDirectory returns for a single
+// directory so huge folders (build output, datasets) stay cheap to render.
+const maxDirEntries = 500
+
 // ListDirectory returns the contents of a directory, sorted dirs-first.
 // If dir is empty, it defaults to the current working directory.
+// Only one level is read; the sidebar loads subdirectories on expand.
 func (a *App) ListDirectory(dir string) []FileEntry {
 	if dir == "" {
 		dir, _ = os.Getwd()
@@ -27,11 +38,11 @@ func (a *App) ListDirectory(dir string) []FileEntry {
 		return nil
 	}
 
+	filter := a.fileFilter()
 	result := make([]FileEntry, 0, len(entries))
 	for _, e := range entries {
 		name := e.Name()
-		// Skip hidden files and common noise
-		if strings.HasPrefix(name, ".") || name == "node_modules" {
+		if filter.hides(name) {
 			continue
 		}
 		result = append(result, FileEntry{
@@ -49,9 +60,36 @@ func (a *App) ListDirectory(dir string) []FileEntry {
 		return strings.ToLower(result[i].Name) < strings.ToLower(result[j].Name)
 	})
 
+	if len(result) > maxDirEntries {
+		hidden := len(result) - maxDirEntries
+		result = append(result[:maxDirEntries:maxDirEntries], FileEntry{
+			Name: fmt.Sprintf("… %d weitere", hidden),
+			Path: filepath.Join(dir, "…"),
+			More: hidden,
+		})
+	}
+
+	a.applyGitStatus(result, dir)
 	return result
 }
 
+// applyGitStatus fills in GitStatus for entries below dir. Directories that
+// are not in a git repository leave all entries unchanged. The statuses
+// come from the cache GetGitFileStatuses keeps fresh while the sidebar is
+// open, so expanding folders and searching do not each run git.
+func (a *App) applyGitStatus(entries []FileEntry, dir string) {
+	if len(entries) == 0 {
+		return
+	}
+	files := a.gitStatuses.get(gitRepoKey(dir), time.Now(), func() map[string]string {
+		return gitFileStatuses(dir)
+	})
+	statuses := withParentDirs(files, dir)
+	for i := range entries {
+		entries[i].GitStatus = statuses[entries[i].Path]
+	}
+}
+
 // CreateDirectory creates a new directory (including parents) and returns
 // an error string (empty on success).
 func (a *App) CreateDirectory(path string) string {
@@ -61,39 +99,70 @@ func (a *App) CreateDirectory(path string) string {
 	return ""
 }
 
-// SearchFiles searches for files matching a query string in the given directory.
+// Search result limits: matching stops after maxSearchCandidates entries,
+// which are ranked and cut down to maxSearchResults.
+const (
+	maxSearchResults    = 100
+	maxSearchCandidates = 1000
+)
+
+// SearchFiles fuzzy-searches file and directory names below dir. A name
+// matches if it contains the query's characters in order ("mtl" finds
+// "main_terminal.go"). Directories come first so the tree stays navigable;
+// within each group results are ordered by match score.
 func (a *App) SearchFiles(dir string, query string) []FileEntry {
 	if query == "" || dir == "" {
 		return nil
 	}
-	query = strings.ToLower(query)
 
-	var results []FileEntry
+	type scored struct {
+		entry FileEntry
+		score int
+	}
+	var matches []scored
+	filter := a.fileFilter()
 	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
+		if err != nil || path == dir {
 			return nil
 		}
 		name := info.Name()
-		// Skip hidden dirs
-		if info.IsDir() && strings.HasPrefix(name, ".") {
+		if info.IsDir() && filter.skipsDir(name) {
 			return filepath.SkipDir
 		}
-		if name == "node_modules" && info.IsDir() {
-			return filepath.SkipDir
+		if !info.IsDir() && filter.hides(name) {
+			return nil
 		}
-		if strings.Contains(strings.ToLower(name), query) {
-			results = append(results, FileEntry{
-				Name:  name,
-				Path:  path,
-				IsDir: info.IsDir(),
+		if score, ok := fuzzyScore(query, name); ok {
+			matches = append(matches, scored{
+				entry: FileEntry{Name: name, Path: path, IsDir: info.IsDir()},
+				score: score,
 			})
 		}
-		if len(results) >= 100 {
+		if len(matches) >= maxSearchCandidates {
 			return filepath.SkipAll
 		}
 		return nil
 	})
 
+	sort.SliceStable(matches, func(i, j int) bool {
+		mi, mj := matches[i], matches[j]
+		if mi.entry.IsDir != mj.entry.IsDir {
+			return mi.entry.IsDir
+		}
+		if mi.score != mj.score {
+			return mi.score > mj.score
+		}
+		return len(mi.entry.Name) < len(mj.entry.Name)
+	})
+	if len(matches) > maxSearchResults {
+		matches = matches[:maxSearchResults]
+	}
+
+	results := make([]FileEntry, len(matches))
+	for i, m := range matches {
+		results[i] = m.entry
+	}
+	a.applyGitStatus(results, dir)
 	return results
 }
 
@@ -0,0 +1,40 @@
+package backend
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// sidebarFilter decides which files the sidebar lists and searches
+// (sidebar_ignore, sidebar_show_hidden).
+type sidebarFilter struct {
+	showHidden bool
+	ignore     []string
+}
+
+// fileFilter returns the filter for the current configuration.
+func (a *App) fileFilter() sidebarFilter {
+	cfg := a.currentConfig()
+	return sidebarFilter{showHidden: cfg.SidebarShowHidden, ignore: cfg.SidebarIgnore}
+}
+
+// hides reports whether a file or folder called name is left out. Names
+// starting with "." are hidden unless showHidden is set. The ignore globs
+// are then applied in order like .gitignore lines: the last one matching
+// the name decides, and "!glob" shows a match again.
+func (f sidebarFilter) hides(name string) bool {
+	hidden := !f.showHidden && strings.HasPrefix(name, ".")
+	for _, glob := range f.ignore {
+		negate := strings.HasPrefix(glob, "!")
+		if ok, _ := filepath.Match(strings.TrimPrefix(glob, "!"), name); ok {
+			hidden = !negate
+		}
+	}
+	return hidden
+}
+
+// skipsDir reports whether the file searches leave out a folder: the
+// hidden ones, and .git even when dotfiles are shown.
+func (f sidebarFilter) skipsDir(name string) bool {
+	return name == ".git" || f.hides(name)
+}
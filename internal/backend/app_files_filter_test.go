@@ -0,0 +1,80 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestSidebarFilter_Hides(t *testing.T) {
+	f := sidebarFilter{ignore: []string{"node_modules", "*.log", "!keep.log", "!.env"}}
+	for name, want := range map[string]bool{
+		"main.go":      false,
+		"node_modules": true,
+		"debug.log":    true,
+		"keep.log":     false,
+		".git":         true,
+		".env":         false,
+	} {
+		if got := f.hides(name); got != want {
+			t.Errorf("hides(%q) = %v, want %v", name, got, want)
+		}
+	}
+
+	f.showHidden = true
+	if f.hides(".github") || !f.skipsDir(".git") {
+		t.Error("show hidden: dot folders are listed, but searches still skip .git")
+	}
+}
+
+func TestListDirectory_CustomIgnoreAndShowHidden(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"src", "target", "node_modules", ".venv"} {
+		os.Mkdir(filepath.Join(dir, name), 0755)
+	}
+	os.WriteFile(filepath.Join(dir, ".env"), []byte(""), 0644)
+
+	names := func(a *App) []string {
+		var out []string
+		for _, e := range a.ListDirectory(dir) {
+			out = append(out, e.Name)
+		}
+		sort.Strings(out)
+		return out
+	}
+
+	a := newTestApp()
+	a.cfg.SidebarIgnore = []string{"target"}
+	if got, want := names(a), []string{"node_modules", "src"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("custom ignore: %q, want %q", got, want)
+	}
+
+	a.cfg.SidebarShowHidden = true
+	a.cfg.SidebarIgnore = []string{".venv"}
+	if got, want := names(a), []string{".env", "node_modules", "src", "target"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("show hidden: %q, want %q", got, want)
+	}
+}
+
+func TestSearchFiles_CustomIgnore(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "dist"), 0755)
+	os.MkdirAll(filepath.Join(dir, ".config"), 0755)
+	os.WriteFile(filepath.Join(dir, "dist", "app.js"), []byte(""), 0644)
+	os.WriteFile(filepath.Join(dir, ".config", "app.yaml"), []byte(""), 0644)
+	os.WriteFile(filepath.Join(dir, "app.go"), []byte(""), 0644)
+
+	a := newTestApp()
+	a.cfg.SidebarIgnore = []string{"dist"}
+	a.cfg.SidebarShowHidden = true
+	var got []string
+	for _, e := range a.SearchFiles(dir, "app.") {
+		got = append(got, e.Name)
+	}
+	sort.Strings(got)
+	if want := []string{"app.go", "app.yaml"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SearchFiles = %q, want %q", got, want)
+	}
+}
@@ -1,7 +1,9 @@
 package backend
 
 import (
+	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 )
@@ -121,6 +123,144 @@ func TestListDirectory_PathCorrectness(t *testing.T) {
 	}
 }
 
+func TestListDirectory_CapsLargeDirectories(t *testing.T) {
+	dir := t.TempDir()
+	total := maxDirEntries + 25
+	for i := 0; i < total; i++ {
+		os.WriteFile(filepath.Join(dir, fmt.Sprintf("f%04d.txt", i)), []byte(""), 0644)
+	}
+
+	a := newTestApp()
+	entries := a.ListDirectory(dir)
+
+	if len(entries) != maxDirEntries+1 {
+		t.Fatalf("expected %d entries (cap + sentinel), got %d", maxDirEntries+1, len(entries))
+	}
+	last := entries[len(entries)-1]
+	if last.More != 25 {
+		t.Fatalf("sentinel More = %d, want 25", last.More)
+	}
+	if entries[maxDirEntries-1].Name != fmt.Sprintf("f%04d.txt", maxDirEntries-1) {
+		t.Fatalf("entries before the sentinel should be the first %d sorted names", maxDirEntries)
+	}
+}
+
+// BenchmarkListDirectory_LargeDir measures expanding a folder with
+// thousands of entries in the sidebar, which should stay well under 50ms.
+func BenchmarkListDirectory_LargeDir(b *testing.B) {
+	dir := b.TempDir()
+	for i := 0; i < 5000; i++ {
+		os.WriteFile(filepath.Join(dir, fmt.Sprintf("f%05d.txt", i)), []byte(""), 0644)
+	}
+	a := newTestApp()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = a.ListDirectory(dir)
+	}
+}
+
+func TestListDirectory_GitStatus(t *testing.T) {
+	if !gitAvailable() {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), gitTestEnv()...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	run("init")
+	os.Mkdir(filepath.Join(dir, "src"), 0755)
+	os.WriteFile(filepath.Join(dir, "clean.txt"), []byte("clean"), 0644)
+	os.WriteFile(filepath.Join(dir, "src", "main.go"), []byte("package main"), 0644)
+	run("add", ".")
+	run("commit", "--no-gpg-sign", "-m", "initial")
+
+	os.WriteFile(filepath.Join(dir, "src", "main.go"), []byte("package main\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "new.txt"), []byte("new"), 0644)
+
+	a := newTestApp()
+	got := make(map[string]string)
+	for _, e := range a.ListDirectory(dir) {
+		got[e.Name] = e.GitStatus
+	}
+
+	want := map[string]string{"src": "M", "clean.txt": "", "new.txt": "?"}
+	for name, status := range want {
+		if got[name] != status {
+			t.Errorf("%s: GitStatus = %q, want %q", name, got[name], status)
+		}
+	}
+}
+
+func TestListDirectory_GitStatusReusesPolledStatus(t *testing.T) {
+	if !gitAvailable() {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), gitTestEnv()...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	run("init")
+	os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0644)
+	run("add", ".")
+	run("commit", "--no-gpg-sign", "-m", "initial")
+
+	status := func(a *App) string {
+		for _, e := range a.ListDirectory(dir) {
+			if e.Name == "a.txt" {
+				return e.GitStatus
+			}
+		}
+		return "missing"
+	}
+
+	a := newTestApp()
+	if got := status(a); got != "" {
+		t.Fatalf("clean file: GitStatus = %q, want empty", got)
+	}
+
+	// A change is not seen until the status is polled again.
+	os.WriteFile(filepath.Join(dir, "a.txt"), []byte("changed"), 0644)
+	if got := status(a); got != "" {
+		t.Fatalf("before poll: GitStatus = %q, want cached empty status", got)
+	}
+	a.GetGitFileStatuses(dir)
+	if got := status(a); got != "M" {
+		t.Fatalf("after poll: GitStatus = %q, want \"M\"", got)
+	}
+}
+
+func TestListDirectory_GitStatusOutsideRepo(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0644)
+
+	a := newTestApp()
+	for _, e := range a.ListDirectory(dir) {
+		if e.GitStatus != "" {
+			t.Errorf("%s: expected no git status outside a repo, got %q", e.Name, e.GitStatus)
+		}
+	}
+}
+
 // ---------------------------------------------------------------------------
 // CreateDirectory
 // ---------------------------------------------------------------------------
@@ -0,0 +1,59 @@
+package backend
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// FocusTarget is the pane a notification click should bring into view:
+// Kind "session" selects the pane running session ID, Kind "issue" the
+// pane linked to issue number ID. The zero value only focuses the window.
+type FocusTarget struct {
+	Kind string `json:"kind"`
+	ID   int    `json:"id"`
+}
+
+// focusURI builds the protocol URI a notification activates. target is
+// "session/<id>" or "issue/<n>"; "" gives the bare focus URI.
+func focusURI(target string) string {
+	if target == "" {
+		return "multiterminal:focus"
+	}
+	return "multiterminal://focus/" + target
+}
+
+// ParseFocusURI extracts the target from multiterminal://focus/session/<id>
+// or multiterminal://focus/issue/<n>. Bare or malformed URIs give the zero
+// FocusTarget, so the click still focuses the window.
+func ParseFocusURI(uri string) FocusTarget {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(uri), "multiterminal:")
+	if !ok {
+		return FocusTarget{}
+	}
+	rest = strings.TrimPrefix(rest, "//")
+	parts := strings.Split(strings.Trim(rest, "/"), "/")
+	if len(parts) != 3 || parts[0] != "focus" {
+		return FocusTarget{}
+	}
+	if parts[1] != "session" && parts[1] != "issue" {
+		return FocusTarget{}
+	}
+	id, err := strconv.Atoi(parts[2])
+	if err != nil || id <= 0 {
+		return FocusTarget{}
+	}
+	return FocusTarget{Kind: parts[1], ID: id}
+}
+
+// readFocusTarget reads the URI line a second instance sends over the
+// focus connection; the caller sets the read deadline. Older senders close
+// without writing; that and any read error yield the zero FocusTarget.
+func readFocusTarget(r io.Reader) FocusTarget {
+	line, err := bufio.NewReader(io.LimitReader(r, 256)).ReadString('\n')
+	if err != nil && line == "" {
+		return FocusTarget{}
+	}
+	return ParseFocusURI(line)
+}
@@ -0,0 +1,58 @@
+package backend
+
+import (
+	"net"
+	"testing"
+)
+
+func TestParseFocusURI(t *testing.T) {
+	tests := []struct {
+		uri  string
+		want FocusTarget
+	}{
+		{"multiterminal:focus", FocusTarget{}},
+		{"multiterminal://focus", FocusTarget{}},
+		{"multiterminal://focus/session/7", FocusTarget{"session", 7}},
+		{"multiterminal://focus/session/7/", FocusTarget{"session", 7}},
+		{"multiterminal:focus/issue/42", FocusTarget{"issue", 42}},
+		{"multiterminal://focus/issue/42\r\n", FocusTarget{"issue", 42}},
+		{"multiterminal://focus/session/abc", FocusTarget{}},
+		{"multiterminal://focus/session/-1", FocusTarget{}},
+		{"multiterminal://focus/tab/3", FocusTarget{}},
+		{"multiterminal://open/session/3", FocusTarget{}},
+		{"https://focus/session/3", FocusTarget{}},
+	}
+	for _, tt := range tests {
+		if got := ParseFocusURI(tt.uri); got != tt.want {
+			t.Errorf("ParseFocusURI(%q) = %+v, want %+v", tt.uri, got, tt.want)
+		}
+	}
+}
+
+func TestFocusURI_RoundTrip(t *testing.T) {
+	if got := focusURI(""); got != "multiterminal:focus" {
+		t.Errorf("focusURI(\"\") = %q, want the bare URI", got)
+	}
+	if got := ParseFocusURI(focusURI("session/12")); got != (FocusTarget{"session", 12}) {
+		t.Errorf("round trip = %+v, want session 12", got)
+	}
+}
+
+func TestReadFocusTarget(t *testing.T) {
+	client, server := net.Pipe()
+	go func() {
+		client.Write([]byte("multiterminal://focus/issue/5\n"))
+		client.Close()
+	}()
+	if got := readFocusTarget(server); got != (FocusTarget{"issue", 5}) {
+		t.Errorf("readFocusTarget = %+v, want issue 5", got)
+	}
+}
+
+func TestReadFocusTarget_SilentSender(t *testing.T) {
+	client, server := net.Pipe()
+	client.Close() // older instances connect and close without a URI
+	if got := readFocusTarget(server); got != (FocusTarget{}) {
+		t.Errorf("readFocusTarget = %+v, want zero target", got)
+	}
+}
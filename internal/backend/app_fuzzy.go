@@ -0,0 +1,102 @@
+package backend
+
+import "unicode"
+
+// Fuzzy match scoring weights. A tight, early match beats a scattered one:
+// "mtl" ranks "mtl.go" above "main_terminal.go" above "my_tools_list.go".
+const (
+	fuzzyMatchScore    = 1  // each matched rune
+	fuzzyConsecutive   = 5  // match directly after the previous match
+	fuzzyPrefixBonus   = 10 // first rune of the name matched
+	fuzzyBoundaryBonus = 8  // match at a word start (after _-. or camelCase)
+	fuzzyGapPenalty    = 1  // per skipped rune between matches
+	fuzzyMaxGapPenalty = 5  // cap per gap so long names are not over-punished
+)
+
+// fuzzyScore reports whether every rune of query occurs in name in order
+// (case-insensitive) and, if so, how well it matches. Matching is greedy:
+// each query rune takes the earliest position that still fits, preferring
+// a word boundary when one is available before the next plain occurrence.
+func fuzzyScore(query, name string) (int, bool) {
+	if query == "" {
+		return 0, true
+	}
+	q := []rune(query)
+	n := []rune(name)
+	score, qi, last := 0, 0, -1
+	for qi < len(q) {
+		pos := fuzzyNext(n, q[qi:], last+1)
+		if pos < 0 {
+			return 0, false
+		}
+		score += fuzzyMatchScore
+		switch {
+		case pos == 0:
+			score += fuzzyPrefixBonus
+		case pos == last+1:
+			score += fuzzyConsecutive
+		}
+		if pos > 0 && isWordStart(n, pos) {
+			score += fuzzyBoundaryBonus
+		}
+		if last >= 0 && pos > last+1 {
+			gap := (pos - last - 1) * fuzzyGapPenalty
+			if gap > fuzzyMaxGapPenalty {
+				gap = fuzzyMaxGapPenalty
+			}
+			score -= gap
+		}
+		last = pos
+		qi++
+	}
+	return score, true
+}
+
+// fuzzyNext finds the position of q[0] in name at or after from. A
+// consecutive match wins; otherwise the first word-start occurrence is
+// preferred over the first plain one, as long as the rest of q still fits
+// after it.
+func fuzzyNext(name []rune, q []rune, from int) int {
+	r := unicode.ToLower(q[0])
+	first := -1
+	for i := from; i < len(name); i++ {
+		if unicode.ToLower(name[i]) != r {
+			continue
+		}
+		if i == from {
+			return i
+		}
+		if first < 0 {
+			first = i
+		}
+		if isWordStart(name, i) && isSubsequence(q[1:], name[i+1:]) {
+			return i
+		}
+	}
+	return first
+}
+
+// isSubsequence reports whether q occurs in name in order (case-insensitive).
+func isSubsequence(q, name []rune) bool {
+	qi := 0
+	for i := 0; i < len(name) && qi < len(q); i++ {
+		if unicode.ToLower(name[i]) == unicode.ToLower(q[qi]) {
+			qi++
+		}
+	}
+	return qi == len(q)
+}
+
+// isWordStart reports whether name[i] begins a word: it follows a separator
+// or is an upper-case rune after a lower-case one.
+func isWordStart(name []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev := name[i-1]
+	switch prev {
+	case '_', '-', '.', ' ', '/', '\\':
+		return true
+	}
+	return unicode.IsUpper(name[i]) && unicode.IsLower(prev)
+}
@@ -0,0 +1,81 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFuzzyScore_Matches(t *testing.T) {
+	tests := []struct {
+		query, name string
+		want        bool
+	}{
+		{"mtl", "main_terminal.go", true},
+		{"MTL", "main_terminal.go", true},
+		{"apgo", "app.go", true},
+		{"sess", "session_helpers.go", true},
+		{"ssh", "SessionHelper.ts", true},
+		{"ab", "xab_a", true}, // boundary preference must not break the match
+		{"mtl", "terminal.go", false},
+		{"zz", "main.go", false},
+		{"main.go", "main", false},
+	}
+	for _, tt := range tests {
+		if _, ok := fuzzyScore(tt.query, tt.name); ok != tt.want {
+			t.Errorf("fuzzyScore(%q, %q) matched = %v, want %v", tt.query, tt.name, ok, tt.want)
+		}
+	}
+}
+
+func TestFuzzyScore_Ranking(t *testing.T) {
+	// Each pair: the first name should outscore the second for the query
+	tests := []struct {
+		query, better, worse string
+	}{
+		{"mtl", "mtl.go", "main_terminal.go"},      // tight beats scattered
+		{"mtl", "main_terminal.go", "mortal.go"},   // word starts beat mid-word
+		{"term", "terminal.go", "app_terminal.go"}, // prefix bonus
+		{"conf", "config.go", "deconfigure.go"},    // prefix beats mid-word
+		{"sh", "SessionHelper.ts", "pushover.ts"},  // camelCase boundary
+		{"app", "app.go", "wrapper.go"},            // consecutive + prefix
+	}
+	for _, tt := range tests {
+		b, okB := fuzzyScore(tt.query, tt.better)
+		w, okW := fuzzyScore(tt.query, tt.worse)
+		if !okB || !okW {
+			t.Errorf("%q: expected both %q and %q to match", tt.query, tt.better, tt.worse)
+			continue
+		}
+		if b <= w {
+			t.Errorf("%q: score(%q)=%d should exceed score(%q)=%d", tt.query, tt.better, b, tt.worse, w)
+		}
+	}
+}
+
+func TestSearchFiles_FuzzyRanking(t *testing.T) {
+	dir := t.TempDir()
+	os.Mkdir(filepath.Join(dir, "internal"), 0755)
+	os.Mkdir(filepath.Join(dir, "internal", "mtl_tools"), 0755)
+	os.WriteFile(filepath.Join(dir, "internal", "main_terminal.go"), []byte(""), 0644)
+	os.WriteFile(filepath.Join(dir, "internal", "mortal.go"), []byte(""), 0644)
+	os.WriteFile(filepath.Join(dir, "mtl.go"), []byte(""), 0644)
+	os.WriteFile(filepath.Join(dir, "README.md"), []byte(""), 0644)
+
+	a := newTestApp()
+	results := a.SearchFiles(dir, "mtl")
+
+	var names []string
+	for _, r := range results {
+		names = append(names, r.Name)
+	}
+	want := []string{"mtl_tools", "mtl.go", "main_terminal.go", "mortal.go"}
+	if len(names) != len(want) {
+		t.Fatalf("got %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("got %v, want %v", names, want)
+		}
+	}
+}
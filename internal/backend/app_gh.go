@@ -0,0 +1,57 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// errGitHubTimeout is returned when a gh call exceeds github_timeout_seconds.
+// The frontend matches its message to tell a hung network apart from a
+// failing command.
+var errGitHubTimeout = errors.New("github timed out")
+
+// ghTimeout returns how long a single gh call may run.
+func (a *App) ghTimeout() time.Duration {
+	secs := a.currentConfig().GitHubTimeoutSeconds
+	if secs <= 0 {
+		return 15 * time.Second
+	}
+	return time.Duration(secs) * time.Second
+}
+
+// runGH runs gh with args in dir and returns its stdout. The call is killed
+// after ghTimeout or when the app shuts down, so a hung gh cannot block the
+// issues panel.
+func (a *App) runGH(dir string, args ...string) ([]byte, error) {
+	parent := a.appCtx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, a.ghTimeout())
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "gh", args...)
+	cmd.Dir = dir
+	hideConsole(cmd)
+	out, err := cmd.Output()
+	if err == nil {
+		return out, nil
+	}
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return nil, errGitHubTimeout
+	case ctx.Err() != nil:
+		return nil, ctx.Err()
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if msg := strings.TrimSpace(string(exitErr.Stderr)); msg != "" {
+			return nil, fmt.Errorf("gh %s: %s", args[0], msg)
+		}
+	}
+	return nil, fmt.Errorf("gh %s: %w", args[0], err)
+}
@@ -0,0 +1,68 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/config"
+)
+
+// fakeGH puts a shell script named gh on PATH for the duration of the test.
+func fakeGH(t *testing.T, script string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake gh is a shell script")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "gh"), []byte("#!/bin/sh\n"+script+"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestRunGH_ReturnsOutput(t *testing.T) {
+	fakeGH(t, `echo "$@"`)
+	app := &App{cfg: config.DefaultConfig()}
+
+	out, err := app.runGH("", "issue", "list")
+	if err != nil || strings.TrimSpace(string(out)) != "issue list" {
+		t.Errorf("runGH = %q, %v", out, err)
+	}
+}
+
+func TestRunGH_TimeoutIsDistinct(t *testing.T) {
+	fakeGH(t, "exec sleep 5")
+	cfg := config.DefaultConfig()
+	cfg.GitHubTimeoutSeconds = 1
+	app := &App{cfg: cfg}
+
+	if _, err := app.runGH("", "issue", "list"); !errors.Is(err, errGitHubTimeout) {
+		t.Errorf("err = %v, want errGitHubTimeout", err)
+	}
+}
+
+func TestRunGH_FailureCarriesStderr(t *testing.T) {
+	fakeGH(t, "echo 'no git remotes found' >&2; exit 1")
+	app := &App{cfg: config.DefaultConfig()}
+
+	_, err := app.runGH("", "issue", "list")
+	if err == nil || errors.Is(err, errGitHubTimeout) || !strings.Contains(err.Error(), "no git remotes found") {
+		t.Errorf("err = %v, want a failure carrying gh's message", err)
+	}
+}
+
+func TestRunGH_ShutdownCancels(t *testing.T) {
+	fakeGH(t, "exec sleep 5")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	app := &App{cfg: config.DefaultConfig(), appCtx: ctx}
+
+	if _, err := app.runGH("", "issue", "list"); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
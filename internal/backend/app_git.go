@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // GetGitBranch returns the current git branch for the given directory.
@@ -44,13 +45,25 @@ type GitFileStatus struct {
 	Status string `json:"status"`
 }
 
-// GetGitFileStatuses returns a map of relative file paths to their git status
-// for the given directory. Uses `git status --porcelain` for parsing.
+// GetGitFileStatuses returns a map of absolute file paths to their git
+// status for the given directory; directories below dir that contain
+// changes are marked "M". Uses `git status --porcelain` for parsing. The
+// sidebar polls it, and each call refreshes the statuses ListDirectory
+// and SearchFiles reuse (see gitStatusCache).
 func (a *App) GetGitFileStatuses(dir string) map[string]string {
-	result := make(map[string]string)
 	if dir == "" {
-		return result
+		return make(map[string]string)
 	}
+	files := gitFileStatuses(dir)
+	a.gitStatuses.put(gitRepoKey(dir), files, time.Now())
+	return withParentDirs(files, dir)
+}
+
+// gitFileStatuses runs git status in dir and returns the status of every
+// changed file in the repository by absolute path. Outside a repository
+// the map is empty.
+func gitFileStatuses(dir string) map[string]string {
+	result := make(map[string]string)
 
 	// Get git repo root to compute relative paths correctly
 	rootCmd := exec.Command("git", "rev-parse", "--show-toplevel")
@@ -85,19 +98,28 @@ func (a *App) GetGitFileStatuses(dir string) map[string]string {
 			relPath = relPath[idx+4:]
 		}
 
-		// Convert to absolute path then make relative to browsed dir
 		absPath := filepath.Join(repoRoot, relPath)
-		status := classifyGitStatus(xy)
-		if status != "" {
+		if status := classifyGitStatus(xy); status != "" {
 			result[absPath] = status
-			// Also mark parent directories as modified
-			markParentDirs(result, absPath, dir)
 		}
 	}
 
 	return result
 }
 
+// withParentDirs returns a copy of files in which every directory between
+// a changed file and root is marked as modified.
+func withParentDirs(files map[string]string, root string) map[string]string {
+	result := make(map[string]string, len(files))
+	for path, status := range files {
+		result[path] = status
+	}
+	for path := range files {
+		markParentDirs(result, path, root)
+	}
+	return result
+}
+
 // classifyGitStatus converts porcelain XY codes to a simple status string.
 func classifyGitStatus(xy string) string {
 	x, y := xy[0], xy[1]
@@ -115,7 +115,8 @@ func branchExists(dir string, branch string) bool {
 
 // GetOrCreateIssueBranch checks if an issue branch exists, creates it if not,
 // and switches to it. Returns the branch name or an error.
-// If the working tree is dirty, it returns an error rather than risking data loss.
+// If the working tree is dirty, it returns an error rather than risking data
+// loss; GetOrCreateIssueBranchAutoStash stashes the changes instead.
 func (a *App) GetOrCreateIssueBranch(dir string, number int, title string) (string, error) {
 	if dir == "" || !isGitRepo(dir) {
 		return "", fmt.Errorf("not a git repository")
@@ -134,27 +135,35 @@ func (a *App) GetOrCreateIssueBranch(dir string, number int, title string) (stri
 		return "", fmt.Errorf("uncommitted changes — bitte zuerst committen oder stashen")
 	}
 
+	if err := checkoutIssueBranch(dir, branch); err != nil {
+		return "", err
+	}
+	return branch, nil
+}
+
+// checkoutIssueBranch switches dir to branch, creating it from HEAD when it
+// does not exist yet.
+func checkoutIssueBranch(dir, branch string) error {
 	if branchExists(dir, branch) {
 		// Switch to existing branch
 		cmd := exec.Command("git", "checkout", branch)
 		cmd.Dir = dir
 		hideConsole(cmd)
 		if err := cmd.Run(); err != nil {
-			return "", fmt.Errorf("checkout failed: %w", err)
+			return fmt.Errorf("checkout failed: %w", err)
 		}
 		log.Printf("[GetOrCreateIssueBranch] switched to existing branch %s", branch)
-	} else {
-		// Create and switch to new branch
-		cmd := exec.Command("git", "checkout", "-b", branch)
-		cmd.Dir = dir
-		hideConsole(cmd)
-		if err := cmd.Run(); err != nil {
-			return "", fmt.Errorf("branch create failed: %w", err)
-		}
-		log.Printf("[GetOrCreateIssueBranch] created new branch %s", branch)
+		return nil
 	}
-
-	return branch, nil
+	// Create and switch to new branch
+	cmd := exec.Command("git", "checkout", "-b", branch)
+	cmd.Dir = dir
+	hideConsole(cmd)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("branch create failed: %w", err)
+	}
+	log.Printf("[GetOrCreateIssueBranch] created new branch %s", branch)
+	return nil
 }
 
 // IsGitRepo checks if the given directory is a git repository (exported for frontend).
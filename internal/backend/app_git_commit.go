@@ -0,0 +1,59 @@
+package backend
+
+import (
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+)
+
+// QuickCommit stages every change in dir (git add -A) and commits it with
+// message, skipping commit hooks when noVerify is set. It returns the new
+// commit hash. Non-repositories, empty messages and trees with nothing to
+// commit are refused with an error before anything is committed.
+func (a *App) QuickCommit(dir string, message string, noVerify bool) (string, error) {
+	if dir == "" || !isGitRepo(dir) {
+		return "", fmt.Errorf("not a git repository")
+	}
+	if strings.TrimSpace(message) == "" {
+		return "", fmt.Errorf("commit message is empty")
+	}
+
+	if out, err := gitCombined(dir, "add", "-A"); err != nil {
+		return "", fmt.Errorf("git add failed: %v: %s", err, out)
+	}
+	// --quiet exits 1 when the index differs from HEAD
+	cmd := exec.Command("git", "diff", "--cached", "--quiet")
+	cmd.Dir = dir
+	hideConsole(cmd)
+	if err := cmd.Run(); err == nil {
+		return "", fmt.Errorf("nothing to commit")
+	} else if _, ok := err.(*exec.ExitError); !ok {
+		return "", fmt.Errorf("git diff failed: %w", err)
+	}
+
+	args := []string{"commit", "-m", message}
+	if noVerify {
+		args = append(args, "--no-verify")
+	}
+	if out, err := gitCombined(dir, args...); err != nil {
+		return "", fmt.Errorf("git commit failed: %v: %s", err, out)
+	}
+	hash, err := gitCombined(dir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("commit created, but reading HEAD failed: %v", err)
+	}
+	a.gitSummaries.invalidate(dir)
+	log.Printf("[QuickCommit] %s: committed %s", dir, hash)
+	return hash, nil
+}
+
+// gitCombined runs git in dir and returns its trimmed combined output,
+// which carries the reason (e.g. a failing hook) when it exits non-zero.
+func gitCombined(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	hideConsole(cmd)
+	out, err := cmd.CombinedOutput()
+	return strings.TrimSpace(string(out)), err
+}
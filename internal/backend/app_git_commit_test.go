@@ -0,0 +1,92 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestQuickCommit_CommitsAllChanges(t *testing.T) {
+	if !gitAvailable() {
+		t.Skip("git not available")
+	}
+	gitIdentityEnv(t)
+	dir := t.TempDir()
+	gitInit(t, dir)
+	gitCommitFile(t, dir, "file.txt", "content", "initial")
+	os.WriteFile(filepath.Join(dir, "file.txt"), []byte("changed"), 0644)
+	os.WriteFile(filepath.Join(dir, "new.txt"), []byte("new"), 0644)
+
+	hash, err := newTestApp().QuickCommit(dir, "Fix login bug (#42)", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if head := gitOutput(t, dir, "rev-parse", "HEAD"); hash != head {
+		t.Fatalf("returned hash %q, HEAD is %q", hash, head)
+	}
+	if !hasCleanWorkingTree(dir) {
+		t.Fatal("expected modified and untracked files to be committed")
+	}
+	if msg := gitOutput(t, dir, "log", "-1", "--format=%s"); msg != "Fix login bug (#42)" {
+		t.Fatalf("commit message = %q", msg)
+	}
+}
+
+func TestQuickCommit_NoVerifySkipsHooks(t *testing.T) {
+	if !gitAvailable() {
+		t.Skip("git not available")
+	}
+	gitIdentityEnv(t)
+	dir := t.TempDir()
+	gitInit(t, dir)
+	gitCommitFile(t, dir, "file.txt", "content", "initial")
+	hook := filepath.Join(dir, ".git", "hooks", "pre-commit")
+	os.MkdirAll(filepath.Dir(hook), 0755)
+	os.WriteFile(hook, []byte("#!/bin/sh\necho rejected by hook\nexit 1\n"), 0755)
+	os.WriteFile(filepath.Join(dir, "file.txt"), []byte("changed"), 0644)
+
+	a := newTestApp()
+	if _, err := a.QuickCommit(dir, "blocked", false); err == nil || !strings.Contains(err.Error(), "rejected by hook") {
+		t.Fatalf("expected the hook's output in the error, got %v", err)
+	}
+	if _, err := a.QuickCommit(dir, "skips hook", true); err != nil {
+		t.Fatalf("expected --no-verify to skip the hook: %v", err)
+	}
+}
+
+func TestQuickCommit_NothingToCommit(t *testing.T) {
+	if !gitAvailable() {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	gitInit(t, dir)
+	gitCommitFile(t, dir, "file.txt", "content", "initial")
+
+	_, err := newTestApp().QuickCommit(dir, "empty", false)
+	if err == nil || !strings.Contains(err.Error(), "nothing to commit") {
+		t.Fatalf("expected 'nothing to commit', got %v", err)
+	}
+}
+
+func TestQuickCommit_Refusals(t *testing.T) {
+	a := newTestApp()
+	if _, err := a.QuickCommit(t.TempDir(), "msg", false); err == nil {
+		t.Fatal("expected error for non-git dir")
+	}
+	if _, err := a.QuickCommit("", "msg", false); err == nil {
+		t.Fatal("expected error for empty dir")
+	}
+	if !gitAvailable() {
+		return
+	}
+	dir := t.TempDir()
+	gitInit(t, dir)
+	os.WriteFile(filepath.Join(dir, "file.txt"), []byte("content"), 0644)
+	if _, err := a.QuickCommit(dir, "  \n", false); err == nil {
+		t.Fatal("expected error for blank message")
+	}
+	if gitOutput(t, dir, "status", "--porcelain") != "?? file.txt" {
+		t.Fatal("a refused commit must not stage anything")
+	}
+}
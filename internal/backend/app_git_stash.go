@@ -0,0 +1,135 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+)
+
+// errStashFailed marks a failed `git stash push`: nothing was changed, the
+// working tree is still dirty and the branch was not switched.
+var errStashFailed = errors.New("stash failed")
+
+// IssueBranchResult is what GetOrCreateIssueBranchAutoStash did. When the
+// tree was dirty, Stashed is set and StashRef is the stash commit, which
+// PopIssueStash takes to bring the changes back.
+type IssueBranchResult struct {
+	Branch       string `json:"branch"`
+	Stashed      bool   `json:"stashed"`
+	StashRef     string `json:"stash_ref"`
+	StashMessage string `json:"stash_message"`
+}
+
+// issueStashMessage labels the stash made before switching to an issue
+// branch, so it can be told apart in `git stash list`.
+func issueStashMessage(number int, branch string) string {
+	return fmt.Sprintf("multiterminal: issue #%d – changes stashed before switching to %s", number, branch)
+}
+
+// GetOrCreateIssueBranchAutoStash works like GetOrCreateIssueBranch, but a
+// dirty working tree (untracked files included) is stashed first instead of
+// being an error. If the switch then fails, the stash is popped again. A
+// failing stash returns an error wrapping errStashFailed.
+func (a *App) GetOrCreateIssueBranchAutoStash(dir string, number int, title string) (IssueBranchResult, error) {
+	if dir == "" || !isGitRepo(dir) {
+		return IssueBranchResult{}, fmt.Errorf("not a git repository")
+	}
+	res := IssueBranchResult{Branch: issueBranchName(number, title)}
+	if a.GetGitBranch(dir) == res.Branch {
+		return res, nil
+	}
+
+	if !hasCleanWorkingTree(dir) {
+		res.StashMessage = issueStashMessage(number, res.Branch)
+		ref, err := stashPush(dir, res.StashMessage)
+		if err != nil {
+			return IssueBranchResult{}, err
+		}
+		if ref != "" {
+			res.Stashed, res.StashRef = true, ref
+			log.Printf("[GetOrCreateIssueBranchAutoStash] stashed changes as %s (%s)", ref, res.StashMessage)
+		} else {
+			res.StashMessage = ""
+		}
+	}
+
+	if err := checkoutIssueBranch(dir, res.Branch); err != nil {
+		if res.Stashed {
+			if perr := popStash(dir, res.StashRef); perr != nil {
+				return IssueBranchResult{}, fmt.Errorf("%w; restoring stash %s failed: %v", err, res.StashRef, perr)
+			}
+		}
+		return IssueBranchResult{}, err
+	}
+	return res, nil
+}
+
+// PopIssueStash applies and drops the stash made by
+// GetOrCreateIssueBranchAutoStash (IssueBranchResult.StashRef) on the
+// current branch.
+func (a *App) PopIssueStash(dir string, ref string) error {
+	if dir == "" || ref == "" {
+		return fmt.Errorf("no stash given")
+	}
+	return popStash(dir, ref)
+}
+
+// stashPush stashes all changes including untracked files under message
+// and returns the stash commit hash. The hash stays valid when later
+// stashes shift the stash@{n} numbering. It returns "" when git found
+// nothing to stash (e.g. only a dirty submodule): `git stash push` then
+// succeeds without a new entry, and stash@{0} is an older, unrelated one.
+func stashPush(dir, message string) (string, error) {
+	before := stashTop(dir)
+	cmd := exec.Command("git", "stash", "push", "--include-untracked", "-m", message)
+	cmd.Dir = dir
+	hideConsole(cmd)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("%w: %v: %s", errStashFailed, err, strings.TrimSpace(string(out)))
+	}
+	after := stashTop(dir)
+	if after == before {
+		return "", nil
+	}
+	return after, nil
+}
+
+// stashTop returns the commit hash of stash@{0}, or "" if there is none.
+func stashTop(dir string) string {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", "stash@{0}")
+	cmd.Dir = dir
+	hideConsole(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// popStash pops the stash entry whose commit is ref. `git stash pop` only
+// takes stash@{n} names, so the entry is looked up in the stash list.
+func popStash(dir, ref string) error {
+	cmd := exec.Command("git", "stash", "list", "--format=%H %gd")
+	cmd.Dir = dir
+	hideConsole(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("stash list failed: %w", err)
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		hash, name, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok || hash != ref {
+			continue
+		}
+		cmd := exec.Command("git", "stash", "pop", name)
+		cmd.Dir = dir
+		hideConsole(cmd)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("stash pop failed: %v: %s", err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	}
+	return fmt.Errorf("stash %s not found", ref)
+}
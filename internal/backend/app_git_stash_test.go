@@ -0,0 +1,176 @@
+package backend
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// gitIdentityEnv gives git commands run by the App (not gitRun) an identity,
+// which git needs to create stash and commit objects.
+func gitIdentityEnv(t *testing.T) {
+	t.Helper()
+	for _, kv := range gitTestEnv() {
+		k, v, _ := strings.Cut(kv, "=")
+		t.Setenv(k, v)
+	}
+}
+
+func gitOutput(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git %v failed: %v", args, err)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestAutoStash_DirtyTree(t *testing.T) {
+	if !gitAvailable() {
+		t.Skip("git not available")
+	}
+	gitIdentityEnv(t)
+	dir := t.TempDir()
+	gitInit(t, dir)
+	gitCommitFile(t, dir, "file.txt", "content", "initial")
+	os.WriteFile(filepath.Join(dir, "file.txt"), []byte("modified"), 0644)
+	os.WriteFile(filepath.Join(dir, "new.txt"), []byte("untracked"), 0644)
+
+	a := newTestApp()
+	res, err := a.GetOrCreateIssueBranchAutoStash(dir, 42, "Fix login bug")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Branch != "issue/42-fix-login-bug" || a.GetGitBranch(dir) != res.Branch {
+		t.Fatalf("expected to be on issue/42-fix-login-bug, result %+v, HEAD %q", res, a.GetGitBranch(dir))
+	}
+	if !res.Stashed || res.StashRef == "" {
+		t.Fatalf("expected a stash, got %+v", res)
+	}
+	if !hasCleanWorkingTree(dir) {
+		t.Fatal("expected clean tree after stashing, untracked files included")
+	}
+	if list := gitOutput(t, dir, "stash", "list"); !strings.Contains(list, "issue #42") {
+		t.Fatalf("stash label should name the issue, stash list: %q", list)
+	}
+
+	if err := a.PopIssueStash(dir, res.StashRef); err != nil {
+		t.Fatalf("PopIssueStash: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "file.txt")); string(data) != "modified" {
+		t.Fatalf("expected stashed change back, got %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "new.txt")); err != nil {
+		t.Fatalf("expected untracked file back: %v", err)
+	}
+	if list := gitOutput(t, dir, "stash", "list"); list != "" {
+		t.Fatalf("expected stash to be dropped, stash list: %q", list)
+	}
+}
+
+func TestAutoStash_PopFindsShiftedEntry(t *testing.T) {
+	if !gitAvailable() {
+		t.Skip("git not available")
+	}
+	gitIdentityEnv(t)
+	dir := t.TempDir()
+	gitInit(t, dir)
+	gitCommitFile(t, dir, "file.txt", "content", "initial")
+	os.WriteFile(filepath.Join(dir, "file.txt"), []byte("mine"), 0644)
+
+	a := newTestApp()
+	res, err := a.GetOrCreateIssueBranchAutoStash(dir, 7, "docs")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// A later stash moves ours to stash@{1}
+	os.WriteFile(filepath.Join(dir, "other.txt"), []byte("other"), 0644)
+	gitRun(t, dir, "stash", "push", "-u", "-m", "unrelated")
+
+	if err := a.PopIssueStash(dir, res.StashRef); err != nil {
+		t.Fatalf("PopIssueStash: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "file.txt")); string(data) != "mine" {
+		t.Fatalf("expected our stash popped, file.txt = %q", data)
+	}
+	if list := gitOutput(t, dir, "stash", "list"); !strings.Contains(list, "unrelated") || strings.Contains(list, "issue #7") {
+		t.Fatalf("expected only the unrelated stash left, stash list: %q", list)
+	}
+}
+
+func TestAutoStash_CleanTree(t *testing.T) {
+	if !gitAvailable() {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	gitInit(t, dir)
+	gitCommitFile(t, dir, "file.txt", "content", "initial")
+
+	res, err := newTestApp().GetOrCreateIssueBranchAutoStash(dir, 3, "clean")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Stashed || res.Branch != "issue/3-clean" {
+		t.Fatalf("expected a plain switch without stash, got %+v", res)
+	}
+}
+
+func TestAutoStash_StashFails(t *testing.T) {
+	if !gitAvailable() {
+		t.Skip("git not available")
+	}
+	gitIdentityEnv(t)
+	dir := t.TempDir()
+	gitInit(t, dir)
+	// Without an initial commit there is nothing to stash against
+	os.WriteFile(filepath.Join(dir, "file.txt"), []byte("content"), 0644)
+
+	_, err := newTestApp().GetOrCreateIssueBranchAutoStash(dir, 5, "some issue")
+	if !errors.Is(err, errStashFailed) {
+		t.Fatalf("expected errStashFailed, got %v", err)
+	}
+	if hasCleanWorkingTree(dir) {
+		t.Fatal("working tree should be untouched after a failed stash")
+	}
+}
+
+func TestStashPush_NothingToStashIgnoresOlderStash(t *testing.T) {
+	if !gitAvailable() {
+		t.Skip("git not available")
+	}
+	gitIdentityEnv(t)
+	dir := t.TempDir()
+	gitInit(t, dir)
+	gitCommitFile(t, dir, "file.txt", "content", "initial")
+	os.WriteFile(filepath.Join(dir, "file.txt"), []byte("older work"), 0644)
+	gitRun(t, dir, "stash", "push", "-m", "older work")
+
+	// Clean tree: git exits 0 with "No local changes to save"
+	ref, err := stashPush(dir, "multiterminal: issue #9")
+	if err != nil {
+		t.Fatalf("stashPush: %v", err)
+	}
+	if ref != "" {
+		t.Fatalf("stashPush returned the older stash %s although nothing was stashed", ref)
+	}
+	if list := gitOutput(t, dir, "stash", "list"); !strings.Contains(list, "older work") {
+		t.Fatalf("older stash should be untouched, stash list: %q", list)
+	}
+}
+
+func TestPopIssueStash_UnknownRef(t *testing.T) {
+	if !gitAvailable() {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	gitInit(t, dir)
+	gitCommitFile(t, dir, "file.txt", "content", "initial")
+	if err := newTestApp().PopIssueStash(dir, "0123456789abcdef0123456789abcdef01234567"); err == nil {
+		t.Fatal("expected error for unknown stash")
+	}
+}
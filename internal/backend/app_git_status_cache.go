@@ -0,0 +1,67 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// gitStatusMaxAge is how long ListDirectory and SearchFiles reuse the file
+// statuses of a repository. The sidebar refreshes them every 5 s through
+// GetGitFileStatuses, so while it is open they never run git themselves.
+const gitStatusMaxAge = 10 * time.Second
+
+type gitStatusEntry struct {
+	files   map[string]string
+	fetched time.Time
+}
+
+// gitStatusCache keeps the last gitFileStatuses result per repository
+// (see gitRepoKey). The maps are never modified after they are stored.
+// The zero value is ready to use.
+type gitStatusCache struct {
+	mu      sync.Mutex
+	entries map[string]gitStatusEntry
+}
+
+// get returns the cached statuses for key if they are younger than
+// gitStatusMaxAge, and otherwise fetches and stores new ones.
+func (c *gitStatusCache) get(key string, now time.Time, fetch func() map[string]string) map[string]string {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && now.Sub(e.fetched) < gitStatusMaxAge {
+		return e.files
+	}
+	files := fetch()
+	c.put(key, files, now)
+	return files
+}
+
+// put stores files as the statuses of key fetched at now.
+func (c *gitStatusCache) put(key string, files map[string]string, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]gitStatusEntry)
+	}
+	c.entries[key] = gitStatusEntry{files: files, fetched: now}
+}
+
+// gitRepoKey returns the working tree root above dir (the nearest
+// directory holding a .git file or folder), so every folder of a
+// repository shares one cache entry. Outside a repository it is dir.
+func gitRepoKey(dir string) string {
+	dir = filepath.Clean(dir)
+	for d := dir; ; {
+		if _, err := os.Stat(filepath.Join(d, ".git")); err == nil {
+			return d
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return dir
+		}
+		d = parent
+	}
+}
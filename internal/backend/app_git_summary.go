@@ -0,0 +1,125 @@
+package backend
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// gitSummaryTTL is how long a GetGitSummary result is reused. The footer
+// polls every few seconds and again on every tab switch; this keeps those
+// from each running git status in a large repo.
+const gitSummaryTTL = 2 * time.Second
+
+// GitSummary is the working tree state shown in the footer: the branch,
+// file counts by status and how far the branch is from its upstream.
+// Renamed and conflicted files count as modified.
+type GitSummary struct {
+	Branch    string `json:"branch"`
+	Modified  int    `json:"modified"`
+	Added     int    `json:"added"`
+	Deleted   int    `json:"deleted"`
+	Untracked int    `json:"untracked"`
+	Ahead     int    `json:"ahead"`
+	Behind    int    `json:"behind"`
+}
+
+// GetGitSummary returns the GitSummary for dir, or the zero value outside
+// a git repository. Results are cached for gitSummaryTTL per directory.
+func (a *App) GetGitSummary(dir string) GitSummary {
+	if dir == "" {
+		return GitSummary{}
+	}
+	return a.gitSummaries.get(dir, time.Now(), func() GitSummary {
+		cmd := exec.Command("git", "status", "--porcelain=v2", "-b", "-uall")
+		cmd.Dir = dir
+		hideConsole(cmd)
+		out, err := cmd.Output()
+		if err != nil {
+			return GitSummary{}
+		}
+		return parseGitSummary(string(out))
+	})
+}
+
+// parseGitSummary reads `git status --porcelain=v2 -b` output. Entry lines
+// carry the XY code in their second field ('.' for unchanged, which
+// classifyGitStatus ignores); untracked files are "? <path>".
+func parseGitSummary(out string) GitSummary {
+	var s GitSummary
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if head, ok := strings.CutPrefix(line, "# branch.head "); ok {
+			s.Branch = head
+			continue
+		}
+		if ab, ok := strings.CutPrefix(line, "# branch.ab "); ok {
+			if f := strings.Fields(ab); len(f) == 2 {
+				s.Ahead, _ = strconv.Atoi(strings.TrimPrefix(f[0], "+"))
+				s.Behind, _ = strconv.Atoi(strings.TrimPrefix(f[1], "-"))
+			}
+			continue
+		}
+		var xy string
+		switch {
+		case strings.HasPrefix(line, "? "):
+			xy = "??"
+		case len(line) >= 4 && strings.ContainsRune("12u", rune(line[0])) && line[1] == ' ':
+			xy = line[2:4]
+		default:
+			continue
+		}
+		switch classifyGitStatus(xy) {
+		case "?":
+			s.Untracked++
+		case "A":
+			s.Added++
+		case "D":
+			s.Deleted++
+		case "M", "R", "U":
+			s.Modified++
+		}
+	}
+	return s
+}
+
+type gitSummaryEntry struct {
+	summary GitSummary
+	fetched time.Time
+}
+
+// gitSummaryCache keeps the last GitSummary per directory. The zero value
+// is ready to use.
+type gitSummaryCache struct {
+	mu      sync.Mutex
+	entries map[string]gitSummaryEntry
+}
+
+// get returns the cached summary for dir if it is younger than
+// gitSummaryTTL, and otherwise fetches and stores a new one.
+func (c *gitSummaryCache) get(dir string, now time.Time, fetch func() GitSummary) GitSummary {
+	c.mu.Lock()
+	e, ok := c.entries[dir]
+	c.mu.Unlock()
+	if ok && now.Sub(e.fetched) < gitSummaryTTL {
+		return e.summary
+	}
+
+	summary := fetch()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]gitSummaryEntry)
+	}
+	c.entries[dir] = gitSummaryEntry{summary: summary, fetched: now}
+	return summary
+}
+
+// invalidate drops the cached summary for dir, e.g. after a commit.
+func (c *gitSummaryCache) invalidate(dir string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, dir)
+}
@@ -0,0 +1,91 @@
+package backend
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseGitSummary(t *testing.T) {
+	out := "# branch.oid 0123abcd\n" +
+		"# branch.head main\n" +
+		"# branch.upstream origin/main\n" +
+		"# branch.ab +2 -1\n" +
+		"1 .M N... 100644 100644 100644 aaaa bbbb changed.go\n" +
+		"1 M. N... 100644 100644 100644 aaaa bbbb staged.go\n" +
+		"1 A. N... 000000 100644 100644 0000 bbbb new.go\n" +
+		"1 .D N... 100644 100644 000000 aaaa aaaa gone.go\n" +
+		"2 R. N... 100644 100644 100644 aaaa aaaa R100 to.go\tfrom.go\n" +
+		"u UU N... 100644 100644 100644 100644 aaaa bbbb cccc both.go\n" +
+		"? notes.txt\n" +
+		"? tmp/scratch.txt\n"
+	got := parseGitSummary(out)
+	want := GitSummary{Branch: "main", Modified: 4, Added: 1, Deleted: 1, Untracked: 2, Ahead: 2, Behind: 1}
+	if got != want {
+		t.Fatalf("parseGitSummary = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseGitSummary_NoUpstream(t *testing.T) {
+	got := parseGitSummary("# branch.oid (initial)\r\n# branch.head feature/x\r\n")
+	if got != (GitSummary{Branch: "feature/x"}) {
+		t.Fatalf("parseGitSummary = %+v, want only the branch", got)
+	}
+}
+
+func TestGitSummaryCache(t *testing.T) {
+	var c gitSummaryCache
+	calls := 0
+	fetch := func() GitSummary { calls++; return GitSummary{Modified: calls} }
+	now := time.Now()
+
+	c.get("/repo", now, fetch)
+	if got := c.get("/repo", now.Add(gitSummaryTTL/2), fetch); got.Modified != 1 || calls != 1 {
+		t.Fatalf("fresh entry refetched: got %+v after %d calls", got, calls)
+	}
+	if got := c.get("/other", now, fetch); got.Modified != 2 {
+		t.Fatalf("other dir served from cache: %+v", got)
+	}
+	if got := c.get("/repo", now.Add(gitSummaryTTL), fetch); got.Modified != 3 {
+		t.Fatalf("expired entry not refetched: %+v", got)
+	}
+}
+
+func TestGetGitSummary_ValidRepo(t *testing.T) {
+	if !gitAvailable() {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), gitTestEnv()...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	run("init", "-b", "main")
+	os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0644)
+	run("add", ".")
+	run("commit", "--no-gpg-sign", "-m", "initial")
+	os.WriteFile(filepath.Join(dir, "a.txt"), []byte("changed"), 0644)
+	os.WriteFile(filepath.Join(dir, "b.txt"), []byte("new"), 0644)
+
+	got := newTestApp().GetGitSummary(dir)
+	want := GitSummary{Branch: "main", Modified: 1, Untracked: 1}
+	if got != want {
+		t.Fatalf("GetGitSummary = %+v, want %+v", got, want)
+	}
+}
+
+func TestGetGitSummary_NotARepo(t *testing.T) {
+	a := newTestApp()
+	if got := a.GetGitSummary(t.TempDir()); got != (GitSummary{}) {
+		t.Fatalf("expected zero summary outside a repo, got %+v", got)
+	}
+	if got := a.GetGitSummary(""); got != (GitSummary{}) {
+		t.Fatalf("expected zero summary for empty dir, got %+v", got)
+	}
+}
@@ -0,0 +1,115 @@
+package backend
+
+import (
+	"bufio"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// GrepOptions control how GrepFiles matches.
+type GrepOptions struct {
+	IgnoreCase bool `json:"ignoreCase"`
+	Regex      bool `json:"regex"` // pattern is a Go regular expression; otherwise literal text
+}
+
+// GrepHit is one line matched by GrepFiles. Line is 1-based.
+type GrepHit struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+// Content search limits: the walk stops after maxGrepHits matching lines,
+// files larger than maxGrepFileSize are skipped, and matched lines are cut
+// to maxGrepLineRunes for display (minified code has huge lines).
+const (
+	maxGrepHits      = 200
+	maxGrepFileSize  = 1 << 20
+	maxGrepLineRunes = 200
+)
+
+// GrepFiles searches the contents of the files below dir for pattern and
+// returns the matching lines, in walk order. It skips the same files and
+// folders as SearchFiles, binary files (NUL in the first 512 bytes) and files over
+// 1 MB. An invalid regular expression is returned as an error.
+func (a *App) GrepFiles(dir string, pattern string, opts GrepOptions) ([]GrepHit, error) {
+	if dir == "" || pattern == "" {
+		return nil, nil
+	}
+	expr := pattern
+	if !opts.Regex {
+		expr = regexp.QuoteMeta(pattern)
+	}
+	if opts.IgnoreCase {
+		expr = "(?i)" + expr
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	var hits []GrepHit
+	filter := a.fileFilter()
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || path == dir {
+			return nil
+		}
+		if d.IsDir() {
+			if filter.skipsDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || filter.hides(d.Name()) {
+			return nil
+		}
+		hits = grepFile(path, re, hits)
+		if len(hits) >= maxGrepHits {
+			hits = hits[:maxGrepHits]
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return hits, nil
+}
+
+// grepFile appends the lines of path that match re to hits. Files that are
+// too large, unreadable or binary add nothing.
+func grepFile(path string, re *regexp.Regexp, hits []GrepHit) []GrepHit {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() > maxGrepFileSize {
+		return hits
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || bytes.IndexByte(data[:min(len(data), 512)], 0) >= 0 {
+		return hits
+	}
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), maxGrepFileSize)
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Bytes()
+		if !re.Match(line) {
+			continue
+		}
+		hits = append(hits, GrepHit{Path: path, Line: n, Text: grepLineText(line)})
+		if len(hits) >= maxGrepHits {
+			break
+		}
+	}
+	return hits
+}
+
+// grepLineText trims a matched line for display and cuts it to
+// maxGrepLineRunes.
+func grepLineText(line []byte) string {
+	text := strings.TrimSpace(strings.ToValidUTF8(string(line), "�"))
+	if utf8.RuneCountInString(text) <= maxGrepLineRunes {
+		return text
+	}
+	return string([]rune(text)[:maxGrepLineRunes]) + "…"
+}
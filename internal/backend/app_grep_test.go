@@ -0,0 +1,81 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// grepTree creates a small project with a match in a normal file, a hidden
+// folder, node_modules and a binary file.
+func grepTree(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.go":                 "package main\n\nfunc NewServer() {}\n",
+		"lib/util.go":             "// newServer is not exported\nfunc helper() {}\n",
+		".git/config":             "NewServer\n",
+		"node_modules/x/index.js": "NewServer()\n",
+		"image.bin":               "NewServer\x00\x01",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte(content), 0644)
+	}
+	return dir
+}
+
+func TestGrepFiles_LiteralMatch(t *testing.T) {
+	dir := grepTree(t)
+	a := newTestApp()
+	hits, err := a.GrepFiles(dir, "NewServer(", GrepOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 1 {
+		t.Fatalf("hits = %+v, want only main.go", hits)
+	}
+	h := hits[0]
+	if h.Path != filepath.Join(dir, "main.go") || h.Line != 3 || h.Text != "func NewServer() {}" {
+		t.Errorf("hit = %+v", h)
+	}
+}
+
+func TestGrepFiles_IgnoreCaseAndRegex(t *testing.T) {
+	dir := grepTree(t)
+	a := newTestApp()
+	hits, _ := a.GrepFiles(dir, "newserver", GrepOptions{IgnoreCase: true})
+	if len(hits) != 2 {
+		t.Errorf("ignore case: %d hits, want 2: %+v", len(hits), hits)
+	}
+	hits, _ = a.GrepFiles(dir, `^func \w+\(\)`, GrepOptions{Regex: true})
+	if len(hits) != 2 {
+		t.Errorf("regex: %d hits, want 2: %+v", len(hits), hits)
+	}
+	if _, err := a.GrepFiles(dir, "(", GrepOptions{Regex: true}); err == nil {
+		t.Error("invalid regex should be an error")
+	}
+}
+
+func TestGrepFiles_CapsHitsAndLines(t *testing.T) {
+	dir := t.TempDir()
+	long := strings.Repeat("x", 2*maxGrepLineRunes)
+	os.WriteFile(filepath.Join(dir, "many.txt"), []byte(strings.Repeat("match "+long+"\n", maxGrepHits+50)), 0644)
+	os.WriteFile(filepath.Join(dir, "big.txt"), []byte("match"+strings.Repeat(" ", maxGrepFileSize)), 0644)
+
+	a := newTestApp()
+	hits, _ := a.GrepFiles(dir, "match", GrepOptions{})
+	if len(hits) != maxGrepHits {
+		t.Fatalf("%d hits, want %d", len(hits), maxGrepHits)
+	}
+	for _, h := range hits {
+		if filepath.Base(h.Path) == "big.txt" {
+			t.Fatal("files over the size cap must be skipped")
+		}
+	}
+	if n := len([]rune(hits[0].Text)); n != maxGrepLineRunes+1 {
+		t.Errorf("line text has %d runes, want %d plus an ellipsis", n, maxGrepLineRunes)
+	}
+}
@@ -19,14 +19,16 @@ type HealthInfo struct {
 	CrashDetected  bool `json:"crash_detected"`
 	LoggingEnabled bool `json:"logging_enabled"`
 	LoggingAuto    bool `json:"logging_auto"`
+	CrashReport    bool `json:"crash_report"` // a crash loop was detected; see GetCrashReport
 }
 
 // CheckHealth returns the current health/logging state for the frontend.
 func (a *App) CheckHealth() HealthInfo {
 	return HealthInfo{
 		CrashDetected:  config.HasRepeatedCrashes(&a.health),
-		LoggingEnabled: a.cfg.LoggingEnabled,
+		LoggingEnabled: a.currentConfig().LoggingEnabled,
 		LoggingAuto:    a.health.LoggingAuto,
+		CrashReport:    a.GetCrashReport() != "",
 	}
 }
 
@@ -39,8 +41,10 @@ func (a *App) EnableLogging(auto bool) string {
 		return ""
 	}
 
-	a.cfg.LoggingEnabled = true
-	_ = config.Save(a.cfg)
+	_ = a.updateConfig(func(cfg *config.Config) bool {
+		cfg.LoggingEnabled = true
+		return true
+	})
 
 	if auto {
 		config.EnableAutoLogging(&a.health)
@@ -53,8 +57,10 @@ func (a *App) EnableLogging(auto bool) string {
 
 // DisableLogging deactivates file logging and resets to stderr.
 func (a *App) DisableLogging() {
-	a.cfg.LoggingEnabled = false
-	_ = config.Save(a.cfg)
+	_ = a.updateConfig(func(cfg *config.Config) bool {
+		cfg.LoggingEnabled = false
+		return true
+	})
 
 	config.DisableAutoLogging(&a.health)
 	_ = config.SaveHealth(a.health)
@@ -0,0 +1,134 @@
+package backend
+
+import (
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+// maxFilterLines bounds the lines FilterSessionLines returns; the most
+// recent matches are kept.
+const maxFilterLines = 1000
+
+// SetSessionHighlight sets the pattern a pane marks in its output, like
+// less's & filter for a log that keeps scrolling. An empty pattern clears
+// it. It returns "" on success or why the pattern was refused. Consumers
+// of GetScreenDiff should fetch a fresh GetScreenSnapshot afterwards,
+// since cells whose marking changed are not reported as changes.
+func (a *App) SetSessionHighlight(id int, pattern string) string {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	a.mu.Unlock()
+	if sess == nil {
+		return "session not found"
+	}
+	if pattern == "" {
+		sess.SetHighlight(nil)
+		return ""
+	}
+	re, err := terminal.CompileHighlight(pattern)
+	if err != nil {
+		return err.Error()
+	}
+	sess.SetHighlight(re)
+	log.Printf("[SetSessionHighlight] session %d pattern %q", id, pattern)
+	return ""
+}
+
+// MatchSessionHighlight matches the pane's highlight pattern against
+// lines the frontend reads from its own terminal buffer. For each line it
+// returns flat [start, end) column pairs; the result is nil when no
+// pattern is set. Matching runs in Go so a pattern typed in the UI never
+// reaches a backtracking regex engine.
+func (a *App) MatchSessionHighlight(id int, lines []string) [][]int {
+	re := a.sessionHighlight(id)
+	if re == nil {
+		return nil
+	}
+	out := make([][]int, len(lines))
+	for i, line := range lines {
+		out[i] = terminal.MatchColumns(re, line)
+	}
+	return out
+}
+
+// FilterSessionLines returns the lines of a pane's scrollback and screen
+// that match its highlight pattern, oldest first, for the filter view.
+func (a *App) FilterSessionLines(id int) []string {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	a.mu.Unlock()
+	if sess == nil || sess.Highlight() == nil {
+		return nil
+	}
+	re := sess.Highlight()
+	var lines []string
+	for _, line := range strings.Split(sess.Screen.Export(false), "\n") {
+		if re.MatchString(line) {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > maxFilterLines {
+		lines = lines[len(lines)-maxFilterLines:]
+	}
+	return lines
+}
+
+func (a *App) sessionHighlight(id int) *regexp.Regexp {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	a.mu.Unlock()
+	if sess == nil {
+		return nil
+	}
+	return sess.Highlight()
+}
+
+// markHighlight sets AttrHighlight on the cells of a snapshot row that
+// fall inside a match of re. text is the row as RowText returns it.
+func markHighlight(row []SnapshotCell, re *regexp.Regexp, text string) {
+	cols := terminal.MatchColumns(re, text)
+	for i := 0; i < len(cols); i += 2 {
+		for c := cols[i]; c < cols[i+1] && c < len(row); c++ {
+			row[c].Attrs |= AttrHighlight
+		}
+	}
+}
+
+// highlightChanges marks matches in the rows a diff touches. Output can
+// complete a match that started in unchanged cells, so matched cells of a
+// touched row that are not in the diff yet are added, marked.
+func highlightChanges(screen *terminal.Screen, re *regexp.Regexp, changes []CellUpdate) []CellUpdate {
+	type pos struct{ row, col int }
+	index := make(map[pos]int, len(changes))
+	rows := make([]int, 0, len(changes))
+	for i, ch := range changes {
+		rows = append(rows, ch.Row)
+		index[pos{ch.Row, ch.Col}] = i
+	}
+	slices.Sort(rows)
+	rows = slices.Compact(rows)
+	for _, r := range rows {
+		cells, _, _ := screen.CellRows(r, r+1)
+		if len(cells) == 0 {
+			continue
+		}
+		line := cells[0][:min(len(cells[0]), maxSnapshotCols)]
+		cols := terminal.MatchColumns(re, terminal.RowText(line))
+		for i := 0; i < len(cols); i += 2 {
+			for c := cols[i]; c < cols[i+1] && c < len(line); c++ {
+				if k, ok := index[pos{r, c}]; ok {
+					changes[k].Cell.Attrs |= AttrHighlight
+					continue
+				}
+				cell := snapshotCell(line[c], screen.Monochrome())
+				cell.Attrs |= AttrHighlight
+				changes = append(changes, CellUpdate{Row: r, Col: c, Cell: cell})
+			}
+		}
+	}
+	return changes
+}
@@ -0,0 +1,80 @@
+package backend
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+func TestSetSessionHighlight(t *testing.T) {
+	a := newTestApp()
+	a.sessions[1] = terminal.NewSession(1, 3, 20)
+
+	if msg := a.SetSessionHighlight(9, "x"); msg == "" {
+		t.Error("missing session accepted")
+	}
+	if msg := a.SetSessionHighlight(1, "(oops"); msg == "" {
+		t.Error("invalid pattern accepted")
+	}
+	if msg := a.SetSessionHighlight(1, "err(or)?"); msg != "" {
+		t.Fatalf("valid pattern refused: %s", msg)
+	}
+	got := a.MatchSessionHighlight(1, []string{"no match", "an ERROR and err"})
+	if got[0] != nil {
+		t.Errorf("line 0 matches = %v, want none", got[0])
+	}
+	if want := []int{3, 8, 13, 16}; !reflect.DeepEqual(got[1], want) {
+		t.Errorf("line 1 matches = %v, want %v", got[1], want)
+	}
+
+	a.SetSessionHighlight(1, "")
+	if got := a.MatchSessionHighlight(1, []string{"error"}); got != nil {
+		t.Errorf("cleared highlight still matches: %v", got)
+	}
+}
+
+func TestFilterSessionLines(t *testing.T) {
+	a := newTestApp()
+	sess := terminal.NewSession(1, 4, 20)
+	sess.Screen.Write([]byte("GET /a 200\r\nGET /b 500\r\nPOST /c 500\r\n"))
+	a.sessions[1] = sess
+
+	if got := a.FilterSessionLines(1); got != nil {
+		t.Errorf("filter without a pattern = %q, want nil", got)
+	}
+	a.SetSessionHighlight(1, ` 5\d\d$`)
+	if got, want := a.FilterSessionLines(1), []string{"GET /b 500", "POST /c 500"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterSessionLines = %q, want %q", got, want)
+	}
+}
+
+func TestSnapshot_MarksHighlight(t *testing.T) {
+	a := newTestApp()
+	sess := terminal.NewSession(1, 2, 10)
+	sess.Screen.Write([]byte("a WARN b"))
+	a.sessions[1] = sess
+	a.GetScreenDiff(1) // consume the initial full diff
+	a.SetSessionHighlight(1, "warn")
+
+	row := a.GetScreenSnapshot(1).Cells[0]
+	for c, cell := range row {
+		if marked := cell.Attrs&AttrHighlight != 0; marked != (c >= 2 && c < 6) {
+			t.Errorf("col %d %q highlighted = %v", c, cell.Char, marked)
+		}
+	}
+
+	// "WA" already on screen; the diff for the new "RN" must mark all four.
+	sess.Screen.Write([]byte("\r\nx WA"))
+	a.GetScreenDiff(1)
+	sess.Screen.Write([]byte("RN"))
+	marked := 0
+	for _, ch := range a.GetScreenDiff(1).Changes {
+		if ch.Row == 1 && ch.Cell.Attrs&AttrHighlight != 0 {
+			marked++
+		}
+	}
+	if marked != 4 {
+		t.Errorf("diff marked %d cells of the completed match, want 4", marked)
+	}
+}
@@ -0,0 +1,117 @@
+package backend
+
+import (
+	"log"
+	"time"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// idleWarningLead is how long before an idle shell pane is closed the
+// frontend gets terminal:idle-warning, so the user can keep it open.
+const idleWarningLead = time.Minute
+
+// SetSessionPinned records whether a pane is pinned. Pinned panes are never
+// closed by idle_timeout_minutes; the frontend calls this for every pane.
+func (a *App) SetSessionPinned(id int, pinned bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if pinned {
+		a.pinnedSessions[id] = true
+	} else {
+		delete(a.pinnedSessions, id)
+	}
+}
+
+// KeepSessionOpen restarts the idle timeout of a session as if the user had
+// typed into it. The frontend calls it when a warned pane should stay.
+func (a *App) KeepSessionOpen(id int) {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	delete(a.idleWarned, id)
+	a.mu.Unlock()
+	if sess != nil {
+		sess.KeepAlive()
+	}
+}
+
+// idleTimeout returns idle_timeout_minutes as a duration; 0 = off.
+func (a *App) idleTimeout() time.Duration {
+	return time.Duration(a.currentConfig().IdleTimeoutMinutes) * time.Minute
+}
+
+// closeIdleShells closes shell panes that had neither input nor output for
+// timeout. idleWarningLead before that it emits terminal:idle-warning with
+// the seconds left, and terminal:idle-warning with 0 when the pane becomes
+// busy again. Claude panes, pinned panes, mirrors and shells running a
+// program (vim, a build) are never closed. Closed panes are reported with
+// terminal:idle-closed so the frontend removes them.
+func (a *App) closeIdleShells(timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	a.mu.Lock()
+	ids := make([]int, 0, len(a.sessions))
+	sessions := make([]*terminal.Session, 0, len(a.sessions))
+	for id, s := range a.sessions {
+		if a.pinnedSessions[id] || s.IsMirror() || !s.IsRunning() {
+			continue
+		}
+		ids = append(ids, id)
+		sessions = append(sessions, s)
+	}
+	a.mu.Unlock()
+
+	for i, sess := range sessions {
+		id := ids[i]
+		if a.isClaudeArgv(sess.Argv()) {
+			continue
+		}
+		left := timeout - min(sess.IdleFor(), sess.InputIdleFor())
+		a.mu.Lock()
+		warned := a.idleWarned[id]
+		a.mu.Unlock()
+
+		switch {
+		case left > idleWarningLead:
+			if warned {
+				a.setIdleWarned(id, false)
+				a.emitIdleWarning(id, 0)
+			}
+		case sess.HasChildProcesses():
+			// A program runs in the shell; it may just be waiting quietly
+		case left > 0:
+			if !warned {
+				a.setIdleWarned(id, true)
+				a.emitIdleWarning(id, int(left.Seconds()))
+			}
+		default:
+			log.Printf("[idle-close] session %d: no input or output for %v, closing", id, timeout)
+			a.setIdleWarned(id, false)
+			a.CloseSession(id)
+			if a.ctx != nil {
+				runtime.EventsEmit(a.ctx, "terminal:idle-closed", id)
+			}
+		}
+	}
+}
+
+func (a *App) setIdleWarned(id int, warned bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if warned {
+		a.idleWarned[id] = true
+	} else {
+		delete(a.idleWarned, id)
+	}
+}
+
+// emitIdleWarning tells the frontend that session id will be closed in
+// seconds, or that the warning is over (0).
+func (a *App) emitIdleWarning(id, seconds int) {
+	if a.ctx == nil {
+		return
+	}
+	runtime.EventsEmit(a.ctx, "terminal:idle-warning", id, seconds)
+}
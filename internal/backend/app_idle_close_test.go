@@ -0,0 +1,101 @@
+package backend
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+// startIdleShell starts a quiet session running argv and registers it.
+func startIdleShell(t *testing.T, a *App, id int, argv ...string) *terminal.Session {
+	t.Helper()
+	sess := terminal.NewSession(id, 24, 80)
+	if err := sess.Start(argv, t.TempDir(), nil); err != nil {
+		t.Skipf("cannot start a PTY: %v", err)
+	}
+	t.Cleanup(sess.Close)
+	a.sessions[id] = sess
+	return sess
+}
+
+func waitStopped(sess *terminal.Session) bool {
+	for i := 0; i < 100 && sess.IsRunning(); i++ {
+		time.Sleep(20 * time.Millisecond)
+	}
+	return !sess.IsRunning()
+}
+
+func TestCloseIdleShells_WarnsThenCloses(t *testing.T) {
+	a := newTestApp()
+	sess := startIdleShell(t, a, 1, "sleep", "30")
+
+	timeout := 300 * time.Millisecond
+	a.closeIdleShells(timeout)
+	if !a.idleWarned[1] || !sess.IsRunning() {
+		t.Fatalf("within the warning lead: warned=%v running=%v, want a warning only", a.idleWarned[1], sess.IsRunning())
+	}
+
+	time.Sleep(timeout)
+	a.closeIdleShells(timeout)
+	if !waitStopped(sess) {
+		t.Fatal("idle shell still running after the timeout")
+	}
+}
+
+func TestCloseIdleShells_InputKeepsPaneOpen(t *testing.T) {
+	a := newTestApp()
+	sess := startIdleShell(t, a, 1, "sleep", "30")
+
+	timeout := 300 * time.Millisecond
+	time.Sleep(timeout)
+	a.KeepSessionOpen(1)
+	a.closeIdleShells(timeout)
+	if !sess.IsRunning() {
+		t.Fatal("pane closed right after KeepSessionOpen")
+	}
+}
+
+func TestCloseIdleShells_ExemptPanes(t *testing.T) {
+	a := newTestApp()
+	a.cfg.ClaudeCommand = "sleep" // stands in for the Claude CLI
+	claude := startIdleShell(t, a, 1, "sleep", "30")
+	pinned := startIdleShell(t, a, 2, "sh", "-c", "read x")
+	a.SetSessionPinned(2, true)
+
+	time.Sleep(50 * time.Millisecond)
+	a.closeIdleShells(time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	if !claude.IsRunning() || !pinned.IsRunning() {
+		t.Errorf("claude running=%v pinned running=%v, want both kept", claude.IsRunning(), pinned.IsRunning())
+	}
+	if a.idleWarned[1] || a.idleWarned[2] {
+		t.Error("exempt panes were warned")
+	}
+}
+
+func TestCloseIdleShells_ShellRunningAProgram(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("reads /proc")
+	}
+	a := newTestApp()
+	// The shell forks sleep and waits for it, like a shell running vim
+	sess := startIdleShell(t, a, 1, "sh", "-c", "sleep 30; true")
+
+	time.Sleep(100 * time.Millisecond)
+	a.closeIdleShells(time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	if !sess.IsRunning() {
+		t.Error("shell with a running child was closed")
+	}
+}
+
+func TestCloseIdleShells_Off(t *testing.T) {
+	a := newTestApp()
+	sess := startIdleShell(t, a, 1, "sleep", "30")
+	a.closeIdleShells(a.idleTimeout()) // idle_timeout_minutes defaults to 0
+	if !sess.IsRunning() || a.idleWarned[1] {
+		t.Error("idle timeout acted while switched off")
+	}
+}
@@ -0,0 +1,110 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	_ "image/gif" // register decoders for image.Decode
+	"image/jpeg"
+	_ "image/png"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Limits for GetImageThumbnail. The pixel cap is checked from the header
+// before decoding, so a small file claiming a huge image is not expanded.
+const (
+	maxImageFileSize  = 20 << 20   // bytes
+	maxImagePixels    = 50_000_000 // width × height
+	maxThumbnailDim   = 1024
+	defaultThumbDim   = 256
+	thumbnailSamples  = 4 // per axis and output pixel when shrinking
+	thumbnailQuality  = 80
+	thumbnailDataType = "data:image/jpeg;base64,"
+)
+
+// imageExts are the formats the standard library can decode.
+var imageExts = map[string]bool{".png": true, ".jpg": true, ".jpeg": true, ".gif": true}
+
+// isImageFile reports whether path has an extension GetImageThumbnail
+// can preview.
+func isImageFile(path string) bool {
+	return imageExts[strings.ToLower(filepath.Ext(path))]
+}
+
+// GetImageThumbnail returns a JPEG data URL of the image at path, scaled
+// down so neither side exceeds maxDim (capped at 1024; <= 0 means 256).
+// Transparent areas are drawn on white. It returns "" for unsupported,
+// oversized or corrupt files.
+func (a *App) GetImageThumbnail(path string, maxDim int) string {
+	if maxDim <= 0 {
+		maxDim = defaultThumbDim
+	}
+	maxDim = min(maxDim, maxThumbnailDim)
+	if !isImageFile(path) {
+		return ""
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() || info.Size() > maxImageFileSize {
+		return ""
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxImagePixels {
+		return ""
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return ""
+	}
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, thumbnail(img, maxDim), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return ""
+	}
+	return thumbnailDataType + base64.StdEncoding.EncodeToString(out.Bytes())
+}
+
+// thumbnail scales img to fit in maxDim × maxDim, keeping its aspect
+// ratio; smaller images keep their size. Each output pixel averages a
+// grid of samples from the area it covers.
+func thumbnail(img image.Image, maxDim int) *image.RGBA {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	tw, th := w, h
+	if w > maxDim || h > maxDim {
+		if w >= h {
+			tw, th = maxDim, max(1, h*maxDim/w)
+		} else {
+			tw, th = max(1, w*maxDim/h), maxDim
+		}
+	}
+	samples := 1
+	if tw < w || th < h {
+		samples = thumbnailSamples
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		for x := 0; x < tw; x++ {
+			var r, g, bl, n uint32
+			for sy := 0; sy < samples; sy++ {
+				py := b.Min.Y + ((y*samples+sy)*h)/(th*samples)
+				for sx := 0; sx < samples; sx++ {
+					px := b.Min.X + ((x*samples+sx)*w)/(tw*samples)
+					cr, cg, cb, ca := img.At(px, py).RGBA()
+					// Premultiplied colour over white
+					r += cr + 0xffff - ca
+					g += cg + 0xffff - ca
+					bl += cb + 0xffff - ca
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(bl / n), A: 0xffff})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,91 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writePNG(t *testing.T, path string, w, h int, c color.Color) {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func decodeThumbnail(t *testing.T, url string) image.Image {
+	t.Helper()
+	if !strings.HasPrefix(url, thumbnailDataType) {
+		t.Fatalf("not a JPEG data URL: %.40q", url)
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(url, thumbnailDataType))
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return img
+}
+
+func TestGetImageThumbnail_ScalesDown(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shot.PNG")
+	writePNG(t, path, 400, 100, color.RGBA{R: 200, A: 255})
+
+	img := decodeThumbnail(t, (&App{}).GetImageThumbnail(path, 100))
+	if b := img.Bounds(); b.Dx() != 100 || b.Dy() != 25 {
+		t.Errorf("thumbnail is %dx%d, want 100x25", b.Dx(), b.Dy())
+	}
+	if r, _, _, _ := img.At(50, 12).RGBA(); r>>8 < 180 {
+		t.Errorf("thumbnail lost the image colour: red = %d", r>>8)
+	}
+}
+
+func TestGetImageThumbnail_KeepsSmallImagesAndFillsTransparency(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "icon.png")
+	writePNG(t, path, 8, 6, color.RGBA{})
+
+	img := decodeThumbnail(t, (&App{}).GetImageThumbnail(path, 0))
+	if b := img.Bounds(); b.Dx() != 8 || b.Dy() != 6 {
+		t.Errorf("small image resized to %dx%d", b.Dx(), b.Dy())
+	}
+	if r, g, b, _ := img.At(4, 3).RGBA(); r>>8 < 240 || g>>8 < 240 || b>>8 < 240 {
+		t.Errorf("transparent pixel = (%d,%d,%d), want white", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestGetImageThumbnail_Rejects(t *testing.T) {
+	dir := t.TempDir()
+	corrupt := filepath.Join(dir, "broken.png")
+	os.WriteFile(corrupt, []byte("\x89PNG not really"), 0o644)
+	text := filepath.Join(dir, "notes.txt")
+	os.WriteFile(text, []byte("hello"), 0o644)
+	imgDir := filepath.Join(dir, "folder.png")
+	os.Mkdir(imgDir, 0o755)
+
+	a := &App{}
+	for _, path := range []string{corrupt, text, imgDir, filepath.Join(dir, "missing.jpg")} {
+		if got := a.GetImageThumbnail(path, 64); got != "" {
+			t.Errorf("GetImageThumbnail(%s) = %.40q, want empty", filepath.Base(path), got)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+// inputLogMaxBytes caps the hex dump of a single write in the log.
+const inputLogMaxBytes = 64
+
+var (
+	pasteStart = []byte("\x1b[200~")
+	pasteEnd   = []byte("\x1b[201~")
+)
+
+// WriteToSession sends raw input data (base64-encoded) to a session's PTY.
+func (a *App) WriteToSession(id int, b64data string) {
+	a.writeSession(id, b64data, "write", "")
+}
+
+// WriteKeyToSession sends the bytes xterm.js produced for a keystroke. key
+// is the key spec that caused them (e.g. "shift+enter"; "" for mouse
+// reports and IME input). When the program enabled the kitty keyboard
+// protocol, the key is re-encoded in its CSI u form instead.
+func (a *App) WriteKeyToSession(id int, b64data string, key string) {
+	source := "key " + key
+	if key == "" {
+		source = "key -"
+	}
+	a.writeSession(id, b64data, source, key)
+}
+
+// PasteToSession sends clipboard text. It is written like any input, but
+// log_input never logs its content.
+func (a *App) PasteToSession(id int, b64data string) {
+	a.writeSession(id, b64data, "paste", "")
+}
+
+// writeSession decodes and writes input; key is the key spec for
+// keystrokes and "" otherwise.
+func (a *App) writeSession(id int, b64data, source, key string) {
+	data, err := base64.StdEncoding.DecodeString(b64data)
+	if err != nil {
+		return
+	}
+	a.writeBytes(id, data, source, key)
+}
+
+// writeBytes writes input to session id. It reports false when the session
+// does not exist or is read-only.
+func (a *App) writeBytes(id int, data []byte, source, key string) bool {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	a.mu.Unlock()
+	if sess == nil {
+		return false
+	}
+	if key != "" && sess.KittyKeyboard() {
+		if seq, ok := terminal.KeyToKittyBytes(key); ok {
+			data = seq
+		}
+	}
+	if !a.currentConfig().LogInput {
+		return writeInput(sess, data)
+	}
+	// Describe before writing: the prompt the input answers is still on screen
+	desc := describeInput(data, source == "paste", sess.AwaitingPassword())
+	written := writeInput(sess, data)
+	if !written {
+		desc += " (dropped: read-only)"
+	}
+	log.Printf("[input] session %d %s: %s", id, source, desc)
+	return written
+}
+
+// describeInput renders input for the debug log as a hex dump. Secrets
+// are replaced by their length: all of a paste, anything typed at a
+// password prompt, and the body of a bracketed paste.
+func describeInput(data []byte, paste, password bool) string {
+	switch {
+	case paste:
+		return fmt.Sprintf("%d bytes redacted (paste)", len(data))
+	case password:
+		return fmt.Sprintf("%d bytes redacted (password prompt)", len(data))
+	}
+	start := bytes.Index(data, pasteStart)
+	if start < 0 {
+		return hexDump(data)
+	}
+	body := data[start+len(pasteStart):]
+	end := bytes.Index(body, pasteEnd)
+	if end < 0 {
+		end = len(body)
+	}
+	tail := body[end:]
+	return strings.TrimSpace(fmt.Sprintf("%s [%d bytes redacted (bracketed paste)] %s",
+		hexDump(data[:start+len(pasteStart)]), end, hexDump(tail)))
+}
+
+// hexDump formats data as space-separated hex bytes, e.g. "1b 5b 41",
+// truncated after inputLogMaxBytes.
+func hexDump(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	shown := data
+	if len(shown) > inputLogMaxBytes {
+		shown = shown[:inputLogMaxBytes]
+	}
+	out := fmt.Sprintf("% x", shown)
+	if n := len(data) - len(shown); n > 0 {
+		out += fmt.Sprintf(" … (+%d bytes)", n)
+	}
+	return out
+}
@@ -0,0 +1,114 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/base64"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+func TestDescribeInput_HexDump(t *testing.T) {
+	if got := describeInput([]byte("\x1b[A"), false, false); got != "1b 5b 41" {
+		t.Errorf("got %q, want %q", got, "1b 5b 41")
+	}
+}
+
+func TestDescribeInput_Redacted(t *testing.T) {
+	if got := describeInput([]byte("secret"), true, false); got != "6 bytes redacted (paste)" {
+		t.Errorf("paste: got %q", got)
+	}
+	if got := describeInput([]byte("hunter2\r"), false, true); got != "8 bytes redacted (password prompt)" {
+		t.Errorf("password: got %q", got)
+	}
+}
+
+func TestDescribeInput_BracketedPaste(t *testing.T) {
+	got := describeInput([]byte("\x1b[200~token\x1b[201~"), false, false)
+	want := "1b 5b 32 30 30 7e [5 bytes redacted (bracketed paste)] 1b 5b 32 30 31 7e"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if strings.Contains(got, "74 6f 6b") {
+		t.Error("paste body leaked into the log")
+	}
+}
+
+func TestHexDump_Truncated(t *testing.T) {
+	got := hexDump(bytes.Repeat([]byte{'a'}, inputLogMaxBytes+3))
+	if !strings.HasSuffix(got, " … (+3 bytes)") {
+		t.Errorf("got %q, want truncation suffix", got)
+	}
+	if n := strings.Count(got, "61"); n != inputLogMaxBytes {
+		t.Errorf("dumped %d bytes, want %d", n, inputLogMaxBytes)
+	}
+}
+
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+	return &buf
+}
+
+func TestWriteKeyToSession_Logs(t *testing.T) {
+	a := newTestApp()
+	a.cfg.LogInput = true
+	sess := terminal.NewSession(1, 5, 40)
+	sess.SetReadOnly(true) // not started: keep the write from reaching a PTY
+	a.sessions[1] = sess
+	buf := captureLog(t)
+
+	a.WriteKeyToSession(1, base64.StdEncoding.EncodeToString([]byte("\r")), "enter")
+	want := "[input] session 1 key enter: 0d (dropped: read-only)"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("log = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteKeyToSession_PasswordPrompt(t *testing.T) {
+	a := newTestApp()
+	a.cfg.LogInput = true
+	sess := terminal.NewSession(1, 5, 40)
+	sess.SetReadOnly(true)
+	sess.Screen.Write([]byte("$ sudo ls\r\n[sudo] password for user: "))
+	a.sessions[1] = sess
+	buf := captureLog(t)
+
+	a.WriteKeyToSession(1, base64.StdEncoding.EncodeToString([]byte("x")), "")
+	if !strings.Contains(buf.String(), "key -: 1 bytes redacted (password prompt)") {
+		t.Errorf("log = %q", buf.String())
+	}
+}
+
+func TestWriteToSession_NoLogByDefault(t *testing.T) {
+	a := newTestApp()
+	sess := terminal.NewSession(1, 5, 40)
+	sess.SetReadOnly(true)
+	a.sessions[1] = sess
+	buf := captureLog(t)
+
+	a.PasteToSession(1, base64.StdEncoding.EncodeToString([]byte("x")))
+	if buf.Len() != 0 {
+		t.Errorf("log_input off, but logged %q", buf.String())
+	}
+}
+
+func TestWriteKeyToSession_KittyEncoding(t *testing.T) {
+	a := newTestApp()
+	a.cfg.LogInput = true
+	sess := terminal.NewSession(1, 5, 40)
+	sess.SetReadOnly(true)
+	sess.Screen.Write([]byte("\x1b[>1u"))
+	a.sessions[1] = sess
+	buf := captureLog(t)
+
+	a.WriteKeyToSession(1, base64.StdEncoding.EncodeToString([]byte("\r")), "ctrl+enter")
+	if !strings.Contains(buf.String(), "key ctrl+enter: 1b 5b 31 33 3b 35 75") {
+		t.Errorf("log = %q, want CSI 13;5u", buf.String())
+	}
+}
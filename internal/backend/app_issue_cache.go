@@ -0,0 +1,104 @@
+package backend
+
+import (
+	"sync"
+	"time"
+)
+
+// issueKey identifies an issue by repository directory and number.
+type issueKey struct {
+	dir    string
+	number int
+}
+
+type issueCacheEntry struct {
+	detail  *IssueDetail
+	fetched time.Time
+}
+
+// issueCache keeps recently viewed issue details so reopening an issue does
+// not wait for `gh issue view` again. The zero value is ready to use.
+type issueCache struct {
+	mu         sync.Mutex
+	entries    map[issueKey]issueCacheEntry
+	refreshing map[issueKey]bool
+}
+
+// get returns the cached detail for key if it is younger than ttl, and
+// otherwise fetches it. A hit older than half the ttl is returned as is
+// while fetch refreshes the entry in the background, so an issue that is
+// opened regularly rarely pays for gh. A ttl of 0 disables caching.
+// Errors come from synchronous fetches only; a failed background refresh
+// keeps the old entry.
+func (c *issueCache) get(key issueKey, ttl time.Duration, now time.Time, fetch func() (*IssueDetail, error)) (*IssueDetail, error) {
+	if ttl <= 0 {
+		return fetch()
+	}
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	age := now.Sub(e.fetched)
+	if ok && age < ttl {
+		if age >= ttl/2 && !c.refreshing[key] {
+			if c.refreshing == nil {
+				c.refreshing = make(map[issueKey]bool)
+			}
+			c.refreshing[key] = true
+			go func() {
+				detail, _ := fetch()
+				c.mu.Lock()
+				defer c.mu.Unlock()
+				delete(c.refreshing, key)
+				// Skip if the entry was invalidated or replaced meanwhile:
+				// this fetch may predate an edit
+				if cur, ok := c.entries[key]; ok && cur.fetched.Equal(e.fetched) && detail != nil {
+					c.entries[key] = issueCacheEntry{detail: detail, fetched: time.Now()}
+				}
+			}()
+		}
+		c.mu.Unlock()
+		return e.detail, nil
+	}
+	c.mu.Unlock()
+
+	detail, err := fetch()
+	c.put(key, detail, now)
+	return detail, err
+}
+
+// put stores detail for key; failed fetches (nil) are not cached.
+func (c *issueCache) put(key issueKey, detail *IssueDetail, now time.Time) {
+	if detail == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[issueKey]issueCacheEntry)
+	}
+	c.entries[key] = issueCacheEntry{detail: detail, fetched: now}
+}
+
+// invalidate drops the cached detail for key, e.g. after the issue was
+// edited or commented on.
+func (c *issueCache) invalidate(key issueKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// issueCacheTTL returns how long issue details are served from the cache
+// (issue_cache_seconds; 0 = always ask gh).
+func (a *App) issueCacheTTL() time.Duration {
+	return time.Duration(a.currentConfig().IssueCacheSeconds) * time.Second
+}
+
+// RefreshIssue fetches an issue from GitHub, bypassing the cache, and
+// stores the fresh result for later GetIssueDetail calls.
+func (a *App) RefreshIssue(dir string, number int) (*IssueDetail, error) {
+	if dir == "" || number <= 0 {
+		return nil, nil
+	}
+	detail, err := a.fetchIssueDetail(dir, number)
+	a.issues.put(issueKey{dir, number}, detail, time.Now())
+	return detail, err
+}
@@ -0,0 +1,105 @@
+package backend
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingFetch returns a fetch func that counts its calls and returns an
+// issue whose title is the call number.
+func countingFetch(calls *atomic.Int32) func() (*IssueDetail, error) {
+	return func() (*IssueDetail, error) {
+		n := calls.Add(1)
+		return &IssueDetail{Number: 7, Title: string(rune('0' + n))}, nil
+	}
+}
+
+func TestIssueCache_FreshHitSkipsFetch(t *testing.T) {
+	var c issueCache
+	var calls atomic.Int32
+	key := issueKey{"/repo", 7}
+	now := time.Now()
+
+	first, _ := c.get(key, time.Minute, now, countingFetch(&calls))
+	second, _ := c.get(key, time.Minute, now.Add(10*time.Second), countingFetch(&calls))
+	if calls.Load() != 1 || second != first {
+		t.Fatalf("calls = %d, second = %+v; want one fetch and the cached detail", calls.Load(), second)
+	}
+}
+
+func TestIssueCache_ExpiredEntryIsFetchedAgain(t *testing.T) {
+	var c issueCache
+	var calls atomic.Int32
+	key := issueKey{"/repo", 7}
+	now := time.Now()
+
+	c.get(key, time.Minute, now, countingFetch(&calls))
+	got, _ := c.get(key, time.Minute, now.Add(2*time.Minute), countingFetch(&calls))
+	if calls.Load() != 2 || got.Title != "2" {
+		t.Errorf("calls = %d, title = %q; want a synchronous refetch", calls.Load(), got.Title)
+	}
+}
+
+func TestIssueCache_AgingHitRefreshesInBackground(t *testing.T) {
+	var c issueCache
+	var calls atomic.Int32
+	key := issueKey{"/repo", 7}
+	now := time.Now()
+
+	c.get(key, time.Minute, now, countingFetch(&calls))
+	got, _ := c.get(key, time.Minute, now.Add(40*time.Second), countingFetch(&calls))
+	if got.Title != "1" {
+		t.Fatalf("aging hit returned %q, want the cached detail right away", got.Title)
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		c.mu.Lock()
+		title := c.entries[key].detail.Title
+		c.mu.Unlock()
+		if title == "2" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("background refresh did not update the entry")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestIssueCache_InvalidateForcesFetch(t *testing.T) {
+	var c issueCache
+	var calls atomic.Int32
+	key := issueKey{"/repo", 7}
+	now := time.Now()
+
+	c.get(key, time.Minute, now, countingFetch(&calls))
+	c.invalidate(key)
+	c.get(key, time.Minute, now, countingFetch(&calls))
+	if calls.Load() != 2 {
+		t.Errorf("calls = %d, want a fetch after invalidate", calls.Load())
+	}
+}
+
+func TestIssueCache_FailuresAndZeroTTLAreNotCached(t *testing.T) {
+	var c issueCache
+	key := issueKey{"/repo", 7}
+	now := time.Now()
+
+	failed := func() (*IssueDetail, error) { return nil, errGitHubTimeout }
+	if got, err := c.get(key, time.Minute, now, failed); got != nil || err != errGitHubTimeout {
+		t.Fatalf("get = %+v, %v; want the fetch error", got, err)
+	}
+	var calls atomic.Int32
+	c.get(key, time.Minute, now, countingFetch(&calls))
+	if calls.Load() != 1 {
+		t.Errorf("failed fetch was cached")
+	}
+
+	var uncached issueCache
+	uncached.get(key, 0, now, countingFetch(&calls))
+	uncached.get(key, 0, now, countingFetch(&calls))
+	if calls.Load() != 3 {
+		t.Errorf("calls = %d, want every call to fetch with ttl 0", calls.Load())
+	}
+}
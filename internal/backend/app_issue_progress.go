@@ -22,8 +22,8 @@ const (
 func (a *App) reportIssueProgress(sessionID int, event issueProgressEvent, cost string) {
 	a.mu.Lock()
 	si := a.sessionIssues[sessionID]
-	cfg := a.cfg
 	a.mu.Unlock()
+	cfg := a.currentConfig()
 
 	if si == nil || si.Number == 0 || si.Dir == "" {
 		return
@@ -3,11 +3,13 @@ package backend
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os/exec"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Issue represents a GitHub issue summary for list views.
@@ -53,14 +55,15 @@ type IssueLabel struct {
 }
 
 // CheckGitHubCLI checks if the gh CLI is installed and authenticated.
-// Returns "ok", "not_installed", or "not_authenticated".
+// Returns "ok", "not_installed", "not_authenticated", or "timeout".
 func (a *App) CheckGitHubCLI() string {
 	if _, err := exec.LookPath("gh"); err != nil {
 		return "not_installed"
 	}
-	cmd := exec.Command("gh", "auth", "status")
-	hideConsole(cmd)
-	if err := cmd.Run(); err != nil {
+	if _, err := a.runGH("", "auth", "status"); err != nil {
+		if errors.Is(err, errGitHubTimeout) {
+			return "timeout"
+		}
 		return "not_authenticated"
 	}
 	return "ok"
@@ -68,57 +71,59 @@ func (a *App) CheckGitHubCLI() string {
 
 // GetIssues returns a list of GitHub issues for the repo in dir.
 // state can be "open", "closed", or "all".
-func (a *App) GetIssues(dir string, state string) []Issue {
+func (a *App) GetIssues(dir string, state string) ([]Issue, error) {
 	if dir == "" {
-		return nil
+		return nil, nil
 	}
 	if state == "" {
 		state = "open"
 	}
 
 	fields := "number,title,state,author,labels,body,createdAt,updatedAt,comments,url"
-	cmd := exec.Command("gh", "issue", "list",
+	out, err := a.runGH(dir, "issue", "list",
 		"--state", state,
 		"--limit", "50",
 		"--json", fields,
 	)
-	cmd.Dir = dir
-	hideConsole(cmd)
-	out, err := cmd.Output()
 	if err != nil {
 		log.Printf("[GetIssues] gh error: %v", err)
-		return nil
+		return nil, err
 	}
 
-	return parseIssueList(out)
+	return parseIssueList(out), nil
 }
 
-// GetIssueDetail returns the full details of a single issue including comments.
-func (a *App) GetIssueDetail(dir string, number int) *IssueDetail {
+// GetIssueDetail returns the full details of a single issue including
+// comments. Recently fetched issues are served from a short-lived cache;
+// RefreshIssue bypasses it.
+func (a *App) GetIssueDetail(dir string, number int) (*IssueDetail, error) {
 	if dir == "" || number <= 0 {
-		return nil
+		return nil, nil
 	}
+	return a.issues.get(issueKey{dir, number}, a.issueCacheTTL(), time.Now(), func() (*IssueDetail, error) {
+		return a.fetchIssueDetail(dir, number)
+	})
+}
 
+// fetchIssueDetail runs `gh issue view` for one issue.
+func (a *App) fetchIssueDetail(dir string, number int) (*IssueDetail, error) {
 	fields := "number,title,state,author,labels,body,createdAt,updatedAt,assignees,comments,url"
-	cmd := exec.Command("gh", "issue", "view",
+	out, err := a.runGH(dir, "issue", "view",
 		strconv.Itoa(number),
 		"--json", fields,
 	)
-	cmd.Dir = dir
-	hideConsole(cmd)
-	out, err := cmd.Output()
 	if err != nil {
 		log.Printf("[GetIssueDetail] gh error: %v", err)
-		return nil
+		return nil, err
 	}
 
-	return parseIssueDetail(out)
+	return parseIssueDetail(out), nil
 }
 
 // CreateIssue creates a new GitHub issue and returns it.
-func (a *App) CreateIssue(dir string, title string, body string, labels []string) *Issue {
+func (a *App) CreateIssue(dir string, title string, body string, labels []string) (*Issue, error) {
 	if dir == "" || title == "" {
-		return nil
+		return nil, fmt.Errorf("invalid parameters")
 	}
 
 	args := []string{"issue", "create", "--title", title}
@@ -131,25 +136,19 @@ func (a *App) CreateIssue(dir string, title string, body string, labels []string
 		}
 	}
 
-	cmd := exec.Command("gh", args...)
-	cmd.Dir = dir
-	hideConsole(cmd)
-	out, err := cmd.Output()
+	out, err := a.runGH(dir, args...)
 	if err != nil {
 		log.Printf("[CreateIssue] gh error: %v", err)
-		return nil
+		return nil, err
 	}
 
 	// gh issue create outputs the URL of the created issue
 	url := strings.TrimSpace(string(out))
 	// Extract issue number from URL (last path segment)
 	parts := strings.Split(url, "/")
-	if len(parts) == 0 {
-		return nil
-	}
 	num, err := strconv.Atoi(parts[len(parts)-1])
 	if err != nil {
-		return nil
+		return nil, fmt.Errorf("unexpected gh output %q", url)
 	}
 
 	return &Issue{
@@ -158,7 +157,7 @@ func (a *App) CreateIssue(dir string, title string, body string, labels []string
 		State:  "open",
 		Labels: labels,
 		URL:    url,
-	}
+	}, nil
 }
 
 // UpdateIssue updates an existing issue's title, body, and/or state.
@@ -168,6 +167,7 @@ func (a *App) UpdateIssue(dir string, number int, title string, body string, sta
 	}
 
 	numStr := strconv.Itoa(number)
+	defer a.issues.invalidate(issueKey{dir, number})
 
 	// Update title and body if provided
 	if title != "" || body != "" {
@@ -178,10 +178,7 @@ func (a *App) UpdateIssue(dir string, number int, title string, body string, sta
 		if body != "" {
 			args = append(args, "--body", body)
 		}
-		cmd := exec.Command("gh", args...)
-		cmd.Dir = dir
-		hideConsole(cmd)
-		if err := cmd.Run(); err != nil {
+		if _, err := a.runGH(dir, args...); err != nil {
 			log.Printf("[UpdateIssue] edit error: %v", err)
 			return fmt.Errorf("issue edit failed: %w", err)
 		}
@@ -189,17 +186,11 @@ func (a *App) UpdateIssue(dir string, number int, title string, body string, sta
 
 	// Update state if provided
 	if state == "closed" {
-		cmd := exec.Command("gh", "issue", "close", numStr)
-		cmd.Dir = dir
-		hideConsole(cmd)
-		if err := cmd.Run(); err != nil {
+		if _, err := a.runGH(dir, "issue", "close", numStr); err != nil {
 			return fmt.Errorf("issue close failed: %w", err)
 		}
 	} else if state == "open" {
-		cmd := exec.Command("gh", "issue", "reopen", numStr)
-		cmd.Dir = dir
-		hideConsole(cmd)
-		if err := cmd.Run(); err != nil {
+		if _, err := a.runGH(dir, "issue", "reopen", numStr); err != nil {
 			return fmt.Errorf("issue reopen failed: %w", err)
 		}
 	}
@@ -212,14 +203,12 @@ func (a *App) AddIssueComment(dir string, number int, body string) error {
 	if dir == "" || number <= 0 || body == "" {
 		return fmt.Errorf("invalid parameters")
 	}
+	defer a.issues.invalidate(issueKey{dir, number})
 
-	cmd := exec.Command("gh", "issue", "comment",
+	if _, err := a.runGH(dir, "issue", "comment",
 		strconv.Itoa(number),
 		"--body", body,
-	)
-	cmd.Dir = dir
-	hideConsole(cmd)
-	if err := cmd.Run(); err != nil {
+	); err != nil {
 		log.Printf("[AddIssueComment] gh error: %v", err)
 		return fmt.Errorf("comment failed: %w", err)
 	}
@@ -227,24 +216,21 @@ func (a *App) AddIssueComment(dir string, number int, body string) error {
 }
 
 // GetIssueLabels returns all available labels for the repo in dir.
-func (a *App) GetIssueLabels(dir string) []IssueLabel {
+func (a *App) GetIssueLabels(dir string) ([]IssueLabel, error) {
 	if dir == "" {
-		return nil
+		return nil, nil
 	}
 
-	cmd := exec.Command("gh", "label", "list", "--json", "name,color", "--limit", "100")
-	cmd.Dir = dir
-	hideConsole(cmd)
-	out, err := cmd.Output()
+	out, err := a.runGH(dir, "label", "list", "--json", "name,color", "--limit", "100")
 	if err != nil {
 		log.Printf("[GetIssueLabels] gh error: %v", err)
-		return nil
+		return nil, err
 	}
 
 	var labels []IssueLabel
 	if err := json.Unmarshal(out, &labels); err != nil {
 		log.Printf("[GetIssueLabels] parse error: %v", err)
-		return nil
+		return nil, nil
 	}
-	return labels
+	return labels, nil
 }
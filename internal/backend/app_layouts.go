@@ -0,0 +1,42 @@
+package backend
+
+import (
+	"log"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/config"
+)
+
+// SaveLayout stores the given tab/pane arrangement under name so it can be
+// recreated later with LoadLayout. The frontend passes its current state,
+// exactly as it does for SaveTabs.
+func (a *App) SaveLayout(name string, state config.SessionState) error {
+	log.Printf("[SaveLayout] %q with %d tabs", name, len(state.Tabs))
+	// Layouts describe an arrangement, not what ran in it
+	state.DropScrollback()
+	if err := config.SaveLayout(name, state); err != nil {
+		log.Printf("[SaveLayout] error: %v", err)
+		return err
+	}
+	return nil
+}
+
+// LoadLayout returns the named layout. The frontend is responsible for
+// closing the current panes and recreating the saved tabs.
+func (a *App) LoadLayout(name string) (*config.SessionState, error) {
+	state, err := config.LoadLayout(name)
+	if err != nil {
+		log.Printf("[LoadLayout] error: %v", err)
+		return nil, err
+	}
+	return state, nil
+}
+
+// ListLayouts returns the names of all saved layouts.
+func (a *App) ListLayouts() []string {
+	names, err := config.ListLayouts()
+	if err != nil {
+		log.Printf("[ListLayouts] error: %v", err)
+		return []string{}
+	}
+	return names
+}
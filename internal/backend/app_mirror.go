@@ -0,0 +1,48 @@
+package backend
+
+import "log"
+
+// MirrorSession opens a read-only mirror of session sourceID and returns
+// its id, or -1 if the source is unknown, not running or itself a mirror.
+// The mirror shares the source's screen and follows its output without a
+// second process; input and resizes sent to it are ignored, and closing it
+// leaves the source running. Its output starts once the pane that shows it
+// calls AttachMirror.
+func (a *App) MirrorSession(sourceID int) int {
+	a.mu.Lock()
+	src := a.sessions[sourceID]
+	if src == nil || src.IsMirror() {
+		a.mu.Unlock()
+		return -1
+	}
+	a.nextID++
+	id := a.nextID
+	a.mu.Unlock()
+
+	m := src.Mirror(id)
+	if m == nil {
+		return -1
+	}
+	a.mu.Lock()
+	a.sessions[id] = m
+	a.pendingMirrors[id] = true
+	a.mu.Unlock()
+	log.Printf("[MirrorSession] session %d mirrors %d", id, sourceID)
+	return id
+}
+
+// AttachMirror starts streaming a mirror's output to the frontend. The
+// mirror's first event repaints the current screen, so the pane calls this
+// once its output listener is registered. Later calls do nothing.
+func (a *App) AttachMirror(id int) {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	pending := a.pendingMirrors[id]
+	delete(a.pendingMirrors, id)
+	a.mu.Unlock()
+	if sess == nil || !pending {
+		return
+	}
+	go a.streamOutput(id, sess, nil)
+	go a.watchExit(id, sess)
+}
@@ -0,0 +1,32 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/config"
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+func TestMirrorSession_Rejected(t *testing.T) {
+	app := NewApp(config.DefaultConfig(), "")
+	app.sessions[1] = terminal.NewSession(1, 5, 20) // never started
+
+	if id := app.MirrorSession(42); id != -1 {
+		t.Errorf("MirrorSession(unknown) = %d, want -1", id)
+	}
+	if id := app.MirrorSession(1); id != -1 {
+		t.Errorf("MirrorSession(not running) = %d, want -1", id)
+	}
+	if len(app.sessions) != 1 || len(app.pendingMirrors) != 0 {
+		t.Errorf("rejected mirror left state behind: %d sessions, %d pending", len(app.sessions), len(app.pendingMirrors))
+	}
+}
+
+func TestAttachMirror_IgnoresPlainSessions(t *testing.T) {
+	app := NewApp(config.DefaultConfig(), "")
+	app.sessions[1] = terminal.NewSession(1, 5, 20)
+
+	// Not a pending mirror: no stream may start (a.ctx is nil in tests)
+	app.AttachMirror(1)
+	app.AttachMirror(7)
+}
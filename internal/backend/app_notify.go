@@ -1,39 +1,59 @@
 package backend
 
 import (
+	"bufio"
+	"fmt"
 	"log"
 	"net"
+	"time"
 
 	"github.com/go-toast/toast"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
-const focusAddr = "127.0.0.1:41987"
-
 // SendNotification shows a native Windows toast notification with
 // "Multiterminal" as the application name. Clicking it brings the
-// window to the foreground via the multiterminal: custom protocol.
-func (a *App) SendNotification(title string, body string) {
+// window to the foreground via the multiterminal: custom protocol and,
+// when target is "session/<id>" or "issue/<n>", selects that pane.
+func (a *App) SendNotification(title string, body string, target string) {
 	n := toast.Notification{
 		AppID:               "Multiterminal",
 		Title:               title,
 		Message:             body,
 		ActivationType:      "protocol",
-		ActivationArguments: "multiterminal:focus",
+		ActivationArguments: focusURI(target),
 	}
 	if err := n.Push(); err != nil {
 		log.Printf("[SendNotification] failed: %v", err)
 	}
 }
 
+// ControlAddr is the localhost address of the focus listener for port
+// (control_port); a second instance dials it to hand over a notification
+// click.
+func ControlAddr(port int) string {
+	return fmt.Sprintf("127.0.0.1:%d", port)
+}
+
 // startFocusListener starts a TCP listener that brings the window to
 // the foreground when a signal is received (triggered by notification click).
+// A target sent along is passed to the frontend as "app:focus". Connections
+// that open with "{" are control API clients (see serveControl); the token
+// they must send is written to controlTokenPath once the port is ours.
 func (a *App) startFocusListener() {
-	ln, err := net.Listen("tcp", focusAddr)
+	addr := ControlAddr(a.currentConfig().ControlPort)
+	ln, err := net.Listen("tcp", addr)
 	if err != nil {
-		log.Printf("[focusListener] could not listen on %s: %v", focusAddr, err)
+		log.Printf("[focusListener] could not listen on %s: %v", addr, err)
 		return
 	}
+	if path := controlTokenPath(); path != "" {
+		if token, err := writeControlToken(path); err != nil {
+			log.Printf("[focusListener] control API off, writing %s: %v", path, err)
+		} else {
+			a.controlToken = token
+		}
+	}
 	go func() {
 		defer ln.Close()
 		for {
@@ -41,13 +61,33 @@ func (a *App) startFocusListener() {
 			if err != nil {
 				return
 			}
-			conn.Close()
-			if runtime.WindowIsMinimised(a.ctx) {
-				runtime.WindowUnminimise(a.ctx)
-			}
-			runtime.WindowShow(a.ctx)
-			runtime.WindowSetAlwaysOnTop(a.ctx, true)
-			runtime.WindowSetAlwaysOnTop(a.ctx, false)
+			go a.serveConn(conn)
 		}
 	}()
 }
+
+// serveConn tells a control API client from a focus signal by the first
+// byte and handles it. It runs per connection, so a client that connects
+// and stays silent holds up no one else.
+func (a *App) serveConn(conn net.Conn) {
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	r := bufio.NewReader(conn)
+	if first, err := r.Peek(1); err == nil && first[0] == '{' {
+		a.serveControl(conn, r)
+		return
+	}
+	target := readFocusTarget(r)
+	conn.Close()
+	if a.ctx == nil {
+		return
+	}
+	if runtime.WindowIsMinimised(a.ctx) {
+		runtime.WindowUnminimise(a.ctx)
+	}
+	runtime.WindowShow(a.ctx)
+	runtime.WindowSetAlwaysOnTop(a.ctx, true)
+	runtime.WindowSetAlwaysOnTop(a.ctx, false)
+	if target.Kind != "" {
+		runtime.EventsEmit(a.ctx, "app:focus", target)
+	}
+}
@@ -0,0 +1,82 @@
+package backend
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+// pipedInputMaxWait is longer than startupMaxWait: Claude takes a few
+// seconds before its prompt accepts input.
+const pipedInputMaxWait = 15 * time.Second
+
+// takePipedInput returns the text piped into `mtui --stdin` the first time
+// it is called and "" afterwards.
+func (a *App) takePipedInput() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	text := a.pipedInput
+	a.pipedInput = ""
+	return text
+}
+
+// prefillPipedInput types the piped text into the first pane the user
+// opens once its program is ready. Enter is not pressed, so a Claude prompt or shell
+// command can be reviewed before it is sent.
+func (a *App) prefillPipedInput(id int, sess *terminal.Session) {
+	text := a.takePipedInput()
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+	go func() {
+		if !sess.WaitIdle(startupQuietPeriod, pipedInputMaxWait) {
+			log.Printf("[pipedInput] session %d exited before the piped input was written", id)
+			return
+		}
+		log.Printf("[pipedInput] session %d: %d bytes", id, len(text))
+		writeInput(sess, pipedPayload(text, sess.Screen.BracketedPaste()))
+	}()
+}
+
+// lineJoiner turns line breaks and tabs into spaces.
+var lineJoiner = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ", "\t", " ")
+
+// pipedPayload drops trailing line breaks, so the text is not submitted,
+// and wraps it in paste markers when the program enabled bracketed paste;
+// multi-line text then stays one prompt instead of one command per line.
+// Paste markers inside the text are removed first, so it cannot end the
+// paste early and run the rest, like planPaste in the frontend. Without
+// bracketed paste every line break would act as Enter, so the lines are
+// joined with spaces into a single line and control bytes (ESC, Ctrl+C …)
+// are dropped.
+func pipedPayload(text string, bracketed bool) []byte {
+	text = strings.TrimRight(stripPasteMarkers(text), "\r\n")
+	if !bracketed {
+		return []byte(dropControls(lineJoiner.Replace(text)))
+	}
+	return []byte("\x1b[200~" + text + "\x1b[201~")
+}
+
+// stripPasteMarkers removes bracketed paste start and end markers,
+// including ones that only form once an inner marker is removed.
+func stripPasteMarkers(text string) string {
+	for {
+		out := strings.ReplaceAll(strings.ReplaceAll(text, "\x1b[200~", ""), "\x1b[201~", "")
+		if out == text {
+			return out
+		}
+		text = out
+	}
+}
+
+// dropControls removes C0 and C1 control characters and DEL.
+func dropControls(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || (r >= 0x7f && r <= 0x9f) {
+			return -1
+		}
+		return r
+	}, text)
+}
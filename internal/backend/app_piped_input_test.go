@@ -0,0 +1,37 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/config"
+)
+
+func TestTakePipedInput_Once(t *testing.T) {
+	a := NewApp(config.DefaultConfig(), "explain this diff\n")
+	if got := a.takePipedInput(); got != "explain this diff\n" {
+		t.Fatalf("first take = %q", got)
+	}
+	if got := a.takePipedInput(); got != "" {
+		t.Errorf("second take = %q, want the input used only once", got)
+	}
+}
+
+func TestPipedPayload(t *testing.T) {
+	tests := []struct {
+		text      string
+		bracketed bool
+		want      string
+	}{
+		{"ls -la\n", false, "ls -la"},
+		{"git status\r\nrm -rf build\n", false, "git status rm -rf build"},
+		{"line one\nline two\r\n\n", true, "\x1b[200~line one\nline two\x1b[201~"},
+		{"a\x1b[201~rm -rf ~\r\n", true, "\x1b[200~arm -rf ~\x1b[201~"},
+		{"a\x1b[20\x1b[201~1~b", true, "\x1b[200~ab\x1b[201~"},
+		{"echo\thi\x1b[201~\x03\x1b[31m\x7f\n", false, "echo hi[31m"},
+	}
+	for _, tt := range tests {
+		if got := string(pipedPayload(tt.text, tt.bracketed)); got != tt.want {
+			t.Errorf("pipedPayload(%q, %v) = %q, want %q", tt.text, tt.bracketed, got, tt.want)
+		}
+	}
+}
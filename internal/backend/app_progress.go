@@ -0,0 +1,37 @@
+package backend
+
+import (
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ProgressInfo is sent to the frontend when a pane's OSC 9;4 progress
+// report changes.
+type ProgressInfo struct {
+	ID      int    `json:"id"`
+	State   string `json:"state"`   // "none", "normal", "error", "indeterminate", "paused"
+	Percent int    `json:"percent"` // 0-100; meaningful for normal, error and paused
+}
+
+// prevProgress tracks the last emitted progress per session, guarded by
+// prevActivityMu.
+var prevProgress = make(map[int]ProgressInfo)
+
+// emitProgressChange sends terminal:progress if the session's progress
+// report changed since the last emit.
+func (a *App) emitProgressChange(id int, sess *terminal.Session) {
+	state, pct := sess.Screen.Progress()
+	info := ProgressInfo{ID: id, State: state.String(), Percent: pct}
+
+	prevActivityMu.Lock()
+	prev, seen := prevProgress[id]
+	changed := prev != info && (seen || state != terminal.ProgressNone)
+	if changed {
+		prevProgress[id] = info
+	}
+	prevActivityMu.Unlock()
+
+	if changed {
+		runtime.EventsEmit(a.ctx, "terminal:progress", info)
+	}
+}
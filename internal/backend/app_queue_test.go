@@ -3,14 +3,18 @@ package backend
 import (
 	"testing"
 
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/config"
 	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
 )
 
 func newTestApp() *App {
 	return &App{
-		sessions:      make(map[int]*terminal.Session),
-		queues:        make(map[int]*sessionQueue),
-		sessionIssues: make(map[int]*sessionIssue),
+		cfg:            config.DefaultConfig(),
+		sessions:       make(map[int]*terminal.Session),
+		queues:         make(map[int]*sessionQueue),
+		sessionIssues:  make(map[int]*sessionIssue),
+		pinnedSessions: make(map[int]bool),
+		idleWarned:     make(map[int]bool),
 	}
 }
 
@@ -0,0 +1,33 @@
+package backend
+
+import "log"
+
+// inputWriter is the part of a session that user input goes to.
+type inputWriter interface {
+	ReadOnly() bool
+	Write(p []byte) (int, error)
+}
+
+// SetSessionReadOnly locks (ro = true) or unlocks a pane against typed
+// input, so stray keystrokes cannot reach a session under review. The
+// frontend keeps handling its own shortcuts, including the unlock key.
+func (a *App) SetSessionReadOnly(id int, ro bool) {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	a.mu.Unlock()
+	if sess == nil {
+		return
+	}
+	sess.SetReadOnly(ro)
+	log.Printf("[SetSessionReadOnly] session %d read-only=%v", id, ro)
+}
+
+// writeInput writes user input to w unless it is locked. It reports
+// whether the input was written.
+func writeInput(w inputWriter, data []byte) bool {
+	if w.ReadOnly() {
+		return false
+	}
+	w.Write(data)
+	return true
+}
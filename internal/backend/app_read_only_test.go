@@ -0,0 +1,55 @@
+package backend
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+// fakeInput records writes and reports a fixed lock state.
+type fakeInput struct {
+	locked  bool
+	written []string
+}
+
+func (f *fakeInput) ReadOnly() bool { return f.locked }
+
+func (f *fakeInput) Write(p []byte) (int, error) {
+	f.written = append(f.written, string(p))
+	return len(p), nil
+}
+
+func TestWriteInput_DroppedWhileLocked(t *testing.T) {
+	f := &fakeInput{locked: true}
+	if writeInput(f, []byte("rm -rf build\r")) {
+		t.Error("writeInput reported a write to a locked pane")
+	}
+	if len(f.written) != 0 {
+		t.Fatalf("locked pane received %q", f.written)
+	}
+
+	f.locked = false
+	if !writeInput(f, []byte("ls\r")) || len(f.written) != 1 || f.written[0] != "ls\r" {
+		t.Errorf("unlocked pane received %q, want [\"ls\\r\"]", f.written)
+	}
+}
+
+func TestSetSessionReadOnly(t *testing.T) {
+	a := newTestApp()
+	sess := terminal.NewSession(1, 5, 20)
+	a.sessions[1] = sess
+
+	a.SetSessionReadOnly(1, true)
+	if !sess.ReadOnly() {
+		t.Fatal("session should be read-only")
+	}
+	// Dropped before reaching the (unstarted) process; must not panic
+	a.WriteToSession(1, base64.StdEncoding.EncodeToString([]byte("x")))
+
+	a.SetSessionReadOnly(1, false)
+	if sess.ReadOnly() {
+		t.Error("session should be writable again")
+	}
+	a.SetSessionReadOnly(99, true) // unknown session: no-op
+}
@@ -0,0 +1,82 @@
+package backend
+
+import (
+	"time"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+// resizeSettle is how long a pane's size has to stay put while the window
+// or a split is dragged before the last size reaches the PTY. Each resize
+// makes the program redraw; applying every intermediate size lets those
+// redraws overlap and garble the pane.
+const resizeSettle = 60 * time.Millisecond
+
+// pendingResize tracks a burst of ResizeSession calls for one session.
+type pendingResize struct {
+	rows, cols int
+	dirty      bool // a size arrived after the one last applied
+	timer      *time.Timer
+}
+
+// ResizeSession updates the PTY and screen buffer dimensions. The first
+// size of a burst applies at once, so new panes and single resizes are not
+// delayed; later ones are merged and the last is applied once no further
+// size arrived for resizeSettle.
+func (a *App) ResizeSession(id int, rows int, cols int) {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	if sess == nil {
+		a.mu.Unlock()
+		return
+	}
+	if p := a.resizes[id]; p != nil {
+		p.rows, p.cols, p.dirty = rows, cols, true
+		p.timer.Reset(resizeSettle)
+		a.mu.Unlock()
+		return
+	}
+	if a.resizes == nil {
+		a.resizes = make(map[int]*pendingResize)
+	}
+	p := &pendingResize{}
+	p.timer = time.AfterFunc(resizeSettle, func() { a.settleResize(id, p) })
+	a.resizes[id] = p
+	a.mu.Unlock()
+	a.applyResize(sess, rows, cols)
+}
+
+// settleResize ends the burst p and applies its last size if one arrived
+// after the first. A timer reset just as it fired may run twice; the
+// second run finds p gone and does nothing.
+func (a *App) settleResize(id int, p *pendingResize) {
+	a.mu.Lock()
+	if a.resizes[id] != p {
+		a.mu.Unlock()
+		return
+	}
+	delete(a.resizes, id)
+	sess := a.sessions[id]
+	rows, cols, dirty := p.rows, p.cols, p.dirty
+	a.mu.Unlock()
+	if dirty && sess != nil {
+		a.applyResize(sess, rows, cols)
+	}
+}
+
+func (a *App) applyResize(sess *terminal.Session, rows, cols int) {
+	sess.Screen.SetReflow(a.currentConfig().ShouldReflowOnResize())
+	sess.Resize(rows, cols)
+}
+
+// SetCellSize reports the pixel size of one cell as the pane renders it
+// (font and zoom), so programs asking for pixel geometry get real values.
+// It is not debounced: an unchanged size costs nothing.
+func (a *App) SetCellSize(id int, width int, height int) {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	a.mu.Unlock()
+	if sess != nil {
+		sess.SetCellPixels(width, height)
+	}
+}
@@ -0,0 +1,77 @@
+package backend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+func screenSize(sess *terminal.Session) [2]int {
+	return [2]int{sess.Screen.Rows(), sess.Screen.Cols()}
+}
+
+func TestResizeSession_FirstSizeImmediate(t *testing.T) {
+	a := newTestApp()
+	sess := terminal.NewSession(1, 24, 80)
+	a.sessions[1] = sess
+
+	a.ResizeSession(1, 10, 30)
+	if got := screenSize(sess); got != [2]int{10, 30} {
+		t.Errorf("size = %v, want [10 30] without waiting", got)
+	}
+}
+
+func TestResizeSession_BurstAppliesLastSize(t *testing.T) {
+	a := newTestApp()
+	sess := terminal.NewSession(1, 24, 80)
+	a.sessions[1] = sess
+
+	a.ResizeSession(1, 10, 30)
+	for i := 0; i < 20; i++ {
+		a.ResizeSession(1, 11+i, 31+i)
+	}
+	if got := screenSize(sess); got != [2]int{10, 30} {
+		t.Errorf("size during burst = %v, want first size [10 30]", got)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for screenSize(sess) != [2]int{30, 50} {
+		if time.Now().After(deadline) {
+			t.Fatalf("size = %v, want last size [30 50] after settling", screenSize(sess))
+		}
+		time.Sleep(resizeSettle / 4)
+	}
+
+	// The burst is over: the next size applies at once again
+	a.mu.Lock()
+	pending := len(a.resizes)
+	a.mu.Unlock()
+	if pending != 0 {
+		t.Fatalf("%d bursts still pending after settling", pending)
+	}
+	a.ResizeSession(1, 12, 40)
+	if got := screenSize(sess); got != [2]int{12, 40} {
+		t.Errorf("size = %v, want [12 40]", got)
+	}
+}
+
+func TestResizeSession_UnknownSession(t *testing.T) {
+	a := newTestApp()
+	a.ResizeSession(99, 10, 30) // must not panic or leave a pending burst
+	if len(a.resizes) != 0 {
+		t.Errorf("pending bursts = %d, want 0", len(a.resizes))
+	}
+}
+
+func TestSetCellSize(t *testing.T) {
+	a := newTestApp()
+	sess := terminal.NewSession(1, 24, 80)
+	a.sessions[1] = sess
+
+	a.SetCellSize(1, 9, 18)
+	if w, h, ok := sess.Screen.CellPixels(); !ok || w != 9 || h != 18 {
+		t.Errorf("cell pixels = %dx%d (known %v), want 9x18", w, h, ok)
+	}
+	a.SetCellSize(99, 9, 18) // unknown session: no-op
+}
@@ -0,0 +1,30 @@
+package backend
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// RestartSession respawns the process of an exited session in place, reusing
+// its argv, working directory and environment. The screen is cleared and the
+// frontend is notified via "terminal:restarted" so it can reset its terminal.
+func (a *App) RestartSession(id int) error {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	a.mu.Unlock()
+	if sess == nil {
+		return fmt.Errorf("session %d not found", id)
+	}
+
+	if err := sess.Restart(); err != nil {
+		log.Printf("[RestartSession] id=%d: %v", id, err)
+		return fmt.Errorf("restart failed: %w", err)
+	}
+	log.Printf("[RestartSession] session %d restarted", id)
+
+	runtime.EventsEmit(a.ctx, "terminal:restarted", id)
+	a.startStreaming(id, sess, sess.Argv())
+	return nil
+}
@@ -14,7 +14,10 @@ import (
 // ActivityInfo is sent to the frontend when a session's activity state changes.
 type ActivityInfo struct {
 	ID       int    `json:"id"`
-	Activity string `json:"activity"` // "idle", "active", "done", "needsInput"
+	Activity string `json:"activity"` // "idle", "active", "done", "needsInput", "passwordInput"
+	Previous string `json:"previous"` // activity of the last event (same as Activity if only the cost changed); "" at first
+	Reason   string `json:"reason"`   // prompt (done) or question (needsInput, passwordInput) seen on screen
+	Result   string `json:"result"`   // "pass" or "fail" when a finished command's output shows it; "" otherwise
 	Cost     string `json:"cost"`
 }
 
@@ -25,41 +28,73 @@ var (
 	prevCost       = make(map[int]string)
 )
 
-// scanInterval returns the scan tick duration based on the number of active sessions.
-// More sessions → slower ticks to reduce overhead.
-func (a *App) scanInterval() time.Duration {
+// scanBusyWindow is how long after a session's last output the scan loop
+// keeps its fast interval. It outlasts the quiet period DetectActivity
+// waits for before it reports a session as done.
+const scanBusyWindow = 5 * time.Second
+
+// scanBounds returns the fast and idle scan intervals from the config
+// (scan_interval_min_ms / scan_interval_max_ms).
+func (a *App) scanBounds() (fast, idle time.Duration) {
+	cfg := a.currentConfig()
+	fast = time.Duration(cfg.ScanIntervalMinMs) * time.Millisecond
+	idle = time.Duration(cfg.ScanIntervalMaxMs) * time.Millisecond
+	if fast <= 0 {
+		fast = 200 * time.Millisecond
+	}
+	return fast, max(idle, fast)
+}
+
+// scanInterval returns the fast interval while any session produced output
+// within scanBusyWindow and the idle interval otherwise, so many quiet
+// panes cost almost nothing. busy reports which one was chosen.
+func (a *App) scanInterval(now time.Time) (d time.Duration, busy bool) {
+	fast, idle := a.scanBounds()
 	a.mu.Lock()
-	n := len(a.sessions)
+	sessions := make([]*terminal.Session, 0, len(a.sessions))
+	for _, s := range a.sessions {
+		sessions = append(sessions, s)
+	}
 	a.mu.Unlock()
-	switch {
-	case n <= 3:
-		return 500 * time.Millisecond
-	case n <= 6:
-		return 600 * time.Millisecond
+	for _, s := range sessions {
+		if now.Sub(s.LastOutput()) < scanBusyWindow {
+			return fast, true
+		}
+	}
+	return idle, false
+}
+
+// wakeScan tells a backed-off scan loop that output arrived, so activity
+// is picked up without waiting out the idle interval. It never blocks.
+func (a *App) wakeScan() {
+	select {
+	case a.scanWake <- struct{}{}:
 	default:
-		return 750 * time.Millisecond
 	}
 }
 
-// scanLoop periodically scans all sessions for activity changes and token info.
-// The interval adapts to the number of active sessions.
+// scanLoop periodically scans all sessions for activity changes and token
+// info, at the fast interval while sessions are producing output and at
+// the idle interval once they are all quiet.
 func (a *App) scanLoop(ctx context.Context) {
-	interval := a.scanInterval()
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
+	interval, busy := a.scanInterval(time.Now())
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-ticker.C:
-			a.scanAllSessions()
-			// Re-check if interval should change
-			if newInterval := a.scanInterval(); newInterval != interval {
-				interval = newInterval
-				ticker.Reset(interval)
+		case <-a.scanWake:
+			if busy {
+				continue // the next fast tick comes soon enough
 			}
+			timer.Stop()
+		case <-timer.C:
 		}
+		a.scanAllSessions()
+		interval, busy = a.scanInterval(time.Now())
+		timer.Reset(interval)
 	}
 }
 
@@ -71,6 +106,8 @@ func activityString(a terminal.ActivityState) string {
 		return "done"
 	case terminal.ActivityNeedsInput:
 		return "needsInput"
+	case terminal.ActivityPasswordInput:
+		return "passwordInput"
 	default:
 		return "idle"
 	}
@@ -81,6 +118,8 @@ func cleanupActivityTracking(id int) {
 	prevActivityMu.Lock()
 	delete(prevActivity, id)
 	delete(prevCost, id)
+	delete(prevTitle, id)
+	delete(prevProgress, id)
 	prevActivityMu.Unlock()
 }
 
@@ -97,6 +136,9 @@ func (a *App) scanAllSessions() {
 
 	for i, sess := range sessions {
 		id := ids[i]
+		if sess.IsMirror() {
+			continue // the source reports activity, cost and title
+		}
 		sess.ScanTokens()
 		activity := sess.DetectActivity()
 		actStr := activityString(activity)
@@ -109,7 +151,8 @@ func (a *App) scanAllSessions() {
 
 		// Only emit when state or cost actually changed
 		prevActivityMu.Lock()
-		activityChanged := prevActivity[id] != actStr
+		previous := prevActivity[id]
+		activityChanged := previous != actStr
 		costChanged := prevCost[id] != costStr
 		changed := activityChanged || costChanged
 		if changed {
@@ -118,15 +161,31 @@ func (a *App) scanAllSessions() {
 		}
 		prevActivityMu.Unlock()
 
+		result := ""
+		if activityChanged && actStr == "done" {
+			result = a.scanResult(sess)
+		}
+
 		if changed {
 			log.Printf("[scan] session %d: activity=%s cost=%s", id, actStr, costStr)
 			runtime.EventsEmit(a.ctx, "terminal:activity", ActivityInfo{
 				ID:       id,
 				Activity: actStr,
+				Previous: previous,
+				Reason:   sess.ActivityLine(),
+				Result:   result,
 				Cost:     costStr,
 			})
 		}
 
+		a.emitTitleChange(id, sess)
+		a.emitProgressChange(id, sess)
+
+		// Answer trusted confirmation prompts in YOLO panes
+		if activityChanged && actStr == "needsInput" && !sess.ReadOnly() {
+			a.autoApprove(id, sess)
+		}
+
 		// Trigger pipeline queue on fresh "done" transition
 		if activityChanged && actStr == "done" {
 			a.processQueue(id)
@@ -137,6 +196,9 @@ func (a *App) scanAllSessions() {
 			a.onActivityChangeForIssue(id, actStr, costStr)
 		}
 	}
+	a.sampleFocusedUsage(time.Now())
+	a.closeIdleShells(a.idleTimeout())
+	a.enforceScrollbackBudget()
 }
 
 // onActivityChangeForIssue triggers issue progress reports when
@@ -146,3 +208,14 @@ func (a *App) onActivityChangeForIssue(sessionID int, newActivity string, cost s
 		a.reportIssueProgress(sessionID, progressDone, cost)
 	}
 }
+
+// scanResult classifies the output of the command that just finished in
+// sess as "pass" or "fail" using the configured result patterns.
+func (a *App) scanResult(sess *terminal.Session) string {
+	p := a.currentConfig().ResultPatterns
+	r := sess.ScanResult(terminal.NewResultPatterns(p.Pass, p.Fail))
+	if r == terminal.ResultUnknown {
+		return ""
+	}
+	return r.String()
+}
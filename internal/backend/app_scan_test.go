@@ -2,7 +2,9 @@ package backend
 
 import (
 	"testing"
+	"time"
 
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/config"
 	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
 )
 
@@ -11,6 +13,7 @@ import (
 // These strings drive the CSS classes for pane border colors:
 //   "done"       → green glow (Claude finished)
 //   "needsInput" → yellow pulse (needs user confirmation)
+//   "passwordInput" → red pulse + lock (sudo/ssh password prompt)
 //   "active"     → normal active state
 //   "idle"       → no special styling
 // ---------------------------------------------------------------------------
@@ -24,6 +27,7 @@ func TestActivityString_AllStates(t *testing.T) {
 		{terminal.ActivityActive, "active"},
 		{terminal.ActivityDone, "done"},
 		{terminal.ActivityNeedsInput, "needsInput"},
+		{terminal.ActivityPasswordInput, "passwordInput"},
 	}
 	for _, tt := range tests {
 		got := activityString(tt.state)
@@ -40,3 +44,43 @@ func TestActivityString_UnknownState(t *testing.T) {
 		t.Errorf("activityString(99) = %q, want 'idle'", got)
 	}
 }
+
+// ---------------------------------------------------------------------------
+// scanInterval — fast while panes produce output, backed off when quiet
+// ---------------------------------------------------------------------------
+
+func TestScanInterval_BusyAndIdle(t *testing.T) {
+	app := NewApp(config.DefaultConfig(), "")
+	sess := terminal.NewSession(1, 5, 20)
+	app.sessions[1] = sess
+	now := time.Now()
+
+	sess.LastOutputAt = now.Add(-time.Second)
+	if d, busy := app.scanInterval(now); !busy || d != 200*time.Millisecond {
+		t.Errorf("recent output: interval = %v (busy %v), want 200ms", d, busy)
+	}
+
+	sess.LastOutputAt = now.Add(-time.Minute)
+	if d, busy := app.scanInterval(now); busy || d != 2*time.Second {
+		t.Errorf("quiet sessions: interval = %v (busy %v), want 2s", d, busy)
+	}
+}
+
+func TestScanInterval_NoSessionsIsIdle(t *testing.T) {
+	app := NewApp(config.DefaultConfig(), "")
+	app.cfg.ScanIntervalMaxMs = 3000
+	if d, busy := app.scanInterval(time.Now()); busy || d != 3*time.Second {
+		t.Errorf("interval = %v (busy %v), want the configured 3s", d, busy)
+	}
+}
+
+func TestWakeScan_NeverBlocks(t *testing.T) {
+	app := NewApp(config.DefaultConfig(), "")
+	app.wakeScan()
+	app.wakeScan() // buffer already full: must not block
+	select {
+	case <-app.scanWake:
+	default:
+		t.Fatal("wakeScan did not signal the scan loop")
+	}
+}
@@ -0,0 +1,14 @@
+package backend
+
+// CreateSessionWithHistory is CreateSession for a restored pane: the saved
+// scrollback text (may be "") is written into the new screen before the
+// process starts, so the prompt appears below it just as it does in the
+// frontend, which writes the same text into xterm.js. The text is
+// display-only and never sent to the process. Restored panes do not take
+// the `mtui --stdin` text.
+func (a *App) CreateSessionWithHistory(argv []string, dir string, rows int, cols int, env map[string]string, history string) int {
+	if !a.currentConfig().RestoreScrollback {
+		history = ""
+	}
+	return a.createSession(argv, dir, rows, cols, env, history, false)
+}
@@ -0,0 +1,63 @@
+package backend
+
+import (
+	"log"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+// ScrollbackStats is the approximate memory the scrollback of all panes
+// takes, and what scrollback_memory_budget_mb dropped to limit it.
+type ScrollbackStats struct {
+	Rows        int `json:"rows"`
+	Bytes       int `json:"bytes"`
+	BudgetBytes int `json:"budget_bytes"` // 0 = unlimited
+	EvictedRows int `json:"evicted_rows"` // dropped since the app started
+}
+
+// GetScrollbackStats returns the current scrollback memory of all panes.
+func (a *App) GetScrollbackStats() ScrollbackStats {
+	usage := terminal.MeasureScrollback(a.sessionList())
+	a.mu.Lock()
+	evicted := a.scrollbackEvicted
+	a.mu.Unlock()
+	return ScrollbackStats{
+		Rows:        usage.Rows,
+		Bytes:       usage.Bytes,
+		BudgetBytes: a.scrollbackBudget(),
+		EvictedRows: evicted,
+	}
+}
+
+// scrollbackBudget returns scrollback_memory_budget_mb in bytes; 0 = off.
+func (a *App) scrollbackBudget() int {
+	return a.currentConfig().ScrollbackMemoryBudgetMB << 20
+}
+
+// enforceScrollbackBudget drops the oldest scrollback of the least recently
+// active panes while all scrollback together exceeds the budget.
+func (a *App) enforceScrollbackBudget() {
+	budget := a.scrollbackBudget()
+	if budget <= 0 {
+		return
+	}
+	usage := terminal.EnforceScrollbackBudget(a.sessionList(), budget)
+	if usage.Evicted == 0 {
+		return
+	}
+	a.mu.Lock()
+	a.scrollbackEvicted += usage.Evicted
+	a.mu.Unlock()
+	log.Printf("[scrollback] dropped %d rows to stay within %d MB (%d KB kept)", usage.Evicted, budget>>20, usage.Bytes>>10)
+}
+
+// sessionList returns the open sessions in no particular order.
+func (a *App) sessionList() []*terminal.Session {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	list := make([]*terminal.Session, 0, len(a.sessions))
+	for _, s := range a.sessions {
+		list = append(list, s)
+	}
+	return list
+}
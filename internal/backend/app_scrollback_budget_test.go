@@ -0,0 +1,34 @@
+package backend
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+func TestEnforceScrollbackBudget(t *testing.T) {
+	a := newTestApp()
+	line := strings.Repeat("x", 79) + "\r\n"
+	for id := 1; id <= 2; id++ {
+		sess := terminal.NewSession(id, 3, 80)
+		sess.Screen.Write([]byte(strings.Repeat(line, terminal.ScrollbackRows+2)))
+		sess.LastOutputAt = time.Now().Add(time.Duration(id) * time.Minute)
+		a.sessions[id] = sess
+	}
+	full := a.GetScrollbackStats()
+	if full.Rows != 2*terminal.ScrollbackRows || full.BudgetBytes != 0 {
+		t.Fatalf("stats before = %+v", full)
+	}
+
+	a.cfg.ScrollbackMemoryBudgetMB = full.Bytes*3/4>>20 + 1
+	a.enforceScrollbackBudget()
+	stats := a.GetScrollbackStats()
+	if stats.Bytes > stats.BudgetBytes || stats.EvictedRows == 0 || stats.Rows != full.Rows-stats.EvictedRows {
+		t.Errorf("stats after = %+v, want within the budget", stats)
+	}
+	if rows, _ := a.sessions[2].Screen.ScrollbackUsage(); rows != terminal.ScrollbackRows {
+		t.Errorf("the more recently active pane lost rows (%d left)", rows)
+	}
+}
@@ -0,0 +1,19 @@
+package backend
+
+// GetSessionDir returns the current working directory of a session, so the
+// footer and git branch follow `cd` in a pane. A directory reported by the
+// shell via OSC 7 wins (works on every platform); otherwise the process cwd
+// is looked up, falling back to the session's start directory. It returns
+// an empty string for unknown sessions or sessions started without a dir.
+func (a *App) GetSessionDir(id int) string {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	a.mu.Unlock()
+	if sess == nil {
+		return ""
+	}
+	if dir := sess.ReportedDir(); dir != "" {
+		return dir
+	}
+	return sess.CurrentDir()
+}
@@ -0,0 +1,31 @@
+package backend
+
+import "sort"
+
+// envList converts per-pane environment overrides into sorted "KEY=value"
+// entries for terminal.Session.Start. Entries with an empty name are
+// dropped; values are never logged since they may hold secrets.
+func envList(env map[string]string) []string {
+	if len(env) == 0 {
+		return nil
+	}
+	list := make([]string, 0, len(env))
+	for k, v := range env {
+		if k != "" {
+			list = append(list, k+"="+v)
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+// sessionEnv returns the environment entries a new pane starts with:
+// NO_COLOR=1 when panes are monochrome, so programs emit less colour, then
+// the per-pane overrides, which win.
+func sessionEnv(monochrome bool, env map[string]string) []string {
+	list := envList(env)
+	if monochrome {
+		list = append([]string{"NO_COLOR=1"}, list...)
+	}
+	return list
+}
@@ -0,0 +1,25 @@
+package backend
+
+import "testing"
+
+func TestEnvList(t *testing.T) {
+	if got := envList(nil); got != nil {
+		t.Errorf("envList(nil) = %v, want nil", got)
+	}
+	got := envList(map[string]string{"NO_COLOR": "1", "ANTHROPIC_API_KEY": "sk=x", "": "skip"})
+	want := []string{"ANTHROPIC_API_KEY=sk=x", "NO_COLOR=1"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("envList = %v, want %v", got, want)
+	}
+}
+
+func TestSessionEnv_Monochrome(t *testing.T) {
+	if got := sessionEnv(false, nil); got != nil {
+		t.Errorf("sessionEnv(false, nil) = %v, want nil", got)
+	}
+	got := sessionEnv(true, map[string]string{"NO_COLOR": ""})
+	want := []string{"NO_COLOR=1", "NO_COLOR="}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("sessionEnv = %v, want %v (the pane's override last)", got, want)
+	}
+}
@@ -0,0 +1,17 @@
+package backend
+
+// SetSessionFocus tells a session's process that its pane gained or lost
+// focus. Programs that enabled focus reporting (CSI ? 1004 h, e.g. vim or
+// Claude Code) receive CSI I / CSI O; for all others this is a no-op.
+func (a *App) SetSessionFocus(id int, focused bool) {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	if focused && sess != nil {
+		a.focusedSession = id
+	}
+	a.mu.Unlock()
+	if sess == nil {
+		return
+	}
+	sess.ReportFocus(focused)
+}
@@ -0,0 +1,25 @@
+package backend
+
+import "github.com/wailsapp/wails/v2/pkg/runtime"
+
+// processCount returns how many sessions own a process, i.e. all sessions
+// except mirrors. max_sessions limits this number, since every process
+// holds a PTY and its file descriptors. The caller holds a.mu.
+func (a *App) processCount() int {
+	n := 0
+	for _, s := range a.sessions {
+		if !s.IsMirror() {
+			n++
+		}
+	}
+	return n
+}
+
+// emitSessionError reports a failed session start to the frontend, which
+// shows it as a terminal:error alert. id is 0 when no id was assigned.
+func (a *App) emitSessionError(id int, msg string) {
+	if a.ctx == nil {
+		return
+	}
+	runtime.EventsEmit(a.ctx, "terminal:error", id, msg)
+}
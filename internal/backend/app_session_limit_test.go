@@ -0,0 +1,40 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/config"
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+func limitedApp(max, open int) *App {
+	cfg := config.DefaultConfig()
+	cfg.MaxSessions = max
+	app := NewApp(cfg, "")
+	for id := 1; id <= open; id++ {
+		app.sessions[id] = terminal.NewSession(id, 5, 20)
+		app.nextID = id
+	}
+	return app
+}
+
+func TestCreateSession_RejectsBeyondMaxSessions(t *testing.T) {
+	app := limitedApp(3, 3)
+
+	if id := app.CreateSession(nil, "", 24, 80, nil); id != -1 {
+		t.Fatalf("CreateSession with %d of 3 open = %d, want -1", len(app.sessions), id)
+	}
+	if app.nextID != 3 || app.starting != 0 || len(app.sessions) != 3 {
+		t.Errorf("rejected session changed state: nextID=%d starting=%d sessions=%d",
+			app.nextID, app.starting, len(app.sessions))
+	}
+}
+
+func TestCreateSession_CountsSessionsStillStarting(t *testing.T) {
+	app := limitedApp(2, 1)
+	app.starting = 1 // a concurrent CreateSession holds the last slot
+
+	if id := app.CreateSessionWithHistory(nil, "", 24, 80, nil, ""); id != -1 {
+		t.Errorf("CreateSessionWithHistory = %d, want -1 while the last slot is taken", id)
+	}
+}
@@ -0,0 +1,23 @@
+package backend
+
+// SessionStats is how long a session has been running and how long it
+// has produced no output, for time tracking in the footer.
+type SessionStats struct {
+	UptimeSeconds int64 `json:"uptime_seconds"`
+	IdleSeconds   int64 `json:"idle_seconds"`
+}
+
+// GetSessionStats returns the uptime and idle time of a session; zero for
+// unknown ids and mirrors.
+func (a *App) GetSessionStats(id int) SessionStats {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	a.mu.Unlock()
+	if sess == nil {
+		return SessionStats{}
+	}
+	return SessionStats{
+		UptimeSeconds: int64(sess.Uptime().Seconds()),
+		IdleSeconds:   int64(sess.IdleFor().Seconds()),
+	}
+}
@@ -0,0 +1,30 @@
+package backend
+
+import (
+	"os"
+	"testing"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+func TestGetSessionStats_UnknownIsZero(t *testing.T) {
+	a := newTestApp()
+	if s := a.GetSessionStats(42); s != (SessionStats{}) {
+		t.Fatalf("unknown session stats = %+v", s)
+	}
+}
+
+func TestGetSessionStats_RunningSession(t *testing.T) {
+	a := newTestApp()
+	sess := terminal.NewSession(1, 24, 80)
+	if err := sess.Start([]string{"sh", "-c", "sleep 5"}, os.TempDir(), nil); err != nil {
+		t.Skipf("cannot start a PTY: %v", err)
+	}
+	defer sess.Close()
+	a.sessions[1] = sess
+
+	s := a.GetSessionStats(1)
+	if s.UptimeSeconds < 0 || s.UptimeSeconds > 2 || s.IdleSeconds > s.UptimeSeconds {
+		t.Errorf("stats of a fresh session = %+v", s)
+	}
+}
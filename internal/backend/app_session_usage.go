@@ -0,0 +1,35 @@
+package backend
+
+import "time"
+
+// SessionUsage is the CPU and memory use of a session's process tree.
+type SessionUsage struct {
+	CPUPercent float64 `json:"cpu_percent"` // share of one core; above 100 when several are busy
+	RSSBytes   int64   `json:"rss_bytes"`
+}
+
+// sampleFocusedUsage refreshes the resource usage of the focused pane's
+// session. The scan loop calls it once per tick; only the footer shows
+// usage, so the other sessions are not sampled.
+func (a *App) sampleFocusedUsage(now time.Time) {
+	a.mu.Lock()
+	sess := a.sessions[a.focusedSession]
+	a.mu.Unlock()
+	if sess != nil && !sess.IsMirror() {
+		sess.SampleUsage(now)
+	}
+}
+
+// GetSessionUsage returns the last sampled CPU and memory use of a
+// session. It is zero for unknown ids, mirrors, sessions that never had
+// focus, and on platforms without a process table.
+func (a *App) GetSessionUsage(id int) SessionUsage {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	a.mu.Unlock()
+	if sess == nil {
+		return SessionUsage{}
+	}
+	cpu, rss := sess.ResourceUsage()
+	return SessionUsage{CPUPercent: cpu, RSSBytes: rss}
+}
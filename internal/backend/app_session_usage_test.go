@@ -0,0 +1,47 @@
+package backend
+
+import (
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+func TestGetSessionUsage_UnknownIsZero(t *testing.T) {
+	a := newTestApp()
+	if u := a.GetSessionUsage(42); u != (SessionUsage{}) {
+		t.Fatalf("unknown session usage = %+v", u)
+	}
+}
+
+func TestSampleFocusedUsage_OnlyFocusedSession(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("reads /proc")
+	}
+	a := newTestApp()
+	for id := 1; id <= 2; id++ {
+		sess := terminal.NewSession(id, 24, 80)
+		if err := sess.Start([]string{"sh", "-c", "sleep 5"}, os.TempDir(), nil); err != nil {
+			t.Skipf("cannot start a PTY: %v", err)
+		}
+		defer sess.Close()
+		a.sessions[id] = sess
+	}
+	a.SetSessionFocus(2, true)
+	a.SetSessionFocus(2, false) // leaving the window keeps the pane sampled
+
+	now := time.Now()
+	for i := 0; i < 50 && a.GetSessionUsage(2).RSSBytes == 0; i++ {
+		now = now.Add(time.Second)
+		a.sampleFocusedUsage(now)
+		time.Sleep(10 * time.Millisecond)
+	}
+	if a.GetSessionUsage(2).RSSBytes == 0 {
+		t.Fatal("focused session was not sampled")
+	}
+	if u := a.GetSessionUsage(1); u != (SessionUsage{}) {
+		t.Fatalf("unfocused session was sampled: %+v", u)
+	}
+}
@@ -0,0 +1,134 @@
+package backend
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// sidebarPollInterval is how often the folders shown in the sidebar are
+// listed again. Like the config watcher, this polls instead of using OS
+// file notifications; a change is reported once a poll finds the folders
+// unchanged again, or after sidebarMaxDelay while they keep changing.
+const (
+	sidebarPollInterval = time.Second
+	sidebarMaxDelay     = 5 * time.Second
+)
+
+// maxWatchedDirs caps how many folders WatchSidebarDirs polls.
+const maxWatchedDirs = 200
+
+// WatchSidebarDirs sets the folders the sidebar shows: its roots and the
+// folders expanded below them. When files are added to or removed from one
+// of them, sidebar:changed is emitted with the changed folders so the tree
+// can be reloaded. An empty list stops watching (sidebar hidden).
+func (a *App) WatchSidebarDirs(dirs []string) {
+	seen := make(map[string]bool, len(dirs))
+	list := make([]string, 0, min(len(dirs), maxWatchedDirs))
+	for _, d := range dirs {
+		if d == "" || seen[d] || len(list) == maxWatchedDirs {
+			continue
+		}
+		seen[d] = true
+		list = append(list, d)
+	}
+	a.mu.Lock()
+	a.sidebarDirs = list
+	a.mu.Unlock()
+}
+
+// watchSidebar polls the folders set by WatchSidebarDirs while
+// sidebar_watch is on.
+func (a *App) watchSidebar(ctx context.Context) {
+	ticker := time.NewTicker(sidebarPollInterval)
+	defer ticker.Stop()
+
+	var w dirWatcher
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if !a.currentConfig().ShouldWatchSidebar() {
+			w = dirWatcher{}
+			continue
+		}
+		a.mu.Lock()
+		dirs := a.sidebarDirs
+		a.mu.Unlock()
+		if changed := w.poll(dirs, a.fileFilter(), time.Now()); len(changed) > 0 && a.ctx != nil {
+			runtime.EventsEmit(a.ctx, "sidebar:changed", changed)
+		}
+	}
+}
+
+// dirWatcher detects changes to the entries the sidebar lists in a set of
+// folders. Entries the sidebar hides (sidebar_ignore, dotfiles) are not
+// compared, so churn in node_modules or editor swap files stays quiet.
+type dirWatcher struct {
+	listings map[string]string // folder → its visible entries at the last poll
+	changed  map[string]bool   // folders changed since the last report
+	since    time.Time         // first unreported change
+}
+
+// poll lists dirs and returns the folders to report as changed, sorted,
+// or nil. Folders seen for the first time are only recorded.
+func (w *dirWatcher) poll(dirs []string, filter sidebarFilter, now time.Time) []string {
+	fresh := false
+	listings := make(map[string]string, len(dirs))
+	for _, dir := range dirs {
+		listing := dirListing(dir, filter)
+		listings[dir] = listing
+		if old, ok := w.listings[dir]; ok && old != listing {
+			if w.changed == nil {
+				w.changed = make(map[string]bool)
+			}
+			w.changed[dir] = true
+			fresh = true
+		}
+	}
+	w.listings = listings
+
+	if len(w.changed) == 0 {
+		return nil
+	}
+	if w.since.IsZero() {
+		w.since = now
+	}
+	if fresh && now.Sub(w.since) < sidebarMaxDelay {
+		return nil // still changing; wait for a quiet poll
+	}
+	changed := make([]string, 0, len(w.changed))
+	for dir := range w.changed {
+		changed = append(changed, dir)
+	}
+	sort.Strings(changed)
+	w.changed, w.since = nil, time.Time{}
+	return changed
+}
+
+// dirListing returns the entries of dir the sidebar shows, one per line
+// with "/" after folders ("" if dir cannot be read).
+func dirListing(dir string, filter sidebarFilter) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, e := range entries {
+		if filter.hides(e.Name()) {
+			continue
+		}
+		b.WriteString(e.Name())
+		if e.IsDir() {
+			b.WriteByte('/')
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
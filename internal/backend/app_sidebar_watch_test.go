@@ -0,0 +1,82 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDirWatcher_ReportsOnceQuiet(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "src")
+	os.Mkdir(sub, 0755)
+	filter := sidebarFilter{ignore: []string{"node_modules"}}
+	var w dirWatcher
+	now := time.Now()
+	poll := func() []string {
+		now = now.Add(sidebarPollInterval)
+		return w.poll([]string{root, sub}, filter, now)
+	}
+
+	if got := poll(); got != nil {
+		t.Fatalf("first poll reported %v, want only a baseline", got)
+	}
+
+	// Hidden entries and changes in unwatched folders stay quiet
+	os.Mkdir(filepath.Join(root, "node_modules"), 0755)
+	os.WriteFile(filepath.Join(root, "node_modules", "x.js"), nil, 0644)
+	os.WriteFile(filepath.Join(sub, ".main.go.swp"), nil, 0644)
+	if got := poll(); got != nil {
+		t.Errorf("ignored entries reported as %v", got)
+	}
+
+	os.WriteFile(filepath.Join(sub, "new.go"), nil, 0644)
+	if got := poll(); got != nil {
+		t.Errorf("reported %v while the folder was still changing", got)
+	}
+	os.WriteFile(filepath.Join(root, "README.md"), nil, 0644)
+	if got := poll(); got != nil {
+		t.Errorf("reported %v while the folders were still changing", got)
+	}
+	if got, want := poll(), []string{root, sub}; !reflect.DeepEqual(got, want) {
+		t.Errorf("quiet poll reported %v, want %v", got, want)
+	}
+	if got := poll(); got != nil {
+		t.Errorf("reported %v again", got)
+	}
+}
+
+func TestDirWatcher_ReportsDuringLongChurn(t *testing.T) {
+	root := t.TempDir()
+	var w dirWatcher
+	now := time.Now()
+	w.poll([]string{root}, sidebarFilter{}, now)
+
+	for i := 0; ; i++ {
+		os.WriteFile(filepath.Join(root, "out"+string(rune('a'+i))), nil, 0644)
+		now = now.Add(sidebarPollInterval)
+		if got := w.poll([]string{root}, sidebarFilter{}, now); got != nil {
+			if i < int(sidebarMaxDelay/sidebarPollInterval) {
+				t.Errorf("reported after %d polls, want to wait up to sidebarMaxDelay", i+1)
+			}
+			return
+		}
+		if i > 2*int(sidebarMaxDelay/sidebarPollInterval) {
+			t.Fatal("never reported a folder that keeps changing")
+		}
+	}
+}
+
+func TestWatchSidebarDirs_DedupAndCap(t *testing.T) {
+	a := newTestApp()
+	dirs := []string{"/a", "", "/b", "/a"}
+	for i := 0; i < maxWatchedDirs; i++ {
+		dirs = append(dirs, filepath.Join("/many", string(rune('a'+i%26)), string(rune('0'+i/26))))
+	}
+	a.WatchSidebarDirs(dirs)
+	if len(a.sidebarDirs) != maxWatchedDirs || a.sidebarDirs[0] != "/a" || a.sidebarDirs[1] != "/b" {
+		t.Errorf("sidebarDirs = %d entries starting %v", len(a.sidebarDirs), a.sidebarDirs[:2])
+	}
+}
@@ -0,0 +1,156 @@
+package backend
+
+import "github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+
+// Snapshot size limits keep the JSON payload bounded even for huge panes.
+const (
+	maxSnapshotRows = 300
+	maxSnapshotCols = 500
+)
+
+// Cell attribute bits used in SnapshotCell.Attrs.
+const (
+	AttrBold = 1 << iota
+	AttrDim
+	AttrItalic
+	AttrUnderline
+	AttrReverse
+	AttrStrike
+	AttrHighlight // inside a match of the pane's highlight pattern
+)
+
+// SnapshotCell is one character cell as seen by the Go-side screen buffer.
+// FG/BG use the terminal.CellStyle colour encoding (0 = default).
+type SnapshotCell struct {
+	Char  string `json:"char"`
+	FG    int    `json:"fg"`
+	BG    int    `json:"bg"`
+	Attrs int    `json:"attrs"` // bitmask of Attr* flags
+}
+
+// ScreenSnapshot is a structured copy of a session's virtual screen.
+// Rows/Cols describe the full screen; Cells may be truncated to the
+// snapshot limits, in which case Truncated is set.
+type ScreenSnapshot struct {
+	Rows      int              `json:"rows"`
+	Cols      int              `json:"cols"`
+	CursorRow int              `json:"cursor_row"`
+	CursorCol int              `json:"cursor_col"`
+	Cells     [][]SnapshotCell `json:"cells"`
+	Truncated bool             `json:"truncated"`
+}
+
+// GetScreenSnapshot returns what the Go-side screen buffer currently holds
+// for a session. Returns an empty snapshot if the session does not exist.
+func (a *App) GetScreenSnapshot(id int) ScreenSnapshot {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	a.mu.Unlock()
+	if sess == nil {
+		return ScreenSnapshot{}
+	}
+	return buildSnapshot(sess)
+}
+
+// buildSnapshot converts the session's screen into a ScreenSnapshot.
+func buildSnapshot(sess *terminal.Session) ScreenSnapshot {
+	rows, cols := sess.Screen.Rows(), sess.Screen.Cols()
+	cells, curRow, curCol := sess.Screen.CellRows(0, min(rows, maxSnapshotRows))
+
+	snap := ScreenSnapshot{
+		Rows:      rows,
+		Cols:      cols,
+		CursorRow: curRow,
+		CursorCol: curCol,
+		Cells:     make([][]SnapshotCell, len(cells)),
+		Truncated: rows > maxSnapshotRows || cols > maxSnapshotCols,
+	}
+	re := sess.Highlight()
+	mono := sess.Screen.Monochrome()
+	for r, line := range cells {
+		out := make([]SnapshotCell, min(len(line), maxSnapshotCols))
+		for c := range out {
+			out[c] = snapshotCell(line[c], mono)
+		}
+		if re != nil {
+			markHighlight(out, re, terminal.RowText(line))
+		}
+		snap.Cells[r] = out
+	}
+	return snap
+}
+
+// CellUpdate is one changed cell in a ScreenDiff.
+type CellUpdate struct {
+	Row  int          `json:"row"`
+	Col  int          `json:"col"`
+	Cell SnapshotCell `json:"cell"`
+}
+
+// ScreenDiff lists the cells that changed since the previous diff of the
+// same session, within the snapshot limits. The first diff holds every cell.
+type ScreenDiff struct {
+	CursorRow int          `json:"cursor_row"`
+	CursorCol int          `json:"cursor_col"`
+	Changes   []CellUpdate `json:"changes"`
+}
+
+// GetScreenDiff returns the screen changes since the last call for a
+// session, so callers can apply minimal updates instead of fetching a full
+// snapshot. Diff state is per session, so only one consumer should poll it.
+// Returns an empty diff if the session does not exist.
+func (a *App) GetScreenDiff(id int) ScreenDiff {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	a.mu.Unlock()
+	if sess == nil {
+		return ScreenDiff{}
+	}
+	changes := sess.Screen.RenderDiff()
+	diff := ScreenDiff{Changes: make([]CellUpdate, 0, len(changes))}
+	diff.CursorRow, diff.CursorCol = sess.Screen.Cursor()
+	mono := sess.Screen.Monochrome()
+	for _, ch := range changes {
+		if ch.Row >= maxSnapshotRows || ch.Col >= maxSnapshotCols {
+			continue
+		}
+		diff.Changes = append(diff.Changes, CellUpdate{Row: ch.Row, Col: ch.Col, Cell: snapshotCell(ch.Cell, mono)})
+	}
+	if re := sess.Highlight(); re != nil {
+		diff.Changes = highlightChanges(sess.Screen, re, diff.Changes)
+	}
+	return diff
+}
+
+// snapshotCell converts a terminal cell into its JSON representation,
+// without colours for a monochrome screen.
+func snapshotCell(cell terminal.Cell, mono bool) SnapshotCell {
+	ch := cell.Char
+	if ch == 0 {
+		ch = ' '
+	}
+	st := cell.Style
+	if mono {
+		st = st.WithoutColor()
+	}
+	attrs := 0
+	if st.Bold {
+		attrs |= AttrBold
+	}
+	if st.Dim {
+		attrs |= AttrDim
+	}
+	if st.Italic {
+		attrs |= AttrItalic
+	}
+	if st.Underline {
+		attrs |= AttrUnderline
+	}
+	if st.Reverse {
+		attrs |= AttrReverse
+	}
+	if st.Strike {
+		attrs |= AttrStrike
+	}
+	return SnapshotCell{Char: string(ch), FG: st.FG, BG: st.BG, Attrs: attrs}
+}
@@ -0,0 +1,88 @@
+package backend
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+func TestGetScreenSnapshot(t *testing.T) {
+	a := newTestApp()
+	sess := terminal.NewSession(1, 2, 4)
+	sess.Screen.Write([]byte("\x1b[1;4;32mok\x1b[0m\r\nx"))
+	a.sessions[1] = sess
+
+	snap := a.GetScreenSnapshot(1)
+	if snap.Rows != 2 || snap.Cols != 4 || len(snap.Cells) != 2 || len(snap.Cells[0]) != 4 {
+		t.Fatalf("unexpected dimensions: %+v", snap)
+	}
+	if snap.CursorRow != 1 || snap.CursorCol != 1 {
+		t.Errorf("cursor = (%d,%d), want (1,1)", snap.CursorRow, snap.CursorCol)
+	}
+	want := SnapshotCell{Char: "o", FG: 3, Attrs: AttrBold | AttrUnderline}
+	if snap.Cells[0][0] != want {
+		t.Errorf("cell (0,0) = %+v, want %+v", snap.Cells[0][0], want)
+	}
+	if snap.Cells[0][3] != (SnapshotCell{Char: " "}) {
+		t.Errorf("blank cell = %+v", snap.Cells[0][3])
+	}
+	if _, err := json.Marshal(snap); err != nil {
+		t.Fatalf("snapshot not JSON-serializable: %v", err)
+	}
+}
+
+func TestGetScreenSnapshot_Monochrome(t *testing.T) {
+	a := newTestApp()
+	sess := terminal.NewSession(1, 2, 4)
+	sess.Screen.Write([]byte("\x1b[1;4;32;44mok"))
+	sess.Screen.SetMonochrome(true)
+	a.sessions[1] = sess
+
+	want := SnapshotCell{Char: "o", Attrs: AttrBold | AttrUnderline}
+	if got := a.GetScreenSnapshot(1).Cells[0][0]; got != want {
+		t.Errorf("cell (0,0) = %+v, want %+v", got, want)
+	}
+}
+
+func TestGetScreenSnapshot_Truncated(t *testing.T) {
+	a := newTestApp()
+	a.sessions[1] = terminal.NewSession(1, maxSnapshotRows+10, maxSnapshotCols+10)
+
+	snap := a.GetScreenSnapshot(1)
+	if !snap.Truncated {
+		t.Error("expected Truncated for oversized screen")
+	}
+	if len(snap.Cells) != maxSnapshotRows || len(snap.Cells[0]) != maxSnapshotCols {
+		t.Errorf("got %dx%d cells, want %dx%d", len(snap.Cells), len(snap.Cells[0]), maxSnapshotRows, maxSnapshotCols)
+	}
+}
+
+func TestGetScreenDiff(t *testing.T) {
+	a := newTestApp()
+	sess := terminal.NewSession(1, 2, 4)
+	a.sessions[1] = sess
+
+	if diff := a.GetScreenDiff(1); len(diff.Changes) != 8 {
+		t.Fatalf("first diff = %d changes, want all 8 cells", len(diff.Changes))
+	}
+	sess.Screen.Write([]byte("\x1b[2;2H\x1b[31mz"))
+	diff := a.GetScreenDiff(1)
+	want := CellUpdate{Row: 1, Col: 1, Cell: SnapshotCell{Char: "z", FG: 2}}
+	if len(diff.Changes) != 1 || diff.Changes[0] != want {
+		t.Fatalf("diff = %+v, want [%+v]", diff.Changes, want)
+	}
+	if diff.CursorRow != 1 || diff.CursorCol != 2 {
+		t.Errorf("cursor = (%d,%d), want (1,2)", diff.CursorRow, diff.CursorCol)
+	}
+	if diff := a.GetScreenDiff(42); diff.Changes != nil {
+		t.Errorf("unknown session diff = %+v, want empty", diff)
+	}
+}
+
+func TestGetScreenSnapshot_UnknownSession(t *testing.T) {
+	a := newTestApp()
+	if snap := a.GetScreenSnapshot(42); snap.Rows != 0 || snap.Cells != nil {
+		t.Errorf("expected empty snapshot, got %+v", snap)
+	}
+}
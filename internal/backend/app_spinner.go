@@ -0,0 +1,126 @@
+package backend
+
+import (
+	"bytes"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// spinnerFrameInterval is the minimum time between forwarded spinner frames
+// (5 fps). Frames arriving faster are held back; only the latest is kept.
+const spinnerFrameInterval = 200 * time.Millisecond
+
+// maxSpinnerFrameLen bounds what is considered a single-line spinner redraw.
+// Anything longer is treated as real content and always passes through.
+const maxSpinnerFrameLen = 512
+
+// spinnerGlyphs are the animation characters used by Claude Code's thinking
+// indicator and common braille spinners. Plain ASCII (|/-\) is deliberately
+// excluded so progress bars and prompts are never mistaken for spinners,
+// and so is '·', which status lines and prose use as a separator.
+const spinnerGlyphs = "✢✳✶✻✽⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏"
+
+// spinnerThrottle rate-limits rapid single-line spinner overwrites in a
+// session's output stream. It is not safe for concurrent use; each
+// streamOutput goroutine owns its own instance.
+type spinnerThrottle struct {
+	interval time.Duration
+	lastSent time.Time
+	pending  []byte // latest held-back spinner frame
+}
+
+// newSpinnerThrottle creates a throttle forwarding at most one spinner frame
+// per spinnerFrameInterval.
+func newSpinnerThrottle() *spinnerThrottle {
+	return &spinnerThrottle{interval: spinnerFrameInterval}
+}
+
+// Filter returns the bytes to forward for chunk. Spinner-only chunks inside
+// the throttle window are held back (nil is returned). Real content is
+// always forwarded immediately, preceded by any held frame so the terminal
+// ends up in exactly the state the unfiltered stream would produce.
+func (t *spinnerThrottle) Filter(chunk []byte, now time.Time) []byte {
+	if !isSpinnerFrame(chunk) {
+		out := chunk
+		if t.pending != nil {
+			out = append(t.pending, chunk...)
+			t.pending = nil
+		}
+		return out
+	}
+	if now.Sub(t.lastSent) < t.interval {
+		t.pending = append(t.pending[:0], chunk...)
+		return nil
+	}
+	t.pending = nil
+	t.lastSent = now
+	return chunk
+}
+
+// Pending reports whether a spinner frame is being held back and, if so,
+// how long until it may be flushed.
+func (t *spinnerThrottle) Pending(now time.Time) (time.Duration, bool) {
+	if t.pending == nil {
+		return 0, false
+	}
+	wait := t.interval - now.Sub(t.lastSent)
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
+
+// Flush releases the held-back spinner frame, if any.
+func (t *spinnerThrottle) Flush(now time.Time) []byte {
+	out := t.pending
+	t.pending = nil
+	if out != nil {
+		t.lastSent = now
+	}
+	return out
+}
+
+// isSpinnerFrame reports whether chunk looks like a single-line spinner
+// redraw: it returns the cursor to column 0 with a bare \r, contains no
+// line feed, is short, and shows one of the known spinner glyphs.
+func isSpinnerFrame(chunk []byte) bool {
+	if len(chunk) == 0 || len(chunk) > maxSpinnerFrameLen {
+		return false
+	}
+	if bytes.IndexByte(chunk, '\n') >= 0 || bytes.IndexByte(chunk, '\r') < 0 {
+		return false
+	}
+	for i := 0; i < len(chunk); {
+		r, size := utf8.DecodeRune(chunk[i:])
+		if r != utf8.RuneError && strings.ContainsRune(spinnerGlyphs, r) {
+			return true
+		}
+		i += size
+	}
+	return false
+}
+
+// isClaudeArgv reports whether argv launches the Claude CLI, either via the
+// configured command or the resolved absolute path.
+func (a *App) isClaudeArgv(argv []string) bool {
+	if len(argv) == 0 {
+		return false
+	}
+	// Normalise separators so Windows paths resolve on every platform
+	base := func(p string) string {
+		b := strings.ToLower(path.Base(strings.ReplaceAll(p, `\`, "/")))
+		return strings.TrimSuffix(b, path.Ext(b))
+	}
+	name := base(argv[0])
+	if name == "claude" {
+		return true
+	}
+	for _, known := range []string{a.currentConfig().ClaudeCommand, a.resolvedClaudePath} {
+		if known != "" && name == base(known) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package backend
+
+import (
+	"testing"
+	"time"
+)
+
+func spinnerFrame(glyph string) []byte {
+	return []byte("\r\x1b[2K" + glyph + " Thinking… (esc to interrupt)")
+}
+
+func TestIsSpinnerFrame(t *testing.T) {
+	tests := []struct {
+		name  string
+		chunk string
+		want  bool
+	}{
+		{"claude glyph", "\r✻ Thinking…", true},
+		{"braille glyph", "\r⠙ Loading", true},
+		{"no carriage return", "✻ Thinking…", false},
+		{"contains newline", "\r✻ done\n", false},
+		{"plain text", "\rhello world", false},
+		{"ascii progress bar", "\r[----    ] 40%", false},
+		{"middle dot separator", "\rOpus · 12k tokens · $0.40", false},
+		{"empty", "", false},
+	}
+	for _, tt := range tests {
+		if got := isSpinnerFrame([]byte(tt.chunk)); got != tt.want {
+			t.Errorf("%s: isSpinnerFrame(%q) = %v, want %v", tt.name, tt.chunk, got, tt.want)
+		}
+	}
+}
+
+func TestSpinnerThrottle_ThrottlesSpinnerOnlyStream(t *testing.T) {
+	th := newSpinnerThrottle()
+	start := time.Now()
+	glyphs := []string{"✢", "✳", "✶", "✻", "✽"}
+	forwarded := 0
+	// 50 frames at 20 ms intervals = 1 s of spinner at 50 fps
+	for i := 0; i < 50; i++ {
+		now := start.Add(time.Duration(i) * 20 * time.Millisecond)
+		if out := th.Filter(spinnerFrame(glyphs[i%len(glyphs)]), now); out != nil {
+			forwarded++
+		}
+	}
+	if forwarded > 6 {
+		t.Fatalf("forwarded %d spinner frames in 1s, want at most ~5", forwarded)
+	}
+	if forwarded == 0 {
+		t.Fatal("expected at least one spinner frame to pass")
+	}
+}
+
+func TestSpinnerThrottle_RealContentPassesPromptly(t *testing.T) {
+	th := newSpinnerThrottle()
+	now := time.Now()
+	th.Filter(spinnerFrame("✻"), now)
+
+	// Held back: inside the throttle window
+	held := spinnerFrame("✽")
+	if out := th.Filter(held, now.Add(10*time.Millisecond)); out != nil {
+		t.Fatalf("expected spinner frame to be held, got %q", out)
+	}
+
+	// Real content right after must pass immediately, preceded by the held frame
+	content := []byte("Here is the answer\r\n")
+	out := th.Filter(content, now.Add(20*time.Millisecond))
+	want := string(held) + string(content)
+	if string(out) != want {
+		t.Fatalf("got %q, want %q", out, want)
+	}
+	if _, ok := th.Pending(now.Add(20 * time.Millisecond)); ok {
+		t.Fatal("expected no pending frame after real content")
+	}
+}
+
+func TestSpinnerThrottle_PendingAndFlush(t *testing.T) {
+	th := newSpinnerThrottle()
+	now := time.Now()
+	th.Filter(spinnerFrame("✻"), now)
+	th.Filter(spinnerFrame("✽"), now.Add(50*time.Millisecond))
+
+	wait, ok := th.Pending(now.Add(50 * time.Millisecond))
+	if !ok {
+		t.Fatal("expected a pending frame")
+	}
+	if wait != 150*time.Millisecond {
+		t.Fatalf("wait = %v, want 150ms", wait)
+	}
+	if out := th.Flush(now.Add(200 * time.Millisecond)); string(out) != string(spinnerFrame("✽")) {
+		t.Fatalf("flushed %q, want latest frame", out)
+	}
+	if out := th.Flush(now.Add(250 * time.Millisecond)); out != nil {
+		t.Fatalf("second flush returned %q, want nil", out)
+	}
+}
+
+func TestIsClaudeArgv(t *testing.T) {
+	a := newTestApp()
+	a.cfg.ClaudeCommand = "claude"
+	a.resolvedClaudePath = "/opt/tools/claude-cli"
+
+	tests := []struct {
+		argv []string
+		want bool
+	}{
+		{[]string{"claude"}, true},
+		{[]string{"claude", "--model", "x"}, true},
+		{[]string{`C:\Users\x\AppData\Roaming\npm\claude.cmd`}, true},
+		{[]string{"/opt/tools/claude-cli"}, true},
+		{[]string{"bash"}, false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := a.isClaudeArgv(tt.argv); got != tt.want {
+			t.Errorf("isClaudeArgv(%v) = %v, want %v", tt.argv, got, tt.want)
+		}
+	}
+}
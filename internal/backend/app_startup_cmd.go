@@ -0,0 +1,41 @@
+package backend
+
+import (
+	"log"
+	"strings"
+	"time"
+)
+
+// Startup commands are typed once the shell has printed its prompt: the
+// session must have produced output and then been quiet for
+// startupQuietPeriod. Shells that print nothing get the command after
+// startupMaxWait.
+const (
+	startupQuietPeriod = 300 * time.Millisecond
+	startupMaxWait     = 5 * time.Second
+)
+
+// RunStartupCommand types command (followed by Enter) into a freshly started
+// session once its shell is ready, so it is not swallowed by shell
+// initialisation. It returns immediately; the write happens in the
+// background and is skipped if the session exits first.
+func (a *App) RunStartupCommand(id int, command string) {
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return
+	}
+	a.mu.Lock()
+	sess := a.sessions[id]
+	a.mu.Unlock()
+	if sess == nil {
+		return
+	}
+	go func() {
+		if !sess.WaitIdle(startupQuietPeriod, startupMaxWait) {
+			log.Printf("[RunStartupCommand] session %d exited before startup command", id)
+			return
+		}
+		log.Printf("[RunStartupCommand] session %d: %q", id, command)
+		sess.Write([]byte(command + "\r"))
+	}()
+}
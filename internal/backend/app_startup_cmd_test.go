@@ -0,0 +1,42 @@
+package backend
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+func TestRunStartupCommand_WaitsForQuietShell(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs sh")
+	}
+	// The "shell" keeps initialising for a while before it reads a line.
+	sess := terminal.NewSession(1, 5, 40)
+	script := `printf 'init '; sleep 0.2; printf 'ready '; read line; printf 'got:%s' "$line"; sleep 5`
+	if err := sess.Start([]string{"sh", "-c", script}, t.TempDir(), nil); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(sess.Close)
+
+	a := newTestApp()
+	a.sessions[1] = sess
+	a.RunStartupCommand(1, "  echo hi\n")
+
+	// The terminal echoes input as soon as it is written, so the command
+	// must not be on screen before the shell has been quiet for a while.
+	time.Sleep(startupQuietPeriod / 2)
+	if text := sess.Screen.PlainText(); strings.Contains(text, "echo hi") {
+		t.Fatalf("command typed during shell start-up: %q", text)
+	}
+
+	deadline := time.Now().Add(startupMaxWait)
+	for !strings.Contains(sess.Screen.PlainText(), "got:echo hi") {
+		if time.Now().After(deadline) {
+			t.Fatalf("startup command never ran: %q", sess.Screen.PlainText())
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
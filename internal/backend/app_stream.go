@@ -2,15 +2,34 @@ package backend
 
 import (
 	"encoding/base64"
+	"log"
 	"time"
 
 	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
-// coalesceDelay returns the output coalescing delay based on the number
-// of active sessions. More sessions → longer delay to reduce event load.
+// defaultOutputChunkLimit caps a single coalesced output event (64 KB).
+// Bursts larger than this are flushed in several events so the frontend
+// never has to decode and render one huge chunk at once.
+const defaultOutputChunkLimit = 64 << 10
+
+// lineFlushGap is how long output that stopped at a line break may stay
+// quiet before it is sent without waiting for the rest of the window.
+const lineFlushGap = time.Millisecond
+
+// coalesceDelay returns the output coalescing delay. A fixed delay from the
+// config takes precedence; otherwise it adapts to the number of active
+// sessions. More sessions → longer delay to reduce event load.
+//
+// The delay trades latency for smoothness: a TUI redraw split over several
+// reads flickers if the halves are rendered separately, but every
+// keystroke echo also waits up to the delay. Slow machines that still
+// flicker want a longer fixed delay, fast ones a shorter one.
 func (a *App) coalesceDelay() time.Duration {
+	if ms := a.currentConfig().OutputCoalesceMs; ms > 0 {
+		return time.Duration(ms) * time.Millisecond
+	}
 	a.mu.Lock()
 	n := len(a.sessions)
 	a.mu.Unlock()
@@ -26,47 +45,216 @@ func (a *App) coalesceDelay() time.Duration {
 	}
 }
 
-// streamOutput reads raw PTY bytes from the session and emits them as
+// coalesceOptions collects the output coalescing settings for one burst.
+func (a *App) coalesceOptions() coalesceOptions {
+	opts := coalesceOptions{delay: a.coalesceDelay(), limit: a.outputChunkLimit()}
+	if a.currentConfig().ShouldFlushOnLine() {
+		opts.lineGap = lineFlushGap
+	}
+	return opts
+}
+
+// outputChunkLimit returns the maximum number of bytes per output event.
+func (a *App) outputChunkLimit() int {
+	if kb := a.currentConfig().OutputChunkLimitKB; kb > 0 {
+		return kb << 10
+	}
+	return defaultOutputChunkLimit
+}
+
+// startStreaming launches the output and exit watchers for a freshly
+// started session process.
+func (a *App) startStreaming(id int, sess *terminal.Session, argv []string) {
+	// Stream PTY output to frontend (optionally throttling Claude's spinner)
+	var throttle *spinnerThrottle
+	if a.currentConfig().ThrottleClaudeSpinner && a.isClaudeArgv(argv) {
+		throttle = newSpinnerThrottle()
+	}
+	sess.SetOutputThrottle(a.outputThrottle())
+	sess.SetMenuPatterns(a.menuPatterns())
+	sess.Screen.SetMonochrome(a.currentConfig().ShouldUseMonochrome())
+	go a.streamOutput(id, sess, throttle)
+
+	// Watch for process exit
+	go a.watchExit(id, sess)
+}
+
+// outputThrottle returns the minimum gap between a session's output events
+// and redraw signals while it floods output (output_throttle_ms; 0 = off).
+func (a *App) outputThrottle() time.Duration {
+	return time.Duration(a.currentConfig().OutputThrottleMs) * time.Millisecond
+}
+
+// menuPatterns returns the compiled approval_menu_patterns, or the built-in
+// patterns when none are configured.
+func (a *App) menuPatterns() terminal.MenuPatterns {
+	return terminal.NewMenuPatterns(a.currentConfig().ApprovalMenuPatterns)
+}
+
+// streamOutput subscribes to the session's raw PTY output and emits it as
 // base64-encoded chunks to the frontend via Wails events.
 // It coalesces rapid output over a short time window so that TUI redraws
 // (which produce many small chunks) arrive as a single event, preventing
-// cursor flicker in xterm.js.
-func (a *App) streamOutput(id int, sess *terminal.Session) {
+// cursor flicker in xterm.js. If throttle is non-nil, spinner-only redraws
+// are rate-limited before being emitted. While a session floods output,
+// output_throttle_ms merges its events further (see throttleWindow).
+func (a *App) streamOutput(id int, sess *terminal.Session, throttle *spinnerThrottle) {
+	// The subscription belongs to this process; after Restart,
+	// startStreaming subscribes again
+	ch, cancel := sess.Subscribe()
+	defer cancel()
+	var lastEmit time.Time
+	var mono terminal.ColorStripper
+	emit := func(buf []byte) {
+		// xterm.js draws these bytes, so colours must go before it sees them
+		if buf = mono.Strip(buf, sess.Screen.Monochrome()); len(buf) == 0 {
+			return
+		}
+		if throttle != nil {
+			if buf = throttle.Filter(buf, time.Now()); buf == nil {
+				return
+			}
+		}
+		// Only events actually sent count for output_throttle_ms
+		lastEmit = time.Now()
+		b64 := base64.StdEncoding.EncodeToString(buf)
+		runtime.EventsEmit(a.ctx, "terminal:output", id, b64)
+	}
 	for {
+		// Release a held-back spinner frame once its throttle window ends
+		var flushC <-chan time.Time
+		if throttle != nil {
+			if wait, ok := throttle.Pending(time.Now()); ok {
+				flushC = time.After(wait)
+			}
+		}
 		select {
-		case data, ok := <-sess.RawOutputCh:
+		case data, ok := <-ch:
 			if !ok {
 				return
 			}
-			buf := append([]byte(nil), data...)
+			a.wakeScan()
 			// Wait briefly for more chunks — TUI apps redraw in bursts
-			deadline := time.After(a.coalesceDelay())
-		collect:
-			for {
-				select {
-				case more, ok := <-sess.RawOutputCh:
-					if !ok {
-						b64 := base64.StdEncoding.EncodeToString(buf)
-						runtime.EventsEmit(a.ctx, "terminal:output", id, b64)
-						return
-					}
-					buf = append(buf, more...)
-				case <-deadline:
-					break collect
-				case <-a.ctx.Done():
-					return
-				}
+			opts := throttleWindow(a.coalesceOptions(), a.outputThrottle(), time.Since(lastEmit))
+			if !coalesceOutput(ch, data, opts, a.ctx.Done(), emit) {
+				return
+			}
+		case <-flushC:
+			if buf := throttle.Flush(time.Now()); buf != nil {
+				lastEmit = time.Now()
+				runtime.EventsEmit(a.ctx, "terminal:output", id, base64.StdEncoding.EncodeToString(buf))
 			}
-			b64 := base64.StdEncoding.EncodeToString(buf)
-			runtime.EventsEmit(a.ctx, "terminal:output", id, b64)
 		case <-a.ctx.Done():
 			return
 		}
 	}
 }
 
-// watchExit waits for a session to exit and notifies the frontend.
+// coalesceOptions tunes coalesceOutput.
+type coalesceOptions struct {
+	delay   time.Duration // collection window after the first chunk
+	lineGap time.Duration // quiet time after a line break that ends the window early; 0 = off
+	limit   int           // bytes per event before an early flush; 0 = unlimited
+	// after starts the timers; nil means time.After. Tests use it to fire
+	// the window and the line gap by hand.
+	after func(time.Duration) <-chan time.Time
+}
+
+// throttleWindow stretches the coalescing window so that output arriving
+// within interval of the previous event waits until interval has passed:
+// a flooding pane (e.g. `yes`) then costs one event and one xterm.js write
+// per interval instead of one per read. The line-break shortcut is off
+// during a flood, and the chunk limit still bounds each event's size.
+func throttleWindow(opts coalesceOptions, interval, sinceLast time.Duration) coalesceOptions {
+	if wait := interval - sinceLast; interval > 0 && wait > 0 {
+		opts.delay = max(opts.delay, wait)
+		opts.lineGap = 0
+	}
+	return opts
+}
+
+// coalesceOutput collects further chunks from ch until the delay expires and
+// emits them together with first. Whenever the accumulated buffer reaches
+// limit bytes it is flushed early and collection continues until the
+// deadline. Returns false if ch was closed or stop fired (the caller should
+// stop streaming); any pending data is emitted before returning on close.
+//
+// With a lineGap, output that ends in a line break and then stays quiet for
+// lineGap is emitted at once: the echo of Enter or a finished line of
+// command output is complete, while a TUI frame cut off mid-redraw rarely
+// ends in a newline and still waits for the whole window.
+func coalesceOutput(ch <-chan []byte, first []byte, opts coalesceOptions, stop <-chan struct{}, emit func([]byte)) bool {
+	after := opts.after
+	if after == nil {
+		after = time.After
+	}
+	buf := append([]byte(nil), first...)
+	deadline := after(opts.delay)
+	var quiet <-chan time.Time
+	for {
+		if opts.limit > 0 && len(buf) >= opts.limit {
+			emit(buf)
+			buf = nil
+		}
+		if quiet == nil && opts.lineGap > 0 && opts.lineGap < opts.delay && endsLine(buf) {
+			quiet = after(opts.lineGap)
+		}
+		select {
+		case more, ok := <-ch:
+			if !ok {
+				if len(buf) > 0 {
+					emit(buf)
+				}
+				return false
+			}
+			buf = append(buf, more...)
+			quiet = nil // the gap restarts after the latest chunk
+		case <-deadline:
+			if len(buf) > 0 {
+				emit(buf)
+			}
+			return true
+		case <-quiet:
+			emit(buf)
+			return true
+		case <-stop:
+			return false
+		}
+	}
+}
+
+// endsLine reports whether buf stops right after a line break.
+func endsLine(buf []byte) bool {
+	return len(buf) > 0 && buf[len(buf)-1] == '\n'
+}
+
+// watchExit waits for a session to exit and notifies the frontend with the
+// exit code, the reason ("normal", "error", "killed", "signaled") and, for
+// signaled, the signal name. A session whose PTY breaks while the process
+// lives on is reported as terminal:failed with its LastError first.
 func (a *App) watchExit(id int, sess *terminal.Session) {
-	<-sess.Done()
-	runtime.EventsEmit(a.ctx, "terminal:exit", id, sess.ExitCode)
+	done, failed := sess.Done(), sess.Failed()
+	select {
+	case <-failed:
+		msg := sess.Err()
+		log.Printf("[watchExit] session %d failed: %s", id, msg)
+		runtime.EventsEmit(a.ctx, "terminal:failed", id, msg)
+		<-done
+	case <-done:
+	}
+	reason, sig := sess.ExitInfo()
+	runtime.EventsEmit(a.ctx, "terminal:exit", id, sess.ExitCode, reason.String(), sig)
+}
+
+// GetSessionError returns why a session stopped working: its PTY broke
+// while the process kept running, or waiting for the process failed. It is
+// "" for healthy sessions, normal exits and unknown ids.
+func (a *App) GetSessionError(id int) string {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	a.mu.Unlock()
+	if sess == nil {
+		return ""
+	}
+	return sess.Err()
 }
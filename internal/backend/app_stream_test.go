@@ -0,0 +1,236 @@
+package backend
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/config"
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+)
+
+func TestCoalesceOutput_MergesWithinDeadline(t *testing.T) {
+	ch := make(chan []byte, 4)
+	ch <- []byte("b")
+	ch <- []byte("c")
+	var events [][]byte
+	ok := coalesceOutput(ch, []byte("a"), coalesceOptions{delay: 20 * time.Millisecond, limit: 1024}, nil, func(b []byte) {
+		events = append(events, b)
+	})
+	if !ok {
+		t.Fatal("expected streaming to continue")
+	}
+	if len(events) != 1 || string(events[0]) != "abc" {
+		t.Fatalf("expected single event 'abc', got %q", events)
+	}
+}
+
+func TestCoalesceOutput_FlushesEarlyOverLimit(t *testing.T) {
+	ch := make(chan []byte, 8)
+	chunk := bytes.Repeat([]byte("x"), 40)
+	for i := 0; i < 4; i++ {
+		ch <- chunk
+	}
+	var events [][]byte
+	coalesceOutput(ch, chunk, coalesceOptions{delay: 20 * time.Millisecond, limit: 100}, nil, func(b []byte) {
+		events = append(events, b)
+	})
+	if len(events) < 2 {
+		t.Fatalf("expected burst to be split into several events, got %d", len(events))
+	}
+	total := 0
+	for _, e := range events[:len(events)-1] {
+		if len(e) < 100 {
+			t.Errorf("early flush of %d bytes, want >= limit", len(e))
+		}
+		total += len(e)
+	}
+	total += len(events[len(events)-1])
+	if total != 200 {
+		t.Fatalf("expected 200 bytes total, got %d", total)
+	}
+}
+
+func TestCoalesceOutput_ClosedChannelEmitsPending(t *testing.T) {
+	ch := make(chan []byte, 1)
+	ch <- []byte("tail")
+	close(ch)
+	var got []byte
+	ok := coalesceOutput(ch, []byte("head-"), coalesceOptions{delay: time.Second, limit: 1024}, nil, func(b []byte) {
+		got = append(got, b...)
+	})
+	if ok {
+		t.Fatal("expected false on closed channel")
+	}
+	if string(got) != "head-tail" {
+		t.Fatalf("expected 'head-tail', got %q", got)
+	}
+}
+
+func TestCoalesceOutput_StopReturnsFalse(t *testing.T) {
+	ch := make(chan []byte)
+	stop := make(chan struct{})
+	close(stop)
+	ok := coalesceOutput(ch, []byte("x"), coalesceOptions{delay: time.Second, limit: 1024}, stop, func([]byte) {})
+	if ok {
+		t.Fatal("expected false when stop fires")
+	}
+}
+
+// fakeTimer is a timer started by coalesceOutput that the test fires.
+type fakeTimer struct {
+	d time.Duration
+	c chan time.Time
+}
+
+// runCoalesce runs coalesceOutput on its own goroutine with timers the test
+// receives from the returned channel and fires by hand.
+func runCoalesce(ch <-chan []byte, first string, delay, lineGap time.Duration) (timers chan fakeTimer, events chan string, result chan bool) {
+	timers = make(chan fakeTimer, 8)
+	events = make(chan string, 8)
+	result = make(chan bool, 1)
+	opts := coalesceOptions{delay: delay, lineGap: lineGap, after: func(d time.Duration) <-chan time.Time {
+		c := make(chan time.Time, 1)
+		timers <- fakeTimer{d, c}
+		return c
+	}}
+	go func() {
+		result <- coalesceOutput(ch, []byte(first), opts, nil, func(b []byte) { events <- string(b) })
+	}()
+	return timers, events, result
+}
+
+func nextTimer(t *testing.T, timers chan fakeTimer, want time.Duration) fakeTimer {
+	t.Helper()
+	select {
+	case tm := <-timers:
+		if tm.d != want {
+			t.Fatalf("timer for %v started, want %v", tm.d, want)
+		}
+		return tm
+	case <-time.After(time.Second):
+		t.Fatalf("no %v timer started", want)
+		return fakeTimer{}
+	}
+}
+
+func TestCoalesceOutput_LineGapFlushesBeforeDeadline(t *testing.T) {
+	ch := make(chan []byte)
+	timers, events, result := runCoalesce(ch, "$ ls\r\n", 50*time.Millisecond, time.Millisecond)
+	nextTimer(t, timers, 50*time.Millisecond) // the window, never fired
+	gap := nextTimer(t, timers, time.Millisecond)
+
+	gap.c <- time.Time{}
+	if ev := <-events; ev != "$ ls\r\n" {
+		t.Fatalf("event = %q", ev)
+	}
+	if !<-result {
+		t.Fatal("expected streaming to continue")
+	}
+}
+
+func TestCoalesceOutput_LineGapRestartsWithMoreOutput(t *testing.T) {
+	ch := make(chan []byte)
+	timers, events, result := runCoalesce(ch, "a\n", 50*time.Millisecond, time.Millisecond)
+	nextTimer(t, timers, 50*time.Millisecond)
+	stale := nextTimer(t, timers, time.Millisecond)
+
+	ch <- []byte("b") // a partial line cancels the gap
+	ch <- []byte("c\n")
+	gap := nextTimer(t, timers, time.Millisecond)
+
+	stale.c <- time.Time{}
+	select {
+	case ev := <-events:
+		t.Fatalf("stale gap timer flushed %q", ev)
+	case <-time.After(20 * time.Millisecond):
+	}
+	gap.c <- time.Time{}
+	if ev := <-events; ev != "a\nbc\n" {
+		t.Fatalf("event = %q, want the whole burst", ev)
+	}
+	<-result
+}
+
+func TestCoalesceOutput_PartialLineWaitsForDeadline(t *testing.T) {
+	ch := make(chan []byte)
+	timers, events, result := runCoalesce(ch, "\x1b[2J\x1b[H frame", 50*time.Millisecond, time.Millisecond)
+	deadline := nextTimer(t, timers, 50*time.Millisecond)
+
+	deadline.c <- time.Time{}
+	if ev := <-events; ev != "\x1b[2J\x1b[H frame" {
+		t.Fatalf("event = %q", ev)
+	}
+	<-result
+	if len(timers) != 0 {
+		t.Fatal("no line gap expected for output without a line break")
+	}
+}
+
+func TestCoalesceOptions_LineFlushConfig(t *testing.T) {
+	a := newTestApp()
+	if got := a.coalesceOptions().lineGap; got != lineFlushGap {
+		t.Fatalf("default lineGap = %v, want %v", got, lineFlushGap)
+	}
+	off := false
+	a.cfg.OutputLineFlush = &off
+	if got := a.coalesceOptions().lineGap; got != 0 {
+		t.Fatalf("lineGap with output_line_flush: false = %v, want 0", got)
+	}
+}
+
+func TestThrottleWindow(t *testing.T) {
+	base := coalesceOptions{delay: 6 * time.Millisecond, lineGap: lineFlushGap}
+
+	if got := throttleWindow(base, 0, time.Millisecond); got.delay != base.delay || got.lineGap != base.lineGap {
+		t.Errorf("throttle off changed the options: %+v", got)
+	}
+	if got := throttleWindow(base, 50*time.Millisecond, time.Second); got.delay != base.delay || got.lineGap != base.lineGap {
+		t.Errorf("output after a quiet second was delayed: %+v", got)
+	}
+	got := throttleWindow(base, 50*time.Millisecond, 10*time.Millisecond)
+	if got.delay != 40*time.Millisecond || got.lineGap != 0 {
+		t.Errorf("flooding output: delay %v lineGap %v, want 40ms and no line flush", got.delay, got.lineGap)
+	}
+	if got := throttleWindow(base, 50*time.Millisecond, 48*time.Millisecond); got.delay != base.delay {
+		t.Errorf("delay shrank below the coalescing window: %v", got.delay)
+	}
+}
+
+func TestCoalesceDelay_ConfigOverride(t *testing.T) {
+	a := newTestApp()
+	if d := a.coalesceDelay(); d != 6*time.Millisecond {
+		t.Fatalf("adaptive delay = %v, want 6ms", d)
+	}
+	a.cfg = config.Config{OutputCoalesceMs: 25}
+	if d := a.coalesceDelay(); d != 25*time.Millisecond {
+		t.Fatalf("configured delay = %v, want 25ms", d)
+	}
+}
+
+func TestOutputChunkLimit_Default(t *testing.T) {
+	a := newTestApp()
+	if got := a.outputChunkLimit(); got != defaultOutputChunkLimit {
+		t.Fatalf("default limit = %d, want %d", got, defaultOutputChunkLimit)
+	}
+	a.cfg.OutputChunkLimitKB = 8
+	if got := a.outputChunkLimit(); got != 8<<10 {
+		t.Fatalf("configured limit = %d, want %d", got, 8<<10)
+	}
+}
+
+func TestGetSessionError(t *testing.T) {
+	a := newTestApp()
+	sess := terminal.NewSession(1, 5, 20)
+	a.sessions[1] = sess
+	if got := a.GetSessionError(1); got != "" {
+		t.Fatalf("healthy session reported %q", got)
+	}
+	sess.LastError = "PTY read failed while the process is still running: EOF"
+	if got := a.GetSessionError(1); got != sess.LastError {
+		t.Fatalf("GetSessionError = %q, want %q", got, sess.LastError)
+	}
+	if got := a.GetSessionError(99); got != "" {
+		t.Fatalf("unknown session reported %q", got)
+	}
+}
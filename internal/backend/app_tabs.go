@@ -0,0 +1,40 @@
+package backend
+
+import (
+	"log"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/config"
+)
+
+// SaveTabs persists the current tab/pane layout to disk so it can be
+// restored on next startup.
+func (a *App) SaveTabs(state config.SessionState) {
+	log.Printf("[SaveTabs] saving %d tabs", len(state.Tabs))
+	if a.currentConfig().RestoreScrollback {
+		state.CapScrollback()
+	} else {
+		state.DropScrollback()
+	}
+	if err := config.SaveSession(state); err != nil {
+		log.Printf("[SaveTabs] error: %v", err)
+	}
+}
+
+// LoadTabs returns the previously saved tab/pane layout, or nil.
+func (a *App) LoadTabs() *config.SessionState {
+	cfg := a.currentConfig()
+	if !cfg.ShouldRestoreSession() {
+		log.Printf("[LoadTabs] restore_session disabled")
+		return nil
+	}
+	state := config.LoadSession()
+	if state == nil {
+		log.Printf("[LoadTabs] no saved session found")
+	} else {
+		if !cfg.RestoreScrollback {
+			state.DropScrollback()
+		}
+		log.Printf("[LoadTabs] loaded %d tabs", len(state.Tabs))
+	}
+	return state
+}
@@ -0,0 +1,26 @@
+package backend
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/config"
+)
+
+// SetTheme switches the active theme and persists it to the config file.
+// name must be a built-in theme or one defined under custom_themes.
+func (a *App) SetTheme(name string) error {
+	if !a.currentConfig().HasTheme(name) {
+		return fmt.Errorf("unknown theme %q", name)
+	}
+	err := a.updateConfig(func(cfg *config.Config) bool {
+		cfg.Theme = name
+		return true
+	})
+	if err != nil {
+		log.Printf("[SetTheme] error: %v", err)
+		return fmt.Errorf("config save failed: %w", err)
+	}
+	log.Printf("[SetTheme] theme=%q", name)
+	return nil
+}
@@ -0,0 +1,46 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/config"
+)
+
+func TestSetTheme_RejectsUnknown(t *testing.T) {
+	a := newTestApp()
+	a.cfg = config.DefaultConfig()
+
+	if err := a.SetTheme("monokai"); err == nil {
+		t.Fatal("expected error for unknown theme")
+	}
+	if a.cfg.Theme != "dark" {
+		t.Errorf("theme changed to %q on error", a.cfg.Theme)
+	}
+}
+
+func TestSetTheme_PersistsCustomTheme(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	a := newTestApp()
+	a.cfg = config.DefaultConfig()
+	a.cfg.CustomThemes = map[string]config.ThemeColors{"tokyonight": {Bg: "#1a1b26"}}
+
+	if err := a.SetTheme("tokyonight"); err != nil {
+		t.Fatalf("SetTheme: %v", err)
+	}
+	if a.cfg.Theme != "tokyonight" {
+		t.Errorf("cfg.Theme = %q, want tokyonight", a.cfg.Theme)
+	}
+	data, err := os.ReadFile(filepath.Join(home, ".multiterminal.yaml"))
+	if err != nil {
+		t.Fatalf("config not written: %v", err)
+	}
+	if !strings.Contains(string(data), "theme: tokyonight") {
+		t.Errorf("saved config does not contain the theme:\n%s", data)
+	}
+}
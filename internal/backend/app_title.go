@@ -0,0 +1,49 @@
+package backend
+
+import (
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/terminal"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// TitleInfo is sent to the frontend when a pane's display title changes.
+type TitleInfo struct {
+	ID     int    `json:"id"`
+	Title  string `json:"title"`  // empty = keep the pane's default name
+	Manual bool   `json:"manual"` // set via SetPaneName rather than OSC
+}
+
+// prevTitle tracks the last emitted title per session, guarded by
+// prevActivityMu like the activity state.
+var prevTitle = make(map[int]TitleInfo)
+
+// SetPaneName sets a manual name for a session's pane that takes precedence
+// over titles the program reports via OSC. An empty name returns the pane
+// to following those titles.
+func (a *App) SetPaneName(id int, name string) {
+	a.mu.Lock()
+	sess := a.sessions[id]
+	a.mu.Unlock()
+	if sess == nil {
+		return
+	}
+	sess.SetManualName(name)
+	a.emitTitleChange(id, sess)
+}
+
+// emitTitleChange sends terminal:title if the session's display title
+// changed since the last emit.
+func (a *App) emitTitleChange(id int, sess *terminal.Session) {
+	title, manual := sess.DisplayTitle()
+	info := TitleInfo{ID: id, Title: title, Manual: manual}
+
+	prevActivityMu.Lock()
+	changed := prevTitle[id] != info
+	if changed {
+		prevTitle[id] = info
+	}
+	prevActivityMu.Unlock()
+
+	if changed {
+		runtime.EventsEmit(a.ctx, "terminal:title", info)
+	}
+}
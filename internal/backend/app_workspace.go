@@ -0,0 +1,60 @@
+package backend
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/patrick-goecommerce/Multiterminal-UI/internal/config"
+)
+
+// GetWorkspaceFolders returns the folders the sidebar shows as extra roots
+// next to the tab directory (workspace_folders).
+func (a *App) GetWorkspaceFolders() []string {
+	return slices.Clone(a.currentConfig().WorkspaceFolders)
+}
+
+// AddWorkspaceFolder adds dir as a sidebar root and persists the config.
+// dir must be an existing directory; it is stored as an absolute path.
+func (a *App) AddWorkspaceFolder(dir string) error {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		return nil
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", abs)
+	}
+	return a.updateConfig(func(cfg *config.Config) bool {
+		if slices.Contains(cfg.WorkspaceFolders, abs) {
+			return false
+		}
+		cfg.WorkspaceFolders = append(slices.Clip(cfg.WorkspaceFolders), abs)
+		log.Printf("[AddWorkspaceFolder] %q total=%d", abs, len(cfg.WorkspaceFolders))
+		return true
+	})
+}
+
+// RemoveWorkspaceFolder removes dir from the sidebar roots and persists
+// the config.
+func (a *App) RemoveWorkspaceFolder(dir string) error {
+	return a.updateConfig(func(cfg *config.Config) bool {
+		i := slices.Index(cfg.WorkspaceFolders, dir)
+		if i < 0 {
+			return false
+		}
+		cfg.WorkspaceFolders = slices.Delete(slices.Clone(cfg.WorkspaceFolders), i, i+1)
+		log.Printf("[RemoveWorkspaceFolder] %q", dir)
+		return true
+	})
+}
@@ -0,0 +1,47 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWorkspaceFolders_AddRemove(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	a := newTestApp()
+	one, two := t.TempDir(), t.TempDir()
+
+	for _, dir := range []string{one, two, one} {
+		if err := a.AddWorkspaceFolder(dir); err != nil {
+			t.Fatalf("AddWorkspaceFolder(%q): %v", dir, err)
+		}
+	}
+	if got := a.GetWorkspaceFolders(); !reflect.DeepEqual(got, []string{one, two}) {
+		t.Fatalf("folders = %q, want %q without duplicates", got, []string{one, two})
+	}
+
+	if err := a.RemoveWorkspaceFolder(one); err != nil {
+		t.Fatalf("RemoveWorkspaceFolder: %v", err)
+	}
+	if got := a.GetWorkspaceFolders(); !reflect.DeepEqual(got, []string{two}) {
+		t.Errorf("after remove: folders = %q, want %q", got, []string{two})
+	}
+}
+
+func TestWorkspaceFolders_RejectsNonDirectories(t *testing.T) {
+	a := newTestApp()
+	file := filepath.Join(t.TempDir(), "notes.txt")
+	os.WriteFile(file, []byte("x"), 0644)
+
+	for _, dir := range []string{file, filepath.Join(t.TempDir(), "missing")} {
+		if err := a.AddWorkspaceFolder(dir); err == nil {
+			t.Errorf("AddWorkspaceFolder(%q) should fail", dir)
+		}
+	}
+	if got := a.GetWorkspaceFolders(); len(got) != 0 {
+		t.Errorf("folders = %q, want none", got)
+	}
+}
@@ -13,27 +13,79 @@ import (
 
 // Config holds all user-configurable settings.
 type Config struct {
-	DefaultShell          string         `yaml:"default_shell" json:"default_shell"`
-	DefaultDir            string         `yaml:"default_dir" json:"default_dir"`
-	Theme                 string         `yaml:"theme" json:"theme"`
-	TerminalColor         string         `yaml:"terminal_color" json:"terminal_color"`
-	MaxPanesPerTab        int            `yaml:"max_panes_per_tab" json:"max_panes_per_tab"`
-	SidebarWidth          int            `yaml:"sidebar_width" json:"sidebar_width"`
-	ClaudeCommand         string         `yaml:"claude_command" json:"claude_command"`
-	ClaudeModels          []ModelEntry   `yaml:"claude_models" json:"claude_models"`
-	CommitReminderMinutes int            `yaml:"commit_reminder_minutes" json:"commit_reminder_minutes"`
-	RestoreSession        *bool          `yaml:"restore_session" json:"restore_session"`
-	LoggingEnabled        bool           `yaml:"logging_enabled" json:"logging_enabled"`
-	AutoBranchOnIssue     *bool          `yaml:"auto_branch_on_issue" json:"auto_branch_on_issue"`
-	UseWorktrees          *bool          `yaml:"use_worktrees" json:"use_worktrees"`
-	IssueTracking         IssueTracking  `yaml:"issue_tracking" json:"issue_tracking"`
-	Commands              []CommandEntry `yaml:"commands" json:"commands"`
-	Audio                 AudioSettings  `yaml:"audio" json:"audio"`
-	LocalhostAutoOpen     string         `yaml:"localhost_auto_open" json:"localhost_auto_open"`
-	SidebarPinned         bool           `yaml:"sidebar_pinned" json:"sidebar_pinned"`
-	Favorites             map[string][]string `yaml:"favorites,omitempty" json:"favorites,omitempty"`
-	FontFamily            string         `yaml:"font_family" json:"font_family"`
-	FontSize              int            `yaml:"font_size"   json:"font_size"`
+	DefaultShell          string                 `yaml:"default_shell" json:"default_shell"`
+	DefaultShellArgs      []string               `yaml:"default_shell_args" json:"default_shell_args"` // full argv, e.g. ["bash", "-l"]; overrides default_shell
+	DefaultDir            string                 `yaml:"default_dir" json:"default_dir"`
+	Theme                 string                 `yaml:"theme" json:"theme"`
+	TerminalColor         string                 `yaml:"terminal_color" json:"terminal_color"`
+	MaxPanesPerTab        int                    `yaml:"max_panes_per_tab" json:"max_panes_per_tab"`
+	MaxSessions           int                    `yaml:"max_sessions" json:"max_sessions"`                 // open terminals across all tabs and windows
+	IdleTimeoutMinutes    int                    `yaml:"idle_timeout_minutes" json:"idle_timeout_minutes"` // close shell panes without input or output this long; 0 = never
+	SidebarWidth          int                    `yaml:"sidebar_width" json:"sidebar_width"`
+	ClaudeCommand         string                 `yaml:"claude_command" json:"claude_command"`
+	ClaudeModels          []ModelEntry           `yaml:"claude_models" json:"claude_models"`
+	CommitReminderMinutes int                    `yaml:"commit_reminder_minutes" json:"commit_reminder_minutes"`
+	CommitWarningFactor   float64                `yaml:"commit_reminder_warning_factor" json:"commit_reminder_warning_factor"` // footer turns yellow at this multiple of the reminder
+	CommitDangerFactor    float64                `yaml:"commit_reminder_danger_factor" json:"commit_reminder_danger_factor"`   // red, and the optional notification
+	CommitReminderNotify  bool                   `yaml:"commit_reminder_notify" json:"commit_reminder_notify"`
+	RestoreSession        *bool                  `yaml:"restore_session" json:"restore_session"`
+	RestoreScrollback     bool                   `yaml:"restore_scrollback" json:"restore_scrollback"` // keep shell pane output across restarts
+	LoggingEnabled        bool                   `yaml:"logging_enabled" json:"logging_enabled"`
+	LogInput              bool                   `yaml:"log_input" json:"log_input"`                       // debug: hex-dump every PTY write to the log; secrets redacted
+	CrashReportScreens    bool                   `yaml:"crash_report_screens" json:"crash_report_screens"` // crash snapshots include the last lines of each pane
+	AutoBranchOnIssue     *bool                  `yaml:"auto_branch_on_issue" json:"auto_branch_on_issue"`
+	UseWorktrees          *bool                  `yaml:"use_worktrees" json:"use_worktrees"`
+	IssueTracking         IssueTracking          `yaml:"issue_tracking" json:"issue_tracking"`
+	IssueCacheSeconds     int                    `yaml:"issue_cache_seconds" json:"issue_cache_seconds"`       // how long issue details are reused; 0 = always ask gh
+	GitHubTimeoutSeconds  int                    `yaml:"github_timeout_seconds" json:"github_timeout_seconds"` // gh calls are killed after this long
+	Commands              []CommandEntry         `yaml:"commands" json:"commands"`
+	Audio                 AudioSettings          `yaml:"audio" json:"audio"`
+	LocalhostAutoOpen     string                 `yaml:"localhost_auto_open" json:"localhost_auto_open"`
+	SidebarPinned         bool                   `yaml:"sidebar_pinned" json:"sidebar_pinned"`
+	SidebarIgnore         []string               `yaml:"sidebar_ignore" json:"sidebar_ignore"`           // file name globs the sidebar hides; "!glob" shows a match again
+	SidebarShowHidden     bool                   `yaml:"sidebar_show_hidden" json:"sidebar_show_hidden"` // list dotfiles and dot folders
+	SidebarWatch          *bool                  `yaml:"sidebar_watch" json:"sidebar_watch"`             // refresh the tree when files are added or removed; false for network drives
+	Favorites             map[string][]string    `yaml:"favorites,omitempty" json:"favorites,omitempty"`
+	WorkspaceFolders      []string               `yaml:"workspace_folders" json:"workspace_folders"` // extra sidebar roots shown next to the tab directory
+	FontFamily            string                 `yaml:"font_family" json:"font_family"`
+	FontSize              int                    `yaml:"font_size"   json:"font_size"`
+	Monochrome            bool                   `yaml:"monochrome" json:"monochrome"`                 // drop text colours, keep bold/underline; NO_COLOR in the environment does the same
+	OutputCoalesceMs      int                    `yaml:"output_coalesce_ms" json:"output_coalesce_ms"` // 1-100 = fixed window; 0 = adaptive
+	OutputLineFlush       *bool                  `yaml:"output_line_flush" json:"output_line_flush"`   // end the window early once output pauses after a line break
+	OutputChunkLimitKB    int                    `yaml:"output_chunk_limit_kb" json:"output_chunk_limit_kb"`
+	OutputThrottleMs      int                    `yaml:"output_throttle_ms" json:"output_throttle_ms"`     // min gap between output events during floods; 0 = off
+	ScanIntervalMinMs     int                    `yaml:"scan_interval_min_ms" json:"scan_interval_min_ms"` // activity scan interval while panes produce output
+	ScanIntervalMaxMs     int                    `yaml:"scan_interval_max_ms" json:"scan_interval_max_ms"` // activity scan interval once all panes are quiet
+	ThrottleClaudeSpinner bool                   `yaml:"throttle_claude_spinner" json:"throttle_claude_spinner"`
+	ReflowOnResize        *bool                  `yaml:"reflow_on_resize" json:"reflow_on_resize"`             // rewrap wrapped lines when a pane changes width
+	DefaultLaunch         string                 `yaml:"default_launch" json:"default_launch"`                 // "dialog", "shell", "claude", "yolo"
+	NewTabDirMode         string                 `yaml:"new_tab_dir_mode" json:"new_tab_dir_mode"`             // "dialog", "inherit-tab", "inherit-pane-cwd", "fixed"
+	AutoApprove           []string               `yaml:"auto_approve" json:"auto_approve"`                     // regexes of prompts YOLO panes answer with "y"; empty = never
+	ApprovalMenuPatterns  []string               `yaml:"approval_menu_patterns" json:"approval_menu_patterns"` // regexes for the highlighted option of an approval menu; empty = built-in
+	ResultPatterns        ResultPatterns         `yaml:"result_patterns" json:"result_patterns"`
+	PasteSafety           string                 `yaml:"paste_safety" json:"paste_safety"`                 // "off", "strip" (trailing newline) or "confirm" (multi-line)
+	PasteWarnDangerous    bool                   `yaml:"paste_warn_dangerous" json:"paste_warn_dangerous"` // confirm pastes containing rm -rf, curl | sh, ...
+	Keybindings           map[string]string      `yaml:"keybindings" json:"keybindings"`                   // action name → key spec, e.g. "new_tab": "ctrl+t"
+	QuitBinding           string                 `yaml:"quit_binding" json:"quit_binding"`                 // "" (off), "double_ctrl_c" or a key spec such as "ctrl+q"
+	InterceptCtrlC        *bool                  `yaml:"intercept_ctrl_c" json:"intercept_ctrl_c"`         // Ctrl+C copies a selection; false = always send it to the pane
+	LaunchProfiles        []LaunchProfile        `yaml:"launch_profiles" json:"launch_profiles"`
+	StartupCommand        string                 `yaml:"startup_command" json:"startup_command"` // typed into new shell panes, e.g. "nvm use && clear"
+	ControlPort           int                    `yaml:"control_port" json:"control_port"`       // localhost port for notification clicks and the control API
+	ControlAPI            []string               `yaml:"control_api" json:"control_api"`         // control API commands scripts may run; empty = API off
+	CustomThemes          map[string]ThemeColors `yaml:"custom_themes,omitempty" json:"custom_themes,omitempty"`
+	ANSIPalettes          map[string][]string    `yaml:"ansi_palettes,omitempty" json:"ansi_palettes,omitempty"` // theme → 16 ANSI colors overriding its terminal palette
+
+	// Memory for the scrollback of all panes together; over it, the oldest
+	// rows of the least recently active panes are dropped. 0 = unlimited.
+	ScrollbackMemoryBudgetMB int `yaml:"scrollback_memory_budget_mb" json:"scrollback_memory_budget_mb"`
+}
+
+// ResultPatterns are regexes that mark a finished command's output as passed
+// or failed, so its pane flashes green or red. An empty list keeps the
+// built-in patterns for go test, cargo, npm, jest and pytest.
+type ResultPatterns struct {
+	Pass []string `yaml:"pass" json:"pass"`
+	Fail []string `yaml:"fail" json:"fail"`
 }
 
 // IssueTracking holds settings for automatic issue progress reporting.
@@ -78,12 +130,17 @@ func DefaultConfig() Config {
 		Theme:                 "dark",
 		TerminalColor:         "#39ff14",
 		MaxPanesPerTab:        12,
+		MaxSessions:           50,
 		SidebarWidth:          30,
 		ClaudeCommand:         "claude",
 		CommitReminderMinutes: 30,
+		CommitWarningFactor:   2,
+		CommitDangerFactor:    3,
 		RestoreSession:        boolPtr(true),
 		AutoBranchOnIssue:     boolPtr(true),
 		UseWorktrees:          boolPtr(false), // opt-in: parallel issue work via git worktrees
+		ReflowOnResize:        boolPtr(true),
+		InterceptCtrlC:        boolPtr(true),
 		IssueTracking: IssueTracking{
 			AutoCommentOnStart:  true,
 			AutoCommentOnDone:   true,
@@ -105,34 +162,22 @@ func DefaultConfig() Config {
 			Volume:      50,
 			WhenFocused: boolPtr(true),
 		},
-		LocalhostAutoOpen: "notify",
-		FontFamily:        "",
-		FontSize:          10,
-	}
-}
-
-// ShouldRestoreSession returns whether the session should be restored.
-func (c Config) ShouldRestoreSession() bool {
-	if c.RestoreSession == nil {
-		return true
-	}
-	return *c.RestoreSession
-}
-
-// ShouldAutoBranch returns whether to auto-create branches for issues.
-func (c Config) ShouldAutoBranch() bool {
-	if c.AutoBranchOnIssue == nil {
-		return true
-	}
-	return *c.AutoBranchOnIssue
-}
-
-// ShouldUseWorktrees returns whether to create git worktrees for issues.
-func (c Config) ShouldUseWorktrees() bool {
-	if c.UseWorktrees == nil {
-		return false
+		LocalhostAutoOpen:    "notify",
+		SidebarIgnore:        []string{"node_modules"},
+		FontFamily:           "",
+		FontSize:             10,
+		OutputCoalesceMs:     0, // 0 = adaptive (based on session count)
+		OutputChunkLimitKB:   64,
+		ScanIntervalMinMs:    200,
+		ScanIntervalMaxMs:    2000,
+		IssueCacheSeconds:    60,
+		GitHubTimeoutSeconds: 15,
+		DefaultLaunch:        "dialog",
+		NewTabDirMode:        "dialog",
+		ControlPort:          41987,
+		PasteSafety:          "off", // opt-in: "strip" or "confirm"
+		Keybindings:          DefaultKeybindings(),
 	}
-	return *c.UseWorktrees
 }
 
 // configPath returns the path to ~/.multiterminal.yaml.
@@ -160,82 +205,24 @@ func Load() Config {
 		return cfg
 	}
 
-	_ = yaml.Unmarshal(data, &cfg)
-
-	// Apply sensible bounds
-	if cfg.MaxPanesPerTab < 1 {
-		cfg.MaxPanesPerTab = 1
-	}
-	if cfg.MaxPanesPerTab > 12 {
-		cfg.MaxPanesPerTab = 12
-	}
-	if cfg.SidebarWidth < 15 {
-		cfg.SidebarWidth = 15
-	}
-	if cfg.SidebarWidth > 60 {
-		cfg.SidebarWidth = 60
-	}
-
-	// Validate theme name
-	validThemes := map[string]bool{"dark": true, "light": true, "dracula": true, "nord": true, "solarized": true}
-	if !validThemes[cfg.Theme] {
-		cfg.Theme = "dark"
-	}
-
-	if cfg.CommitReminderMinutes < 0 {
-		cfg.CommitReminderMinutes = 0
-	}
-
-	if cfg.Audio.Volume < 0 {
-		cfg.Audio.Volume = 0
-	}
-	if cfg.Audio.Volume > 100 {
-		cfg.Audio.Volume = 100
-	}
-	if cfg.Audio.Enabled == nil {
-		cfg.Audio.Enabled = boolPtr(true)
-	}
-	if cfg.Audio.WhenFocused == nil {
-		cfg.Audio.WhenFocused = boolPtr(true)
-	}
-
-	if cfg.RestoreSession == nil {
-		cfg.RestoreSession = boolPtr(true)
-	}
-
-	// Validate localhost_auto_open
-	validAutoOpen := map[string]bool{"auto": true, "notify": true, "off": true}
-	if !validAutoOpen[cfg.LocalhostAutoOpen] {
-		cfg.LocalhostAutoOpen = "notify"
-	}
-
-	validFontSizes := map[int]bool{8: true, 10: true, 12: true, 14: true, 16: true, 18: true, 20: true}
-	if !validFontSizes[cfg.FontSize] {
-		cfg.FontSize = 10
-	}
-
-	if cfg.Favorites == nil {
-		cfg.Favorites = make(map[string][]string)
-	}
-
+	// A YAML error still leaves the fields decoded before it in place
+	cfg, _ = Parse(data)
 	return cfg
 }
 
-// Save writes the given config to the YAML file.
-func Save(cfg Config) error {
-	p := configPath()
-	if p == "" {
-		return nil
-	}
-	return writeDefaults(p, cfg)
-}
+// Parse decodes YAML config data over the built-in defaults and applies the
+// same validation as Load. On a YAML error the partially decoded config is
+// returned together with the error.
+func Parse(data []byte) (Config, error) {
+	cfg := DefaultConfig()
 
-// writeDefaults persists the configuration to disk.
-func writeDefaults(path string, cfg Config) error {
-	data, err := yaml.Marshal(cfg)
-	if err != nil {
-		return err
-	}
-	header := []byte("# Multiterminal UI configuration\n# Edit this file to customise defaults.\n\n")
-	return os.WriteFile(path, append(header, data...), 0644)
+	// Decode keybindings into an empty map so only user entries are seen
+	cfg.Keybindings = nil
+	err := yaml.Unmarshal(data, &cfg)
+	cfg.Keybindings = resolveKeybindings(cfg.Keybindings)
+	cfg.LaunchProfiles = validateLaunchProfiles(cfg.LaunchProfiles)
+	cfg.CustomThemes = validateCustomThemes(cfg.CustomThemes)
+	cfg.ANSIPalettes = validateANSIPalettes(cfg.ANSIPalettes, cfg.HasTheme)
+	logValidation(cfg.Validate())
+	return cfg, err
 }
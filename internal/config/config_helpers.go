@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// ShouldRestoreSession returns whether the session should be restored.
+func (c Config) ShouldRestoreSession() bool {
+	if c.RestoreSession == nil {
+		return true
+	}
+	return *c.RestoreSession
+}
+
+// ShouldAutoBranch returns whether to auto-create branches for issues.
+func (c Config) ShouldAutoBranch() bool {
+	if c.AutoBranchOnIssue == nil {
+		return true
+	}
+	return *c.AutoBranchOnIssue
+}
+
+// ShouldUseWorktrees returns whether to create git worktrees for issues.
+func (c Config) ShouldUseWorktrees() bool {
+	if c.UseWorktrees == nil {
+		return false
+	}
+	return *c.UseWorktrees
+}
+
+// ShouldFlushOnLine reports whether output that pauses after a line break
+// is sent without waiting for the rest of the coalescing window (default true).
+func (c Config) ShouldFlushOnLine() bool {
+	if c.OutputLineFlush == nil {
+		return true
+	}
+	return *c.OutputLineFlush
+}
+
+// ShouldReflowOnResize returns whether screens rewrap lines on width changes.
+func (c Config) ShouldReflowOnResize() bool {
+	if c.ReflowOnResize == nil {
+		return true
+	}
+	return *c.ReflowOnResize
+}
+
+// ShouldWatchSidebar returns whether the sidebar follows file changes in
+// the folders it shows (default true).
+func (c Config) ShouldWatchSidebar() bool {
+	if c.SidebarWatch == nil {
+		return true
+	}
+	return *c.SidebarWatch
+}
+
+// ShouldUseMonochrome reports whether panes are shown without colours:
+// monochrome is set, or NO_COLOR is set in the environment the app was
+// started with (https://no-color.org).
+func (c Config) ShouldUseMonochrome() bool {
+	return c.Monochrome || os.Getenv("NO_COLOR") != ""
+}
+
+// ShellArgv returns the command new shell panes run: default_shell_args if
+// set, otherwise default_shell as the program without arguments. nil means
+// the platform default shell.
+func (c Config) ShellArgv() []string {
+	if len(c.DefaultShellArgs) > 0 {
+		return append([]string(nil), c.DefaultShellArgs...)
+	}
+	if c.DefaultShell != "" {
+		return []string{c.DefaultShell}
+	}
+	return nil
+}
+
+// LaunchShellArgv returns ShellArgv if its program can be found, and
+// otherwise nil (the platform default shell) together with the reason, so
+// a missing default_shell never keeps new panes from opening.
+func (c Config) LaunchShellArgv() ([]string, error) {
+	argv := c.ShellArgv()
+	if argv == nil {
+		return nil, nil
+	}
+	if argv[0] == "" {
+		return nil, fmt.Errorf("default shell program is empty")
+	}
+	if _, err := exec.LookPath(argv[0]); err != nil {
+		return nil, err
+	}
+	return argv, nil
+}
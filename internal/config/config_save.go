@@ -0,0 +1,23 @@
+package config
+
+import "gopkg.in/yaml.v3"
+
+// Save writes the given config to the YAML file.
+func Save(cfg Config) error {
+	p := configPath()
+	if p == "" {
+		return nil
+	}
+	return writeDefaults(p, cfg)
+}
+
+// writeDefaults persists the configuration to disk, readable only by the
+// user (writePrivateFile).
+func writeDefaults(path string, cfg Config) error {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	header := []byte("# Multiterminal UI configuration\n# Edit this file to customise defaults.\n\n")
+	return writePrivateFile(path, append(header, data...))
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
+	"runtime"
 	"testing"
 
 	"gopkg.in/yaml.v3"
@@ -64,6 +66,24 @@ func TestShouldRestoreSession_NilDefault(t *testing.T) {
 	}
 }
 
+func TestShouldFlushOnLine_NilDefault(t *testing.T) {
+	if !(Config{}).ShouldFlushOnLine() {
+		t.Error("ShouldFlushOnLine with nil should return true")
+	}
+	if (Config{OutputLineFlush: boolPtr(false)}).ShouldFlushOnLine() {
+		t.Error("ShouldFlushOnLine should honor false")
+	}
+}
+
+func TestShouldReflowOnResize_NilDefault(t *testing.T) {
+	if !(Config{}).ShouldReflowOnResize() {
+		t.Error("ShouldReflowOnResize with nil should return true")
+	}
+	if (Config{ReflowOnResize: boolPtr(false)}).ShouldReflowOnResize() {
+		t.Error("ShouldReflowOnResize should honor false")
+	}
+}
+
 func TestShouldRestoreSession_True(t *testing.T) {
 	cfg := Config{RestoreSession: boolPtr(true)}
 	if !cfg.ShouldRestoreSession() {
@@ -124,41 +144,31 @@ func TestConfig_YAMLRoundTrip(t *testing.T) {
 // ---------------------------------------------------------------------------
 
 func TestConfig_Validation_MaxPanesPerTab(t *testing.T) {
-	dir := t.TempDir()
-	path := filepath.Join(dir, "test.yaml")
-
 	tests := []struct {
 		input int
 		want  int
+		warn  bool
 	}{
-		{0, 1},
-		{-5, 1},
-		{1, 1},
-		{6, 6},
-		{12, 12},
-		{13, 12},
-		{100, 12},
+		{0, 1, true},
+		{-5, 1, true},
+		{1, 1, false},
+		{6, 6, false},
+		{12, 12, false},
+		{13, 12, true},
+		{100, 12, true},
 	}
 
 	for _, tt := range tests {
 		cfg := DefaultConfig()
 		cfg.MaxPanesPerTab = tt.input
-		data, _ := yaml.Marshal(cfg)
-		os.WriteFile(path, data, 0644)
-
-		// Simulate what Load() does for validation
-		var loaded Config
-		yaml.Unmarshal(data, &loaded)
-		if loaded.MaxPanesPerTab < 1 {
-			loaded.MaxPanesPerTab = 1
-		}
-		if loaded.MaxPanesPerTab > 12 {
-			loaded.MaxPanesPerTab = 12
-		}
+		warnings := cfg.Validate()
 
-		if loaded.MaxPanesPerTab != tt.want {
+		if cfg.MaxPanesPerTab != tt.want {
 			t.Errorf("MaxPanesPerTab(%d) after validation = %d, want %d",
-				tt.input, loaded.MaxPanesPerTab, tt.want)
+				tt.input, cfg.MaxPanesPerTab, tt.want)
+		}
+		if got := hasWarning(warnings, "max_panes_per_tab"); got != tt.warn {
+			t.Errorf("MaxPanesPerTab(%d): warning reported = %v, want %v", tt.input, got, tt.warn)
 		}
 	}
 }
@@ -178,57 +188,291 @@ func TestConfig_Validation_SidebarWidth(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		// Apply the same validation logic as Load()
-		val := tt.input
-		if val < 15 {
-			val = 15
-		}
-		if val > 60 {
-			val = 60
-		}
-		if val != tt.want {
+		cfg := DefaultConfig()
+		cfg.SidebarWidth = tt.input
+		cfg.Validate()
+		if cfg.SidebarWidth != tt.want {
 			t.Errorf("SidebarWidth(%d) after validation = %d, want %d",
-				tt.input, val, tt.want)
+				tt.input, cfg.SidebarWidth, tt.want)
 		}
 	}
 }
 
 func TestConfig_Validation_Theme(t *testing.T) {
-	validThemes := map[string]bool{"dark": true, "light": true, "dracula": true, "nord": true, "solarized": true}
-
 	valid := []string{"dark", "light", "dracula", "nord", "solarized"}
 	for _, theme := range valid {
-		if !validThemes[theme] {
-			t.Errorf("Theme %q should be valid", theme)
+		cfg := DefaultConfig()
+		cfg.Theme = theme
+		if w := cfg.Validate(); len(w) != 0 || cfg.Theme != theme {
+			t.Errorf("Theme %q should be valid, got %q with warnings %v", theme, cfg.Theme, w)
 		}
 	}
 
 	invalid := []string{"", "monokai", "gruvbox", "DARK", "Light"}
 	for _, theme := range invalid {
-		if validThemes[theme] {
-			t.Errorf("Theme %q should be invalid", theme)
+		cfg := DefaultConfig()
+		cfg.Theme = theme
+		w := cfg.Validate()
+		if cfg.Theme != "dark" || !hasWarning(w, "theme") {
+			t.Errorf("Theme %q should be reset to dark with a warning, got %q %v", theme, cfg.Theme, w)
 		}
 	}
 }
 
+func TestConfig_Validation_AutoApprove(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.AutoApprove = []string{`^Run tests\?`, `(unclosed`, ``, `Allow .* \[y/N\]`}
+	w := cfg.Validate()
+	want := []string{`^Run tests\?`, `Allow .* \[y/N\]`}
+	if !reflect.DeepEqual(cfg.AutoApprove, want) {
+		t.Errorf("AutoApprove = %q, want %q", cfg.AutoApprove, want)
+	}
+	if !hasWarning(w, "auto_approve") {
+		t.Errorf("dropped patterns should be reported, got %v", w)
+	}
+}
+
+func TestConfig_Validation_ResultPatterns(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ResultPatterns = ResultPatterns{Pass: []string{`^DONE$`, `[bad`}, Fail: []string{``}}
+	w := cfg.Validate()
+	if !reflect.DeepEqual(cfg.ResultPatterns.Pass, []string{`^DONE$`}) {
+		t.Errorf("Pass = %q, want only the valid pattern", cfg.ResultPatterns.Pass)
+	}
+	if len(cfg.ResultPatterns.Fail) != 0 {
+		t.Errorf("Fail = %q, want empty", cfg.ResultPatterns.Fail)
+	}
+	if !hasWarning(w, "result_patterns.pass") || !hasWarning(w, "result_patterns.fail") {
+		t.Errorf("dropped patterns should be reported, got %v", w)
+	}
+}
+
+func TestConfig_Validation_NewTabDirMode(t *testing.T) {
+	for _, mode := range []string{"dialog", "inherit-tab", "inherit-pane-cwd", "fixed"} {
+		cfg := DefaultConfig()
+		cfg.NewTabDirMode = mode
+		if w := cfg.Validate(); len(w) != 0 || cfg.NewTabDirMode != mode {
+			t.Errorf("new_tab_dir_mode %q: got %q, warnings %v", mode, cfg.NewTabDirMode, w)
+		}
+	}
+	cfg := DefaultConfig()
+	cfg.NewTabDirMode = "cwd"
+	if w := cfg.Validate(); !hasWarning(w, "new_tab_dir_mode") || cfg.NewTabDirMode != "dialog" {
+		t.Errorf("unknown new_tab_dir_mode: got %q, warnings %v", cfg.NewTabDirMode, w)
+	}
+}
+
+func TestConfig_Validation_PasteSafety(t *testing.T) {
+	for _, mode := range []string{"off", "strip", "confirm"} {
+		cfg := DefaultConfig()
+		cfg.PasteSafety = mode
+		if w := cfg.Validate(); len(w) != 0 || cfg.PasteSafety != mode {
+			t.Errorf("paste_safety %q: got %q, warnings %v", mode, cfg.PasteSafety, w)
+		}
+	}
+	cfg := DefaultConfig()
+	cfg.PasteSafety = "ask"
+	if w := cfg.Validate(); !hasWarning(w, "paste_safety") || cfg.PasteSafety != "off" {
+		t.Errorf("unknown paste_safety: got %q, warnings %v", cfg.PasteSafety, w)
+	}
+}
+
+func TestShellArgv(t *testing.T) {
+	cases := []struct {
+		shell string
+		args  []string
+		want  []string
+	}{
+		{"", nil, nil},
+		{"/bin/zsh", nil, []string{"/bin/zsh"}},
+		{"", []string{"bash", "-l"}, []string{"bash", "-l"}},
+		{"/bin/zsh", []string{"bash", "-i"}, []string{"bash", "-i"}},
+		{`C:\Program Files\Git\bin\bash.exe`, nil, []string{`C:\Program Files\Git\bin\bash.exe`}},
+	}
+	for _, tc := range cases {
+		cfg := Config{DefaultShell: tc.shell, DefaultShellArgs: tc.args}
+		if got := cfg.ShellArgv(); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("ShellArgv(%q, %q) = %q, want %q", tc.shell, tc.args, got, tc.want)
+		}
+	}
+}
+
+func TestConfig_Validation_DefaultShell(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Skip(err)
+	}
+	cfg := DefaultConfig()
+	cfg.DefaultShellArgs = []string{exe, "-l"}
+	if w := cfg.Validate(); len(w) != 0 || len(cfg.DefaultShellArgs) != 2 {
+		t.Errorf("existing shell: args %q, warnings %v", cfg.DefaultShellArgs, w)
+	}
+
+	// A missing program is reported but kept, so the config file is not
+	// rewritten without it; only launching falls back.
+	cfg = DefaultConfig()
+	cfg.DefaultShell = "no-such-shell-for-multiterminal"
+	if w := cfg.Validate(); !hasWarning(w, "default_shell") || cfg.DefaultShell == "" {
+		t.Errorf("missing default_shell: shell %q, warnings %v", cfg.DefaultShell, w)
+	}
+	if argv, err := cfg.LaunchShellArgv(); argv != nil || err == nil {
+		t.Errorf("missing default_shell: LaunchShellArgv = %q, %v; want nil and an error", argv, err)
+	}
+
+	cfg = DefaultConfig()
+	cfg.DefaultShellArgs = []string{"no-such-shell-for-multiterminal", "-i"}
+	if w := cfg.Validate(); !hasWarning(w, "default_shell_args") || len(cfg.DefaultShellArgs) != 2 {
+		t.Errorf("missing default_shell_args program: args %q, warnings %v", cfg.DefaultShellArgs, w)
+	}
+
+	cfg = DefaultConfig()
+	cfg.DefaultShellArgs = []string{exe, "-l"}
+	if argv, err := cfg.LaunchShellArgv(); err != nil || len(argv) != 2 {
+		t.Errorf("existing shell: LaunchShellArgv = %q, %v", argv, err)
+	}
+}
+
 func TestConfig_Validation_CommitReminder(t *testing.T) {
 	// Negative values should be clamped to 0
-	val := -10
-	if val < 0 {
-		val = 0
-	}
-	if val != 0 {
-		t.Errorf("CommitReminderMinutes(-10) = %d, want 0", val)
+	cfg := DefaultConfig()
+	cfg.CommitReminderMinutes = -10
+	cfg.Validate()
+	if cfg.CommitReminderMinutes != 0 {
+		t.Errorf("CommitReminderMinutes(-10) = %d, want 0", cfg.CommitReminderMinutes)
 	}
 
 	// Positive values should pass through
-	val = 30
-	if val < 0 {
-		val = 0
+	cfg = DefaultConfig()
+	cfg.CommitReminderMinutes = 30
+	cfg.Validate()
+	if cfg.CommitReminderMinutes != 30 {
+		t.Errorf("CommitReminderMinutes(30) = %d, want 30", cfg.CommitReminderMinutes)
+	}
+}
+
+func TestConfig_Validation_ControlAPI(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.ControlPort != 41987 || len(cfg.ControlAPI) != 0 {
+		t.Errorf("defaults: port %d, commands %v; want 41987 and none", cfg.ControlPort, cfg.ControlAPI)
+	}
+
+	cfg.ControlAPI = []string{"list_sessions", "rm_rf", "send"}
+	cfg.ControlPort = 80
+	w := cfg.Validate()
+	if !hasWarning(w, "control_api") || !reflect.DeepEqual(cfg.ControlAPI, []string{"list_sessions", "send"}) {
+		t.Errorf("control_api = %v, warnings %v; want unknown command dropped", cfg.ControlAPI, w)
+	}
+	if !hasWarning(w, "control_port") || cfg.ControlPort != 1024 {
+		t.Errorf("control_port = %d, want clamped to 1024", cfg.ControlPort)
+	}
+}
+
+func TestConfig_Validation_QuitBinding(t *testing.T) {
+	cfg := DefaultConfig()
+	if w := cfg.Validate(); hasWarning(w, "quit_binding") || cfg.QuitBinding != "" || !*cfg.InterceptCtrlC {
+		t.Errorf("defaults: quit_binding %q, intercept_ctrl_c %v, warnings %v; want off and true", cfg.QuitBinding, *cfg.InterceptCtrlC, w)
+	}
+
+	for _, tc := range []struct{ in, want string }{
+		{"double_ctrl_c", "double_ctrl_c"},
+		{"Ctrl+Q", "ctrl+q"},
+	} {
+		cfg = DefaultConfig()
+		cfg.QuitBinding = tc.in
+		if w := cfg.Validate(); hasWarning(w, "quit_binding") || cfg.QuitBinding != tc.want {
+			t.Errorf("quit_binding %q = %q (warnings %v), want %q", tc.in, cfg.QuitBinding, w, tc.want)
+		}
+	}
+
+	for _, bad := range []string{"q", "ctrl+c", "ctrl+t", "triple_ctrl_c"} {
+		cfg = DefaultConfig()
+		cfg.QuitBinding = bad
+		if w := cfg.Validate(); !hasWarning(w, "quit_binding") || cfg.QuitBinding != "" {
+			t.Errorf("quit_binding %q = %q, want turned off with a warning", bad, cfg.QuitBinding)
+		}
 	}
-	if val != 30 {
-		t.Errorf("CommitReminderMinutes(30) = %d, want 30", val)
+}
+
+func TestConfig_Validation_CommitFactors(t *testing.T) {
+	cfg := DefaultConfig()
+	if w := cfg.Validate(); hasWarning(w, "commit_reminder_warning_factor") || hasWarning(w, "commit_reminder_danger_factor") {
+		t.Errorf("defaults warned: %v", w)
+	}
+
+	cfg = DefaultConfig()
+	cfg.CommitWarningFactor = 0.5
+	if w := cfg.Validate(); !hasWarning(w, "commit_reminder_warning_factor") || cfg.CommitWarningFactor != 2 {
+		t.Errorf("warning factor 0.5: got %g, warnings %v", cfg.CommitWarningFactor, w)
+	}
+
+	cfg = DefaultConfig()
+	cfg.CommitWarningFactor = 4
+	cfg.CommitDangerFactor = 3
+	if w := cfg.Validate(); !hasWarning(w, "commit_reminder_danger_factor") || cfg.CommitDangerFactor != 5 {
+		t.Errorf("danger below warning: got %g, warnings %v", cfg.CommitDangerFactor, w)
+	}
+
+	// Equal factors are allowed: warning is skipped and danger starts at once
+	cfg = DefaultConfig()
+	cfg.CommitWarningFactor = 1.5
+	cfg.CommitDangerFactor = 1.5
+	if w := cfg.Validate(); hasWarning(w, "commit_reminder_danger_factor") {
+		t.Errorf("equal factors warned: %v", w)
+	}
+}
+
+func TestConfig_Validate_DefaultsAreClean(t *testing.T) {
+	cfg := DefaultConfig()
+	if w := cfg.Validate(); len(w) != 0 {
+		t.Errorf("default config should validate without warnings, got %v", w)
+	}
+}
+
+func TestConfig_Validate_ReportsEachCorrection(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Audio.Volume = 150
+	cfg.DefaultLaunch = "tmux"
+	cfg.LocalhostAutoOpen = "always"
+	cfg.FontSize = 11
+	cfg.OutputChunkLimitKB = 2
+	cfg.Audio.Enabled = nil
+
+	w := cfg.Validate()
+	for _, field := range []string{"audio.volume", "default_launch", "localhost_auto_open", "font_size", "output_chunk_limit_kb"} {
+		if !hasWarning(w, field) {
+			t.Errorf("missing warning for %s in %v", field, w)
+		}
+	}
+	if len(w) != 5 {
+		t.Errorf("expected 5 warnings (unset audio.enabled is silent), got %d: %v", len(w), w)
+	}
+	if cfg.Audio.Volume != 100 || cfg.DefaultLaunch != "dialog" || cfg.LocalhostAutoOpen != "notify" ||
+		cfg.FontSize != 10 || cfg.OutputChunkLimitKB != 64 || cfg.Audio.Enabled == nil {
+		t.Errorf("corrections not applied: %+v", cfg)
+	}
+}
+
+func TestConfig_Validate_ScanIntervalMaxBelowMin(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ScanIntervalMinMs = 800
+	cfg.ScanIntervalMaxMs = 300
+
+	if w := cfg.Validate(); !hasWarning(w, "scan_interval_max_ms") {
+		t.Errorf("missing warning for scan_interval_max_ms in %v", w)
+	}
+	if cfg.ScanIntervalMaxMs != 800 {
+		t.Errorf("ScanIntervalMaxMs = %d, want raised to the minimum 800", cfg.ScanIntervalMaxMs)
+	}
+}
+
+// hasWarning reports whether warnings contain an entry for field.
+func hasWarning(warnings []ValidationWarning, field string) bool {
+	for _, w := range warnings {
+		if w.Field == field {
+			return true
+		}
 	}
+	return false
 }
 
 // ---------------------------------------------------------------------------
@@ -257,6 +501,9 @@ func TestSessionState_JSONRoundTrip(t *testing.T) {
 				},
 			},
 		},
+		Sidebar: map[string]SidebarState{
+			"/tmp": {Expanded: []string{"/tmp/src", "/tmp/src/lib"}, Selected: "/tmp/src/lib/a.go", Scroll: 120},
+		},
 	}
 
 	data, err := json.MarshalIndent(original, "", "  ")
@@ -284,6 +531,9 @@ func TestSessionState_JSONRoundTrip(t *testing.T) {
 	if loaded.Tabs[0].Panes[1].Model != "Opus 4.6" {
 		t.Errorf("Tab 0 pane 1 model = %q, want 'Opus 4.6'", loaded.Tabs[0].Panes[1].Model)
 	}
+	if !reflect.DeepEqual(loaded.Sidebar, original.Sidebar) {
+		t.Errorf("Sidebar = %+v, want %+v", loaded.Sidebar, original.Sidebar)
+	}
 }
 
 func TestSessionState_EmptyTabsReturnsNil(t *testing.T) {
@@ -344,6 +594,56 @@ func TestSaveSession_WriteAndRead(t *testing.T) {
 	}
 }
 
+func TestSaveSession_PrivateFileMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix permission bits")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(home, ".multiterminal-session.json")
+	os.WriteFile(path, []byte("{}"), 0644) // left by an older version
+
+	state := SessionState{Tabs: []SavedTab{{Name: "Main", Panes: []SavedPane{
+		{Name: "Claude", Mode: 1, Env: map[string]string{"ANTHROPIC_API_KEY": "sk-test"}},
+	}}}}
+	if err := SaveSession(state); err != nil {
+		t.Fatalf("SaveSession: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("session file mode = %o, want 600", mode)
+	}
+}
+
+func TestSave_PrivateFileMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix permission bits")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(home, ".multiterminal.yaml")
+	os.WriteFile(path, []byte("theme: dark\n"), 0644) // left by an older version
+
+	cfg := DefaultConfig()
+	cfg.LaunchProfiles = []LaunchProfile{{Label: "work", Argv: []string{"claude"}, Mode: "claude", Env: map[string]string{"ANTHROPIC_API_KEY": "sk-test"}}}
+	if err := Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("config file mode = %o, want 600", mode)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temp file left behind: %v", err)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Favorites
 // ---------------------------------------------------------------------------
@@ -390,3 +690,38 @@ func TestConfig_FavoritesDefaultNil(t *testing.T) {
 		t.Errorf("DefaultConfig should have nil Favorites, got %v", cfg.Favorites)
 	}
 }
+
+func TestDefaultConfig_OutputStreaming(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.OutputCoalesceMs != 0 {
+		t.Errorf("OutputCoalesceMs = %d, want 0 (adaptive)", cfg.OutputCoalesceMs)
+	}
+	if cfg.OutputChunkLimitKB != 64 {
+		t.Errorf("OutputChunkLimitKB = %d, want 64", cfg.OutputChunkLimitKB)
+	}
+	if cfg.OutputThrottleMs != 0 {
+		t.Errorf("OutputThrottleMs = %d, want 0 (off)", cfg.OutputThrottleMs)
+	}
+	if cfg.ScanIntervalMinMs != 200 || cfg.ScanIntervalMaxMs != 2000 {
+		t.Errorf("scan intervals = %d/%d ms, want 200/2000", cfg.ScanIntervalMinMs, cfg.ScanIntervalMaxMs)
+	}
+}
+
+func TestDefaultConfig_DefaultLaunch(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.DefaultLaunch != "dialog" {
+		t.Errorf("DefaultLaunch = %q, want 'dialog'", cfg.DefaultLaunch)
+	}
+}
+
+func TestConfig_Validation_SidebarIgnore(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SidebarIgnore = []string{"target", "[", "!", "!*.lock"}
+	w := cfg.Validate()
+	if !hasWarning(w, "sidebar_ignore") {
+		t.Errorf("expected sidebar_ignore warnings, got %v", w)
+	}
+	if want := []string{"target", "!*.lock"}; !reflect.DeepEqual(cfg.SidebarIgnore, want) {
+		t.Errorf("SidebarIgnore = %q, want %q", cfg.SidebarIgnore, want)
+	}
+}
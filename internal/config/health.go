@@ -10,6 +10,8 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+
+	"gopkg.in/yaml.v3"
 )
 
 // HealthState tracks shutdown history and auto-logging state.
@@ -24,6 +26,9 @@ type HealthState struct {
 
 const maxShutdownHistory = 5
 
+// CrashLoopRuns is how many dirty shutdowns in a row count as a crash loop.
+const CrashLoopRuns = 3
+
 // healthPath returns the path to ~/.multiterminal-health.json.
 func healthPath() string {
 	home, err := os.UserHomeDir()
@@ -99,6 +104,22 @@ func HasRepeatedCrashes(h *HealthState) bool {
 	return !h.Shutdowns[n-3] && !h.Shutdowns[n-2]
 }
 
+// HasCrashLoop returns true if the last CrashLoopRuns completed sessions
+// were all dirty. Like HasRepeatedCrashes it skips the current session,
+// which MarkStarting has just recorded as dirty.
+func HasCrashLoop(h *HealthState) bool {
+	n := len(h.Shutdowns) - 1
+	if n < CrashLoopRuns {
+		return false
+	}
+	for _, clean := range h.Shutdowns[n-CrashLoopRuns : n] {
+		if clean {
+			return false
+		}
+	}
+	return true
+}
+
 // ShouldAutoDisableLogging returns true if auto-logging should be turned off
 // (3 consecutive clean shutdowns since it was enabled).
 func ShouldAutoDisableLogging(h *HealthState) bool {
@@ -116,3 +137,25 @@ func DisableAutoLogging(h *HealthState) {
 	h.LoggingAuto = false
 	h.CleanSinceAuto = 0
 }
+
+// ReportYAML renders the config for a crash report. Launch profile
+// environment values are replaced, as they may hold tokens.
+func ReportYAML(c Config) string {
+	profiles := make([]LaunchProfile, len(c.LaunchProfiles))
+	for i, p := range c.LaunchProfiles {
+		if len(p.Env) > 0 {
+			env := make(map[string]string, len(p.Env))
+			for k := range p.Env {
+				env[k] = "<redacted>"
+			}
+			p.Env = env
+		}
+		profiles[i] = p
+	}
+	c.LaunchProfiles = profiles
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return "# config could not be rendered: " + err.Error() + "\n"
+	}
+	return string(data)
+}
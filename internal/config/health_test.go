@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -92,6 +93,38 @@ func TestHasRepeatedCrashes_AllClean(t *testing.T) {
 	}
 }
 
+func TestHasCrashLoop(t *testing.T) {
+	tests := []struct {
+		name      string
+		shutdowns []bool
+		want      bool
+	}{
+		{"three dirty before current", []bool{false, false, false, false}, true},
+		{"clean within the window", []bool{false, true, false, false}, false},
+		{"clean before the window", []bool{true, false, false, false, false}, true},
+		{"only two completed", []bool{false, false, false}, false},
+		{"no history", nil, false},
+	}
+	for _, tt := range tests {
+		h := HealthState{Shutdowns: tt.shutdowns}
+		if got := HasCrashLoop(&h); got != tt.want {
+			t.Errorf("%s: HasCrashLoop(%v) = %v, want %v", tt.name, tt.shutdowns, got, tt.want)
+		}
+	}
+}
+
+func TestReportYAML_RedactsProfileEnv(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.LaunchProfiles = []LaunchProfile{{Label: "api", Argv: []string{"bash"}, Env: map[string]string{"API_TOKEN": "s3cret"}}}
+	out := ReportYAML(cfg)
+	if strings.Contains(out, "s3cret") || !strings.Contains(out, "API_TOKEN: <redacted>") {
+		t.Errorf("env not redacted:\n%s", out)
+	}
+	if cfg.LaunchProfiles[0].Env["API_TOKEN"] != "s3cret" {
+		t.Error("ReportYAML changed the caller's config")
+	}
+}
+
 func TestShouldAutoDisableLogging(t *testing.T) {
 	tests := []struct {
 		name   string
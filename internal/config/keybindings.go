@@ -0,0 +1,146 @@
+package config
+
+import (
+	"log"
+	"sort"
+	"strings"
+)
+
+// defaultKeybindings maps action names to their built-in key specs.
+// Ctrl+1-9 (focus pane by index) is fixed and not remappable.
+var defaultKeybindings = map[string]string{
+	"new_pane":         "ctrl+n",
+	"launch_dialog":    "ctrl+shift+n",
+	"new_tab":          "ctrl+t",
+	"close_tab":        "ctrl+w",
+	"toggle_sidebar":   "ctrl+b",
+	"toggle_maximize":  "ctrl+z",
+	"open_issues":      "ctrl+i",
+	"restart_pane":     "ctrl+shift+r",
+	"cycle_theme":      "ctrl+shift+t",
+	"search":           "ctrl+f",
+	"select_mode":      "ctrl+shift+space",
+	"command_history":  "ctrl+shift+h",
+	"toggle_readonly":  "ctrl+shift+l",
+	"toggle_pin":       "ctrl+shift+k",
+	"split_horizontal": "ctrl+shift+e",
+	"split_vertical":   "ctrl+shift+o",
+}
+
+// modifierOrder is the canonical modifier order in a normalised key spec.
+var modifierOrder = []string{"ctrl", "alt", "shift", "meta"}
+
+// DefaultKeybindings returns a fresh copy of the built-in keybindings.
+func DefaultKeybindings() map[string]string {
+	m := make(map[string]string, len(defaultKeybindings))
+	for action, spec := range defaultKeybindings {
+		m[action] = spec
+	}
+	return m
+}
+
+// normalizeKeySpec converts a spec like "Shift+Ctrl+N" into the canonical
+// form "ctrl+shift+n". Specs must have exactly one non-modifier key and at
+// least one of ctrl/alt/meta so plain typing is never captured.
+func normalizeKeySpec(spec string) (string, bool) {
+	mods := make(map[string]bool)
+	key := ""
+	for _, part := range strings.Split(strings.ToLower(strings.TrimSpace(spec)), "+") {
+		part = strings.TrimSpace(part)
+		switch part {
+		case "ctrl", "control":
+			mods["ctrl"] = true
+		case "alt", "option":
+			mods["alt"] = true
+		case "shift":
+			mods["shift"] = true
+		case "meta", "cmd", "super":
+			mods["meta"] = true
+		case "":
+			return "", false
+		default:
+			if key != "" {
+				return "", false
+			}
+			key = part
+		}
+	}
+	if key == "" || !(mods["ctrl"] || mods["alt"] || mods["meta"]) {
+		return "", false
+	}
+	parts := make([]string, 0, len(mods)+1)
+	for _, m := range modifierOrder {
+		if mods[m] {
+			parts = append(parts, m)
+		}
+	}
+	return strings.Join(append(parts, key), "+"), true
+}
+
+// resolveKeybindings merges user bindings over the defaults. Unknown actions
+// and malformed specs are dropped; user bindings that end up sharing a key
+// with another action are rejected in favour of the default. Every problem
+// is logged so users can see why a binding did not take effect.
+func resolveKeybindings(user map[string]string) map[string]string {
+	result := DefaultKeybindings()
+	custom := make(map[string]bool)
+
+	actions := make([]string, 0, len(user))
+	for action := range user {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	for _, action := range actions {
+		if _, known := defaultKeybindings[action]; !known {
+			log.Printf("[config] keybindings: unknown action %q ignored", action)
+			continue
+		}
+		spec, ok := normalizeKeySpec(user[action])
+		if !ok {
+			log.Printf("[config] keybindings: invalid key %q for %q, using %q", user[action], action, result[action])
+			continue
+		}
+		if spec != result[action] {
+			result[action] = spec
+			custom[action] = true
+		}
+	}
+
+	// Revert conflicting custom bindings until every key is unique. Each pass
+	// reverts at least one action, so this terminates after len(custom) passes.
+	for {
+		owners := make(map[string][]string)
+		for _, action := range sortedKeys(result) {
+			owners[result[action]] = append(owners[result[action]], action)
+		}
+		reverted := false
+		for spec, list := range owners {
+			if len(list) < 2 {
+				continue
+			}
+			for _, action := range list {
+				if custom[action] {
+					log.Printf("[config] keybindings: %q for %q conflicts with %v, using %q",
+						spec, action, list, defaultKeybindings[action])
+					result[action] = defaultKeybindings[action]
+					delete(custom, action)
+					reverted = true
+				}
+			}
+		}
+		if !reverted {
+			return result
+		}
+	}
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
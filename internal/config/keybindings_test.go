@@ -0,0 +1,75 @@
+package config
+
+import "testing"
+
+func TestNormalizeKeySpec(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"ctrl+n", "ctrl+n", true},
+		{"Shift+Ctrl+N", "ctrl+shift+n", true},
+		{" alt + t ", "alt+t", true},
+		{"cmd+k", "meta+k", true},
+		{"n", "", false},          // no modifier
+		{"shift+n", "", false},    // shift alone would capture typing
+		{"ctrl+a+b", "", false},   // two keys
+		{"ctrl+", "", false},      // missing key
+		{"ctrl++", "", false},     // empty part
+		{"ctrl+shift", "", false}, // modifiers only
+	}
+	for _, tt := range tests {
+		got, ok := normalizeKeySpec(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("normalizeKeySpec(%q) = (%q, %v), want (%q, %v)", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestResolveKeybindings_DefaultsForUnmapped(t *testing.T) {
+	got := resolveKeybindings(map[string]string{"new_tab": "Alt+T"})
+	if got["new_tab"] != "alt+t" {
+		t.Errorf("new_tab = %q, want alt+t", got["new_tab"])
+	}
+	if got["close_tab"] != "ctrl+w" {
+		t.Errorf("close_tab = %q, want default ctrl+w", got["close_tab"])
+	}
+	if len(got) != len(defaultKeybindings) {
+		t.Errorf("got %d bindings, want %d", len(got), len(defaultKeybindings))
+	}
+}
+
+func TestResolveKeybindings_DropsUnknownAndInvalid(t *testing.T) {
+	got := resolveKeybindings(map[string]string{
+		"launch_rockets": "ctrl+r",
+		"toggle_sidebar": "b",
+	})
+	if _, ok := got["launch_rockets"]; ok {
+		t.Error("unknown action should be dropped")
+	}
+	if got["toggle_sidebar"] != "ctrl+b" {
+		t.Errorf("toggle_sidebar = %q, want default ctrl+b", got["toggle_sidebar"])
+	}
+}
+
+func TestResolveKeybindings_RejectsConflicts(t *testing.T) {
+	// new_tab on ctrl+n collides with the default new_pane binding
+	got := resolveKeybindings(map[string]string{"new_tab": "ctrl+n"})
+	if got["new_tab"] != "ctrl+t" || got["new_pane"] != "ctrl+n" {
+		t.Errorf("conflict not rejected: new_tab=%q new_pane=%q", got["new_tab"], got["new_pane"])
+	}
+
+	// Two custom bindings on the same key both fall back to their defaults
+	got = resolveKeybindings(map[string]string{"new_tab": "alt+x", "close_tab": "alt+x"})
+	if got["new_tab"] != "ctrl+t" || got["close_tab"] != "ctrl+w" {
+		t.Errorf("custom conflict not rejected: new_tab=%q close_tab=%q", got["new_tab"], got["close_tab"])
+	}
+}
+
+func TestResolveKeybindings_AllowsSwap(t *testing.T) {
+	got := resolveKeybindings(map[string]string{"new_tab": "ctrl+n", "new_pane": "ctrl+t"})
+	if got["new_tab"] != "ctrl+n" || got["new_pane"] != "ctrl+t" {
+		t.Errorf("swap rejected: new_tab=%q new_pane=%q", got["new_tab"], got["new_pane"])
+	}
+}
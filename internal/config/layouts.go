@@ -0,0 +1,118 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// layoutNamePattern restricts layout names to characters that are safe as
+// file names on every platform (no path separators or dots).
+var layoutNamePattern = regexp.MustCompile(`^[\p{L}\p{N} _-]{1,64}$`)
+
+// reservedLayoutName matches device names Windows refuses as file names,
+// whatever the case (CON, NUL, COM1, ...).
+var reservedLayoutName = regexp.MustCompile(`(?i)^(con|prn|aux|nul|com[0-9]|lpt[0-9])$`)
+
+// layoutsDir returns the directory holding named layouts
+// (~/.multiterminal-layouts).
+func layoutsDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".multiterminal-layouts")
+}
+
+// validateLayoutName trims name and checks that it is usable as a file name.
+func validateLayoutName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if !layoutNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid layout name %q (letters, digits, space, - and _ only)", name)
+	}
+	if reservedLayoutName.MatchString(name) {
+		return "", fmt.Errorf("invalid layout name %q (reserved by Windows)", name)
+	}
+	return name, nil
+}
+
+// SaveLayout stores state as a named layout, replacing any existing one.
+func SaveLayout(name string, state SessionState) error {
+	return saveLayoutIn(layoutsDir(), name, state)
+}
+
+// LoadLayout reads the named layout.
+func LoadLayout(name string) (*SessionState, error) {
+	return loadLayoutIn(layoutsDir(), name)
+}
+
+// ListLayouts returns the names of all saved layouts, sorted.
+func ListLayouts() ([]string, error) {
+	return listLayoutsIn(layoutsDir())
+}
+
+func saveLayoutIn(dir, name string, state SessionState) error {
+	name, err := validateLayoutName(name)
+	if err != nil {
+		return err
+	}
+	if dir == "" {
+		return fmt.Errorf("no home directory")
+	}
+	if len(state.Tabs) == 0 {
+		return fmt.Errorf("layout %q has no tabs", name)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writePrivateFile(filepath.Join(dir, name+".json"), data)
+}
+
+func loadLayoutIn(dir, name string) (*SessionState, error) {
+	name, err := validateLayoutName(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name+".json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("layout %q not found", name)
+		}
+		return nil, err
+	}
+	var state SessionState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("layout %q is corrupt: %w", name, err)
+	}
+	if len(state.Tabs) == 0 {
+		return nil, fmt.Errorf("layout %q has no tabs", name)
+	}
+	return &state, nil
+}
+
+func listLayoutsIn(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+	names := []string{}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
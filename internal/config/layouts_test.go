@@ -0,0 +1,82 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func sampleLayout() SessionState {
+	return SessionState{
+		ActiveTab: 1,
+		Tabs: []SavedTab{
+			{Name: "Review", Dir: "/src", Panes: []SavedPane{{Name: "Claude", Mode: 1}}},
+			{Name: "Build", Dir: "/src", FocusIdx: 1, Panes: []SavedPane{
+				{Name: "Shell", Mode: 0},
+				{Name: "Tests", Mode: 0, Argv: []string{"npm", "test"}},
+			}},
+		},
+	}
+}
+
+func TestLayouts_SaveLoadRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	want := sampleLayout()
+	if err := saveLayoutIn(dir, "review", want); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	got, err := loadLayoutIn(dir, " review ")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("round-trip mismatch:\n got %+v\nwant %+v", *got, want)
+	}
+}
+
+func TestLayouts_ListSorted(t *testing.T) {
+	dir := t.TempDir()
+	if names, err := listLayoutsIn(filepath.Join(dir, "missing")); err != nil || len(names) != 0 {
+		t.Fatalf("missing dir: names=%v err=%v, want empty", names, err)
+	}
+	for _, n := range []string{"build", "Review 2", "alpha"} {
+		if err := saveLayoutIn(dir, n, sampleLayout()); err != nil {
+			t.Fatalf("save %q: %v", n, err)
+		}
+	}
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0644)
+
+	names, err := listLayoutsIn(dir)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	want := []string{"Review 2", "alpha", "build"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("ListLayouts = %v, want %v", names, want)
+	}
+}
+
+func TestLayouts_RejectsBadNames(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"", "   ", "../evil", "a/b", `a\b`, "x.json", "CON", "nul", "Com1", "LPT9", " aux "} {
+		if err := saveLayoutIn(dir, name, sampleLayout()); err == nil {
+			t.Errorf("saveLayout(%q) should fail", name)
+		}
+	}
+	for _, name := range []string{"console", "com", "nul-test", "COM 1"} {
+		if err := saveLayoutIn(dir, name, sampleLayout()); err != nil {
+			t.Errorf("saveLayout(%q): %v", name, err)
+		}
+	}
+}
+
+func TestLayouts_EmptyAndMissing(t *testing.T) {
+	dir := t.TempDir()
+	if err := saveLayoutIn(dir, "empty", SessionState{}); err == nil {
+		t.Error("saving a layout without tabs should fail")
+	}
+	if _, err := loadLayoutIn(dir, "nope"); err == nil {
+		t.Error("loading a missing layout should fail")
+	}
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"log"
+	"strings"
+)
+
+// LaunchProfile is a user-defined entry in the launch dialog that spawns a
+// fixed command (e.g. "Run tests" → npm test).
+type LaunchProfile struct {
+	Label          string            `yaml:"label" json:"label"`
+	Argv           []string          `yaml:"argv" json:"argv"`
+	Dir            string            `yaml:"dir,omitempty" json:"dir,omitempty"`                         // optional working dir; empty = tab dir
+	Mode           string            `yaml:"mode" json:"mode"`                                           // "shell", "claude" or "yolo"
+	StartupCommand string            `yaml:"startup_command,omitempty" json:"startup_command,omitempty"` // typed into the pane once it is ready
+	Env            map[string]string `yaml:"env,omitempty" json:"env,omitempty"`                         // extra environment, overrides inherited vars
+}
+
+// validateLaunchProfiles drops profiles without a label or command and
+// normalises unknown modes to "shell". Dropped entries and invalid env
+// names are logged.
+func validateLaunchProfiles(profiles []LaunchProfile) []LaunchProfile {
+	valid := make([]LaunchProfile, 0, len(profiles))
+	for i, p := range profiles {
+		p.Label = strings.TrimSpace(p.Label)
+		p.StartupCommand = strings.TrimSpace(p.StartupCommand)
+		argv := make([]string, 0, len(p.Argv))
+		for _, arg := range p.Argv {
+			if arg != "" {
+				argv = append(argv, arg)
+			}
+		}
+		p.Argv = argv
+		if p.Label == "" || len(p.Argv) == 0 {
+			log.Printf("[config] launch_profiles[%d]: label and argv are required, skipping", i)
+			continue
+		}
+		for name := range p.Env {
+			if !validEnvName(name) {
+				log.Printf("[config] launch_profiles[%d] %q: invalid env name %q, skipping", i, p.Label, name)
+				delete(p.Env, name)
+			}
+		}
+		switch p.Mode {
+		case "shell", "claude", "yolo":
+		default:
+			if p.Mode != "" {
+				log.Printf("[config] launch_profiles[%d] %q: unknown mode %q, using shell", i, p.Label, p.Mode)
+			}
+			p.Mode = "shell"
+		}
+		valid = append(valid, p)
+	}
+	return valid
+}
+
+// validEnvName reports whether name can be used as an environment variable
+// name: non-empty, without "=", whitespace or NUL.
+func validEnvName(name string) bool {
+	return name != "" && !strings.ContainsAny(name, "= \t\r\n\x00")
+}
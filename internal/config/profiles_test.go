@@ -0,0 +1,65 @@
+package config
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidateLaunchProfiles(t *testing.T) {
+	got := validateLaunchProfiles([]LaunchProfile{
+		{Label: "Run tests", Argv: []string{"npm", "test"}},
+		{Label: "  ", Argv: []string{"ls"}},
+		{Label: "Empty", Argv: []string{""}},
+		{Label: "Dev server", Argv: []string{"pnpm", "dev"}, Dir: "/srv/app", Mode: "shell", StartupCommand: "  nvm use  "},
+		{Label: "Review", Argv: []string{"claude", "-p", "review"}, Mode: "claude",
+			Env: map[string]string{"ANTHROPIC_API_KEY": "sk-test", "": "x", "BAD NAME": "y", "A=B": "z"}},
+		{Label: "Odd", Argv: []string{"top"}, Mode: "fullscreen"},
+	})
+	if len(got) != 4 {
+		t.Fatalf("expected 4 valid profiles, got %d: %+v", len(got), got)
+	}
+	if got[0].Mode != "shell" {
+		t.Errorf("missing mode = %q, want shell", got[0].Mode)
+	}
+	if got[1].Dir != "/srv/app" {
+		t.Errorf("Dir = %q, want /srv/app", got[1].Dir)
+	}
+	if got[1].StartupCommand != "nvm use" {
+		t.Errorf("StartupCommand = %q, want trimmed \"nvm use\"", got[1].StartupCommand)
+	}
+	if got[2].Mode != "claude" {
+		t.Errorf("Mode = %q, want claude", got[2].Mode)
+	}
+	if len(got[2].Env) != 1 || got[2].Env["ANTHROPIC_API_KEY"] != "sk-test" {
+		t.Errorf("Env = %v, want only the valid ANTHROPIC_API_KEY entry", got[2].Env)
+	}
+	if got[3].Mode != "shell" {
+		t.Errorf("unknown mode = %q, want shell", got[3].Mode)
+	}
+}
+
+func TestLaunchProfiles_YAMLRoundTrip(t *testing.T) {
+	src := "launch_profiles:\n  - label: Run tests\n    argv: [npm, test]\n    mode: shell\n    env:\n      NO_COLOR: \"1\"\n"
+	var cfg Config
+	if err := yaml.Unmarshal([]byte(src), &cfg); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(cfg.LaunchProfiles) != 1 || cfg.LaunchProfiles[0].Argv[1] != "test" {
+		t.Fatalf("unexpected profiles: %+v", cfg.LaunchProfiles)
+	}
+	out, err := yaml.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var back Config
+	if err := yaml.Unmarshal(out, &back); err != nil {
+		t.Fatalf("re-unmarshal: %v", err)
+	}
+	if back.LaunchProfiles[0].Label != "Run tests" {
+		t.Errorf("round-trip label = %q", back.LaunchProfiles[0].Label)
+	}
+	if back.LaunchProfiles[0].Env["NO_COLOR"] != "1" {
+		t.Errorf("round-trip env = %v", back.LaunchProfiles[0].Env)
+	}
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"errors"
+	"os"
+)
+
+// Path returns the location of the config file, or "" if the home
+// directory cannot be determined.
+func Path() string {
+	return configPath()
+}
+
+// Reload re-reads the config file for a live reload. Unlike Load it never
+// writes defaults and reports read and YAML errors, so the caller can keep
+// its current config when the file is missing or malformed.
+func Reload() (Config, error) {
+	p := configPath()
+	if p == "" {
+		return Config{}, errors.New("config path unknown")
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return Config{}, err
+	}
+	cfg, err := Parse(data)
+	if err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
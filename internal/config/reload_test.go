@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParse_AppliesValidation(t *testing.T) {
+	cfg, err := Parse([]byte("theme: neon\nmax_panes_per_tab: 99\nkeybindings:\n  new_tab: alt+t\n"))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if cfg.Theme != "dark" {
+		t.Errorf("Theme = %q, want dark", cfg.Theme)
+	}
+	if cfg.MaxPanesPerTab != 12 {
+		t.Errorf("MaxPanesPerTab = %d, want 12", cfg.MaxPanesPerTab)
+	}
+	if cfg.Keybindings["new_tab"] != "alt+t" || cfg.Keybindings["close_tab"] != "ctrl+w" {
+		t.Errorf("keybindings not resolved: %v", cfg.Keybindings)
+	}
+}
+
+func TestParse_ReportsYAMLError(t *testing.T) {
+	if _, err := Parse([]byte("theme: [unclosed")); err == nil {
+		t.Fatal("expected YAML error")
+	}
+}
+
+func TestReload(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	if _, err := Reload(); err == nil {
+		t.Fatal("expected error for missing config file")
+	}
+	if _, err := os.Stat(filepath.Join(home, ".multiterminal.yaml")); !os.IsNotExist(err) {
+		t.Fatal("Reload must not write a default config")
+	}
+
+	os.WriteFile(Path(), []byte("theme: nord\n"), 0644)
+	cfg, err := Reload()
+	if err != nil || cfg.Theme != "nord" {
+		t.Fatalf("Reload() = (%q, %v), want (nord, nil)", cfg.Theme, err)
+	}
+
+	os.WriteFile(Path(), []byte("theme: [broken"), 0644)
+	if _, err := Reload(); err == nil {
+		t.Fatal("expected parse error for malformed config")
+	}
+}
@@ -0,0 +1,60 @@
+package config
+
+import "strings"
+
+// Per-pane limits for saved scrollback. The session file is rewritten on
+// every layout change, so the text is kept short and plain.
+const (
+	MaxScrollbackLines = 1000
+	MaxScrollbackBytes = 128 << 10
+)
+
+// CapScrollback prepares pane output for the session file: control
+// characters (escape sequences included) are removed, trailing blank lines
+// are dropped and only the newest lines within the limits are kept.
+func CapScrollback(text string) string {
+	text = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if r < 0x20 || r == 0x7f || (r >= 0x80 && r < 0xa0) {
+			return -1
+		}
+		return r
+	}, text)
+	text = strings.TrimRight(text, " \t\n")
+
+	lines := strings.Split(text, "\n")
+	if len(lines) > MaxScrollbackLines {
+		lines = lines[len(lines)-MaxScrollbackLines:]
+	}
+	size := 0
+	for i := len(lines) - 1; i >= 0; i-- {
+		size += len(lines[i]) + 1
+		if size > MaxScrollbackBytes {
+			lines = lines[i+1:]
+			break
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CapScrollback trims every pane's saved output (see CapScrollback).
+func (st *SessionState) CapScrollback() {
+	for i := range st.Tabs {
+		for j := range st.Tabs[i].Panes {
+			p := &st.Tabs[i].Panes[j]
+			p.Scrollback = CapScrollback(p.Scrollback)
+		}
+	}
+}
+
+// DropScrollback removes all saved pane output, e.g. when
+// restore_scrollback is off or the state is stored as a named layout.
+func (st *SessionState) DropScrollback() {
+	for i := range st.Tabs {
+		for j := range st.Tabs[i].Panes {
+			st.Tabs[i].Panes[j].Scrollback = ""
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCapScrollback_StripsControlCharacters(t *testing.T) {
+	got := CapScrollback("\x1b[32mok\x1b[0m\r\nnext\tcol\a\n\n  \n")
+	if want := "[32mok[0m\nnext\tcol"; got != want {
+		t.Errorf("CapScrollback = %q, want %q", got, want)
+	}
+}
+
+func TestCapScrollback_KeepsNewestLines(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < MaxScrollbackLines+50; i++ {
+		b.WriteString("line\n")
+	}
+	b.WriteString("last")
+	got := strings.Split(CapScrollback(b.String()), "\n")
+	if len(got) != MaxScrollbackLines {
+		t.Fatalf("kept %d lines, want %d", len(got), MaxScrollbackLines)
+	}
+	if got[len(got)-1] != "last" {
+		t.Errorf("last line = %q, want newest line kept", got[len(got)-1])
+	}
+}
+
+func TestCapScrollback_ByteLimit(t *testing.T) {
+	long := strings.Repeat("x", 1000)
+	text := strings.Repeat(long+"\n", 200) + "tail"
+	got := CapScrollback(text)
+	if len(got) > MaxScrollbackBytes {
+		t.Fatalf("len = %d, exceeds %d", len(got), MaxScrollbackBytes)
+	}
+	if !strings.HasSuffix(got, "\ntail") {
+		t.Errorf("newest line lost: ...%q", got[max(0, len(got)-10):])
+	}
+}
+
+func TestSessionState_DropScrollback(t *testing.T) {
+	st := sampleLayout()
+	st.Tabs[1].Panes[0].Scrollback = "$ make\nok"
+	st.DropScrollback()
+	if st.Tabs[1].Panes[0].Scrollback != "" {
+		t.Errorf("scrollback kept: %q", st.Tabs[1].Panes[0].Scrollback)
+	}
+}
@@ -12,8 +12,16 @@ import (
 
 // SessionState is the top-level structure serialised to disk.
 type SessionState struct {
-	ActiveTab int        `json:"active_tab"`
-	Tabs      []SavedTab `json:"tabs"`
+	ActiveTab int                     `json:"active_tab"`
+	Tabs      []SavedTab              `json:"tabs"`
+	Sidebar   map[string]SidebarState `json:"sidebar,omitempty"` // file tree state per tab directory
+}
+
+// SidebarState is what the file tree remembers for one root directory.
+type SidebarState struct {
+	Expanded []string `json:"expanded,omitempty"` // absolute paths of open folders
+	Selected string   `json:"selected,omitempty"` // path of the selected file
+	Scroll   int      `json:"scroll,omitempty"`   // scroll offset in pixels
 }
 
 // SavedTab captures a single tab's layout.
@@ -26,12 +34,19 @@ type SavedTab struct {
 
 // SavedPane captures enough information to re-launch a single pane.
 type SavedPane struct {
-	Name        string `json:"name"`
-	Mode        int    `json:"mode"`                   // maps to ui.PaneMode (0=shell, 1=claude, 2=yolo)
-	Model       string `json:"model"`                  // model label (empty for shell)
-	IssueNumber int    `json:"issue_number,omitempty"` // linked GitHub issue number
-	IssueBranch string `json:"issue_branch,omitempty"` // branch created for issue
-	ZoomDelta   int    `json:"zoom_delta,omitempty"`   // per-pane font zoom offset
+	Name        string            `json:"name"`
+	Mode        int               `json:"mode"`                   // maps to ui.PaneMode (0=shell, 1=claude, 2=yolo)
+	Model       string            `json:"model"`                  // model label (empty for shell)
+	IssueNumber int               `json:"issue_number,omitempty"` // linked GitHub issue number
+	IssueBranch string            `json:"issue_branch,omitempty"` // branch created for issue
+	ZoomDelta   int               `json:"zoom_delta,omitempty"`   // per-pane font zoom offset
+	Argv        []string          `json:"argv,omitempty"`         // custom command (launch profiles); empty = derive from mode
+	Dir         string            `json:"dir,omitempty"`          // working dir override; empty = tab dir
+	Maximized   bool              `json:"maximized,omitempty"`    // zoomed pane of its tab (at most one per tab)
+	Env         map[string]string `json:"env,omitempty"`          // per-pane environment overrides
+	NameManual  bool              `json:"name_manual,omitempty"`  // Name was set by the user, not derived from OSC titles
+	Pinned      bool              `json:"pinned,omitempty"`       // survives closing its tab by moving to the next one
+	Scrollback  string            `json:"scrollback,omitempty"`   // plain-text tail of a shell pane's output (restore_scrollback)
 }
 
 // sessionPath returns the path to ~/.multiterminal-session.json.
@@ -53,7 +68,29 @@ func SaveSession(state SessionState) error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(p, data, 0644)
+	return writePrivateFile(p, data)
+}
+
+// writePrivateFile writes data readable only by the user. The config,
+// session and layout files carry env values (API keys) and scrollback, so a
+// file left world-readable by an older version is replaced by a private
+// one. The data goes to a temp file that is renamed over path, so the
+// config watcher never reads a half-written file.
+func writePrivateFile(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	// A temp file left by a crash keeps its old mode
+	if err := os.Chmod(tmp, 0600); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
 }
 
 // LoadSession reads a previously saved session state from disk.
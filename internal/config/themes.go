@@ -0,0 +1,163 @@
+package config
+
+import (
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// builtinThemes are the theme names shipped with the frontend.
+var builtinThemes = []string{"dark", "light", "dracula", "nord", "solarized"}
+
+// hexColorRe accepts #rgb, #rrggbb and #rrggbbaa.
+var hexColorRe = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+
+// ThemeColors is a user-defined UI palette. Fields mirror ThemeColors in
+// frontend/src/stores/theme.ts; empty or invalid fields fall back to the
+// dark theme. The terminal palette is derived from bg/fg/pane_bg.
+type ThemeColors struct {
+	Bg                string `yaml:"bg" json:"bg"`
+	BgSecondary       string `yaml:"bg_secondary" json:"bg_secondary"`
+	BgTertiary        string `yaml:"bg_tertiary" json:"bg_tertiary"`
+	Fg                string `yaml:"fg" json:"fg"`
+	FgMuted           string `yaml:"fg_muted" json:"fg_muted"`
+	Accent            string `yaml:"accent" json:"accent"`
+	AccentHover       string `yaml:"accent_hover" json:"accent_hover"`
+	Border            string `yaml:"border" json:"border"`
+	BorderFocused     string `yaml:"border_focused" json:"border_focused"`
+	Success           string `yaml:"success" json:"success"`
+	Warning           string `yaml:"warning" json:"warning"`
+	Error             string `yaml:"error" json:"error"`
+	TabBg             string `yaml:"tab_bg" json:"tab_bg"`
+	TabActiveBg       string `yaml:"tab_active_bg" json:"tab_active_bg"`
+	TabActiveFg       string `yaml:"tab_active_fg" json:"tab_active_fg"`
+	PaneBg            string `yaml:"pane_bg" json:"pane_bg"`
+	PaneBorder        string `yaml:"pane_border" json:"pane_border"`
+	PaneBorderFocused string `yaml:"pane_border_focused" json:"pane_border_focused"`
+	ToolbarBg         string `yaml:"toolbar_bg" json:"toolbar_bg"`
+	FooterBg          string `yaml:"footer_bg" json:"footer_bg"`
+}
+
+// darkThemeColors is the built-in dark (Catppuccin Mocha) palette.
+var darkThemeColors = ThemeColors{
+	Bg: "#1e1e2e", BgSecondary: "#181825", BgTertiary: "#313244",
+	Fg: "#cdd6f4", FgMuted: "#6c7086",
+	Accent: "#cba6f7", AccentHover: "#b4befe",
+	Border: "#45475a", BorderFocused: "#cba6f7",
+	Success: "#a6e3a1", Warning: "#f9e2af", Error: "#f38ba8",
+	TabBg: "#181825", TabActiveBg: "#1e1e2e", TabActiveFg: "#cba6f7",
+	PaneBg: "#11111b", PaneBorder: "#45475a", PaneBorderFocused: "#cba6f7",
+	ToolbarBg: "#181825", FooterBg: "#181825",
+}
+
+// fields returns the palette's colors by their config key, for validation.
+func (t *ThemeColors) fields() map[string]*string {
+	return map[string]*string{
+		"bg": &t.Bg, "bg_secondary": &t.BgSecondary, "bg_tertiary": &t.BgTertiary,
+		"fg": &t.Fg, "fg_muted": &t.FgMuted,
+		"accent": &t.Accent, "accent_hover": &t.AccentHover,
+		"border": &t.Border, "border_focused": &t.BorderFocused,
+		"success": &t.Success, "warning": &t.Warning, "error": &t.Error,
+		"tab_bg": &t.TabBg, "tab_active_bg": &t.TabActiveBg, "tab_active_fg": &t.TabActiveFg,
+		"pane_bg": &t.PaneBg, "pane_border": &t.PaneBorder, "pane_border_focused": &t.PaneBorderFocused,
+		"toolbar_bg": &t.ToolbarBg, "footer_bg": &t.FooterBg,
+	}
+}
+
+// isBuiltinTheme reports whether name is one of the shipped themes.
+func isBuiltinTheme(name string) bool {
+	for _, b := range builtinThemes {
+		if b == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validateCustomThemes drops themes that shadow a built-in or have an empty
+// name, and replaces missing or non-hex colors with the dark theme's value.
+func validateCustomThemes(themes map[string]ThemeColors) map[string]ThemeColors {
+	if len(themes) == 0 {
+		return nil
+	}
+	defaults := darkThemeColors
+	fallback := defaults.fields()
+
+	result := make(map[string]ThemeColors, len(themes))
+	for name, theme := range themes {
+		key := strings.TrimSpace(name)
+		if key == "" || isBuiltinTheme(key) {
+			log.Printf("[config] custom_themes: theme %q ignored (empty or built-in name)", name)
+			continue
+		}
+		for field, color := range theme.fields() {
+			switch {
+			case *color == "":
+				*color = *fallback[field]
+			case !hexColorRe.MatchString(*color):
+				log.Printf("[config] custom_themes: %s.%s %q is not a hex color, using %q",
+					key, field, *color, *fallback[field])
+				*color = *fallback[field]
+			}
+		}
+		result[key] = theme
+	}
+	return result
+}
+
+// ansiColors is the number of palette entries ansi_palettes may override:
+// black, red, green, yellow, blue, magenta, cyan, white, then the bright
+// variants in the same order.
+const ansiColors = 16
+
+// validateANSIPalettes drops palettes of unknown themes and entries past
+// the 16th. Invalid colors become "", which keeps the theme's own color at
+// that position, so a list may also override only its first few entries.
+func validateANSIPalettes(palettes map[string][]string, known func(string) bool) map[string][]string {
+	if len(palettes) == 0 {
+		return nil
+	}
+	result := make(map[string][]string, len(palettes))
+	for name, colors := range palettes {
+		if !known(name) {
+			log.Printf("[config] ansi_palettes: unknown theme %q ignored", name)
+			continue
+		}
+		if len(colors) > ansiColors {
+			log.Printf("[config] ansi_palettes: %s has %d colors, using the first %d", name, len(colors), ansiColors)
+			colors = colors[:ansiColors]
+		}
+		clean := make([]string, len(colors))
+		for i, c := range colors {
+			if c != "" && !hexColorRe.MatchString(c) {
+				log.Printf("[config] ansi_palettes: %s[%d] %q is not a hex color, keeping the theme's", name, i, c)
+				continue
+			}
+			clean[i] = c
+		}
+		result[name] = clean
+	}
+	return result
+}
+
+// ThemeNames returns the built-in theme names followed by the configured
+// custom themes in alphabetical order.
+func (c Config) ThemeNames() []string {
+	names := append([]string(nil), builtinThemes...)
+	custom := make([]string, 0, len(c.CustomThemes))
+	for name := range c.CustomThemes {
+		custom = append(custom, name)
+	}
+	sort.Strings(custom)
+	return append(names, custom...)
+}
+
+// HasTheme reports whether name is a built-in or configured custom theme.
+func (c Config) HasTheme(name string) bool {
+	if isBuiltinTheme(name) {
+		return true
+	}
+	_, ok := c.CustomThemes[name]
+	return ok
+}
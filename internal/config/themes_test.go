@@ -0,0 +1,106 @@
+package config
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidateCustomThemes_FillsAndRejects(t *testing.T) {
+	var themes map[string]ThemeColors
+	data := []byte(`
+tokyonight:
+  bg: "#1a1b26"
+  fg: "#c0caf5"
+  accent: "not-a-color"
+dark:
+  bg: "#000000"
+"  ":
+  bg: "#111"
+`)
+	if err := yaml.Unmarshal(data, &themes); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	got := validateCustomThemes(themes)
+	if len(got) != 1 {
+		t.Fatalf("expected only tokyonight to survive, got %v", got)
+	}
+	tn := got["tokyonight"]
+	if tn.Bg != "#1a1b26" || tn.Fg != "#c0caf5" {
+		t.Errorf("explicit colors changed: bg=%q fg=%q", tn.Bg, tn.Fg)
+	}
+	if tn.Accent != darkThemeColors.Accent {
+		t.Errorf("invalid accent = %q, want dark fallback %q", tn.Accent, darkThemeColors.Accent)
+	}
+	if tn.PaneBg != darkThemeColors.PaneBg {
+		t.Errorf("missing pane_bg = %q, want dark fallback %q", tn.PaneBg, darkThemeColors.PaneBg)
+	}
+}
+
+func TestHexColorRe(t *testing.T) {
+	for _, c := range []string{"#fff", "#1A1B26", "#1a1b2680"} {
+		if !hexColorRe.MatchString(c) {
+			t.Errorf("%q should be a valid hex color", c)
+		}
+	}
+	for _, c := range []string{"fff", "#ffff", "#12345g", "red", ""} {
+		if hexColorRe.MatchString(c) {
+			t.Errorf("%q should be rejected", c)
+		}
+	}
+}
+
+func TestConfig_ThemeNames(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.CustomThemes = map[string]ThemeColors{"tokyonight": {}, "gruvbox": {}}
+
+	names := cfg.ThemeNames()
+	want := []string{"dark", "light", "dracula", "nord", "solarized", "gruvbox", "tokyonight"}
+	if len(names) != len(want) {
+		t.Fatalf("ThemeNames() = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("ThemeNames() = %v, want %v", names, want)
+		}
+	}
+	if !cfg.HasTheme("tokyonight") || cfg.HasTheme("monokai") {
+		t.Error("HasTheme should know custom themes and reject unknown ones")
+	}
+}
+
+func TestValidateANSIPalettes(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.CustomThemes = map[string]ThemeColors{"tokyonight": {}}
+	long := make([]string, 20)
+	for i := range long {
+		long[i] = "#000000"
+	}
+	got := validateANSIPalettes(map[string][]string{
+		"nord":       {"#3b4252", "crimson", "", "#ebcb8b"},
+		"tokyonight": long,
+		"gruvbox":    {"#282828"},
+	}, cfg.HasTheme)
+
+	if _, ok := got["gruvbox"]; ok || len(got) != 2 {
+		t.Fatalf("palettes = %v, want nord and tokyonight only", got)
+	}
+	nord := got["nord"]
+	if len(nord) != 4 || nord[0] != "#3b4252" || nord[1] != "" || nord[2] != "" || nord[3] != "#ebcb8b" {
+		t.Errorf("nord = %q, want invalid entry cleared and order kept", nord)
+	}
+	if n := len(got["tokyonight"]); n != ansiColors {
+		t.Errorf("tokyonight has %d colors, want %d", n, ansiColors)
+	}
+}
+
+func TestParse_ANSIPalettes(t *testing.T) {
+	cfg, err := Parse([]byte("ansi_palettes:\n  nord: [\"#3b4252\", \"#bf616a\"]\n"))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if p := cfg.ANSIPalettes["nord"]; len(p) != 2 || p[1] != "#bf616a" {
+		t.Errorf("ansi_palettes.nord = %q", p)
+	}
+}
@@ -0,0 +1,231 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ValidationWarning describes one correction Validate applied to a config.
+type ValidationWarning struct {
+	Field   string `yaml:"field" json:"field"`
+	Message string `yaml:"message" json:"message"`
+}
+
+// String formats the warning as "field: message".
+func (w ValidationWarning) String() string {
+	return w.Field + ": " + w.Message
+}
+
+// Allowed values for enum-like settings.
+var (
+	validLaunchModes = map[string]bool{"dialog": true, "shell": true, "claude": true, "yolo": true}
+	validAutoOpen    = map[string]bool{"auto": true, "notify": true, "off": true}
+	validPasteSafety = map[string]bool{"off": true, "strip": true, "confirm": true}
+	validNewTabDir   = map[string]bool{"dialog": true, "inherit-tab": true, "inherit-pane-cwd": true, "fixed": true}
+	validControlAPI  = map[string]bool{"list_sessions": true, "new_pane": true, "send": true}
+	validFontSizes   = map[int]bool{8: true, 10: true, 12: true, 14: true, 16: true, 18: true, 20: true}
+)
+
+// DoubleCtrlC is the quit_binding value that quits on two Ctrl+C presses
+// in quick succession. The first press still reaches the pane.
+const DoubleCtrlC = "double_ctrl_c"
+
+// Validate clamps numeric settings to their ranges and resets unknown enum
+// values (theme, default_launch, new_tab_dir_mode, localhost_auto_open,
+// paste_safety, font_size) to their defaults; unknown control_api commands
+// are dropped and an unusable quit_binding is turned off. A default shell that is not found on PATH
+// is dropped in favour of the platform shell. It returns one warning per
+// corrected field; unset optional fields are filled in silently.
+// Keybindings, launch profiles and custom themes are checked during Parse,
+// which logs its own warnings.
+func (c *Config) Validate() []ValidationWarning {
+	var w []ValidationWarning
+	warn := func(field, format string, args ...any) {
+		w = append(w, ValidationWarning{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+	clamp := func(field string, v *int, min, max int) {
+		switch {
+		case *v < min:
+			warn(field, "%d is below %d, using %d", *v, min, min)
+			*v = min
+		case *v > max:
+			warn(field, "%d is above %d, using %d", *v, max, max)
+			*v = max
+		}
+	}
+
+	clamp("max_panes_per_tab", &c.MaxPanesPerTab, 1, 12)
+	clamp("max_sessions", &c.MaxSessions, 1, 500)
+	clamp("sidebar_width", &c.SidebarWidth, 15, 60)
+	if c.IdleTimeoutMinutes < 0 {
+		warn("idle_timeout_minutes", "%d is negative, never closing idle panes", c.IdleTimeoutMinutes)
+		c.IdleTimeoutMinutes = 0
+	}
+	if c.ScrollbackMemoryBudgetMB < 0 {
+		warn("scrollback_memory_budget_mb", "%d is negative, not limiting scrollback", c.ScrollbackMemoryBudgetMB)
+		c.ScrollbackMemoryBudgetMB = 0
+	}
+	if c.CommitReminderMinutes < 0 {
+		warn("commit_reminder_minutes", "%d is negative, disabling the reminder", c.CommitReminderMinutes)
+		c.CommitReminderMinutes = 0
+	}
+	if c.CommitWarningFactor < 1 {
+		warn("commit_reminder_warning_factor", "%g is below 1, using 2", c.CommitWarningFactor)
+		c.CommitWarningFactor = 2
+	}
+	if c.CommitDangerFactor < c.CommitWarningFactor {
+		warn("commit_reminder_danger_factor", "%g is below the warning factor, using %g", c.CommitDangerFactor, c.CommitWarningFactor+1)
+		c.CommitDangerFactor = c.CommitWarningFactor + 1
+	}
+	clamp("audio.volume", &c.Audio.Volume, 0, 100)
+	clamp("output_coalesce_ms", &c.OutputCoalesceMs, 0, 100)
+	if c.OutputChunkLimitKB < 4 {
+		// Values this small would flood the frontend with events
+		warn("output_chunk_limit_kb", "%d is below 4, using 64", c.OutputChunkLimitKB)
+		c.OutputChunkLimitKB = 64
+	}
+	clamp("output_chunk_limit_kb", &c.OutputChunkLimitKB, 4, 1024)
+	clamp("output_throttle_ms", &c.OutputThrottleMs, 0, 1000)
+	clamp("issue_cache_seconds", &c.IssueCacheSeconds, 0, 3600)
+	clamp("github_timeout_seconds", &c.GitHubTimeoutSeconds, 1, 300)
+	clamp("scan_interval_min_ms", &c.ScanIntervalMinMs, 50, 1000)
+	clamp("scan_interval_max_ms", &c.ScanIntervalMaxMs, 200, 10000)
+	if c.ScanIntervalMaxMs < c.ScanIntervalMinMs {
+		warn("scan_interval_max_ms", "%d is below scan_interval_min_ms, using %d", c.ScanIntervalMaxMs, c.ScanIntervalMinMs)
+		c.ScanIntervalMaxMs = c.ScanIntervalMinMs
+	}
+
+	if !c.HasTheme(c.Theme) {
+		warn("theme", "unknown theme %q, using \"dark\"", c.Theme)
+		c.Theme = "dark"
+	}
+	if !validLaunchModes[c.DefaultLaunch] {
+		warn("default_launch", "unknown value %q, using \"dialog\"", c.DefaultLaunch)
+		c.DefaultLaunch = "dialog"
+	}
+	if !validNewTabDir[c.NewTabDirMode] {
+		warn("new_tab_dir_mode", "unknown value %q, using \"dialog\"", c.NewTabDirMode)
+		c.NewTabDirMode = "dialog"
+	}
+	if !validPasteSafety[c.PasteSafety] {
+		warn("paste_safety", "unknown value %q, using \"off\"", c.PasteSafety)
+		c.PasteSafety = "off"
+	}
+	if !validAutoOpen[c.LocalhostAutoOpen] {
+		warn("localhost_auto_open", "unknown value %q, using \"notify\"", c.LocalhostAutoOpen)
+		c.LocalhostAutoOpen = "notify"
+	}
+	clamp("control_port", &c.ControlPort, 1024, 65535)
+	if len(c.ControlAPI) > 0 {
+		allowed := c.ControlAPI[:0]
+		for _, cmd := range c.ControlAPI {
+			if validControlAPI[cmd] {
+				allowed = append(allowed, cmd)
+			} else {
+				warn("control_api", "unknown command %q, ignoring it", cmd)
+			}
+		}
+		c.ControlAPI = allowed
+	}
+	c.validateQuitBinding(warn)
+	if !validFontSizes[c.FontSize] {
+		warn("font_size", "%d is not a supported size, using 10", c.FontSize)
+		c.FontSize = 10
+	}
+	if argv := c.ShellArgv(); argv != nil {
+		field := "default_shell"
+		if len(c.DefaultShellArgs) > 0 {
+			field = "default_shell_args"
+		}
+		// Keep the setting: the shell may be installed later or only be
+		// missing from this PATH. LaunchShellArgv falls back per pane.
+		if _, err := exec.LookPath(argv[0]); argv[0] == "" || err != nil {
+			warn(field, "%q not found, new panes use the system shell until it is", argv[0])
+		}
+	}
+	// Drop patterns that cannot be compiled; an empty one would match
+	// every line
+	validPatterns := func(field string, list []string) []string {
+		kept := list[:0]
+		for _, p := range list {
+			if _, err := regexp.Compile(p); err != nil || p == "" {
+				warn(field, "ignoring pattern %q", p)
+				continue
+			}
+			kept = append(kept, p)
+		}
+		return kept
+	}
+	// filepath.Match only reports a bad pattern when it is used
+	validGlobs := c.SidebarIgnore[:0]
+	for _, g := range c.SidebarIgnore {
+		glob := strings.TrimPrefix(g, "!")
+		if _, err := filepath.Match(glob, ""); glob == "" || err != nil {
+			warn("sidebar_ignore", "ignoring pattern %q", g)
+			continue
+		}
+		validGlobs = append(validGlobs, g)
+	}
+	c.SidebarIgnore = validGlobs
+	c.AutoApprove = validPatterns("auto_approve", c.AutoApprove)
+	c.ApprovalMenuPatterns = validPatterns("approval_menu_patterns", c.ApprovalMenuPatterns)
+	c.ResultPatterns.Pass = validPatterns("result_patterns.pass", c.ResultPatterns.Pass)
+	c.ResultPatterns.Fail = validPatterns("result_patterns.fail", c.ResultPatterns.Fail)
+
+	// Unset optional fields: fill in defaults without warning
+	if c.Audio.Enabled == nil {
+		c.Audio.Enabled = boolPtr(true)
+	}
+	if c.Audio.WhenFocused == nil {
+		c.Audio.WhenFocused = boolPtr(true)
+	}
+	if c.RestoreSession == nil {
+		c.RestoreSession = boolPtr(true)
+	}
+	if c.InterceptCtrlC == nil {
+		c.InterceptCtrlC = boolPtr(true)
+	}
+	if c.Favorites == nil {
+		c.Favorites = make(map[string][]string)
+	}
+	return w
+}
+
+// validateQuitBinding normalises quit_binding. Invalid keys, plain
+// Ctrl+C (the pane would never see an interrupt) and keys taken by a
+// shortcut turn quitting by key off.
+func (c *Config) validateQuitBinding(warn func(field, format string, args ...any)) {
+	if c.QuitBinding == "" || c.QuitBinding == DoubleCtrlC {
+		return
+	}
+	spec, ok := normalizeKeySpec(c.QuitBinding)
+	if !ok {
+		warn("quit_binding", "invalid key %q, quitting by key is off", c.QuitBinding)
+		c.QuitBinding = ""
+		return
+	}
+	if spec == "ctrl+c" {
+		warn("quit_binding", "ctrl+c would never reach the pane, use %q instead; quitting by key is off", DoubleCtrlC)
+		c.QuitBinding = ""
+		return
+	}
+	for _, action := range sortedKeys(c.Keybindings) {
+		if c.Keybindings[action] == spec {
+			warn("quit_binding", "%q is already bound to %s, quitting by key is off", spec, action)
+			c.QuitBinding = ""
+			return
+		}
+	}
+	c.QuitBinding = spec
+}
+
+// logValidation logs each warning returned by Validate.
+func logValidation(warnings []ValidationWarning) {
+	for _, w := range warnings {
+		log.Printf("[config] %s", w)
+	}
+}
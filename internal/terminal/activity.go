@@ -18,22 +18,45 @@ type TokenInfo struct {
 type ActivityState int
 
 const (
-	ActivityIdle       ActivityState = iota // no recent output
-	ActivityActive                          // currently producing output
-	ActivityDone                            // just finished (prompt returned)
-	ActivityNeedsInput                      // waiting for user confirmation
+	ActivityIdle          ActivityState = iota // no recent output
+	ActivityActive                             // currently producing output
+	ActivityDone                               // just finished (prompt returned)
+	ActivityNeedsInput                         // waiting for user confirmation
+	ActivityPasswordInput                      // waiting for a password (input is not echoed)
 )
 
+// Scan windows: ScanTokens and the screen classification read the bottom
+// third of the screen, so tall panes see all of Claude's status block,
+// but at least the given minimum and never more than scanMaxRows rows.
+const (
+	scanWindowFraction = 3
+	tokenScanMinRows   = 10
+	promptScanMinRows  = 15
+	scanMaxRows        = 40
+)
+
+// scanWindow returns how many bottom rows of a screen with rows rows a scan
+// reads (see scanWindowFraction); a pane smaller than minRows is read whole.
+func scanWindow(rows, minRows int) int {
+	return min(max(rows/scanWindowFraction, minRows), scanMaxRows, rows)
+}
+
 // ScanTokens scans the screen buffer for token/cost patterns and updates
 // the Tokens field. Call this periodically (e.g. from the tick handler).
 func (s *Session) ScanTokens() {
-	rows := s.Screen.Rows()
-	// Scan last 10 rows of the screen for cost/token patterns
-	scanStart := rows - 10
-	if scanStart < 0 {
-		scanStart = 0
+	gen := s.Screen.Generation()
+	s.mu.Lock()
+	unchanged := gen == s.tokensGen
+	s.mu.Unlock()
+	if unchanged {
+		return
 	}
-	lines := s.Screen.PlainTextRows(scanStart, rows)
+
+	rows := s.Screen.Rows()
+	// Scan the bottom of the screen, where Claude's status block sits
+	scanStart := rows - scanWindow(rows, tokenScanMinRows)
+	// Logical lines, so a cost or token line that wrapped still matches
+	lines := s.Screen.PlainTextLogicalRows(scanStart, rows)
 	var text strings.Builder
 	for _, line := range lines {
 		text.WriteString(line)
@@ -43,6 +66,7 @@ func (s *Session) ScanTokens() {
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.tokensGen = gen
 
 	// Look for cost patterns like $0.12 or $1.50
 	if matches := costPattern.FindStringSubmatch(content); len(matches) >= 2 {
@@ -88,24 +112,80 @@ func (s *Session) DetectActivity() ActivityState {
 		return ActivityActive
 	}
 
-	// Output stopped for >1.5s — classify what's on screen
-	newState := s.classifyScreenState()
+	// Output stopped for >1.5s — classify what's on screen, unless it has
+	// not changed since the last classification.
+	gen := s.Screen.Generation()
+	s.mu.Lock()
+	newState, line, cached := s.classified, s.classifiedLine, gen == s.classifiedGen
+	s.mu.Unlock()
+	if !cached {
+		newState, line = s.classifyWithLine()
+	}
 	s.mu.Lock()
 	s.Activity = newState
+	s.classifiedGen, s.classified, s.classifiedLine = gen, newState, line
 	s.mu.Unlock()
 	return newState
 }
 
+// ActivityLine returns the screen line behind the current state: the
+// prompt for ActivityDone, the question for ActivityNeedsInput or
+// ActivityPasswordInput. It is empty while idle or active.
+func (s *Session) ActivityLine() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch s.Activity {
+	case ActivityDone, ActivityNeedsInput, ActivityPasswordInput:
+		return s.classifiedLine
+	}
+	return ""
+}
+
 // classifyScreenState examines the last rows of the screen to determine
-// if Claude is done or waiting for input.
+// if Claude is done or waiting for input, from their plain text only.
 func (s *Session) classifyScreenState() ActivityState {
+	state, _ := s.classify(false)
+	return state
+}
+
+// classifyScreenStateStyled is classifyScreenState that also consults the
+// cell styles of the rows that match (see rowLooksLikePrompt), so styled
+// output such as highlighted code is not taken for a prompt.
+func (s *Session) classifyScreenStateStyled() ActivityState {
+	state, _ := s.classifyWithLine()
+	return state
+}
+
+// NeedsInputLine returns the trimmed screen line that makes the session
+// count as waiting for input, or "" if the screen shows no such prompt.
+func (s *Session) NeedsInputLine() string {
+	if state, line := s.classifyWithLine(); state == ActivityNeedsInput {
+		return line
+	}
+	return ""
+}
+
+// classifyWithLine does the work of classifyScreenStateStyled and also
+// returns the line that decided the state ("" for ActivityIdle).
+func (s *Session) classifyWithLine() (ActivityState, string) {
+	return s.classify(true)
+}
+
+// classify implements the classification; styled rejects matching rows
+// whose cell styles say they are not a prompt.
+func (s *Session) classify(styled bool) (ActivityState, string) {
 	rows := s.Screen.Rows()
-	// Check last 15 non-empty rows (Claude Code uses a rich TUI with status bars)
-	scanFrom := rows - 15
-	if scanFrom < 0 {
-		scanFrom = 0
+	// Check the bottom rows (Claude Code uses a rich TUI with status bars)
+	scanFrom := rows - scanWindow(rows, promptScanMinRows)
+	// Password prompts only count while the cursor still sits on them;
+	// once Enter is pressed the prompt scrolls into history.
+	row, _ := s.Screen.Cursor()
+	if line := strings.TrimSpace(s.Screen.PlainTextRow(row)); passwordPromptPattern.MatchString(line) {
+		return ActivityPasswordInput, line
 	}
+
 	lines := s.Screen.PlainTextRows(scanFrom, rows)
+	menuAt, question := s.menuPatterns().findMenu(lines)
 	// Iterate in reverse (bottom-up) to find the most recent prompt/input
 	for i := len(lines) - 1; i >= 0; i-- {
 		line := lines[i]
@@ -114,17 +194,28 @@ func (s *Session) classifyScreenState() ActivityState {
 		}
 		trimmed := strings.TrimSpace(line)
 
+		// Arrow-select approval menu ("❯ 1. Yes" / "  2. No")
+		if i == menuAt {
+			return ActivityNeedsInput, question
+		}
+
+		needsInput := needsInputPattern.MatchString(trimmed)
+		prompt := !needsInput && promptPattern.MatchString(trimmed)
+		if (needsInput || prompt) && styled && !s.rowLooksLikePrompt(scanFrom+i) {
+			continue
+		}
+
 		// Needs input patterns (check first — takes priority)
-		if needsInputPattern.MatchString(trimmed) {
-			return ActivityNeedsInput
+		if needsInput {
+			return ActivityNeedsInput, trimmed
 		}
 
 		// Prompt returned (Claude/shell is done)
-		if promptPattern.MatchString(trimmed) {
-			return ActivityDone
+		if prompt {
+			return ActivityDone, trimmed
 		}
 	}
-	return ActivityIdle
+	return ActivityIdle, ""
 }
 
 // ResetActivity sets the activity state back to Idle.
@@ -147,6 +238,12 @@ var (
 		`permission|Do you want to|Would you like to|` + // Permission phrases
 		`Press Enter to|waiting for|Waiting for`)
 
+	// Waiting for a secret: sudo, su, ssh and gpg prompts ("[sudo] password
+	// for user:", "Enter passphrase for key '...':", German "Passwort für").
+	// The line must end with the colon the cursor sits behind.
+	passwordPromptPattern = regexp.MustCompile(`(?i)` +
+		`(?:password|passwort|kennwort|passphrase|\bpin\b).*:\s*$`)
+
 	// Prompt returned — Claude or shell is done and waiting for new input.
 	// Matches: ❯, >, $, %, # at end of line (with optional whitespace)
 	// Also matches Windows cmd.exe prompt like C:\path>
@@ -0,0 +1,153 @@
+package terminal
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Screen generation – scans skip screens that have not changed
+// ---------------------------------------------------------------------------
+
+func TestScreenGeneration_ChangesOnMutation(t *testing.T) {
+	s := NewScreen(3, 10)
+	g := s.Generation()
+	if g == 0 {
+		t.Fatal("initial generation must not be 0")
+	}
+	s.Write([]byte("x"))
+	if s.Generation() == g {
+		t.Error("Write did not change the generation")
+	}
+	g = s.Generation()
+	s.Resize(4, 10)
+	if s.Generation() == g {
+		t.Error("Resize did not change the generation")
+	}
+	g = s.Generation()
+	s.Reset()
+	if s.Generation() == g {
+		t.Error("Reset did not change the generation")
+	}
+	g = s.Generation()
+	_ = s.PlainText()
+	if s.Generation() != g {
+		t.Error("reading the screen changed the generation")
+	}
+}
+
+func TestScanTokens_SkipsUnchangedScreen(t *testing.T) {
+	sess := NewSession(1, 5, 40)
+	sess.Screen.Write([]byte("Cost: $0.50"))
+	sess.ScanTokens()
+
+	// Overwrite the parsed value; an unchanged screen must not be rescanned.
+	sess.mu.Lock()
+	sess.Tokens.TotalCost = 9
+	sess.mu.Unlock()
+	sess.ScanTokens()
+	if sess.Tokens.TotalCost != 9 {
+		t.Fatalf("unchanged screen was rescanned: cost = %v", sess.Tokens.TotalCost)
+	}
+
+	sess.Screen.Write([]byte("\r\x1b[2KCost: $1.25"))
+	sess.ScanTokens()
+	if sess.Tokens.TotalCost != 1.25 {
+		t.Errorf("cost after new output = %v, want 1.25", sess.Tokens.TotalCost)
+	}
+}
+
+func TestDetectActivity_ReclassifiesOnlyAfterChange(t *testing.T) {
+	sess := newStaleSession(5, 40)
+	sess.Screen.Write([]byte("user@host:~$ "))
+	if state := sess.DetectActivity(); state != ActivityDone {
+		t.Fatalf("state = %d, want ActivityDone", state)
+	}
+	gen := sess.classifiedGen
+	if state := sess.DetectActivity(); state != ActivityDone || sess.classifiedGen != gen {
+		t.Fatalf("repeat detection: state = %d, gen %d → %d", state, gen, sess.classifiedGen)
+	}
+
+	sess.Screen.Write([]byte("\r\nDo you want to proceed?"))
+	if state := sess.DetectActivity(); state != ActivityNeedsInput {
+		t.Errorf("state after new output = %d, want ActivityNeedsInput", state)
+	}
+}
+
+// TestSession_ConcurrentWritesAndScans exercises the locking contract
+// between the PTY write path and the polling readers; run with -race.
+func TestSession_ConcurrentWritesAndScans(t *testing.T) {
+	sess := newStaleSession(24, 80)
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+
+	for _, poll := range []func(){
+		sess.ScanTokens,
+		func() { sess.DetectActivity() },
+		func() { sess.Screen.RenderDiff() },
+	} {
+		wg.Add(1)
+		go func(poll func()) {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					poll()
+				}
+			}
+		}(poll)
+	}
+
+	for i := 0; i < 500; i++ {
+		fmt.Fprintf(sess.Screen, "\x1b[32mline %d\x1b[0m $0.%02d 1.%dk input\r\n", i, i%100, i%10)
+		if i%100 == 0 {
+			sess.Screen.Resize(24+i%3, 80)
+		}
+	}
+	close(stop)
+	wg.Wait()
+
+	sess.ScanTokens()
+	if sess.Tokens.TotalCost == 0 {
+		t.Error("final scan found no cost")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// ActivityLine – the prompt or question behind the state
+// ---------------------------------------------------------------------------
+
+func TestActivityLine_FollowsClassification(t *testing.T) {
+	sess := newStaleSession(5, 80)
+	sess.Screen.Write([]byte("Overwrite config.yaml? [y/N] "))
+	if st := sess.DetectActivity(); st != ActivityNeedsInput {
+		t.Fatalf("state = %d, want ActivityNeedsInput", st)
+	}
+	if got := sess.ActivityLine(); got != "Overwrite config.yaml? [y/N]" {
+		t.Errorf("ActivityLine = %q, want the question", got)
+	}
+
+	// The cached classification keeps the line
+	sess.DetectActivity()
+	if got := sess.ActivityLine(); got != "Overwrite config.yaml? [y/N]" {
+		t.Errorf("ActivityLine after cached scan = %q", got)
+	}
+
+	sess.Screen.Write([]byte("y\r\nuser@host:~$ "))
+	sess.DetectActivity()
+	if got := sess.ActivityLine(); got != "user@host:~$" {
+		t.Errorf("ActivityLine at the prompt = %q, want the prompt", got)
+	}
+
+	sess.mu.Lock()
+	sess.LastOutputAt = time.Now()
+	sess.mu.Unlock()
+	sess.DetectActivity()
+	if got := sess.ActivityLine(); got != "" {
+		t.Errorf("ActivityLine while active = %q, want empty", got)
+	}
+}
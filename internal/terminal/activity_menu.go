@@ -0,0 +1,98 @@
+package terminal
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultMenuPatterns match the highlighted option of an arrow-select menu,
+// as Claude Code shows for tool approvals ("❯ 1. Yes"). The config's
+// approval_menu_patterns replace them.
+var DefaultMenuPatterns = []string{
+	`^[❯›▶]\s*\d{1,2}[.)]\s+\S`,
+}
+
+// MenuPatterns holds compiled patterns for the highlighted option of an
+// approval menu (see DefaultMenuPatterns).
+type MenuPatterns []*regexp.Regexp
+
+// NewMenuPatterns compiles patterns, using the defaults for an empty list.
+// Patterns that do not compile are skipped.
+func NewMenuPatterns(patterns []string) MenuPatterns {
+	if len(patterns) == 0 {
+		patterns = DefaultMenuPatterns
+	}
+	return MenuPatterns(compileAll(patterns))
+}
+
+var defaultMenuPatterns = NewMenuPatterns(nil)
+
+// menuOptionPattern matches any option of a numbered menu, highlighted or
+// not: "❯ 1. Yes", "2. No", "( ) 3. Skip". Up to four marker characters
+// may precede the number.
+var menuOptionPattern = regexp.MustCompile(`^.{0,4}?\b\d{1,2}[.)]\s+\S`)
+
+// SetMenuPatterns sets the patterns that recognise approval menus; nil
+// restores the defaults. The screen is classified again on the next
+// DetectActivity.
+func (s *Session) SetMenuPatterns(p MenuPatterns) {
+	s.mu.Lock()
+	s.menus = p
+	s.classifiedGen = 0
+	s.mu.Unlock()
+}
+
+// menuPatterns returns the session's menu patterns or the defaults.
+func (s *Session) menuPatterns() MenuPatterns {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.menus == nil {
+		return defaultMenuPatterns
+	}
+	return s.menus
+}
+
+// findMenu looks for an approval menu in lines: a highlighted option next
+// to at least one other numbered option. It returns the index of the
+// menu's last option and the question above the menu (the highlighted
+// option if there is none), or -1 if lines hold no menu.
+func (p MenuPatterns) findMenu(lines []string) (int, string) {
+	for i := len(lines) - 1; i >= 0; i-- {
+		selected := menuText(lines[i])
+		if !p.highlights(selected) {
+			continue
+		}
+		first, last := i, i
+		for first > 0 && menuOptionPattern.MatchString(menuText(lines[first-1])) {
+			first--
+		}
+		for last < len(lines)-1 && menuOptionPattern.MatchString(menuText(lines[last+1])) {
+			last++
+		}
+		if first == last {
+			continue // a lone "> 1. ..." line is not a menu
+		}
+		for j := first - 1; j >= 0; j-- {
+			if q := menuText(lines[j]); q != "" && strings.Trim(q, "╭╮╰╯─") != "" {
+				return last, q
+			}
+		}
+		return last, selected
+	}
+	return -1, ""
+}
+
+func (p MenuPatterns) highlights(line string) bool {
+	for _, re := range p {
+		if re.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// menuText trims whitespace and the side borders of the box Claude Code
+// draws around its menus.
+func menuText(line string) string {
+	return strings.Trim(line, " \t│┃|")
+}
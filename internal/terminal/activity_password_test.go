@@ -0,0 +1,107 @@
+package terminal
+
+import "testing"
+
+// ---------------------------------------------------------------------------
+// Password prompts through the Screen pipeline → ActivityPasswordInput
+//
+// Like activity_realistic_test.go, these feed the bytes a real sudo/ssh/su
+// would write (including ANSI styling and the preceding command output) and
+// check what DetectActivity reports once output has gone quiet.
+// ---------------------------------------------------------------------------
+
+func TestRealistic_PasswordPrompts(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{
+			"sudo with colored shell prompt",
+			"\x1b[01;32muser@host\x1b[00m:\x1b[01;34m~\x1b[00m$ sudo apt update\r\n" +
+				"[sudo] password for user: ",
+		},
+		{
+			"sudo with styled lecture prompt",
+			"\x1b[1m\x1b[31m[sudo]\x1b[0m \x1b[1mpassword for alice:\x1b[0m ",
+		},
+		{
+			"sudo in German locale",
+			"[sudo] Passwort für alice: ",
+		},
+		{
+			"su",
+			"$ su -\r\nPassword: ",
+		},
+		{
+			"ssh password",
+			"$ ssh deploy@example.com\r\ndeploy@example.com's password: ",
+		},
+		{
+			"ssh key passphrase",
+			"$ git push\r\nEnter passphrase for key '/home/user/.ssh/id_ed25519': ",
+		},
+		{
+			"gpg pin entry",
+			"\x1b[2mgpg: signing commit\x1b[0m\r\nEnter PIN: ",
+		},
+	}
+	for _, tt := range tests {
+		sess := newStaleSession(10, 80)
+		sess.Screen.Write([]byte(tt.raw))
+
+		row, _ := sess.Screen.Cursor()
+		if state := sess.DetectActivity(); state != ActivityPasswordInput {
+			t.Errorf("%s: state = %d, want ActivityPasswordInput (%d)\ncursor row %q",
+				tt.name, state, ActivityPasswordInput, sess.Screen.PlainTextRow(row))
+		}
+	}
+}
+
+func TestRealistic_PasswordPrompt_AnsweredIsNotPending(t *testing.T) {
+	sess := newStaleSession(10, 80)
+	// Password was typed (not echoed) and Enter pressed; the command is now
+	// running quietly on the next line.
+	sess.Screen.Write([]byte("$ sudo sleep 60\r\n[sudo] password for user: \r\n"))
+
+	if state := sess.DetectActivity(); state == ActivityPasswordInput {
+		t.Errorf("answered prompt still reported as ActivityPasswordInput")
+	}
+}
+
+func TestRealistic_PasswordPrompt_ThenShellPrompt(t *testing.T) {
+	sess := newStaleSession(10, 80)
+	sess.Screen.Write([]byte(
+		"[sudo] password for user: \r\n" +
+			"Reading package lists... Done\r\n" +
+			"\x1b[01;32muser@host\x1b[00m:\x1b[01;34m~\x1b[00m$ ",
+	))
+
+	if state := sess.DetectActivity(); state != ActivityDone {
+		t.Errorf("state = %d, want ActivityDone (%d)", state, ActivityDone)
+	}
+}
+
+func TestRealistic_PasswordWordInOutputIsNotPrompt(t *testing.T) {
+	sess := newStaleSession(10, 80)
+	sess.Screen.Write([]byte(
+		"$ grep -r password config/\r\n" +
+			"config/db.yml:  password_env: DB_PASSWORD\r\n" +
+			"$ ",
+	))
+
+	if state := sess.DetectActivity(); state != ActivityDone {
+		t.Errorf("state = %d, want ActivityDone (%d)", state, ActivityDone)
+	}
+}
+
+func TestAwaitingPassword(t *testing.T) {
+	sess := newStaleSession(10, 80)
+	sess.Screen.Write([]byte("$ sudo ls\r\n[sudo] password for user: "))
+	if !sess.AwaitingPassword() {
+		t.Error("cursor on sudo prompt: AwaitingPassword = false")
+	}
+	sess.Screen.Write([]byte("\r\n$ "))
+	if sess.AwaitingPassword() {
+		t.Error("cursor on shell prompt: AwaitingPassword = true")
+	}
+}
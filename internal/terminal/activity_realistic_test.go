@@ -518,3 +518,95 @@ func TestRealistic_BashPrompt_NoSpaceBeforeDollar(t *testing.T) {
 			state, ActivityDone)
 	}
 }
+
+// ---------------------------------------------------------------------------
+// Claude Code arrow-select approval menus ("❯ 1. Yes / 2. No") — newer
+// versions show these instead of [Y/n] → should detect ActivityNeedsInput
+// ---------------------------------------------------------------------------
+
+func TestRealistic_ClaudeCode_TrustMenu(t *testing.T) {
+	sess := newStaleSession(15, 80)
+	// No "[Y/n]" and no "Do you want to": only the menu marks the prompt
+	sess.Screen.Write([]byte(
+		" \x1b[1mDo you trust the files in this folder?\x1b[22m\r\n" +
+			"\r\n" +
+			" \x1b[2m/home/user/project\x1b[22m\r\n" +
+			"\r\n" +
+			" \x1b[36m❯ \x1b[1m1. Yes, proceed\x1b[22m\x1b[39m\r\n" +
+			"   2. No, exit\r\n" +
+			"\r\n" +
+			" \x1b[2mEnter to confirm · Esc to exit\x1b[22m",
+	))
+
+	state := sess.DetectActivity()
+	if state != ActivityNeedsInput {
+		t.Errorf("trust menu: state = %d, want ActivityNeedsInput (%d)", state, ActivityNeedsInput)
+	}
+	if line := sess.ActivityLine(); line != "/home/user/project" {
+		t.Errorf("ActivityLine = %q, want the line above the menu", line)
+	}
+}
+
+func TestRealistic_ClaudeCode_BoxedToolApprovalMenu(t *testing.T) {
+	sess := newStaleSession(15, 60)
+	sess.Screen.Write([]byte(
+		"\x1b[38;5;174m╭──────────────────────────────────────────────────╮\x1b[39m\r\n" +
+			"\x1b[38;5;174m│\x1b[39m \x1b[1mBash command\x1b[22m                                     \x1b[38;5;174m│\x1b[39m\r\n" +
+			"\x1b[38;5;174m│\x1b[39m                                                  \x1b[38;5;174m│\x1b[39m\r\n" +
+			"\x1b[38;5;174m│\x1b[39m   npm install                                    \x1b[38;5;174m│\x1b[39m\r\n" +
+			"\x1b[38;5;174m│\x1b[39m                                                  \x1b[38;5;174m│\x1b[39m\r\n" +
+			"\x1b[38;5;174m│\x1b[39m Run this command?                                \x1b[38;5;174m│\x1b[39m\r\n" +
+			"\x1b[38;5;174m│\x1b[39m \x1b[36m❯ 1. Yes\x1b[39m                                         \x1b[38;5;174m│\x1b[39m\r\n" +
+			"\x1b[38;5;174m│\x1b[39m   2. Yes, and don't ask again for npm install    \x1b[38;5;174m│\x1b[39m\r\n" +
+			"\x1b[38;5;174m│\x1b[39m   3. No, and tell Claude what to do (esc)        \x1b[38;5;174m│\x1b[39m\r\n" +
+			"\x1b[38;5;174m╰──────────────────────────────────────────────────╯\x1b[39m",
+	))
+
+	for r := 0; r < 10; r++ {
+		t.Logf("Row %d: %q", r, sess.Screen.PlainTextRow(r))
+	}
+
+	state := sess.DetectActivity()
+	if state != ActivityNeedsInput {
+		t.Errorf("boxed approval menu: state = %d, want ActivityNeedsInput (%d)", state, ActivityNeedsInput)
+	}
+	if line := sess.ActivityLine(); line != "Run this command?" {
+		t.Errorf("ActivityLine = %q, want the menu's question", line)
+	}
+}
+
+func TestRealistic_NumberedListWithoutSelector_IsNotAMenu(t *testing.T) {
+	sess := newStaleSession(10, 80)
+	// A numbered list in command output followed by a quoted "> 1." line:
+	// neither has the selector of a menu
+	sess.Screen.Write([]byte(
+		"Steps:\r\n" +
+			"1. Build\r\n" +
+			"2. Test\r\n" +
+			"> 1. quoted from the changelog\r\n",
+	))
+
+	if state := sess.DetectActivity(); state == ActivityNeedsInput {
+		t.Errorf("numbered list: state = ActivityNeedsInput, want no menu detected")
+	}
+}
+
+func TestRealistic_MenuPatterns_Override(t *testing.T) {
+	menu := "Apply changes?\r\n" +
+		"(*) 1. Apply\r\n" +
+		"( ) 2. Discard\r\n"
+
+	sess := newStaleSession(10, 80)
+	sess.Screen.Write([]byte(menu))
+	if state := sess.DetectActivity(); state == ActivityNeedsInput {
+		t.Fatalf("default patterns: radio-style menu detected, want it unrecognised")
+	}
+
+	sess.SetMenuPatterns(NewMenuPatterns([]string{`^\(\*\)\s*\d\.`}))
+	sess.mu.Lock()
+	sess.Activity = ActivityActive
+	sess.mu.Unlock()
+	if state := sess.DetectActivity(); state != ActivityNeedsInput {
+		t.Errorf("custom pattern: state = %d, want ActivityNeedsInput (%d)", state, ActivityNeedsInput)
+	}
+}
@@ -0,0 +1,138 @@
+package terminal
+
+import (
+	"regexp"
+	"strings"
+)
+
+// CommandResult is the outcome of the last command as read from its output.
+type CommandResult int
+
+const (
+	ResultUnknown CommandResult = iota // no pass or fail pattern in the output
+	ResultPass                         // e.g. "ok", "PASS", "Build succeeded"
+	ResultFail                         // e.g. "FAIL", "error:", "2 failed"
+)
+
+// String returns the name used in frontend events: "unknown", "pass" or "fail".
+func (r CommandResult) String() string {
+	switch r {
+	case ResultPass:
+		return "pass"
+	case ResultFail:
+		return "fail"
+	default:
+		return "unknown"
+	}
+}
+
+// Default result patterns for common compilers and test runners (go test,
+// cargo, npm, jest, pytest, make). The config's result_patterns replace
+// them per list.
+var (
+	DefaultPassPatterns = []string{
+		`^(?:ok|PASS)\b`,
+		`✓|✔`,
+		`(?i)\bbuild (?:succeeded|successful)\b`,
+		`(?i)\ball tests passed\b`,
+		`(?i)\b\d+ (?:passed|passing)\b`,
+		`(?i)^test result: ok\b`,
+	}
+	DefaultFailPatterns = []string{
+		`\bFAIL(?:ED)?\b`,
+		`(?i)\berror(?:\[\w+\])?:`,
+		`(?i)\bbuild failed\b`,
+		`(?i)\b[1-9]\d* (?:failed|failing|errors?)\b`,
+		`npm ERR!`,
+		`✗|✘`,
+	}
+)
+
+// ResultPatterns holds compiled pass and fail patterns for ScanResult.
+type ResultPatterns struct {
+	Pass []*regexp.Regexp
+	Fail []*regexp.Regexp
+}
+
+// NewResultPatterns compiles pass and fail, using the defaults for an
+// empty list. Patterns that do not compile are skipped.
+func NewResultPatterns(pass, fail []string) ResultPatterns {
+	if len(pass) == 0 {
+		pass = DefaultPassPatterns
+	}
+	if len(fail) == 0 {
+		fail = DefaultFailPatterns
+	}
+	return ResultPatterns{Pass: compileAll(pass), Fail: compileAll(fail)}
+}
+
+func compileAll(patterns []string) []*regexp.Regexp {
+	out := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		if re, err := regexp.Compile(p); err == nil && p != "" {
+			out = append(out, re)
+		}
+	}
+	return out
+}
+
+// resultScanRows bounds how far above the prompt ScanResult looks.
+const resultScanRows = 40
+
+// ScanResult classifies the output of the command that just finished and
+// stores it in LastResult. It reads the logical lines above the current
+// prompt up to the previous prompt line (where the command was typed), so
+// results of earlier commands still on screen do not count. Any fail
+// match wins over pass matches; without either the result is unknown.
+func (s *Session) ScanResult(p ResultPatterns) CommandResult {
+	rows := s.Screen.Rows()
+	lines := s.Screen.PlainTextLogicalRows(max(0, rows-resultScanRows), rows)
+
+	// Skip the blank rows below the output and the current prompt itself
+	end := len(lines)
+	for end > 0 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	if end > 0 && promptPattern.MatchString(strings.TrimSpace(lines[end-1])) {
+		end--
+	}
+
+	result := ResultUnknown
+	for i := end - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if isPromptLine(line) {
+			break // the previous prompt, followed by the command
+		}
+		if matchAny(p.Fail, line) {
+			result = ResultFail
+			break
+		}
+		if matchAny(p.Pass, line) {
+			result = ResultPass
+		}
+	}
+	s.mu.Lock()
+	s.LastResult = result
+	s.mu.Unlock()
+	return result
+}
+
+// isPromptLine reports whether line is a shell prompt with the command
+// typed after it, e.g. "user@host:~/src$ go test ./...".
+func isPromptLine(line string) bool {
+	return commandPromptPattern.MatchString(line)
+}
+
+// commandPromptPattern matches a prompt that is followed by a command:
+// "user@host:~$ make", "$ make", "❯ npm test", "C:\src> go build".
+var commandPromptPattern = regexp.MustCompile(
+	`^(?:\S*[@:~/\\]\S*\s?[$#%>]|[❯›»$]|[A-Za-z]:\\[^>]*>)\s+\S`)
+
+func matchAny(res []*regexp.Regexp, line string) bool {
+	for _, re := range res {
+		if re.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package terminal
+
+import "testing"
+
+// runResult feeds output through a session's screen and scans it with the
+// default patterns.
+func runResult(t *testing.T, output string) CommandResult {
+	t.Helper()
+	sess := NewSession(1, 12, 80)
+	sess.Screen.Write([]byte(output))
+	return sess.ScanResult(NewResultPatterns(nil, nil))
+}
+
+func TestScanResult_GoTestColored(t *testing.T) {
+	pass := "\x1b[32muser@host\x1b[0m:~/src$ go test ./...\r\n" +
+		"ok  \tgithub.com/x/y\t0.012s\r\n" +
+		"\x1b[32muser@host\x1b[0m:~/src$ "
+	if got := runResult(t, pass); got != ResultPass {
+		t.Errorf("passing go test = %v, want pass", got)
+	}
+
+	fail := "user@host:~/src$ go test ./...\r\n" +
+		"--- \x1b[31mFAIL\x1b[0m: TestX (0.00s)\r\n" +
+		"ok  \tgithub.com/x/z\t0.010s\r\n" +
+		"\x1b[31mFAIL\x1b[0m\tgithub.com/x/y\t0.012s\r\n" +
+		"user@host:~/src$ "
+	if got := runResult(t, fail); got != ResultFail {
+		t.Errorf("failing go test = %v, want fail", got)
+	}
+}
+
+func TestScanResult_CompilerAndRunners(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   CommandResult
+	}{
+		{"rust error", "$ cargo build\r\n\x1b[1;31merror[E0308]\x1b[0m: mismatched types\r\n$ ", ResultFail},
+		{"jest summary", "❯ npm test\r\nTests:       \x1b[31m2 failed\x1b[0m, 5 passed, 7 total\r\n❯ ", ResultFail},
+		{"pytest pass", "$ pytest\r\n\x1b[32m===== 5 passed in 0.12s =====\x1b[0m\r\n$ ", ResultPass},
+		{"zero failures", "$ npm test\r\n  12 passing\r\n  0 failing\r\n$ ", ResultPass},
+		{"check mark", "$ npm run build\r\n\x1b[32m✓\x1b[0m Build successful\r\n$ ", ResultPass},
+		{"plain listing", "$ ls\r\nmain.go  go.mod\r\n$ ", ResultUnknown},
+	}
+	for _, tt := range tests {
+		if got := runResult(t, tt.output); got != tt.want {
+			t.Errorf("%s: ScanResult = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestScanResult_IgnoresEarlierCommands(t *testing.T) {
+	out := "$ go test\r\nFAIL\tpkg\t0.1s\r\n" +
+		"$ ls\r\nmain.go\r\n$ "
+	if got := runResult(t, out); got != ResultUnknown {
+		t.Errorf("ScanResult = %v, want unknown: the FAIL belongs to an earlier command", got)
+	}
+}
+
+func TestScanResult_CustomPatternsAndLastResult(t *testing.T) {
+	sess := NewSession(1, 6, 80)
+	sess.Screen.Write([]byte("$ deploy\r\nRollout \x1b[1mcomplete\x1b[0m\r\n$ "))
+	p := NewResultPatterns([]string{`Rollout complete`}, []string{`Rollout aborted`, `(broken`})
+
+	if got := sess.ScanResult(p); got != ResultPass {
+		t.Errorf("ScanResult = %v, want pass from the custom pattern", got)
+	}
+	if sess.LastResult != ResultPass {
+		t.Errorf("LastResult = %v, want pass", sess.LastResult)
+	}
+	if len(p.Fail) != 1 {
+		t.Errorf("invalid pattern was compiled: %d fail patterns", len(p.Fail))
+	}
+}
+
+func TestCommandResult_String(t *testing.T) {
+	for r, want := range map[CommandResult]string{ResultUnknown: "unknown", ResultPass: "pass", ResultFail: "fail"} {
+		if r.String() != want {
+			t.Errorf("%d.String() = %q, want %q", r, r.String(), want)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package terminal
+
+// codeColorCount is how many different foreground colours make a row look
+// like syntax-highlighted code rather than a prompt or a question.
+const codeColorCount = 3
+
+// rowLooksLikePrompt judges a screen row that matched a prompt or question
+// pattern by its cell styles. Rows without any styling cannot be judged and
+// pass, which keeps the plain-text classification. A styled row is
+// rejected when its last character (the prompt sigil or question mark)
+// is italic, as rendered comments and documentation are, or when the row
+// is coloured like highlighted code (codeColorCount or more foreground
+// colours) and its last character is neither bold nor bright, as the
+// sigil of a coloured shell prompt usually is.
+func (s *Session) rowLooksLikePrompt(row int) bool {
+	cols := s.Screen.Cols()
+	var last Cell
+	found, styled := false, false
+	colors := make(map[int]bool)
+	for c := cols - 1; c >= 0; c-- {
+		cell := s.Screen.CellAt(row, c)
+		if cell.Char == ' ' || cell.Char == 0 {
+			continue
+		}
+		if !found {
+			last, found = cell, true
+		}
+		if cell.Style != (CellStyle{}) {
+			styled = true
+		}
+		if cell.Style.FG != 0 {
+			colors[cell.Style.FG] = true
+		}
+	}
+	if !found || !styled {
+		return true
+	}
+	if last.Style.Italic {
+		return false
+	}
+	emphasised := last.Style.Bold || (last.Style.FG >= 9 && last.Style.FG <= 16)
+	return len(colors) < codeColorCount || emphasised
+}
@@ -0,0 +1,73 @@
+package terminal
+
+import "testing"
+
+// styledCase is one screen content, classified with and without styles.
+type styledCase struct {
+	name   string
+	output string
+	styled ActivityState // DetectActivity / classifyScreenStateStyled
+	plain  ActivityState // classifyScreenState
+}
+
+func TestClassifyScreenStateStyled(t *testing.T) {
+	tests := []styledCase{
+		{
+			name:   "highlighted HTML ending in >",
+			output: "\x1b[34m<div\x1b[0m \x1b[32mclass\x1b[0m=\x1b[33m\"box\"\x1b[34m>\x1b[0m",
+			styled: ActivityIdle,
+			plain:  ActivityDone,
+		},
+		{
+			name:   "same HTML without colours",
+			output: "<div class=\"box\">",
+			styled: ActivityDone, // nothing to judge: plain-text result
+			plain:  ActivityDone,
+		},
+		{
+			name:   "italic documentation question",
+			output: "\x1b[3mWhen the build is green, can we proceed?\x1b[23m",
+			styled: ActivityIdle,
+			plain:  ActivityNeedsInput,
+		},
+		{
+			name:   "bold permission question",
+			output: "\x1b[1mDo you want to proceed?\x1b[22m",
+			styled: ActivityNeedsInput,
+			plain:  ActivityNeedsInput,
+		},
+		{
+			name:   "colourful prompt with a bright sigil",
+			output: "\x1b[36m~/project\x1b[0m \x1b[35mmain\x1b[0m \x1b[33m*\x1b[0m \x1b[92m$\x1b[0m ",
+			styled: ActivityDone,
+			plain:  ActivityDone,
+		},
+		{
+			name:   "coloured bash prompt",
+			output: "\x1b[01;32muser@host\x1b[00m:\x1b[01;34m~/project\x1b[00m$ ",
+			styled: ActivityDone,
+			plain:  ActivityDone,
+		},
+		{
+			name:   "highlighted code above a real prompt",
+			output: "\x1b[34m<div\x1b[0m \x1b[32mclass\x1b[0m=\x1b[33m\"box\"\x1b[34m>\x1b[0m\r\nuser@host:~$ ",
+			styled: ActivityDone,
+			plain:  ActivityDone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sess := newStaleSession(5, 80)
+			sess.Screen.Write([]byte(tt.output))
+			if got := sess.classifyScreenState(); got != tt.plain {
+				t.Errorf("plain = %d, want %d", got, tt.plain)
+			}
+			if got := sess.classifyScreenStateStyled(); got != tt.styled {
+				t.Errorf("styled = %d, want %d", got, tt.styled)
+			}
+			if got := sess.DetectActivity(); got != tt.styled {
+				t.Errorf("DetectActivity = %d, want the styled result %d", got, tt.styled)
+			}
+		})
+	}
+}
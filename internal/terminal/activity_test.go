@@ -1,6 +1,7 @@
 package terminal
 
 import (
+	"fmt"
 	"testing"
 	"time"
 )
@@ -285,6 +286,69 @@ func TestScanTokens_CostOnLastRow(t *testing.T) {
 	}
 }
 
+func TestScanWindow(t *testing.T) {
+	tests := []struct{ rows, minRows, want int }{
+		{5, tokenScanMinRows, 5}, // tiny pane: read whole, not more
+		{24, tokenScanMinRows, 10},
+		{36, tokenScanMinRows, 12},
+		{60, tokenScanMinRows, 20},
+		{200, tokenScanMinRows, scanMaxRows},
+		{24, promptScanMinRows, 15},
+		{60, promptScanMinRows, 20},
+	}
+	for _, tt := range tests {
+		if got := scanWindow(tt.rows, tt.minRows); got != tt.want {
+			t.Errorf("scanWindow(%d, %d) = %d, want %d", tt.rows, tt.minRows, got, tt.want)
+		}
+	}
+}
+
+// costAt puts a cost line fromBottom rows above the last row of a screen
+// with rows rows, below it a tall status block, and scans for tokens.
+func costAt(rows, fromBottom int) float64 {
+	sess := NewSession(1, rows, 80)
+	line := rows - fromBottom
+	sess.Screen.Write([]byte(fmt.Sprintf("\x1b[%d;1HTotal cost: $4.20", line)))
+	for r := line + 1; r <= rows; r++ {
+		sess.Screen.Write([]byte(fmt.Sprintf("\x1b[%d;1H│ status %d", r, r)))
+	}
+	sess.ScanTokens()
+	return sess.GetTokens().TotalCost
+}
+
+func TestScanTokens_WindowFollowsScreenHeight(t *testing.T) {
+	tests := []struct {
+		rows, fromBottom int
+		found            bool
+	}{
+		{24, 9, true},
+		{24, 14, false}, // above the 10-row window of a normal pane
+		{45, 14, true},  // tall pane: below a fixed 10-row cutoff, still found
+		{60, 14, true},
+		{60, 19, true},
+		{60, 25, false},
+		{5, 4, true}, // tiny pane: whole screen
+	}
+	for _, tt := range tests {
+		got := costAt(tt.rows, tt.fromBottom)
+		if found := got == 4.20; found != tt.found {
+			t.Errorf("%d rows, cost %d rows above the bottom: TotalCost = %v, found = %v, want %v",
+				tt.rows, tt.fromBottom, got, found, tt.found)
+		}
+	}
+}
+
+func TestScanTokens_WrappedCostLineAtWindowEdge(t *testing.T) {
+	// 60 rows scan 20; the cost line starts above the window and wraps into
+	// it between "$" and "7.50"
+	sess := NewSession(1, 60, 20)
+	sess.Screen.Write([]byte("\x1b[40;1H" + "Total session cost $7.50 so far"))
+	sess.ScanTokens()
+	if got := sess.GetTokens().TotalCost; got != 7.50 {
+		t.Errorf("TotalCost = %v, want 7.50 from the wrapped line", got)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // classifyScreenState — via screen buffer content
 // ---------------------------------------------------------------------------
@@ -310,6 +374,20 @@ func TestClassifyScreenState_NeedsInput(t *testing.T) {
 	}
 }
 
+func TestNeedsInputLine(t *testing.T) {
+	sess := NewSession(1, 5, 80)
+	sess.Screen.Write([]byte("build ok\r\n  Apply patch? [Y/n] "))
+	if got := sess.NeedsInputLine(); got != "Apply patch? [Y/n]" {
+		t.Errorf("NeedsInputLine = %q, want the trimmed prompt", got)
+	}
+
+	// A prompt below the question means it has been answered
+	sess.Screen.Write([]byte("y\r\n$ "))
+	if got := sess.NeedsInputLine(); got != "" {
+		t.Errorf("NeedsInputLine after prompt returned = %q, want empty", got)
+	}
+}
+
 func TestClassifyScreenState_Idle(t *testing.T) {
 	sess := NewSession(1, 5, 80)
 	sess.Screen.Write([]byte("compiling main.go...\r\n"))
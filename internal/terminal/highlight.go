@@ -0,0 +1,73 @@
+package terminal
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Limits for highlight patterns. Go's RE2 engine matches in linear time,
+// so there is no catastrophic backtracking; the limits bound the size of
+// the compiled program, which is what each scanned line pays for.
+const (
+	maxHighlightPattern = 256  // bytes of pattern text
+	maxHighlightInsts   = 1000 // instructions in the compiled program
+)
+
+// CompileHighlight compiles a pane highlight pattern. Matching ignores
+// case unless the pattern contains an upper-case letter (smart case, as
+// in less -i). Patterns that are too long or compile to a very large
+// program, e.g. through nested counted repetition, are refused.
+func CompileHighlight(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) > maxHighlightPattern {
+		return nil, fmt.Errorf("pattern longer than %d bytes", maxHighlightPattern)
+	}
+	if !strings.ContainsFunc(pattern, unicode.IsUpper) {
+		pattern = "(?i)" + pattern
+	}
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	prog, err := syntax.Compile(parsed.Simplify())
+	if err != nil {
+		return nil, err
+	}
+	if len(prog.Inst) > maxHighlightInsts {
+		return nil, fmt.Errorf("pattern too complex (%d instructions, max %d)", len(prog.Inst), maxHighlightInsts)
+	}
+	return regexp.Compile(pattern)
+}
+
+// MatchColumns returns the matches of re in text as flat [start, end)
+// pairs of rune offsets. Screen rows hold one rune per cell, so for a row
+// the offsets are columns. Empty matches are skipped.
+func MatchColumns(re *regexp.Regexp, text string) []int {
+	var cols []int
+	for _, m := range re.FindAllStringIndex(text, -1) {
+		if m[0] == m[1] {
+			continue
+		}
+		start := utf8.RuneCountInString(text[:m[0]])
+		cols = append(cols, start, start+utf8.RuneCountInString(text[m[0]:m[1]]))
+	}
+	return cols
+}
+
+// RowText returns the characters of a row of cells, one rune per cell,
+// with blank cells as spaces.
+func RowText(cells []Cell) string {
+	var b strings.Builder
+	b.Grow(len(cells))
+	for _, c := range cells {
+		ch := c.Char
+		if ch == 0 {
+			ch = ' '
+		}
+		b.WriteRune(ch)
+	}
+	return b.String()
+}
@@ -0,0 +1,52 @@
+package terminal
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompileHighlight_SmartCase(t *testing.T) {
+	re, err := CompileHighlight("error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !re.MatchString("FATAL ERROR") {
+		t.Error("lower-case pattern should ignore case")
+	}
+	re, err = CompileHighlight("Error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if re.MatchString("error") {
+		t.Error("pattern with upper case should match case-sensitively")
+	}
+}
+
+func TestCompileHighlight_Limits(t *testing.T) {
+	if _, err := CompileHighlight("(unclosed"); err == nil {
+		t.Error("invalid pattern accepted")
+	}
+	if _, err := CompileHighlight(strings.Repeat("a", maxHighlightPattern+1)); err == nil {
+		t.Error("overlong pattern accepted")
+	}
+	if _, err := CompileHighlight(`(\w{30}\s{30}){30}`); err == nil || !strings.Contains(err.Error(), "too complex") {
+		t.Errorf("nested repetition: err = %v, want too complex", err)
+	}
+	if _, err := CompileHighlight(`(a+)+b`); err != nil {
+		t.Errorf("backtracking-prone pattern is safe in RE2 but was refused: %v", err)
+	}
+}
+
+func TestMatchColumns(t *testing.T) {
+	re, _ := CompileHighlight("ab|x*")
+	if got, want := MatchColumns(re, "äab xx ab"), []int{1, 3, 4, 6, 7, 9}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MatchColumns = %v, want %v (rune offsets, empty matches skipped)", got, want)
+	}
+}
+
+func TestRowText(t *testing.T) {
+	if got := RowText([]Cell{{Char: 'o'}, {}, {Char: 'k'}}); got != "o k" {
+		t.Errorf("RowText = %q, want %q", got, "o k")
+	}
+}
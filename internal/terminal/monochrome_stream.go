@@ -0,0 +1,135 @@
+package terminal
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// maxHeldSequence bounds how much of an unfinished escape sequence
+// ColorStripper holds back; longer ones are passed through unchanged.
+const maxHeldSequence = 256
+
+// ColorStripper removes colour from a stream of raw PTY output, for panes
+// shown in monochrome: SGR sequences lose their colour parameters but keep
+// bold, underline and the other attributes. A sequence cut off at the end
+// of a chunk is held back until the next one. The zero value is ready to
+// use; one stripper serves one stream.
+type ColorStripper struct {
+	pending []byte
+}
+
+// Strip returns chunk without colours. With on false nothing is stripped
+// and only a held-back sequence is released, so monochrome can be turned
+// off mid-stream without losing bytes.
+func (c *ColorStripper) Strip(chunk []byte, on bool) []byte {
+	data := chunk
+	if len(c.pending) > 0 {
+		data = append(c.pending, chunk...)
+		c.pending = nil
+	}
+	if !on {
+		return data
+	}
+
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); {
+		if data[i] != 0x1b {
+			next := bytes.IndexByte(data[i:], 0x1b)
+			if next < 0 {
+				next = len(data) - i
+			}
+			out = append(out, data[i:i+next]...)
+			i += next
+			continue
+		}
+		end, complete := csiEnd(data[i:])
+		switch {
+		case !complete && len(data)-i <= maxHeldSequence:
+			c.pending = append([]byte(nil), data[i:]...)
+			return out
+		case !complete:
+			return append(out, data[i:]...)
+		case end < 0: // not a CSI sequence
+			out = append(out, data[i])
+			i++
+		case data[i+end] == 'm':
+			out = appendSGRWithoutColor(out, data[i+2:i+end])
+			i += end + 1
+		default:
+			out = append(out, data[i:i+end+1]...)
+			i += end + 1
+		}
+	}
+	return out
+}
+
+// csiEnd returns the index of the final byte of the CSI sequence seq
+// starts with, or -1 if seq does not start a CSI sequence. complete is
+// false when seq ends before the sequence does.
+func csiEnd(seq []byte) (end int, complete bool) {
+	if len(seq) < 2 {
+		return 0, false
+	}
+	if seq[1] != '[' {
+		return -1, true
+	}
+	for j := 2; j < len(seq); j++ {
+		switch b := seq[j]; {
+		case b >= 0x40 && b <= 0x7e:
+			return j, true
+		case b < 0x20 || b > 0x3f:
+			return -1, true // malformed; pass it through byte by byte
+		}
+	}
+	return 0, false
+}
+
+// appendSGRWithoutColor appends the SGR sequence with params minus its
+// colour parameters, or nothing if only colours were set.
+func appendSGRWithoutColor(out, params []byte) []byte {
+	if len(params) == 0 || params[0] < '0' || params[0] > ';' {
+		// Reset, or a private sequence such as CSI > 4 ; 1 m
+		out = append(out, "\x1b["...)
+		out = append(out, params...)
+		return append(out, 'm')
+	}
+	fields := bytes.Split(params, []byte{';'})
+	var kept [][]byte
+	for k := 0; k < len(fields); k++ {
+		f := fields[k]
+		if sub := bytes.IndexByte(f, ':'); sub >= 0 {
+			// Colon form, e.g. 38:2::255:0:0 – one field per colour
+			if !isExtendedColor(f[:sub]) {
+				kept = append(kept, f)
+			}
+			continue
+		}
+		n, _ := strconv.Atoi(string(f))
+		switch {
+		case n == 38 || n == 48 || n == 58:
+			// 38;5;n or 38;2;r;g;b
+			if k+1 < len(fields) && string(fields[k+1]) == "5" {
+				k += 2
+			} else if k+1 < len(fields) && string(fields[k+1]) == "2" {
+				k += 4
+			}
+		case n >= 30 && n <= 37, n == 39, n >= 40 && n <= 47, n == 49,
+			n >= 90 && n <= 97, n >= 100 && n <= 107, n == 59:
+		default:
+			kept = append(kept, f)
+		}
+	}
+	if len(kept) == 0 {
+		return out
+	}
+	out = append(out, "\x1b["...)
+	out = append(out, bytes.Join(kept, []byte{';'})...)
+	return append(out, 'm')
+}
+
+// isExtendedColor reports whether an SGR parameter selects a 256-colour or
+// truecolour foreground, background or underline colour.
+func isExtendedColor(p []byte) bool {
+	s := string(p)
+	return s == "38" || s == "48" || s == "58"
+}
@@ -0,0 +1,38 @@
+package terminal
+
+import "testing"
+
+func TestColorStripper_DropsColorsKeepsAttributes(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"\x1b[31mred\x1b[0m", "red\x1b[0m"},
+		{"\x1b[1;31mbold\x1b[m", "\x1b[1mbold\x1b[m"},
+		{"\x1b[38;5;196;4mx", "\x1b[4mx"},
+		{"\x1b[48;2;10;20;30;1;97mx", "\x1b[1mx"},
+		{"\x1b[38:2::255:0:0;3mx", "\x1b[3mx"},
+		{"\x1b[0;39;49m", "\x1b[0m"},
+		{"\x1b[>4;1m\x1b[2J\x1b[?25l", "\x1b[>4;1m\x1b[2J\x1b[?25l"},
+		{"\x1b]0;title\x07\x1b(Bplain", "\x1b]0;title\x07\x1b(Bplain"},
+	}
+	for _, tt := range tests {
+		var c ColorStripper
+		if got := string(c.Strip([]byte(tt.in), true)); got != tt.want {
+			t.Errorf("Strip(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestColorStripper_SequenceSplitAcrossChunks(t *testing.T) {
+	var c ColorStripper
+	got := string(c.Strip([]byte("a\x1b[1;3"), true))
+	got += string(c.Strip([]byte("1mb\x1b"), true))
+	got += string(c.Strip([]byte("[32mc"), true))
+	if got != "a\x1b[1mbc" {
+		t.Errorf("got %q, want %q", got, "a\x1b[1mbc")
+	}
+
+	// Switching monochrome off releases what was held back
+	c.Strip([]byte("\x1b[3"), true)
+	if got := string(c.Strip([]byte("1mred"), false)); got != "\x1b[31mred" {
+		t.Errorf("after switching off got %q", got)
+	}
+}
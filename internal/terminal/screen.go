@@ -52,7 +52,11 @@ const (
 //
 // Thread-safety: all public methods acquire an internal mutex so the
 // screen can safely be written to from a PTY reader goroutine while
-// the Bubbletea render loop reads cells.
+// the Bubbletea render loop reads cells. Write holds the lock for the whole
+// chunk, so readers never see a partially applied chunk. Readers that
+// derive state from the content (token scans, activity detection) should
+// compare Generation with the value from their last pass and skip the
+// walk over the rows when it has not changed.
 type Screen struct {
 	mu sync.Mutex
 
@@ -63,11 +67,15 @@ type Screen struct {
 	style      CellStyle // current drawing style (set by SGR)
 
 	// Parser state
-	state    parserState
-	csiBuf   []byte // collects CSI parameter bytes
-	oscBuf   []byte // collects OSC payload
-	savedRow int    // DEC save cursor
-	savedCol int
+	state     parserState
+	csiBuf    []byte  // collects CSI parameter bytes
+	csiParams [16]int // scratch for parseCSIParams
+	oscBuf    []byte  // collects OSC payload
+	savedRow  int     // DEC save cursor
+	savedCol  int
+	// Rendition saved with the cursor by DECSC (ESC 7). There is no
+	// charset or origin mode state yet; save them here once there is.
+	savedStyle CellStyle
 
 	// Scroll region (1-indexed, inclusive). Zero means "use full screen".
 	scrollTop    int
@@ -76,7 +84,32 @@ type Screen struct {
 	// Title reported by OSC sequences (e.g. xterm window title).
 	Title string
 
-	// UTF-8 multi-byte decoder state
+	// Working directory reported by the shell via OSC 7 (empty if none).
+	reportedDir string
+
+	// DEC private modes (screen_modes.go): focus in/out reports and
+	// bracketed paste.
+	focusReporting bool
+	bracketedPaste bool
+
+	// Kitty keyboard protocol flag stack (CSI > flags u pushes); the top
+	// entry is in effect.
+	kittyFlags []int
+
+	// Progress reported via OSC 9;4 (taskbar-style progress bar).
+	progress    ProgressState
+	progressPct int
+
+	// Cell size in pixels as the frontend renders it (SetCellPixels);
+	// zero until known.
+	cellWidth, cellHeight int
+
+	// Replies to queries (DSR), sent to respond once Write unlocks.
+	respond func([]byte)
+	replies []byte
+
+	// UTF-8 multi-byte decoder state. It survives across Write calls, so
+	// a character split between two PTY reads is still decoded once.
 	utf8Buf [4]byte // buffered UTF-8 bytes
 	utf8Len int     // total bytes expected (2, 3, or 4); 0 = not in sequence
 	utf8Got int     // bytes collected so far
@@ -84,6 +117,37 @@ type Screen struct {
 	// Pre-allocated blank line template for fast scroll operations.
 	// Copied via copy() instead of allocating + initialising each time.
 	blankLine []Cell
+
+	// wrapped[r] is true when row r continues row r-1 after an auto-wrap
+	// rather than starting a new line (see PlainTextLogical).
+	wrapped []bool
+	reflow  bool // rewrap lines when Resize changes the width (SetReflow)
+
+	// Render and Export drop colours (SetMonochrome).
+	monochrome bool
+
+	// Rows scrolled off the top, a ring of up to ScrollbackRows (see Export).
+	scrollback []historyRow
+	sbStart    int
+	sbJoin     bool // the top row continues the newest scrollback row
+
+	// Position of the last OSC 133;B mark while a command is being typed,
+	// and the commands captured so far (see CommandHistory).
+	cmdMark        bool
+	cmdRow, cmdCol int
+	commands       []string
+
+	// Damage tracking for RenderDiff: rows changed since the last diff,
+	// and the cells as of the last diff (nil row = never rendered).
+	dirty    []bool
+	rendered [][]Cell
+
+	// Content generation, bumped by every Write, Resize and Reset.
+	gen uint64
+
+	// Diagnostic counters for escape sequences the parser dropped,
+	// keyed by kind and final byte (e.g. "ESC (", "CSI t"). Lazily allocated.
+	unhandled map[string]int
 }
 
 // makeBlankLine allocates a single row of blank cells.
@@ -97,9 +161,11 @@ func makeBlankLine(cols int) []Cell {
 
 // NewScreen allocates a Screen of the given dimensions.
 func NewScreen(rows, cols int) *Screen {
-	s := &Screen{rows: rows, cols: cols}
+	s := &Screen{rows: rows, cols: cols, gen: 1}
 	s.cells = makeGrid(rows, cols)
 	s.blankLine = makeBlankLine(cols)
+	s.wrapped = make([]bool, rows)
+	s.resetDamage()
 	return s
 }
 
@@ -116,28 +182,44 @@ func makeGrid(rows, cols int) [][]Cell {
 }
 
 // Resize changes the screen dimensions, preserving content where possible.
+// With reflow on (SetReflow), a width change rewraps soft-wrapped lines.
 func (s *Screen) Resize(rows, cols int) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	ng := makeGrid(rows, cols)
-	// Copy existing content
-	for r := 0; r < rows && r < s.rows; r++ {
-		for c := 0; c < cols && c < s.cols; c++ {
-			ng[r][c] = s.cells[r][c]
+	if s.reflow && cols != s.cols && rows > 0 && cols > 0 {
+		s.reflowGrid(rows, cols)
+	} else {
+		ng := makeGrid(rows, cols)
+		// Copy existing content
+		for r := 0; r < rows && r < s.rows; r++ {
+			for c := 0; c < cols && c < s.cols; c++ {
+				ng[r][c] = s.cells[r][c]
+			}
+		}
+		// Rows are cut, not reflowed, so wraps only survive the same width
+		nw := make([]bool, rows)
+		if cols == s.cols {
+			copy(nw, s.wrapped)
+		}
+		s.wrapped = nw
+		if s.cmdRow >= rows {
+			s.cmdMark = false
+		}
+		s.cells = ng
+		if s.curCol >= cols {
+			s.curCol = cols - 1
 		}
 	}
-	s.cells = ng
 	s.rows = rows
 	s.cols = cols
 	s.blankLine = makeBlankLine(cols)
+	s.resetDamage()
+	s.gen++
 	// Clamp cursor
 	if s.curRow >= rows {
 		s.curRow = rows - 1
 	}
-	if s.curCol >= cols {
-		s.curCol = cols - 1
-	}
 }
 
 // Rows returns the current row count.
@@ -171,6 +253,14 @@ func (s *Screen) Cursor() (int, int) {
 	return s.curRow, s.curCol
 }
 
+// ReportedDir returns the last working directory the shell reported via
+// OSC 7, or "" if it never sent one.
+func (s *Screen) ReportedDir() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reportedDir
+}
+
 // ---------------------------------------------------------------------------
 // Write – process raw terminal output bytes
 // ---------------------------------------------------------------------------
@@ -180,11 +270,24 @@ func (s *Screen) Cursor() (int, int) {
 // Implements io.Writer.
 func (s *Screen) Write(p []byte) (int, error) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	for _, b := range p {
 		s.processByte(b)
 	}
+	s.gen++
+	replies, respond := s.takeReplies()
+	s.mu.Unlock()
+
+	if respond != nil {
+		respond(replies)
+	}
 	return len(p), nil
 }
 
+// Generation returns a counter that changes whenever the screen content may
+// have changed. It starts at 1, so 0 never matches a real generation.
+func (s *Screen) Generation() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.gen
+}
+
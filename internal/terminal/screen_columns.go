@@ -0,0 +1,60 @@
+package terminal
+
+// ---------------------------------------------------------------------------
+// DECIC / DECDC – column insert and delete
+// ---------------------------------------------------------------------------
+
+// columnSpan clamps a column count to the cells right of the cursor and
+// returns the 0-indexed rows of the scroll region. The count is 0 when the
+// cursor is outside the region, where DECIC and DECDC have no effect.
+func (s *Screen) columnSpan(n int) (count, top, bottom int) {
+	if max := s.cols - s.curCol; n > max {
+		n = max
+	}
+	bottom = s.scrollRegionBottom() - 1
+	if bottom >= s.rows {
+		bottom = s.rows - 1
+	}
+	top = s.scrollRegionTop() - 1
+	if s.curRow < top || s.curRow > bottom {
+		return 0, top, bottom
+	}
+	return n, top, bottom
+}
+
+// insertColumns inserts n blank columns at the cursor column in every row of
+// the scroll region, shifting cells right; cells pushed past the right edge
+// are lost.
+func (s *Screen) insertColumns(n int) {
+	n, top, bottom := s.columnSpan(n)
+	if n <= 0 {
+		return
+	}
+	s.markDirtyRange(top, bottom)
+	blank := Cell{Char: ' ', Style: s.style}
+	for r := top; r <= bottom; r++ {
+		row := s.cells[r]
+		copy(row[s.curCol+n:], row[s.curCol:])
+		for c := s.curCol; c < s.curCol+n; c++ {
+			row[c] = blank
+		}
+	}
+}
+
+// deleteColumns deletes n columns at the cursor column in every row of the
+// scroll region, shifting cells left and filling the right edge with blanks.
+func (s *Screen) deleteColumns(n int) {
+	n, top, bottom := s.columnSpan(n)
+	if n <= 0 {
+		return
+	}
+	s.markDirtyRange(top, bottom)
+	blank := Cell{Char: ' ', Style: s.style}
+	for r := top; r <= bottom; r++ {
+		row := s.cells[r]
+		copy(row[s.curCol:], row[s.curCol+n:])
+		for c := s.cols - n; c < s.cols; c++ {
+			row[c] = blank
+		}
+	}
+}
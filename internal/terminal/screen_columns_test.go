@@ -0,0 +1,92 @@
+package terminal
+
+import "testing"
+
+// ---------------------------------------------------------------------------
+// DECIC / DECDC – insert / delete columns
+// ---------------------------------------------------------------------------
+
+func TestCSI_InsertColumns(t *testing.T) {
+	s := NewScreen(3, 6)
+	s.Write([]byte("ABCDEF\r\nGHIJKL\r\nMNOPQR"))
+	s.Write([]byte("\x1b[1;3H")) // col 2
+	s.Write([]byte("\x1b[2'}"))  // insert 2 columns
+
+	want := []string{"AB  CD", "GH  IJ", "MN  OP"}
+	for r, w := range want {
+		if got := s.PlainTextRow(r); got != w {
+			t.Errorf("After DECIC 2, row %d = %q, want %q", r, got, w)
+		}
+	}
+	if s.curRow != 0 || s.curCol != 2 {
+		t.Errorf("cursor moved to (%d,%d), want (0,2)", s.curRow, s.curCol)
+	}
+}
+
+func TestCSI_DeleteColumns(t *testing.T) {
+	s := NewScreen(3, 6)
+	s.Write([]byte("ABCDEF\r\nGHIJKL\r\nMNOPQR"))
+	s.Write([]byte("\x1b[3;2H")) // col 1
+	s.Write([]byte("\x1b[2'~"))  // delete 2 columns
+
+	want := []string{"ADEF", "GJKL", "MPQR"}
+	for r, w := range want {
+		if got := s.PlainTextRow(r); got != w {
+			t.Errorf("After DECDC 2, row %d = %q, want %q", r, got, w)
+		}
+	}
+}
+
+func TestCSI_ColumnsRespectScrollRegion(t *testing.T) {
+	s := NewScreen(4, 4)
+	s.Write([]byte("AAAA\r\nBBBB\r\nCCCC\r\nDDDD"))
+	s.Write([]byte("\x1b[2;3r")) // region rows 2-3
+	s.Write([]byte("\x1b[2;1H"))
+	s.Write([]byte("\x1b['}")) // insert 1 column (default)
+
+	want := []string{"AAAA", " BBB", " CCC", "DDDD"}
+	for r, w := range want {
+		if got := s.PlainTextRow(r); got != w {
+			t.Errorf("row %d = %q, want %q", r, got, w)
+		}
+	}
+
+	s.Write([]byte("\x1b[9'~")) // delete more columns than exist
+	for r, w := range []string{"AAAA", "", "", "DDDD"} {
+		if got := s.PlainTextRow(r); got != w {
+			t.Errorf("after DECDC 9, row %d = %q, want %q", r, got, w)
+		}
+	}
+}
+
+func TestCSI_InsertColumnsUsesCurrentStyle(t *testing.T) {
+	s := NewScreen(2, 4)
+	s.Write([]byte("ABCD"))
+	s.Write([]byte("\x1b[1;1H\x1b[44m\x1b['}"))
+
+	if c := s.cells[0][0]; c.Char != ' ' || c.Style.BG != 5 {
+		t.Errorf("inserted cell = %+v, want blank with blue background", c)
+	}
+	if c := s.cells[1][0]; c.Style.BG != 5 {
+		t.Errorf("row 1 inserted cell BG = %d, want 5", c.Style.BG)
+	}
+}
+
+func TestCSI_ColumnsOutsideScrollRegionIgnored(t *testing.T) {
+	s := NewScreen(3, 4)
+	s.Write([]byte("AAAA\r\nBBBB\r\nCCCC"))
+	s.Write([]byte("\x1b[2;3r\x1b[1;1H\x1b[2'~"))
+	for r, w := range []string{"AAAA", "BBBB", "CCCC"} {
+		if got := s.PlainTextRow(r); got != w {
+			t.Errorf("row %d = %q, want unchanged %q", r, got, w)
+		}
+	}
+}
+
+func TestCSI_BraceWithoutQuoteIsIgnored(t *testing.T) {
+	s := NewScreen(1, 4)
+	s.Write([]byte("ABCD\x1b[1;1H\x1b[2}\x1b[2~"))
+	if got := s.PlainTextRow(0); got != "ABCD" {
+		t.Errorf("row 0 = %q, want unchanged 'ABCD'", got)
+	}
+}
@@ -2,7 +2,6 @@ package terminal
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 )
 
@@ -79,6 +78,17 @@ func (s *Screen) dispatchCSI(cmd byte) {
 	case '@': // Insert Characters
 		n := paramDefault(params, 0, 1)
 		s.insertChars(n)
+	case '}', '~': // DECIC / DECDC – Insert / Delete Columns (CSI Pn ' } / ~)
+		if s.csiMarkers() != "'" {
+			s.countUnhandled("CSI", s.csiMarkers()+string(rune(cmd)))
+			break
+		}
+		n := paramDefault(params, 0, 1)
+		if cmd == '}' {
+			s.insertColumns(n)
+		} else {
+			s.deleteColumns(n)
+		}
 	case 'S': // Scroll Up
 		n := paramDefault(params, 0, 1)
 		for i := 0; i < n; i++ {
@@ -99,51 +109,33 @@ func (s *Screen) dispatchCSI(cmd byte) {
 	case 's': // Save Cursor Position
 		s.savedRow = s.curRow
 		s.savedCol = s.curCol
-	case 'u': // Restore Cursor Position
-		s.curRow = s.savedRow
-		s.curCol = s.savedCol
+	case 'u': // Restore Cursor Position, or kitty keyboard flags
+		s.handleKittyKeyboard(params)
 	case 'h', 'l': // Set/Reset Mode – largely ignored
 		// We handle CSI ? 25 h/l (show/hide cursor) by ignoring it
 		// since our rendering always shows the cursor.
+		s.setPrivateModes(params, cmd == 'h')
 	case 'X': // Erase Characters
 		n := paramDefault(params, 0, 1)
+		s.markDirty(s.curRow)
 		for i := 0; i < n && s.curCol+i < s.cols; i++ {
 			s.cells[s.curRow][s.curCol+i] = Cell{Char: ' ', Style: s.style}
 		}
+	case 'n': // DSR – Device Status Report
+		s.handleDSR(params)
+	case 'c': // DA – Device Attributes
+		s.handleDA(params)
+	case 't': // XTWINOPS – window size reports
+		s.handleWindowOps(params)
 	case 'd': // Vertical Position Absolute
 		n := paramDefault(params, 0, 1)
 		s.curRow = n - 1
 		s.clampCursor()
+	default:
+		s.countUnhandled("CSI", s.csiMarkers()+string(rune(cmd)))
 	}
 }
 
-// parseCSIParams splits the CSI parameter buffer into integer parameters.
-// ";" separates values; missing values default to 0.
-func (s *Screen) parseCSIParams() []int {
-	// Strip leading '?' or '>' (private mode prefix)
-	raw := string(s.csiBuf)
-	raw = strings.TrimLeft(raw, "?>=!")
-
-	if raw == "" {
-		return nil
-	}
-	parts := strings.Split(raw, ";")
-	params := make([]int, len(parts))
-	for i, p := range parts {
-		v, _ := strconv.Atoi(p)
-		params[i] = v
-	}
-	return params
-}
-
-// paramDefault returns params[idx] if it exists and is > 0, otherwise def.
-func paramDefault(params []int, idx, def int) int {
-	if idx < len(params) && params[idx] > 0 {
-		return params[idx]
-	}
-	return def
-}
-
 // ---------------------------------------------------------------------------
 // SGR (Select Graphic Rendition) handler
 // ---------------------------------------------------------------------------
@@ -0,0 +1,59 @@
+package terminal
+
+// ---------------------------------------------------------------------------
+// CSI parameter parsing
+// ---------------------------------------------------------------------------
+
+// maxCSIParam caps parsed CSI parameter values so absurdly long digit runs
+// cannot overflow.
+const maxCSIParam = 1 << 24
+
+// parseCSIParams parses the CSI parameter buffer into integer parameters.
+// ";" separates values; missing values default to 0, as do values with
+// non-digit bytes (e.g. ":" sub-parameters). Trailing intermediate bytes
+// (the "'" of CSI Pn ' }) are not parameters. The result aliases the
+// Screen's scratch buffer and is only valid until the next call.
+func (s *Screen) parseCSIParams() []int {
+	// Skip leading '?', '>', '=' or '!' (private mode prefix)
+	buf := s.csiBuf
+	for len(buf) > 0 && (buf[0] == '?' || buf[0] == '>' || buf[0] == '=' || buf[0] == '!') {
+		buf = buf[1:]
+	}
+	for len(buf) > 0 && buf[len(buf)-1] >= 0x20 && buf[len(buf)-1] <= 0x2F {
+		buf = buf[:len(buf)-1]
+	}
+	if len(buf) == 0 {
+		return nil
+	}
+
+	params := s.csiParams[:0]
+	v, valid := 0, true
+	for _, b := range buf {
+		switch {
+		case b == ';':
+			if !valid {
+				v = 0
+			}
+			params = append(params, v)
+			v, valid = 0, true
+		case b >= '0' && b <= '9':
+			if v < maxCSIParam {
+				v = v*10 + int(b-'0')
+			}
+		default:
+			valid = false
+		}
+	}
+	if !valid {
+		v = 0
+	}
+	return append(params, v)
+}
+
+// paramDefault returns params[idx] if it exists and is > 0, otherwise def.
+func paramDefault(params []int, idx, def int) int {
+	if idx < len(params) && params[idx] > 0 {
+		return params[idx]
+	}
+	return def
+}
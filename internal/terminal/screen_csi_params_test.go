@@ -0,0 +1,64 @@
+package terminal
+
+import (
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// parseCSIParams – byte parser edge cases and allocations
+// ---------------------------------------------------------------------------
+
+func TestParseCSIParams_SubParamIsZero(t *testing.T) {
+	s := NewScreen(3, 10)
+	s.csiBuf = []byte("1;38:2:0:255:0:0;4")
+	params := s.parseCSIParams()
+	if len(params) != 3 || params[0] != 1 || params[1] != 0 || params[2] != 4 {
+		t.Fatalf("expected [1,0,4], got %v", params)
+	}
+}
+
+func TestParseCSIParams_MoreThanScratch(t *testing.T) {
+	s := NewScreen(3, 10)
+	s.csiBuf = []byte(strings.Repeat("7;", 19) + "7")
+	params := s.parseCSIParams()
+	if len(params) != 20 || params[19] != 7 {
+		t.Fatalf("expected 20 params of 7, got %v", params)
+	}
+}
+
+func TestParseCSIParams_HugeValueIsCapped(t *testing.T) {
+	s := NewScreen(3, 10)
+	s.csiBuf = []byte(strings.Repeat("9", 40))
+	params := s.parseCSIParams()
+	if len(params) != 1 || params[0] < maxCSIParam {
+		t.Fatalf("expected one capped value >= %d, got %v", maxCSIParam, params)
+	}
+}
+
+func TestParseCSIParams_NoAllocs(t *testing.T) {
+	s := NewScreen(3, 10)
+	s.csiBuf = []byte("38;2;10;20;30;48;5;236;1")
+	if n := testing.AllocsPerRun(100, func() { s.parseCSIParams() }); n != 0 {
+		t.Errorf("parseCSIParams allocated %.0f times per call, want 0", n)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Benchmark: SGR-heavy output (colored logs, syntax-highlighted diffs)
+// ---------------------------------------------------------------------------
+
+func BenchmarkWrite_SGRStream(b *testing.B) {
+	var sb strings.Builder
+	for i := 0; i < 200; i++ {
+		sb.WriteString("\x1b[1;32mok\x1b[0m \x1b[38;2;200;100;50mfile.go\x1b[39m:\x1b[38;5;244m42\x1b[m\r\n")
+	}
+	data := []byte(sb.String())
+	s := NewScreen(50, 200)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Write(data)
+	}
+}
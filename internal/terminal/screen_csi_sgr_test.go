@@ -1,6 +1,9 @@
 package terminal
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 // ---------------------------------------------------------------------------
 // Extended SGR tests – complement existing screen_csi_test.go coverage
@@ -195,6 +198,39 @@ func TestSgrSequence_TruecolorBG(t *testing.T) {
 	}
 }
 
+func TestSgrSequence_Monochrome(t *testing.T) {
+	style := CellStyle{FG: 2, BG: 257 + 0x0080ff, Bold: true, Underline: true}
+	if seq := sgrSequence(style.WithoutColor()); seq != "\x1b[0;1;4m" {
+		t.Fatalf("expected bold+underline without colours, got %q", seq)
+	}
+
+	s := NewScreen(2, 10)
+	s.Write([]byte("\x1b[1;4;31;42mred\x1b[0m \x1b[38;5;196mx"))
+	s.RenderDiff()
+	gen := s.Generation()
+	s.SetMonochrome(true)
+	if s.Generation() == gen {
+		t.Error("SetMonochrome did not change the generation")
+	}
+	if diff := s.RenderDiff(); len(diff) != 20 || diff[0].Cell.Style.FG != 0 {
+		t.Errorf("RenderDiff after SetMonochrome = %d cells, first %+v; want all, without colour", len(diff), diff[0])
+	}
+	for name, out := range map[string]string{"Render": s.Render(), "Export": s.Export(true)} {
+		if !strings.Contains(out, "\x1b[0;1;4mred") {
+			t.Errorf("%s = %q, want bold+underline kept", name, out)
+		}
+		for _, code := range []string{"31", "42", "38;5"} {
+			if strings.Contains(out, code) {
+				t.Errorf("%s = %q, contains colour code %s", name, out, code)
+			}
+		}
+	}
+	s.SetMonochrome(false)
+	if !strings.Contains(s.Render(), "\x1b[0;1;4;31;42mred") {
+		t.Errorf("colours not back after SetMonochrome(false): %q", s.Render())
+	}
+}
+
 // ---------------------------------------------------------------------------
 // parseCSIParams extended tests
 // ---------------------------------------------------------------------------
@@ -298,6 +334,61 @@ func TestOSC_IgnoredTypes(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// OSC 7 working directory reports
+// ---------------------------------------------------------------------------
+
+func TestOSC7_ReportsDirectory(t *testing.T) {
+	s := NewScreen(3, 30)
+	// As emitted by vte.sh / fish before each prompt, ST-terminated
+	s.Write([]byte("\x1b]7;file://localhost/home/user/my%20project\x1b\\$ "))
+
+	if got := s.ReportedDir(); got != "/home/user/my project" {
+		t.Fatalf("ReportedDir() = %q, want %q", got, "/home/user/my project")
+	}
+	if r := s.PlainTextRow(0); r != "$" {
+		t.Errorf("OSC 7 should not print, row 0 = %q", r)
+	}
+}
+
+func TestOSC7_LatestReportWins(t *testing.T) {
+	s := NewScreen(3, 30)
+	s.Write([]byte("\x1b]7;file:///srv/a\x07"))
+	s.Write([]byte("\x1b]7;file:///srv/b\x07"))
+
+	if got := s.ReportedDir(); got != "/srv/b" {
+		t.Fatalf("ReportedDir() = %q, want /srv/b", got)
+	}
+}
+
+func TestOSC7_IgnoresRemoteAndInvalid(t *testing.T) {
+	s := NewScreen(3, 30)
+	s.Write([]byte("\x1b]7;file:///srv/local\x07"))
+	s.Write([]byte("\x1b]7;file://remote-host.invalid/srv/remote\x07"))
+	s.Write([]byte("\x1b]7;https://example.com/x\x07"))
+
+	if got := s.ReportedDir(); got != "/srv/local" {
+		t.Fatalf("ReportedDir() = %q, want /srv/local", got)
+	}
+}
+
+func TestParseOSC7_WindowsPath(t *testing.T) {
+	got, ok := parseOSC7("file:///C:/Users/dev/project")
+	if !ok || got != `C:\Users\dev\project` {
+		t.Fatalf("parseOSC7 = %q, %v; want C:\\Users\\dev\\project", got, ok)
+	}
+}
+
+func TestOSC7_ClearedByReset(t *testing.T) {
+	s := NewScreen(3, 30)
+	s.Write([]byte("\x1b]7;file:///srv/a\x07"))
+	s.Reset()
+
+	if got := s.ReportedDir(); got != "" {
+		t.Fatalf("ReportedDir() after Reset = %q, want empty", got)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // DEC Save/Restore cursor (ESC 7 / ESC 8)
 // ---------------------------------------------------------------------------
@@ -315,6 +406,29 @@ func TestDECSaveRestoreCursor_Ext(t *testing.T) {
 	}
 }
 
+func TestDECSaveRestoreCursor_ExtRestoresRendition(t *testing.T) {
+	s := NewScreen(5, 10)
+	s.Write([]byte("\x1b[1;31m")) // bold red
+	s.Write([]byte("\x1b7"))      // DEC save
+	s.Write([]byte("\x1b[0;32;44mG"))
+	s.Write([]byte("\x1b8")) // DEC restore
+	s.Write([]byte("R"))
+
+	cell := s.CellAt(0, 0)
+	if cell.Char != 'R' || cell.Style.FG != 2 || !cell.Style.Bold || cell.Style.BG != 0 {
+		t.Fatalf("expected bold red R after restore, got %q %+v", cell.Char, cell.Style)
+	}
+}
+
+func TestDECSaveRestoreCursor_ExtCSISaveKeepsStyle(t *testing.T) {
+	s := NewScreen(5, 10)
+	s.Write([]byte("\x1b[31m\x1b[s\x1b[32m\x1b[uX")) // CSI s/u saves position only
+
+	if cell := s.CellAt(0, 0); cell.Style.FG != 3 {
+		t.Fatalf("expected green X after CSI u, got FG=%d", cell.Style.FG)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // UTF-8 characters
 // ---------------------------------------------------------------------------
@@ -353,3 +467,32 @@ func TestUTF8_FourByte_Emoji(t *testing.T) {
 		t.Fatalf("expected '😀', got %c (%U)", cell.Char, cell.Char)
 	}
 }
+
+func TestUTF8_SplitAcrossWrites(t *testing.T) {
+	s := NewScreen(3, 10)
+	s.Write([]byte("\xe2"))
+	if cell := s.CellAt(0, 0); cell.Char != 0 && cell.Char != ' ' {
+		t.Fatalf("incomplete sequence drew %q", cell.Char)
+	}
+	s.Write([]byte("\x82\xac")) // rest of €
+
+	if cell := s.CellAt(0, 0); cell.Char != '€' {
+		t.Fatalf("expected '€', got %q", cell.Char)
+	}
+	if row, col := s.Cursor(); row != 0 || col != 1 {
+		t.Errorf("cursor = (%d,%d), want (0,1) after a single cell", row, col)
+	}
+}
+
+func TestUTF8_SplitByteByByte(t *testing.T) {
+	h := NewScreenHarness(t, 3, 10)
+	h.FeedChunks("\xf0", "\x9f", "\x98", "\x80", "!") // 😀!
+	h.AssertRow(0, "😀!")
+}
+
+func TestUTF8_SplitContinuationIsNotC1(t *testing.T) {
+	h := NewScreenHarness(t, 3, 10)
+	// ě = C4 9B; 0x9B alone would be an 8-bit CSI
+	h.FeedChunks("\xc4", "\x9b2J")
+	h.AssertRow(0, "ě2J")
+}
@@ -775,3 +775,100 @@ func TestCSI_PrivateMode_NoCrash(t *testing.T) {
 	row, col := s.Cursor()
 	_ = fmt.Sprintf("cursor at (%d,%d)", row, col)
 }
+
+// ---------------------------------------------------------------------------
+// Focus reporting mode (CSI ? 1004 h/l)
+// ---------------------------------------------------------------------------
+
+func TestFocusReporting_Mode(t *testing.T) {
+	s := NewScreen(3, 10)
+	if s.FocusReporting() {
+		t.Fatal("focus reporting on by default")
+	}
+	s.Write([]byte("\x1b[?1004h"))
+	if !s.FocusReporting() {
+		t.Fatal("CSI ?1004h did not enable focus reporting")
+	}
+	s.Write([]byte("\x1b[?1004l"))
+	if s.FocusReporting() {
+		t.Fatal("CSI ?1004l did not disable focus reporting")
+	}
+}
+
+func TestFocusReporting_CombinedModesAndReset(t *testing.T) {
+	s := NewScreen(3, 10)
+	s.Write([]byte("\x1b[?25;1004;2004h"))
+	if !s.FocusReporting() {
+		t.Fatal("combined mode set did not enable focus reporting")
+	}
+	s.Write([]byte("\x1bc"))
+	if s.FocusReporting() {
+		t.Error("RIS did not clear focus reporting")
+	}
+}
+
+func TestBracketedPaste_Mode(t *testing.T) {
+	s := NewScreen(3, 10)
+	if s.BracketedPaste() {
+		t.Fatal("bracketed paste on by default")
+	}
+	s.Write([]byte("\x1b[?2004h"))
+	if !s.BracketedPaste() {
+		t.Fatal("CSI ?2004h did not enable bracketed paste")
+	}
+	s.Write([]byte("\x1b[?2004l"))
+	if s.BracketedPaste() {
+		t.Fatal("CSI ?2004l did not disable bracketed paste")
+	}
+	s.Write([]byte("\x1b[?2004h\x1bc"))
+	if s.BracketedPaste() {
+		t.Error("RIS did not clear bracketed paste")
+	}
+}
+
+func TestFocusReporting_NonPrivateIgnored(t *testing.T) {
+	s := NewScreen(3, 10)
+	s.Write([]byte("\x1b[1004h"))
+	if s.FocusReporting() {
+		t.Error("CSI 1004h (without ?) enabled focus reporting")
+	}
+}
+
+func TestCSI_KittyKeyboardFlags(t *testing.T) {
+	s := NewScreen(5, 20)
+	var replies []string
+	s.SetResponder(func(b []byte) { replies = append(replies, string(b)) })
+
+	s.Write([]byte("\x1b[>1u"))
+	if got := s.KittyKeyboardFlags(); got != 1 {
+		t.Fatalf("after push: flags = %d, want 1", got)
+	}
+	s.Write([]byte("\x1b[>3u\x1b[=4;2u"))
+	if got := s.KittyKeyboardFlags(); got != 7 {
+		t.Fatalf("after push + or: flags = %d, want 7", got)
+	}
+	s.Write([]byte("\x1b[?u"))
+	if len(replies) != 1 || replies[0] != "\x1b[?7u" {
+		t.Errorf("query replies = %q, want [\"\\x1b[?7u\"]", replies)
+	}
+	s.Write([]byte("\x1b[<u"))
+	if got := s.KittyKeyboardFlags(); got != 1 {
+		t.Errorf("after pop: flags = %d, want 1", got)
+	}
+	s.Write([]byte("\x1b[<5u"))
+	if got := s.KittyKeyboardFlags(); got != 0 {
+		t.Errorf("after popping past the bottom: flags = %d, want 0", got)
+	}
+}
+
+func TestCSI_KittyPushDoesNotMoveCursor(t *testing.T) {
+	s := NewScreen(5, 20)
+	s.Write([]byte("\x1b[s\x1b[3;5H\x1b[>1u"))
+	if row, col := s.Cursor(); row != 2 || col != 4 {
+		t.Errorf("cursor = %d,%d, want 2,4", row, col)
+	}
+	s.Write([]byte("\x1b[u"))
+	if row, col := s.Cursor(); row != 0 || col != 0 {
+		t.Errorf("CSI u: cursor = %d,%d, want 0,0", row, col)
+	}
+}
@@ -0,0 +1,58 @@
+package terminal
+
+import "fmt"
+
+// maxUnhandledKinds bounds the number of distinct keys tracked so a stream
+// of garbage cannot grow the diagnostic map without limit.
+const maxUnhandledKinds = 64
+
+// countUnhandled records that the parser dropped a sequence of the given
+// kind ("ESC", "CSI", "OSC") and final/identifier. Must hold s.mu.
+func (s *Screen) countUnhandled(kind, final string) {
+	key := kind + " " + printableFinal(final)
+	if s.unhandled == nil {
+		s.unhandled = make(map[string]int)
+	}
+	if _, seen := s.unhandled[key]; !seen && len(s.unhandled) >= maxUnhandledKinds {
+		return
+	}
+	s.unhandled[key]++
+}
+
+// csiMarkers returns the private-mode prefix and intermediate bytes of the
+// current CSI sequence (e.g. "?" for CSI ? u, " " for CSI SP q) so that
+// diagnostic keys distinguish variants sharing a final byte. Must hold s.mu.
+func (s *Screen) csiMarkers() string {
+	var m []byte
+	for _, b := range s.csiBuf {
+		if (b >= 0x3C && b <= 0x3F) || (b >= 0x20 && b <= 0x2F) {
+			m = append(m, b)
+		}
+	}
+	return string(m)
+}
+
+// printableFinal makes a diagnostic key component safe to display.
+func printableFinal(final string) string {
+	if len(final) > 8 {
+		return final[:8] + "…"
+	}
+	for i := 0; i < len(final); i++ {
+		if final[i] < 0x20 || final[i] >= 0x7F {
+			return fmt.Sprintf("%q", final)
+		}
+	}
+	return final
+}
+
+// UnhandledSequences returns a copy of the counters for escape sequences
+// the parser recognised but dropped, keyed like "ESC (" or "CSI t".
+func (s *Screen) UnhandledSequences() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]int, len(s.unhandled))
+	for k, v := range s.unhandled {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,60 @@
+package terminal
+
+import "testing"
+
+func TestUnhandledSequences_CountsByKindAndFinal(t *testing.T) {
+	s := NewScreen(5, 20)
+	s.Write([]byte("\x1b(B"))        // designate G0 charset – not supported
+	s.Write([]byte("\x1b(0"))        // again, with another charset
+	s.Write([]byte("\x1b(B"))        // and once more
+	s.Write([]byte("\x1b[22;0;0t"))  // window manipulation
+	s.Write([]byte("\x1b[2 q"))      // cursor style (intermediate byte)
+	s.Write([]byte("\x1b[>4;1m"))    // private SGR variant is still SGR
+	s.Write([]byte("\x1b]8;;x\x07")) // OSC 8 hyperlink
+
+	got := s.UnhandledSequences()
+	want := map[string]int{"ESC (": 3, "CSI t": 1, "CSI  q": 1, "OSC 8": 1}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("UnhandledSequences()[%q] = %d, want %d (all: %v)", k, got[k], v, got)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("unexpected keys: %v", got)
+	}
+}
+
+func TestUnhandledSequences_HandledNotCounted(t *testing.T) {
+	s := NewScreen(5, 20)
+	s.Write([]byte("\x1b[31mred\x1b[0m\x1b[2J\x1b[H\x1b7\x1b8\x1b]0;title\x07\x1b[?25l"))
+	if got := s.UnhandledSequences(); len(got) != 0 {
+		t.Errorf("expected no unhandled sequences, got %v", got)
+	}
+}
+
+func TestUnhandledSequences_ReturnsCopy(t *testing.T) {
+	s := NewScreen(5, 20)
+	s.Write([]byte("\x1b[t"))
+	s.UnhandledSequences()["CSI t"] = 99
+	if got := s.UnhandledSequences()["CSI t"]; got != 1 {
+		t.Errorf("counter modified through returned map: %d", got)
+	}
+}
+
+func TestUnhandledSequences_BoundedKeys(t *testing.T) {
+	s := NewScreen(5, 20)
+	for i := 0; i < maxUnhandledKinds+20; i++ {
+		s.Write([]byte("\x1b]" + string(rune('a'+i%26)) + string(rune('a'+i/26)) + ";x\x07"))
+	}
+	if got := len(s.UnhandledSequences()); got > maxUnhandledKinds {
+		t.Errorf("tracked %d kinds, want at most %d", got, maxUnhandledKinds)
+	}
+}
+
+func TestSession_UnhandledSequences(t *testing.T) {
+	sess := NewSession(1, 5, 20)
+	sess.Screen.Write([]byte("\x1b(B"))
+	if got := sess.UnhandledSequences()["ESC ("]; got != 1 {
+		t.Errorf("Session.UnhandledSequences()[\"ESC (\"] = %d, want 1", got)
+	}
+}
@@ -0,0 +1,72 @@
+package terminal
+
+// ---------------------------------------------------------------------------
+// RenderDiff – damage tracking so consumers can ship only changed cells
+// ---------------------------------------------------------------------------
+
+// CellChange is a cell whose content differs from what the previous
+// RenderDiff call returned.
+type CellChange struct {
+	Row  int
+	Col  int
+	Cell Cell
+}
+
+// markDirty flags row r as changed since the last RenderDiff.
+func (s *Screen) markDirty(r int) {
+	if r >= 0 && r < len(s.dirty) {
+		s.dirty[r] = true
+	}
+}
+
+// markDirtyRange flags rows from..to (inclusive) as changed.
+func (s *Screen) markDirtyRange(from, to int) {
+	for r := max(from, 0); r <= to && r < len(s.dirty); r++ {
+		s.dirty[r] = true
+	}
+}
+
+// markAllDirty flags every row as changed.
+func (s *Screen) markAllDirty() {
+	s.markDirtyRange(0, len(s.dirty)-1)
+}
+
+// resetDamage sizes the damage tracking for the current dimensions. The
+// shadow copy is dropped, so the next RenderDiff returns every cell.
+func (s *Screen) resetDamage() {
+	s.dirty = make([]bool, s.rows)
+	s.rendered = make([][]Cell, s.rows)
+	s.markAllDirty()
+}
+
+// RenderDiff returns the cells that changed since the previous call and
+// clears the damage. Only rows flagged by a mutation are compared, and
+// within them only cells that differ from the last returned state are
+// reported, so cursor movement alone yields no changes. The first call,
+// and the first after a resize, returns every cell.
+func (s *Screen) RenderDiff() []CellChange {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var changes []CellChange
+	for r, dirty := range s.dirty {
+		if !dirty {
+			continue
+		}
+		prev := s.rendered[r]
+		fresh := prev == nil
+		if fresh {
+			prev = make([]Cell, s.cols)
+			s.rendered[r] = prev
+		}
+		for c, cell := range s.cells[r] {
+			cell.Style = s.shown(cell.Style)
+			if fresh || prev[c] != cell {
+				changes = append(changes, CellChange{Row: r, Col: c, Cell: cell})
+				prev[c] = cell
+			}
+		}
+		s.dirty[r] = false
+	}
+	return changes
+}
@@ -0,0 +1,121 @@
+package terminal
+
+import (
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// RenderDiff – only cells changed since the previous diff are reported
+// ---------------------------------------------------------------------------
+
+func TestRenderDiff_FirstCallReturnsEveryCell(t *testing.T) {
+	s := NewScreen(3, 4)
+	if got := len(s.RenderDiff()); got != 12 {
+		t.Fatalf("first diff = %d changes, want 12", got)
+	}
+	if got := len(s.RenderDiff()); got != 0 {
+		t.Fatalf("second diff without writes = %d changes, want 0", got)
+	}
+}
+
+func TestRenderDiff_ReportsChangedCellsOnly(t *testing.T) {
+	s := NewScreen(3, 10)
+	s.RenderDiff()
+
+	s.Write([]byte("\x1b[2;3Hab"))
+	changes := s.RenderDiff()
+	if len(changes) != 2 {
+		t.Fatalf("diff = %+v, want 2 changes", changes)
+	}
+	if changes[0].Row != 1 || changes[0].Col != 2 || changes[0].Cell.Char != 'a' {
+		t.Errorf("changes[0] = %+v, want 'a' at (1,2)", changes[0])
+	}
+	if changes[1].Cell.Char != 'b' {
+		t.Errorf("changes[1] = %+v, want 'b'", changes[1])
+	}
+}
+
+func TestRenderDiff_CursorMovementIsNotAChange(t *testing.T) {
+	s := NewScreen(3, 10)
+	s.Write([]byte("hello"))
+	s.RenderDiff()
+
+	s.Write([]byte("\x1b[3;5H\x1b[?25l\x1b[?25h"))
+	if changes := s.RenderDiff(); len(changes) != 0 {
+		t.Fatalf("cursor-only update produced %d changes", len(changes))
+	}
+}
+
+func TestRenderDiff_RewriteWithSameContentIsNotAChange(t *testing.T) {
+	s := NewScreen(3, 10)
+	s.Write([]byte("prompt$ "))
+	s.RenderDiff()
+
+	s.Write([]byte("\r\x1b[Kprompt$ "))
+	if changes := s.RenderDiff(); len(changes) != 0 {
+		t.Fatalf("identical redraw produced %+v", changes)
+	}
+}
+
+func TestRenderDiff_ScrollMarksShiftedRows(t *testing.T) {
+	s := NewScreen(3, 3)
+	s.Write([]byte("AAA\r\nBBB\r\nCCC"))
+	s.RenderDiff()
+
+	s.Write([]byte("\n"))
+	rows := map[int]bool{}
+	for _, ch := range s.RenderDiff() {
+		rows[ch.Row] = true
+	}
+	if !rows[0] || !rows[1] || !rows[2] {
+		t.Fatalf("scroll should change all three rows, got rows %v", rows)
+	}
+}
+
+func TestRenderDiff_ResizeReturnsEveryCell(t *testing.T) {
+	s := NewScreen(2, 2)
+	s.RenderDiff()
+
+	s.Resize(3, 3)
+	if got := len(s.RenderDiff()); got != 9 {
+		t.Fatalf("diff after resize = %d changes, want 9", got)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Benchmarks: full Render vs RenderDiff when only the cursor blinks
+// ---------------------------------------------------------------------------
+
+// benchScreen returns a full 50×200 screen with some styled text.
+func benchScreen() *Screen {
+	s := NewScreen(50, 200)
+	line := "\x1b[32m" + strings.Repeat("x", 180) + "\x1b[0m\r\n"
+	for i := 0; i < 49; i++ {
+		s.Write([]byte(line))
+	}
+	return s
+}
+
+// cursorBlink is what a blinking cursor/prompt redraw sends: cursor
+// visibility toggles and a reposition, no cell changes.
+var cursorBlink = []byte("\x1b[?25l\x1b[50;1H\x1b[?25h")
+
+func BenchmarkRender_CursorBlink(b *testing.B) {
+	s := benchScreen()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Write(cursorBlink)
+		_ = s.Render()
+	}
+}
+
+func BenchmarkRenderDiff_CursorBlink(b *testing.B) {
+	s := benchScreen()
+	s.RenderDiff()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Write(cursorBlink)
+		_ = s.RenderDiff()
+	}
+}
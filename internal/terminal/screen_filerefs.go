@@ -0,0 +1,59 @@
+package terminal
+
+import (
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+)
+
+// FileRef is a "path:line[:col]" reference found in screen text, as
+// compilers, linters and test runners print them.
+type FileRef struct {
+	Row    int    // screen row, 0-based
+	Col    int    // cell column of the first character, 0-based
+	Text   string // the reference as printed, e.g. "internal/app/model.go:123:5"
+	Path   string // the path part, unresolved
+	Line   int
+	Column int // 0 when the reference has no column
+}
+
+// fileRefRe matches a path with a file extension followed by :line and an
+// optional :col. The leading group stands in for a look-behind, so paths
+// inside URLs or longer words do not match halfway through. Extensions
+// must start with a letter, which keeps times and versions ("1.5:30") out.
+var fileRefRe = regexp.MustCompile(`(?:^|[^\w./\\~-])` +
+	`((?:[A-Za-z]:[\\/]|~?/|\.{1,2}[\\/])?[\w.@+-]+(?:[\\/][\w.@+-]+)*\.[A-Za-z]\w*)` +
+	`:(\d+)(?::(\d+))?`)
+
+// FindFileRefs returns the file references in rows, top to bottom. Columns
+// count runes, which matches cells for PlainTextRows output.
+func FindFileRefs(rows []string) []FileRef {
+	var refs []FileRef
+	for r, text := range rows {
+		for _, m := range fileRefRe.FindAllStringSubmatchIndex(text, -1) {
+			start, end := m[2], m[1]
+			line, err := strconv.Atoi(text[m[4]:m[5]])
+			if err != nil || line == 0 {
+				continue
+			}
+			col := 0
+			if m[6] >= 0 {
+				col, _ = strconv.Atoi(text[m[6]:m[7]])
+			}
+			refs = append(refs, FileRef{
+				Row:    r,
+				Col:    utf8.RuneCountInString(text[:start]),
+				Text:   text[start:end],
+				Path:   text[m[2]:m[3]],
+				Line:   line,
+				Column: col,
+			})
+		}
+	}
+	return refs
+}
+
+// FileRefs returns the file references on the visible screen.
+func (s *Screen) FileRefs() []FileRef {
+	return FindFileRefs(s.PlainTextRows(0, s.Rows()))
+}
@@ -0,0 +1,65 @@
+package terminal
+
+import "testing"
+
+func TestFindFileRefs(t *testing.T) {
+	tests := []struct {
+		line string
+		want []FileRef
+	}{
+		{"internal/app/model.go:123:5: undefined: foo", []FileRef{
+			{Col: 0, Text: "internal/app/model.go:123:5", Path: "internal/app/model.go", Line: 123, Column: 5},
+		}},
+		{"--- FAIL: TestX (0.00s)\n    main_test.go:42: got 1", []FileRef{
+			{Col: 28, Text: "main_test.go:42", Path: "main_test.go", Line: 42},
+		}},
+		{"  at ./src/lib/links.ts:10:3 and /abs/path/x.py:7", []FileRef{
+			{Col: 5, Text: "./src/lib/links.ts:10:3", Path: "./src/lib/links.ts", Line: 10, Column: 3},
+			{Col: 33, Text: "/abs/path/x.py:7", Path: "/abs/path/x.py", Line: 7},
+		}},
+		{`C:\work\app\main.go:9:2: syntax error`, []FileRef{
+			{Col: 0, Text: `C:\work\app\main.go:9:2`, Path: `C:\work\app\main.go`, Line: 9, Column: 2},
+		}},
+		{"(src/app.rs:3)", []FileRef{
+			{Col: 1, Text: "src/app.rs:3", Path: "src/app.rs", Line: 3},
+		}},
+	}
+	for _, tt := range tests {
+		got := FindFileRefs([]string{tt.line})
+		if len(got) != len(tt.want) {
+			t.Errorf("%q: got %+v, want %+v", tt.line, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%q: ref %d = %+v, want %+v", tt.line, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestFindFileRefs_NotReferences(t *testing.T) {
+	for _, line := range []string{
+		"started at 12:30:05",
+		"version 1.5:30",
+		"see https://example.com/docs/page.html:80",
+		"model.go without a line",
+		"file.go:0",
+	} {
+		if got := FindFileRefs([]string{line}); len(got) != 0 {
+			t.Errorf("%q: got %+v, want none", line, got)
+		}
+	}
+}
+
+func TestScreen_FileRefs(t *testing.T) {
+	s := NewScreen(4, 40)
+	s.Write([]byte("$ go vet\r\n→ pkg/a.go:3:1: bad\r\n"))
+	refs := s.FileRefs()
+	if len(refs) != 1 || refs[0].Row != 1 || refs[0].Path != "pkg/a.go" {
+		t.Fatalf("refs = %+v, want pkg/a.go on row 1", refs)
+	}
+	if refs[0].Col != 2 {
+		t.Errorf("col = %d, want 2 (cells, not bytes)", refs[0].Col)
+	}
+}
@@ -0,0 +1,108 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ---------------------------------------------------------------------------
+// ScreenHarness – headless driver for tests of the escape sequence parser
+// ---------------------------------------------------------------------------
+
+// Reporter is the part of testing.TB that ScreenHarness uses. *testing.T and
+// *testing.B satisfy it; taking an interface keeps "testing" out of this
+// package's non-test code.
+type Reporter interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// ScreenHarness feeds scripted program output into a Screen and checks what
+// it renders. Failed assertions report through t and include a Dump, so a
+// test needs no formatting code of its own:
+//
+//	h := NewScreenHarness(t, 3, 10)
+//	h.Feed("abc\x1b[2;4Hx")
+//	h.AssertRow(1, "   x")
+//	h.AssertCursor(1, 4)
+type ScreenHarness struct {
+	Screen *Screen
+	t      Reporter
+}
+
+// NewScreenHarness returns a harness around a new rows x cols Screen.
+func NewScreenHarness(t Reporter, rows, cols int) *ScreenHarness {
+	return &ScreenHarness{Screen: NewScreen(rows, cols), t: t}
+}
+
+// Feed writes data to the screen in one Write call, like a single PTY read.
+func (h *ScreenHarness) Feed(data string) *ScreenHarness {
+	h.Screen.Write([]byte(data))
+	return h
+}
+
+// FeedChunks writes each chunk in its own Write call, for sequences that
+// arrive split across PTY reads.
+func (h *ScreenHarness) FeedChunks(chunks ...string) *ScreenHarness {
+	for _, c := range chunks {
+		h.Screen.Write([]byte(c))
+	}
+	return h
+}
+
+// AssertRow checks that row n reads want, ignoring trailing spaces.
+func (h *ScreenHarness) AssertRow(n int, want string) {
+	h.t.Helper()
+	if got := h.Screen.PlainTextRow(n); got != want {
+		h.t.Errorf("row %d = %q, want %q\n%s", n, got, want, h.Dump())
+	}
+}
+
+// AssertRows checks rows 0..len(want)-1 in order.
+func (h *ScreenHarness) AssertRows(want ...string) {
+	h.t.Helper()
+	for n, w := range want {
+		h.AssertRow(n, w)
+	}
+}
+
+// AssertCursor checks the 0-based cursor position.
+func (h *ScreenHarness) AssertCursor(row, col int) {
+	h.t.Helper()
+	if r, c := h.Screen.Cursor(); r != row || c != col {
+		h.t.Errorf("cursor = (%d,%d), want (%d,%d)\n%s", r, c, row, col, h.Dump())
+	}
+}
+
+// AssertTitle checks the title last set via OSC 0/2.
+func (h *ScreenHarness) AssertTitle(want string) {
+	h.t.Helper()
+	h.Screen.mu.Lock()
+	got := h.Screen.Title
+	h.Screen.mu.Unlock()
+	if got != want {
+		h.t.Errorf("title = %q, want %q", got, want)
+	}
+}
+
+// Dump returns the screen as numbered rows framed by '|' so trailing spaces
+// are visible, followed by the cursor position.
+func (h *ScreenHarness) Dump() string {
+	s := h.Screen
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var b strings.Builder
+	for r := 0; r < s.rows; r++ {
+		fmt.Fprintf(&b, "%3d |", r)
+		for _, c := range s.cells[r] {
+			ch := c.Char
+			if ch == 0 {
+				ch = ' '
+			}
+			b.WriteRune(ch)
+		}
+		b.WriteString("|\n")
+	}
+	fmt.Fprintf(&b, "cursor (%d,%d)", s.curRow, s.curCol)
+	return b.String()
+}
@@ -0,0 +1,47 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// recorder is a Reporter that collects failures instead of failing the test.
+type recorder struct{ errs []string }
+
+func (r *recorder) Helper() {}
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+func TestScreenHarness_PassingAssertions(t *testing.T) {
+	h := NewScreenHarness(t, 3, 10)
+	h.Feed("abc\r\n").Feed("\x1b]2;t\x07\x1b[3;4Hx")
+	h.AssertRows("abc", "", "   x")
+	h.AssertCursor(2, 4)
+	h.AssertTitle("t")
+}
+
+func TestScreenHarness_FailuresIncludeDump(t *testing.T) {
+	rec := &recorder{}
+	h := NewScreenHarness(rec, 2, 4)
+	h.Feed("ab")
+	h.AssertRow(0, "xy")
+	h.AssertCursor(1, 0)
+
+	if len(rec.errs) != 2 {
+		t.Fatalf("errors = %q, want 2", rec.errs)
+	}
+	if !strings.Contains(rec.errs[0], "  0 |ab  |") || !strings.Contains(rec.errs[0], "cursor (0,2)") {
+		t.Errorf("row failure lacks the dump:\n%s", rec.errs[0])
+	}
+}
+
+func TestScreenHarness_FeedChunksSplitsWrites(t *testing.T) {
+	h := NewScreenHarness(t, 2, 10)
+	h.FeedChunks("\x1b[", "31", "mR", "\xe2\x82", "\xac")
+	h.AssertRow(0, "R€")
+	if fg := h.Screen.CellAt(0, 0).Style.FG; fg != 2 {
+		t.Errorf("FG = %d, want red from the split SGR", fg)
+	}
+}
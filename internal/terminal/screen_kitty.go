@@ -0,0 +1,65 @@
+package terminal
+
+import "fmt"
+
+// ---------------------------------------------------------------------------
+// Kitty keyboard protocol – progressive enhancement flags (CSI > / < / = / ? u)
+// ---------------------------------------------------------------------------
+
+// kittyStackMax bounds the flag stack; the protocol lets a terminal drop
+// the oldest entries when a program pushes too many.
+const kittyStackMax = 16
+
+// handleKittyKeyboard applies a CSI u sequence with a kitty prefix: ">"
+// pushes flags, "<" pops n entries, "=" changes the current flags (mode 1
+// sets, 2 adds, 3 removes) and "?" asks for them. Without a prefix CSI u
+// is SCORC, restore cursor.
+func (s *Screen) handleKittyKeyboard(params []int) {
+	switch s.csiMarkers() {
+	case "":
+		s.curRow = s.savedRow
+		s.curCol = s.savedCol
+	case ">":
+		if len(s.kittyFlags) == kittyStackMax {
+			s.kittyFlags = s.kittyFlags[1:]
+		}
+		s.kittyFlags = append(s.kittyFlags, paramDefault(params, 0, 0))
+	case "<":
+		n := min(paramDefault(params, 0, 1), len(s.kittyFlags))
+		s.kittyFlags = s.kittyFlags[:len(s.kittyFlags)-n]
+	case "=":
+		if len(s.kittyFlags) == 0 {
+			s.kittyFlags = append(s.kittyFlags, 0)
+		}
+		top := &s.kittyFlags[len(s.kittyFlags)-1]
+		flags := paramDefault(params, 0, 0)
+		switch paramDefault(params, 1, 1) {
+		case 1:
+			*top = flags
+		case 2:
+			*top |= flags
+		case 3:
+			*top &^= flags
+		}
+	case "?":
+		s.reply(fmt.Appendf(nil, "\x1b[?%du", s.kittyKeyboardFlags()))
+	default:
+		s.countUnhandled("CSI", s.csiMarkers()+"u")
+	}
+}
+
+// kittyKeyboardFlags returns the flags on top of the stack. Must hold s.mu.
+func (s *Screen) kittyKeyboardFlags() int {
+	if len(s.kittyFlags) == 0 {
+		return 0
+	}
+	return s.kittyFlags[len(s.kittyFlags)-1]
+}
+
+// KittyKeyboardFlags reports the kitty keyboard flags the program enabled
+// (1 = disambiguate escape codes); 0 means legacy key encoding.
+func (s *Screen) KittyKeyboardFlags() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.kittyKeyboardFlags()
+}
@@ -0,0 +1,95 @@
+package terminal
+
+import "strings"
+
+// ---------------------------------------------------------------------------
+// OSC 133 semantic prompt marks – command history of shell panes
+// ---------------------------------------------------------------------------
+
+// MaxCommandHistory caps how many commands a screen remembers.
+const MaxCommandHistory = 100
+
+// handlePromptMark processes the payload after "133;". Shells with
+// semantic prompt support (fish, or bash/zsh with the integration scripts
+// of iTerm2, WezTerm, VS Code, ...) send A at the start of the prompt, B
+// where the typed command begins, C when it is executed and D when it has
+// finished. The text between B and C is the command.
+func (s *Screen) handlePromptMark(payload string) {
+	kind, _, _ := strings.Cut(payload, ";")
+	switch kind {
+	case "A":
+		s.cmdMark = false
+	case "B":
+		s.cmdMark = true
+		s.cmdRow, s.cmdCol = s.curRow, s.curCol
+	case "C":
+		if s.cmdMark {
+			s.addCommand(s.textBetween(s.cmdRow, s.cmdCol, s.curRow, s.curCol))
+			s.cmdMark = false
+		}
+	}
+}
+
+// textBetween returns the text from (r0,c0) up to but excluding (r1,c1).
+// Rows continued by an auto-wrap are joined; other rows end with a newline.
+func (s *Screen) textBetween(r0, c0, r1, c1 int) string {
+	var lines []string
+	var b strings.Builder
+	for r := r0; r <= r1 && r < s.rows; r++ {
+		if r > r0 && !s.wrapped[r] {
+			lines = append(lines, strings.TrimRight(b.String(), " "))
+			b.Reset()
+		}
+		from, to := 0, s.cols
+		if r == r0 {
+			from = c0
+		}
+		if r == r1 {
+			to = min(c1, s.cols)
+		}
+		for c := from; c < to; c++ {
+			ch := s.cells[r][c].Char
+			if ch == 0 {
+				ch = ' '
+			}
+			b.WriteRune(ch)
+		}
+	}
+	lines = append(lines, strings.TrimRight(b.String(), " "))
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// addCommand appends cmd to the history unless it is empty or repeats the
+// previous entry, dropping the oldest entries beyond MaxCommandHistory.
+func (s *Screen) addCommand(cmd string) {
+	if cmd == "" {
+		return
+	}
+	if n := len(s.commands); n > 0 && s.commands[n-1] == cmd {
+		return
+	}
+	s.commands = append(s.commands, cmd)
+	if over := len(s.commands) - MaxCommandHistory; over > 0 {
+		s.commands = append(s.commands[:0], s.commands[over:]...)
+	}
+}
+
+// shiftPromptMark moves a pending B mark with the rows top..bottom when they
+// shift by delta (-1 up, +1 down), and drops it if its row leaves the range.
+func (s *Screen) shiftPromptMark(top, bottom, delta int) {
+	if !s.cmdMark || s.cmdRow < top || s.cmdRow > bottom {
+		return
+	}
+	s.cmdRow += delta
+	if s.cmdRow < top || s.cmdRow > bottom {
+		s.cmdMark = false
+	}
+}
+
+// CommandHistory returns the commands seen between OSC 133 B and C marks,
+// oldest first. It is empty for shells without semantic prompt marks.
+func (s *Screen) CommandHistory() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.commands...)
+}
@@ -0,0 +1,73 @@
+package terminal
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// osc133Prompt is what a shell with OSC 133 integration prints before input.
+const osc133Prompt = "\x1b]133;A\x07$ \x1b]133;B\x07"
+
+// runCommand types cmd at the prompt and presses Enter, then reports C.
+func runCommand(cmd string) string {
+	return osc133Prompt + cmd + "\r\n\x1b]133;C\x07output\r\n\x1b]133;D;0\x07"
+}
+
+func TestCommandHistory_CapturesMarkedCommands(t *testing.T) {
+	h := NewScreenHarness(t, 10, 40)
+	h.Feed(runCommand("ls -la")).Feed(runCommand("git status"))
+
+	want := []string{"ls -la", "git status"}
+	if got := h.Screen.CommandHistory(); !reflect.DeepEqual(got, want) {
+		t.Errorf("history = %q, want %q", got, want)
+	}
+}
+
+func TestCommandHistory_SurvivesScrollAtBottom(t *testing.T) {
+	h := NewScreenHarness(t, 3, 40)
+	// The osc133Prompt sits on the last row, so Enter scrolls before C arrives
+	h.Feed("one\r\ntwo\r\n").Feed(runCommand("make test"))
+
+	if got := h.Screen.CommandHistory(); len(got) != 1 || got[0] != "make test" {
+		t.Errorf("history = %q, want [make test]\n%s", got, h.Dump())
+	}
+}
+
+func TestCommandHistory_JoinsWrappedCommand(t *testing.T) {
+	h := NewScreenHarness(t, 5, 10)
+	h.Feed(runCommand("echo 0123456789"))
+
+	if got := h.Screen.CommandHistory(); len(got) != 1 || got[0] != "echo 0123456789" {
+		t.Errorf("history = %q, want the command in one piece", got)
+	}
+}
+
+func TestCommandHistory_SkipsEmptyAndRepeats(t *testing.T) {
+	h := NewScreenHarness(t, 10, 40)
+	h.Feed(runCommand("")).Feed(runCommand("pwd")).Feed(runCommand("pwd")).Feed(runCommand("ls")).Feed(runCommand("pwd"))
+
+	want := []string{"pwd", "ls", "pwd"}
+	if got := h.Screen.CommandHistory(); !reflect.DeepEqual(got, want) {
+		t.Errorf("history = %q, want %q", got, want)
+	}
+}
+
+func TestCommandHistory_Capped(t *testing.T) {
+	h := NewScreenHarness(t, 5, 40)
+	for i := 0; i < MaxCommandHistory+5; i++ {
+		h.Feed(runCommand(fmt.Sprintf("cmd %d", i)))
+	}
+	got := h.Screen.CommandHistory()
+	if len(got) != MaxCommandHistory || got[0] != "cmd 5" {
+		t.Errorf("len = %d, first = %q; want %d entries starting at cmd 5", len(got), got[0], MaxCommandHistory)
+	}
+}
+
+func TestCommandHistory_NoMarksNoHistory(t *testing.T) {
+	h := NewScreenHarness(t, 5, 40)
+	h.Feed("$ ls\r\nfile\r\n$ ")
+	if got := h.Screen.CommandHistory(); len(got) != 0 {
+		t.Errorf("history = %q, want none without OSC 133", got)
+	}
+}
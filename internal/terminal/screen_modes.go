@@ -0,0 +1,33 @@
+package terminal
+
+// setPrivateModes applies the DEC private modes (CSI ? Pm h/l) the Screen
+// tracks; all others are ignored.
+func (s *Screen) setPrivateModes(params []int, on bool) {
+	if s.csiMarkers() != "?" {
+		return
+	}
+	for _, p := range params {
+		switch p {
+		case 1004: // focus in/out reporting
+			s.focusReporting = on
+		case 2004: // bracketed paste
+			s.bracketedPaste = on
+		}
+	}
+}
+
+// FocusReporting reports whether the program enabled focus in/out
+// reporting with CSI ? 1004 h.
+func (s *Screen) FocusReporting() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.focusReporting
+}
+
+// BracketedPaste reports whether the program enabled bracketed paste with
+// CSI ? 2004 h and expects pasted text between CSI 200 ~ and CSI 201 ~.
+func (s *Screen) BracketedPaste() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bracketedPaste
+}
@@ -0,0 +1,44 @@
+package terminal
+
+// ---------------------------------------------------------------------------
+// Monochrome – rendering without colours
+// ---------------------------------------------------------------------------
+
+// SetMonochrome turns colourless rendering on or off. A monochrome screen
+// still parses and stores colours, so turning it off brings them back, but
+// Render, RenderRegion, RenderDiff and Export leave them out. Bold,
+// underline and the other attributes are kept for contrast. A change counts
+// as new content, so consumers repaint without waiting for output.
+func (s *Screen) SetMonochrome(on bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.monochrome == on {
+		return
+	}
+	s.monochrome = on
+	s.gen++
+	// Forget what RenderDiff sent, so every cell is sent again
+	clear(s.rendered)
+	s.markAllDirty()
+}
+
+// Monochrome reports whether the screen renders without colours.
+func (s *Screen) Monochrome() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.monochrome
+}
+
+// WithoutColor returns st with the default foreground and background.
+func (st CellStyle) WithoutColor() CellStyle {
+	st.FG, st.BG = 0, 0
+	return st
+}
+
+// shown returns st as it is rendered. Caller holds s.mu.
+func (s *Screen) shown(st CellStyle) CellStyle {
+	if s.monochrome {
+		return st.WithoutColor()
+	}
+	return st
+}
@@ -10,9 +10,11 @@ func (s *Screen) putChar(ch rune) {
 		// Line wrap
 		s.curCol = 0
 		s.lineFeed()
+		s.setWrapped(s.curRow, true)
 	}
 	if s.curRow >= 0 && s.curRow < s.rows && s.curCol >= 0 && s.curCol < s.cols {
 		s.cells[s.curRow][s.curCol] = Cell{Char: ch, Style: s.style}
+		s.markDirty(s.curRow)
 	}
 	s.curCol++
 }
@@ -25,6 +27,7 @@ func (s *Screen) lineFeed() {
 	} else if s.curRow < s.rows-1 {
 		s.curRow++
 	}
+	s.setWrapped(s.curRow, false)
 }
 
 // reverseLineFeed moves the cursor up one line, scrolling if needed.
@@ -61,6 +64,12 @@ func (s *Screen) scrollUp() {
 	if top >= bottom || top < 0 || bottom >= s.rows {
 		return
 	}
+	s.markDirtyRange(top, bottom)
+	if top == 0 {
+		s.pushScrollback(s.cells[0], s.wrapped[1])
+	}
+	s.shiftWrappedUp(top, bottom)
+	s.shiftPromptMark(top, bottom, -1)
 	// Shift rows up
 	for r := top; r < bottom; r++ {
 		s.cells[r] = s.cells[r+1]
@@ -78,6 +87,9 @@ func (s *Screen) scrollDown() {
 	if top >= bottom || top < 0 || bottom >= s.rows {
 		return
 	}
+	s.markDirtyRange(top, bottom)
+	s.shiftWrappedDown(top, bottom)
+	s.shiftPromptMark(top, bottom, 1)
 	for r := bottom; r > top; r-- {
 		s.cells[r] = s.cells[r-1]
 	}
@@ -92,17 +104,21 @@ func (s *Screen) eraseDisplay(mode int) {
 	blank := Cell{Char: ' ', Style: s.style}
 	switch mode {
 	case 0: // cursor to end
+		s.markDirtyRange(s.curRow, s.rows-1)
 		// Clear rest of current line
 		for c := s.curCol; c < s.cols; c++ {
 			s.cells[s.curRow][c] = blank
 		}
 		// Clear all lines below
+		s.clearWrapped(s.curRow+1, s.rows-1)
 		for r := s.curRow + 1; r < s.rows; r++ {
 			for c := 0; c < s.cols; c++ {
 				s.cells[r][c] = blank
 			}
 		}
 	case 1: // start to cursor
+		s.markDirtyRange(0, s.curRow)
+		s.clearWrapped(0, s.curRow)
 		for r := 0; r < s.curRow; r++ {
 			for c := 0; c < s.cols; c++ {
 				s.cells[r][c] = blank
@@ -112,6 +128,8 @@ func (s *Screen) eraseDisplay(mode int) {
 			s.cells[s.curRow][c] = blank
 		}
 	case 2, 3: // entire screen
+		s.markAllDirty()
+		s.clearWrapped(0, s.rows-1)
 		for r := 0; r < s.rows; r++ {
 			for c := 0; c < s.cols; c++ {
 				s.cells[r][c] = blank
@@ -119,6 +137,9 @@ func (s *Screen) eraseDisplay(mode int) {
 		}
 		s.curRow = 0
 		s.curCol = 0
+		if mode == 3 {
+			s.clearScrollback()
+		}
 	}
 }
 
@@ -127,6 +148,7 @@ func (s *Screen) eraseDisplay(mode int) {
 //	0 = cursor to end, 1 = start to cursor, 2 = entire line
 func (s *Screen) eraseLine(mode int) {
 	blank := Cell{Char: ' ', Style: s.style}
+	s.markDirty(s.curRow)
 	switch mode {
 	case 0:
 		for c := s.curCol; c < s.cols; c++ {
@@ -146,11 +168,14 @@ func (s *Screen) eraseLine(mode int) {
 // insertLines inserts n blank lines at the cursor row, pushing content down.
 func (s *Screen) insertLines(n int) {
 	bottom := s.scrollRegionBottom() - 1
+	s.markDirtyRange(s.curRow, bottom)
 	for i := 0; i < n; i++ {
 		if s.curRow > bottom {
 			break
 		}
 		// Shift rows down
+		s.shiftWrappedDown(s.curRow, bottom)
+		s.shiftPromptMark(s.curRow, bottom, 1)
 		for r := bottom; r > s.curRow; r-- {
 			s.cells[r] = s.cells[r-1]
 		}
@@ -162,10 +187,13 @@ func (s *Screen) insertLines(n int) {
 // deleteLines deletes n lines at the cursor row, pulling content up.
 func (s *Screen) deleteLines(n int) {
 	bottom := s.scrollRegionBottom() - 1
+	s.markDirtyRange(s.curRow, bottom)
 	for i := 0; i < n; i++ {
 		if s.curRow > bottom {
 			break
 		}
+		s.shiftWrappedUp(s.curRow, bottom)
+		s.shiftPromptMark(s.curRow, bottom, -1)
 		for r := s.curRow; r < bottom; r++ {
 			s.cells[r] = s.cells[r+1]
 		}
@@ -176,6 +204,7 @@ func (s *Screen) deleteLines(n int) {
 
 // deleteChars deletes n characters at the cursor, shifting the rest left.
 func (s *Screen) deleteChars(n int) {
+	s.markDirty(s.curRow)
 	row := s.cells[s.curRow]
 	for i := s.curCol; i < s.cols; i++ {
 		if i+n < s.cols {
@@ -188,6 +217,7 @@ func (s *Screen) deleteChars(n int) {
 
 // insertChars inserts n blank characters at the cursor, shifting content right.
 func (s *Screen) insertChars(n int) {
+	s.markDirty(s.curRow)
 	row := s.cells[s.curRow]
 	for i := s.cols - 1; i >= s.curCol+n; i-- {
 		row[i] = row[i-n]
@@ -197,6 +227,20 @@ func (s *Screen) insertChars(n int) {
 	}
 }
 
+// Reset clears the screen and parser state, as if the screen were new.
+// Used when a session's process is restarted in place.
+func (s *Screen) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fullReset()
+	s.gen++
+	s.state = stateNormal
+	s.utf8Len = 0
+	s.utf8Got = 0
+	s.savedRow, s.savedCol = 0, 0
+	s.savedStyle = CellStyle{}
+}
+
 // fullReset resets the terminal to its initial state.
 func (s *Screen) fullReset() {
 	s.style = CellStyle{}
@@ -205,7 +249,16 @@ func (s *Screen) fullReset() {
 	s.scrollTop = 0
 	s.scrollBottom = 0
 	s.Title = ""
+	s.reportedDir = ""
+	s.focusReporting = false
+	s.bracketedPaste = false
+	s.kittyFlags = nil
+	s.progress, s.progressPct = ProgressNone, 0
 	s.cells = makeGrid(s.rows, s.cols)
+	s.clearWrapped(0, s.rows-1)
+	s.clearScrollback()
+	s.cmdMark = false
+	s.markAllDirty()
 }
 
 // clampCursor ensures the cursor is within screen bounds.
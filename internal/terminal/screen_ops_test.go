@@ -176,6 +176,18 @@ func TestFullReset(t *testing.T) {
 	}
 }
 
+func TestReset_DropsPartialSequence(t *testing.T) {
+	s := NewScreen(3, 10)
+	s.Write([]byte("Hello\x1b[3")) // dangling CSI
+
+	s.Reset()
+	s.Write([]byte("ok"))
+
+	if r := s.PlainTextRow(0); r != "ok" {
+		t.Errorf("After Reset, row 0 = %q, want %q", r, "ok")
+	}
+}
+
 // ---------------------------------------------------------------------------
 // clampCursor: already tested indirectly, but verify directly
 // ---------------------------------------------------------------------------
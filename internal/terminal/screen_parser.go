@@ -1,6 +1,9 @@
 package terminal
 
 import (
+	"net/url"
+	"os"
+	"regexp"
 	"strings"
 	"unicode/utf8"
 )
@@ -58,6 +61,13 @@ func (s *Screen) processNormal(b byte) {
 			s.curCol = s.cols - 1
 		}
 	case 0x07: // BEL – ignore
+	case 0x9B: // C1 CSI – 8-bit form of ESC [
+		s.state = stateCSI
+		s.csiBuf = s.csiBuf[:0]
+	case 0x9D: // C1 OSC – 8-bit form of ESC ]
+		s.state = stateOSC
+		s.oscBuf = s.oscBuf[:0]
+	case 0x9C: // C1 ST without an open string – ignore
 	default:
 		if b >= 0x20 && b <= 0x7E { // printable ASCII
 			s.putChar(rune(b))
@@ -73,7 +83,7 @@ func (s *Screen) processNormal(b byte) {
 				s.utf8Len = 4
 			}
 		}
-		// Ignore C0 controls and invalid bytes (0x80-0xBF outside sequence)
+		// Ignore C0 controls, other C1 controls and stray continuation bytes
 	}
 }
 
@@ -86,13 +96,15 @@ func (s *Screen) processESC(b byte) {
 	case ']': // OSC introducer
 		s.state = stateOSC
 		s.oscBuf = s.oscBuf[:0]
-	case '7': // DEC Save Cursor
+	case '7': // DEC Save Cursor (position and rendition)
 		s.savedRow = s.curRow
 		s.savedCol = s.curCol
+		s.savedStyle = s.style
 		s.state = stateNormal
 	case '8': // DEC Restore Cursor
 		s.curRow = s.savedRow
 		s.curCol = s.savedCol
+		s.style = s.savedStyle
 		s.state = stateNormal
 	case 'D': // Index (move down, scroll if at bottom)
 		s.lineFeed()
@@ -103,8 +115,11 @@ func (s *Screen) processESC(b byte) {
 	case 'c': // Full Reset (RIS)
 		s.fullReset()
 		s.state = stateNormal
+	case '\\': // ST – end of a string sequence (OSC already handled)
+		s.state = stateNormal
 	default:
 		// Unknown ESC sequence – return to normal
+		s.countUnhandled("ESC", string(rune(b)))
 		s.state = stateNormal
 	}
 }
@@ -134,8 +149,13 @@ func (s *Screen) processOSC(b byte) {
 		return
 	}
 	if b == 0x1b {
-		// Possible ST (ESC \)
-		// For simplicity, just terminate the OSC here
+		// ST (ESC \) – terminate here; the '\' is consumed by processESC
+		s.handleOSC()
+		s.state = stateESC
+		return
+	}
+	if b == 0x9C && !midRune(s.oscBuf) {
+		// C1 ST; inside a UTF-8 title 0x9C is a continuation byte instead
 		s.handleOSC()
 		s.state = stateNormal
 		return
@@ -143,6 +163,17 @@ func (s *Screen) processOSC(b byte) {
 	s.oscBuf = append(s.oscBuf, b)
 }
 
+// midRune reports whether buf ends inside a multi-byte UTF-8 sequence, so
+// the next byte in 0x80-0xBF continues it rather than being a C1 control.
+func midRune(buf []byte) bool {
+	for i := len(buf) - 1; i >= 0 && i >= len(buf)-3; i-- {
+		if utf8.RuneStart(buf[i]) {
+			return buf[i] >= 0xC0 && !utf8.FullRune(buf[i:])
+		}
+	}
+	return false
+}
+
 // handleOSC processes the completed OSC payload.
 func (s *Screen) handleOSC() {
 	payload := string(s.oscBuf)
@@ -150,5 +181,48 @@ func (s *Screen) handleOSC() {
 	// OSC 2 ; <title> – set window title
 	if strings.HasPrefix(payload, "0;") || strings.HasPrefix(payload, "2;") {
 		s.Title = payload[2:]
+		return
+	}
+	// OSC 7 ; file://<host>/<path> – shell reports its working directory
+	if strings.HasPrefix(payload, "7;") {
+		if dir, ok := parseOSC7(payload[2:]); ok {
+			s.reportedDir = dir
+		}
+		return
+	}
+	// OSC 133 ; A|B|C|D – semantic prompt marks
+	if rest, ok := strings.CutPrefix(payload, "133;"); ok {
+		s.handlePromptMark(rest)
+		return
+	}
+	// OSC 9 ; 4 ; state ; percent – progress (Windows Terminal, ConEmu)
+	if rest, ok := strings.CutPrefix(payload, "9;4"); ok {
+		if state, pct, ok := parseProgress(rest); ok {
+			s.progress, s.progressPct = state, pct
+			return
+		}
+	}
+	num, _, _ := strings.Cut(payload, ";")
+	s.countUnhandled("OSC", num)
+}
+
+// windowsDrivePath matches the "/C:/..." form of a Windows path in a file URL.
+var windowsDrivePath = regexp.MustCompile(`^/[A-Za-z]:/`)
+
+// parseOSC7 decodes the file URL of an OSC 7 report into a local path.
+// Reports from other hosts (e.g. a shell inside ssh) are ignored.
+func parseOSC7(uri string) (string, bool) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" || u.Path == "" {
+		return "", false
+	}
+	if host := u.Hostname(); host != "" && host != "localhost" {
+		if local, err := os.Hostname(); err != nil || !strings.EqualFold(host, local) {
+			return "", false
+		}
+	}
+	if windowsDrivePath.MatchString(u.Path) {
+		return strings.ReplaceAll(u.Path[1:], "/", `\`), true
 	}
+	return u.Path, true
 }
@@ -0,0 +1,74 @@
+package terminal
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ProgressState is the state reported with the Windows Terminal / ConEmu
+// progress sequence OSC 9 ; 4 ; state ; percent ST, as emitted by winget,
+// some installers and build tools.
+type ProgressState int
+
+const (
+	ProgressNone          ProgressState = iota // 0: no progress (cleared)
+	ProgressNormal                             // 1: percent is valid
+	ProgressError                              // 2: failed; percent optional
+	ProgressIndeterminate                      // 3: busy, no percent
+	ProgressPaused                             // 4: paused; percent optional
+)
+
+// String returns the name used in frontend events.
+func (p ProgressState) String() string {
+	switch p {
+	case ProgressNormal:
+		return "normal"
+	case ProgressError:
+		return "error"
+	case ProgressIndeterminate:
+		return "indeterminate"
+	case ProgressPaused:
+		return "paused"
+	}
+	return "none"
+}
+
+// parseProgress decodes the parameters after "9;4" ("" or ";state;percent").
+// A missing percent is 0, values above 100 are clamped, and unknown states
+// are rejected so they do not clear a running bar.
+func parseProgress(params string) (ProgressState, int, bool) {
+	if params == "" {
+		return ProgressNone, 0, true
+	}
+	if params[0] != ';' {
+		return 0, 0, false
+	}
+	stateStr, pctStr, _ := strings.Cut(params[1:], ";")
+	state := ProgressNone
+	if stateStr != "" {
+		n, err := strconv.Atoi(stateStr)
+		if err != nil || n < int(ProgressNone) || n > int(ProgressPaused) {
+			return 0, 0, false
+		}
+		state = ProgressState(n)
+	}
+	pct := 0
+	if pctStr != "" {
+		n, err := strconv.Atoi(pctStr)
+		if err != nil || n < 0 {
+			return 0, 0, false
+		}
+		pct = min(n, 100)
+	}
+	if state == ProgressNone || state == ProgressIndeterminate {
+		pct = 0
+	}
+	return state, pct, true
+}
+
+// Progress returns the last OSC 9;4 progress report and its percentage.
+func (s *Screen) Progress() (ProgressState, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.progress, s.progressPct
+}
@@ -0,0 +1,74 @@
+package terminal
+
+import "testing"
+
+// ---------------------------------------------------------------------------
+// OSC 9;4 – taskbar progress reports
+// ---------------------------------------------------------------------------
+
+func TestParseProgress(t *testing.T) {
+	tests := []struct {
+		params string
+		state  ProgressState
+		pct    int
+		ok     bool
+	}{
+		{"", ProgressNone, 0, true},
+		{";0", ProgressNone, 0, true},
+		{";1;42", ProgressNormal, 42, true},
+		{";1;250", ProgressNormal, 100, true},
+		{";2", ProgressError, 0, true},
+		{";2;80", ProgressError, 80, true},
+		{";3;50", ProgressIndeterminate, 0, true},
+		{";4;10", ProgressPaused, 10, true},
+		{";0;70", ProgressNone, 0, true},
+		{";5;10", 0, 0, false},
+		{";1;x", 0, 0, false},
+		{";1;-3", 0, 0, false},
+		{"2;1", 0, 0, false},
+	}
+	for _, tt := range tests {
+		state, pct, ok := parseProgress(tt.params)
+		if ok != tt.ok || (ok && (state != tt.state || pct != tt.pct)) {
+			t.Errorf("parseProgress(%q) = (%v, %d, %v), want (%v, %d, %v)",
+				tt.params, state, pct, ok, tt.state, tt.pct, tt.ok)
+		}
+	}
+}
+
+func TestOSCProgress_SetAndClear(t *testing.T) {
+	s := NewScreen(5, 20)
+	s.Write([]byte("\x1b]9;4;1;35\x1b\\"))
+	if state, pct := s.Progress(); state != ProgressNormal || pct != 35 {
+		t.Fatalf("Progress() = (%v, %d), want (normal, 35)", state, pct)
+	}
+
+	s.Write([]byte("\x1b]9;4;2\x07"))
+	if state, _ := s.Progress(); state != ProgressError {
+		t.Errorf("state = %v, want error", state)
+	}
+
+	s.Write([]byte("\x1b]9;4;0;0\x07"))
+	if state, pct := s.Progress(); state != ProgressNone || pct != 0 {
+		t.Errorf("after clear = (%v, %d), want (none, 0)", state, pct)
+	}
+}
+
+func TestOSCProgress_InvalidKeepsStateAndIsCounted(t *testing.T) {
+	s := NewScreen(5, 20)
+	s.Write([]byte("\x1b]9;4;1;60\x07\x1b]9;4;9;1\x07"))
+	if state, pct := s.Progress(); state != ProgressNormal || pct != 60 {
+		t.Errorf("invalid report changed progress to (%v, %d)", state, pct)
+	}
+	if n := s.UnhandledSequences()["OSC 9"]; n != 1 {
+		t.Errorf("unhandled OSC 9 = %d, want 1", n)
+	}
+}
+
+func TestOSCProgress_ClearedByReset(t *testing.T) {
+	s := NewScreen(5, 20)
+	s.Write([]byte("\x1b]9;4;3\x07\x1bc"))
+	if state, _ := s.Progress(); state != ProgressNone {
+		t.Errorf("state after RIS = %v, want none", state)
+	}
+}
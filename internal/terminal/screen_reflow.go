@@ -0,0 +1,126 @@
+package terminal
+
+// ---------------------------------------------------------------------------
+// Reflow – rewrap soft-wrapped lines when the width changes
+// ---------------------------------------------------------------------------
+
+// SetReflow turns rewrapping on width changes on or off (see Resize). It is
+// off for a new Screen, which cuts rows at the new width instead.
+func (s *Screen) SetReflow(on bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reflow = on
+}
+
+// linePos is a position within a logical line: the line index and the
+// cell offset from its start.
+type linePos struct {
+	line, off int
+	ok        bool
+}
+
+// isBlankCell reports whether c is an unstyled blank, i.e. padding that a
+// line does not need to carry to its new width.
+func isBlankCell(c Cell) bool {
+	return (c.Char == ' ' || c.Char == 0) && c.Style == CellStyle{}
+}
+
+// logicalCells joins the rows into logical lines using the wrap flags and
+// maps the cursor and the OSC 133 command mark onto them. Trailing blanks
+// of each line are dropped. Caller holds s.mu.
+func (s *Screen) logicalCells() (lines [][]Cell, cursor, mark linePos) {
+	for r := 0; r < s.rows; r++ {
+		if r == 0 || !s.wrapped[r] {
+			lines = append(lines, nil)
+		}
+		i := len(lines) - 1
+		if r == s.curRow {
+			cursor = linePos{i, len(lines[i]) + s.curCol, true}
+		}
+		if s.cmdMark && r == s.cmdRow {
+			mark = linePos{i, len(lines[i]) + s.cmdCol, true}
+		}
+		lines[i] = append(lines[i], s.cells[r]...)
+	}
+	for i, line := range lines {
+		n := len(line)
+		for n > 0 && isBlankCell(line[n-1]) {
+			n--
+		}
+		lines[i] = line[:n]
+	}
+	return lines, cursor, mark
+}
+
+// reflowGrid rebuilds the grid at the new size from the logical lines, so
+// wrapped output rewraps instead of being cut. The cursor and command mark
+// keep their offsets within their lines. Rows that no longer fit leave at
+// the top into the scrollback, like output scrolling off the screen. Caller
+// holds s.mu and updates s.rows and s.cols afterwards.
+func (s *Screen) reflowGrid(rows, cols int) {
+	lines, cursor, mark := s.logicalCells()
+
+	var grid [][]Cell
+	var wrapped []bool
+	curRow, curCol := 0, 0
+	cmdRow, cmdCol := -1, 0
+	for i, line := range lines {
+		n := max((len(line)+cols-1)/cols, 1)
+		start := len(grid)
+		if cursor.ok && cursor.line == i {
+			curRow, curCol = cursor.off/cols, cursor.off%cols
+			if curCol == 0 && curRow > 0 && curRow >= n {
+				// Right after the last cell: keep the pending wrap
+				curRow, curCol = curRow-1, cols
+			}
+			n = max(n, curRow+1)
+			curRow += start
+		}
+		if mark.ok && mark.line == i {
+			cmdRow, cmdCol = start+min(mark.off/cols, n-1), mark.off%cols
+		}
+		for k := 0; k < n; k++ {
+			row := makeBlankLine(cols)
+			if k*cols < len(line) {
+				copy(row, line[k*cols:min((k+1)*cols, len(line))])
+			}
+			grid = append(grid, row)
+			wrapped = append(wrapped, k > 0)
+		}
+	}
+
+	// Blank rows below the cursor go first, then rows off the top
+	for len(grid) > rows && len(grid)-1 > curRow && !wrapped[len(grid)-1] && isBlankRow(grid[len(grid)-1]) {
+		grid, wrapped = grid[:len(grid)-1], wrapped[:len(wrapped)-1]
+	}
+	if drop := len(grid) - rows; drop > 0 {
+		for k := 0; k < drop; k++ {
+			s.pushScrollback(grid[k], wrapped[k+1])
+		}
+		grid, wrapped = grid[drop:], wrapped[drop:]
+		wrapped[0] = false
+		curRow -= drop
+		cmdRow -= drop
+	}
+	for len(grid) < rows {
+		grid = append(grid, makeBlankLine(cols))
+		wrapped = append(wrapped, false)
+	}
+
+	s.cells, s.wrapped = grid, wrapped
+	s.curRow, s.curCol = max(curRow, 0), curCol
+	if s.cmdMark {
+		s.cmdMark = cmdRow >= 0 && cmdRow < rows
+		s.cmdRow, s.cmdCol = cmdRow, cmdCol
+	}
+}
+
+// isBlankRow reports whether every cell of row is an unstyled blank.
+func isBlankRow(row []Cell) bool {
+	for _, c := range row {
+		if !isBlankCell(c) {
+			return false
+		}
+	}
+	return true
+}
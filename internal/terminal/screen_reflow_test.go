@@ -0,0 +1,102 @@
+package terminal
+
+import (
+	"reflect"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// Reflow on resize
+// ---------------------------------------------------------------------------
+
+func newReflowScreen(rows, cols int) *Screen {
+	s := NewScreen(rows, cols)
+	s.SetReflow(true)
+	return s
+}
+
+func TestReflow_WideToNarrow(t *testing.T) {
+	s := newReflowScreen(4, 10)
+	s.Write([]byte("0123456789ab\r\nshort\r\n$ "))
+	s.Resize(6, 5)
+
+	want := []string{"01234", "56789", "ab", "short", "$", ""}
+	if got := s.PlainTextRows(0, 6); !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %q, want %q", got, want)
+	}
+	if got := s.PlainTextLogicalRows(0, 5); !reflect.DeepEqual(got, []string{"0123456789ab", "short", "$"}) {
+		t.Errorf("logical lines = %q, want the original lines", got)
+	}
+	if row, col := s.Cursor(); row != 4 || col != 2 {
+		t.Errorf("cursor = (%d,%d), want (4,2) after the prompt", row, col)
+	}
+}
+
+func TestReflow_NarrowToWide(t *testing.T) {
+	s := newReflowScreen(5, 4)
+	s.Write([]byte("abcdefghij\r\nxy"))
+	s.Resize(5, 12)
+
+	want := []string{"abcdefghij", "xy", "", "", ""}
+	if got := s.PlainTextRows(0, 5); !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %q, want %q", got, want)
+	}
+	if row, col := s.Cursor(); row != 1 || col != 2 {
+		t.Errorf("cursor = (%d,%d), want (1,2)", row, col)
+	}
+}
+
+func TestReflow_CursorInsideWrappedLine(t *testing.T) {
+	s := newReflowScreen(3, 4)
+	s.Write([]byte("abcdefgh\x1b[2;2H")) // cursor on 'f', offset 5
+	s.Resize(3, 3)
+	if row, col := s.Cursor(); row != 1 || col != 2 {
+		t.Errorf("cursor = (%d,%d), want (1,2) on 'f'", row, col)
+	}
+	if ch := s.CellAt(1, 2).Char; ch != 'f' {
+		t.Errorf("cell under cursor = %q, want 'f'", ch)
+	}
+}
+
+func TestReflow_OverflowDropsTopRows(t *testing.T) {
+	s := newReflowScreen(3, 6)
+	s.Write([]byte("one\r\nabcdefghij\r\n> "))
+	s.Resize(3, 3)
+
+	// "one", "abc", "def", "ghi", "j", ">" needs six rows; the top three go
+	want := []string{"ghi", "j", ">"}
+	if got := s.PlainTextRows(0, 3); !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %q, want %q", got, want)
+	}
+	if row, _ := s.Cursor(); row != 2 {
+		t.Errorf("cursor row = %d, want 2", row)
+	}
+}
+
+func TestReflow_PendingWrapContinuesLine(t *testing.T) {
+	s := newReflowScreen(3, 4)
+	s.Write([]byte("abcdef"))
+	s.Resize(3, 3) // "abc", "def" with the cursor right after 'f'
+	s.Write([]byte("g"))
+	if got := s.PlainTextLogicalRows(0, 3); got[0] != "abcdefg" {
+		t.Errorf("logical line = %q, want 'abcdefg'", got[0])
+	}
+}
+
+func TestReflow_KeepsStyles(t *testing.T) {
+	s := newReflowScreen(2, 4)
+	s.Write([]byte("ab\x1b[31mcdef\x1b[0m"))
+	s.Resize(2, 8)
+	if c := s.CellAt(0, 5); c.Char != 'f' || c.Style.FG != 2 {
+		t.Errorf("cell (0,5) = %+v, want red 'f'", c)
+	}
+}
+
+func TestReflow_OffCutsRows(t *testing.T) {
+	s := NewScreen(3, 4)
+	s.Write([]byte("abcdef"))
+	s.Resize(3, 2)
+	if got := s.PlainTextRows(0, 2); !reflect.DeepEqual(got, []string{"ab", "ef"}) {
+		t.Errorf("rows = %q, want rows cut without reflow", got)
+	}
+}
@@ -26,9 +26,9 @@ func (s *Screen) Render() string {
 		}
 		for c := 0; c < s.cols; c++ {
 			cell := s.cells[r][c]
-			if cell.Style != prev {
-				b.WriteString(sgrSequence(cell.Style))
-				prev = cell.Style
+			if st := s.shown(cell.Style); st != prev {
+				b.WriteString(sgrSequence(st))
+				prev = st
 			}
 			ch := cell.Char
 			if ch == 0 {
@@ -57,9 +57,9 @@ func (s *Screen) RenderRegion(startRow, startCol, endRow, endCol int) string {
 		}
 		for c := startCol; c <= endCol && c < s.cols; c++ {
 			cell := s.cells[r][c]
-			if cell.Style != prev {
-				b.WriteString(sgrSequence(cell.Style))
-				prev = cell.Style
+			if st := s.shown(cell.Style); st != prev {
+				b.WriteString(sgrSequence(st))
+				prev = st
 			}
 			ch := cell.Char
 			if ch == 0 {
@@ -138,3 +138,33 @@ func (s *Screen) PlainText() string {
 	}
 	return b.String()
 }
+
+// CellRows returns a copy of the cells in rows [startRow, endRow) together
+// with the cursor position, all under a single lock acquisition so the grid
+// and cursor are consistent with each other. The copy is allocated before
+// the lock is taken; if the screen is resized meanwhile, it is sized again.
+func (s *Screen) CellRows(startRow, endRow int) (cells [][]Cell, curRow, curCol int) {
+	startRow = max(startRow, 0)
+	for {
+		rows, cols := s.Rows(), s.Cols()
+		from, to := startRow, max(min(endRow, rows), startRow)
+		if from > rows {
+			from, to = rows, rows
+		}
+		cells = make([][]Cell, to-from)
+		for i := range cells {
+			cells[i] = make([]Cell, cols)
+		}
+
+		s.mu.Lock()
+		if s.rows == rows && s.cols == cols {
+			for i := range cells {
+				copy(cells[i], s.cells[from+i])
+			}
+			curRow, curCol = s.curRow, s.curCol
+			s.mu.Unlock()
+			return cells, curRow, curCol
+		}
+		s.mu.Unlock()
+	}
+}
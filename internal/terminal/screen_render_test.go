@@ -178,3 +178,66 @@ func TestRenderRegion_OutOfBounds(t *testing.T) {
 		t.Errorf("RenderRegion out-of-bounds should still contain visible content")
 	}
 }
+
+// ---------------------------------------------------------------------------
+// CellRows
+// ---------------------------------------------------------------------------
+
+func TestCellRows_CopiesCellsAndCursor(t *testing.T) {
+	s := NewScreen(3, 5)
+	s.Write([]byte("\x1b[1;31mAB\x1b[0m\r\nC"))
+
+	cells, row, col := s.CellRows(0, 3)
+	if len(cells) != 3 || len(cells[0]) != 5 {
+		t.Fatalf("got %dx%d grid, want 3x5", len(cells), len(cells[0]))
+	}
+	if cells[0][0].Char != 'A' || !cells[0][0].Style.Bold || cells[0][0].Style.FG != 2 {
+		t.Errorf("cell (0,0) = %+v, want bold red 'A'", cells[0][0])
+	}
+	if cells[1][0].Char != 'C' {
+		t.Errorf("cell (1,0) = %q, want 'C'", cells[1][0].Char)
+	}
+	if row != 1 || col != 1 {
+		t.Errorf("cursor = (%d,%d), want (1,1)", row, col)
+	}
+
+	// The returned grid must be a copy
+	cells[0][0].Char = 'Z'
+	if s.CellAt(0, 0).Char != 'A' {
+		t.Error("modifying CellRows result changed the screen")
+	}
+}
+
+func TestCellRows_ClampsRange(t *testing.T) {
+	s := NewScreen(3, 5)
+	if cells, _, _ := s.CellRows(-2, 99); len(cells) != 3 {
+		t.Errorf("clamped range returned %d rows, want 3", len(cells))
+	}
+	if cells, _, _ := s.CellRows(2, 1); len(cells) != 0 {
+		t.Errorf("inverted range returned %d rows, want 0", len(cells))
+	}
+}
+
+func TestCellRows_WhileResizing(t *testing.T) {
+	s := NewScreen(3, 5)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			s.Resize(3+i%4, 5+i%7)
+		}
+	}()
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		cells, _, _ := s.CellRows(0, 99)
+		for _, row := range cells {
+			if len(row) != len(cells[0]) {
+				t.Fatalf("rows of different widths: %d and %d", len(row), len(cells[0]))
+			}
+		}
+	}
+}
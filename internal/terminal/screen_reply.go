@@ -0,0 +1,143 @@
+package terminal
+
+import "fmt"
+
+// ---------------------------------------------------------------------------
+// Replies to terminal queries (Device Status Report, Device Attributes,
+// window size reports)
+// ---------------------------------------------------------------------------
+
+// Device attribute replies. DA1 claims a VT220 (62) with ANSI colour (22),
+// the only optional capability the Screen implements; extensions such as
+// sixel graphics (4) or 132 columns (1) must not be listed until they are.
+// DA2 reports a VT220 (1) with firmware version 10 and no ROM cartridge.
+const (
+	primaryDA   = "\x1b[?62;22c"
+	secondaryDA = "\x1b[>1;10;0c"
+)
+
+// Nominal cell size in pixels for the XTWINOPS pixel queries until the
+// frontend reports the real one with SetCellPixels.
+const (
+	nominalCellWidth  = 8
+	nominalCellHeight = 16
+)
+
+// SetCellPixels records the size of one cell in pixels as rendered with
+// the pane's font, so image tools (kitty graphics, iTerm2 inline images)
+// can scale to the text area. Non-positive values revert to the nominal
+// 8x16.
+func (s *Screen) SetCellPixels(width, height int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if width <= 0 || height <= 0 {
+		width, height = 0, 0
+	}
+	s.cellWidth, s.cellHeight = width, height
+}
+
+// CellPixels returns the cell size set with SetCellPixels; ok is false
+// while it is unknown.
+func (s *Screen) CellPixels() (width, height int, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cellWidth, s.cellHeight, s.cellWidth > 0
+}
+
+// cellPixels returns the reported cell size, or the nominal one. Must hold
+// s.mu.
+func (s *Screen) cellPixels() (width, height int) {
+	if s.cellWidth > 0 {
+		return s.cellWidth, s.cellHeight
+	}
+	return nominalCellWidth, nominalCellHeight
+}
+
+// SetResponder installs fn to receive the terminal's replies to queries
+// such as CSI 6n. Replies are collected while a chunk is parsed and handed
+// to fn after Write releases the screen lock, so fn may write to the PTY.
+// A nil fn discards replies.
+func (s *Screen) SetResponder(fn func(reply []byte)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.respond = fn
+}
+
+// reply queues bytes to send back to the program.
+func (s *Screen) reply(b []byte) {
+	if s.respond != nil {
+		s.replies = append(s.replies, b...)
+	}
+}
+
+// takeReplies returns and clears the queued replies together with the
+// responder they are for. Must be called with s.mu held.
+func (s *Screen) takeReplies() ([]byte, func([]byte)) {
+	if len(s.replies) == 0 {
+		return nil, nil
+	}
+	out := s.replies
+	s.replies = nil
+	return out, s.respond
+}
+
+// handleDSR answers CSI n (Device Status Report): 5 asks for the device
+// status ("OK" is CSI 0 n), 6 for the cursor position, which is reported
+// 1-indexed as CSI row ; col R.
+func (s *Screen) handleDSR(params []int) {
+	if s.csiMarkers() != "" || len(params) == 0 {
+		s.countUnhandled("CSI", s.csiMarkers()+"n")
+		return
+	}
+	switch params[0] {
+	case 5:
+		s.reply([]byte("\x1b[0n"))
+	case 6:
+		// After writing the last column the cursor waits past the edge
+		// for the next character; terminals report the last column then.
+		col := min(s.curCol, s.cols-1)
+		s.reply(fmt.Appendf(nil, "\x1b[%d;%dR", s.curRow+1, col+1))
+	default:
+		s.countUnhandled("CSI", "n")
+	}
+}
+
+// handleDA answers CSI c (Primary Device Attributes) and CSI > c (Secondary
+// Device Attributes). Both only accept an omitted or zero parameter.
+func (s *Screen) handleDA(params []int) {
+	markers := s.csiMarkers()
+	if len(params) > 1 || paramDefault(params, 0, 0) != 0 {
+		s.countUnhandled("CSI", markers+"c")
+		return
+	}
+	switch markers {
+	case "":
+		s.reply([]byte(primaryDA))
+	case ">":
+		s.reply([]byte(secondaryDA))
+	default:
+		s.countUnhandled("CSI", markers+"c")
+	}
+}
+
+// handleWindowOps answers the XTWINOPS size queries (CSI Ps t): 14 text area
+// in pixels, 16 cell size in pixels, 18 text area and 19 screen in cells.
+// An embedded pane cannot be moved, resized or iconified, so all other
+// operations are ignored and only counted in the parser diagnostics.
+func (s *Screen) handleWindowOps(params []int) {
+	op := paramDefault(params, 0, 0)
+	switch {
+	case s.csiMarkers() != "":
+		s.countUnhandled("CSI", s.csiMarkers()+"t")
+	case op == 14:
+		w, h := s.cellPixels()
+		s.reply(fmt.Appendf(nil, "\x1b[4;%d;%dt", s.rows*h, s.cols*w))
+	case op == 16:
+		w, h := s.cellPixels()
+		s.reply(fmt.Appendf(nil, "\x1b[6;%d;%dt", h, w))
+	case op == 18, op == 19:
+		s.reply(fmt.Appendf(nil, "\x1b[%d;%d;%dt", op-10, s.rows, s.cols))
+	default:
+		s.countUnhandled("CSI", "t")
+	}
+}
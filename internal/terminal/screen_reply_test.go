@@ -0,0 +1,194 @@
+package terminal
+
+import "testing"
+
+// ---------------------------------------------------------------------------
+// Device Status Report replies
+// ---------------------------------------------------------------------------
+
+// replyScreen returns a screen whose replies are appended to *got.
+func replyScreen(rows, cols int, got *[]string) *Screen {
+	s := NewScreen(rows, cols)
+	s.SetResponder(func(reply []byte) { *got = append(*got, string(reply)) })
+	return s
+}
+
+func TestDSR_CursorPosition(t *testing.T) {
+	var got []string
+	s := replyScreen(10, 40, &got)
+	s.Write([]byte("\x1b[3;5H\x1b[6n"))
+	if len(got) != 1 || got[0] != "\x1b[3;5R" {
+		t.Fatalf("replies = %q, want [\"\\x1b[3;5R\"]", got)
+	}
+}
+
+func TestDSR_CursorPositionIsOneIndexed(t *testing.T) {
+	var got []string
+	s := replyScreen(10, 40, &got)
+	s.Write([]byte("\x1b[6n"))
+	if len(got) != 1 || got[0] != "\x1b[1;1R" {
+		t.Fatalf("replies = %q, want [\"\\x1b[1;1R\"]", got)
+	}
+}
+
+func TestDSR_CursorPastLastColumn(t *testing.T) {
+	var got []string
+	s := replyScreen(3, 4, &got)
+	s.Write([]byte("abcd\x1b[6n"))
+	if len(got) != 1 || got[0] != "\x1b[1;4R" {
+		t.Fatalf("replies = %q, want the last column [\"\\x1b[1;4R\"]", got)
+	}
+}
+
+func TestDSR_DeviceStatus(t *testing.T) {
+	var got []string
+	s := replyScreen(10, 40, &got)
+	s.Write([]byte("\x1b[5n"))
+	if len(got) != 1 || got[0] != "\x1b[0n" {
+		t.Fatalf("replies = %q, want [\"\\x1b[0n\"]", got)
+	}
+}
+
+func TestDSR_RepliesOfOneChunkAreBatched(t *testing.T) {
+	var got []string
+	s := replyScreen(10, 40, &got)
+	s.Write([]byte("\x1b[5n\x1b[2;2H\x1b[6n"))
+	if len(got) != 1 || got[0] != "\x1b[0n\x1b[2;2R" {
+		t.Fatalf("replies = %q, want one batched reply", got)
+	}
+}
+
+func TestDSR_PrivateAndUnknownAreIgnored(t *testing.T) {
+	var got []string
+	s := replyScreen(10, 40, &got)
+	s.Write([]byte("\x1b[?6n\x1b[7n\x1b[n"))
+	if len(got) != 0 {
+		t.Fatalf("replies = %q, want none", got)
+	}
+	if n := s.UnhandledSequences()["CSI ?n"]; n != 1 {
+		t.Errorf("unhandled CSI ?n = %d, want 1", n)
+	}
+}
+
+func TestDSR_NoResponderDiscards(t *testing.T) {
+	s := NewScreen(10, 40)
+	s.Write([]byte("\x1b[6n"))
+	if len(s.replies) != 0 {
+		t.Errorf("replies queued without a responder: %q", s.replies)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Device Attributes replies
+// ---------------------------------------------------------------------------
+
+func TestDA_Primary(t *testing.T) {
+	for _, seq := range []string{"\x1b[c", "\x1b[0c"} {
+		var got []string
+		s := replyScreen(10, 40, &got)
+		s.Write([]byte(seq))
+		if len(got) != 1 || got[0] != "\x1b[?62;22c" {
+			t.Errorf("%q: replies = %q, want [\"\\x1b[?62;22c\"]", seq, got)
+		}
+	}
+}
+
+func TestDA_Secondary(t *testing.T) {
+	var got []string
+	s := replyScreen(10, 40, &got)
+	s.Write([]byte("\x1b[>c"))
+	if len(got) != 1 || got[0] != "\x1b[>1;10;0c" {
+		t.Fatalf("replies = %q, want [\"\\x1b[>1;10;0c\"]", got)
+	}
+}
+
+func TestDA_OtherVariantsAreIgnored(t *testing.T) {
+	var got []string
+	s := replyScreen(10, 40, &got)
+	s.Write([]byte("\x1b[=c\x1b[1c\x1b[>5c"))
+	if len(got) != 0 {
+		t.Fatalf("replies = %q, want none", got)
+	}
+	if n := s.UnhandledSequences()["CSI =c"]; n != 1 {
+		t.Errorf("unhandled CSI =c = %d, want 1", n)
+	}
+}
+
+func TestDA_ReplyDoesNotTouchScreen(t *testing.T) {
+	var got []string
+	s := replyScreen(3, 10, &got)
+	s.Write([]byte("ab\x1b[c"))
+	if text := s.PlainTextRow(0); text != "ab" {
+		t.Errorf("row 0 = %q, want \"ab\"", text)
+	}
+	if row, col := s.Cursor(); row != 0 || col != 2 {
+		t.Errorf("cursor = (%d,%d), want (0,2)", row, col)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// XTWINOPS size reports
+// ---------------------------------------------------------------------------
+
+func TestWindowOps_SizeReports(t *testing.T) {
+	tests := []struct {
+		seq, want string
+	}{
+		{"\x1b[18t", "\x1b[8;24;100t"},
+		{"\x1b[19t", "\x1b[9;24;100t"},
+		{"\x1b[14t", "\x1b[4;384;800t"},
+		{"\x1b[16t", "\x1b[6;16;8t"},
+	}
+	for _, tt := range tests {
+		var got []string
+		s := replyScreen(24, 100, &got)
+		s.Write([]byte(tt.seq))
+		if len(got) != 1 || got[0] != tt.want {
+			t.Errorf("%q: replies = %q, want [%q]", tt.seq, got, tt.want)
+		}
+	}
+}
+
+func TestWindowOps_SizeFollowsResize(t *testing.T) {
+	var got []string
+	s := replyScreen(24, 80, &got)
+	s.Resize(40, 132)
+	s.Write([]byte("\x1b[18t"))
+	if len(got) != 1 || got[0] != "\x1b[8;40;132t" {
+		t.Fatalf("replies = %q, want [\"\\x1b[8;40;132t\"]", got)
+	}
+}
+
+func TestWindowOps_WindowManipulationIsIgnored(t *testing.T) {
+	var got []string
+	s := replyScreen(24, 80, &got)
+	s.Write([]byte("\x1b[8;50;200t\x1b[3;0;0t\x1b[2t"))
+	if len(got) != 0 {
+		t.Fatalf("replies = %q, want none", got)
+	}
+	if rows, cols := s.Rows(), s.Cols(); rows != 24 || cols != 80 {
+		t.Errorf("size = %dx%d, want the pane size 24x80 unchanged", rows, cols)
+	}
+	if n := s.UnhandledSequences()["CSI t"]; n != 3 {
+		t.Errorf("unhandled CSI t = %d, want 3", n)
+	}
+}
+
+func TestWindowOps_CellPixels(t *testing.T) {
+	var got []string
+	s := replyScreen(24, 80, &got)
+	s.SetCellPixels(9, 18)
+	s.Write([]byte("\x1b[14t"))
+	s.Write([]byte("\x1b[16t"))
+	want := []string{"\x1b[4;432;720t", "\x1b[6;18;9t"}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("replies = %q, want %q", got, want)
+	}
+
+	got = got[:0]
+	s.SetCellPixels(0, 0) // unknown again: nominal size
+	s.Write([]byte("\x1b[16t"))
+	if len(got) != 1 || got[0] != "\x1b[6;16;8t" {
+		t.Errorf("replies = %q, want nominal [\"\\x1b[6;16;8t\"]", got)
+	}
+}
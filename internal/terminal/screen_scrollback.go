@@ -0,0 +1,114 @@
+package terminal
+
+import "strings"
+
+// ---------------------------------------------------------------------------
+// Scrollback – rows scrolled off the top, kept for Export
+// ---------------------------------------------------------------------------
+
+// ScrollbackRows is how many rows scrolled off the top a Screen keeps,
+// matching what the frontend saves per pane. Older rows are dropped.
+const ScrollbackRows = 1000
+
+// historyRow is a row that left the screen. Trailing blanks are trimmed;
+// width is the screen width at the time, so a wrapped line can be padded
+// back when joined with the row that continues it.
+type historyRow struct {
+	cells   []Cell
+	width   int
+	wrapped bool // continues the row before it (see Screen.wrapped)
+}
+
+// pushScrollback stores a row scrolled off the top of the screen in the
+// scrollback ring. joinNext tells whether the row below continues it; the
+// screen clears that row's wrap flag once it is on top, so it is kept in
+// s.sbJoin instead. Caller holds s.mu.
+func (s *Screen) pushScrollback(row []Cell, joinNext bool) {
+	n := len(row)
+	for n > 0 && isBlankCell(row[n-1]) {
+		n--
+	}
+	h := historyRow{cells: append([]Cell(nil), row[:n]...), width: len(row), wrapped: s.sbJoin}
+	s.sbJoin = joinNext
+	if len(s.scrollback) < ScrollbackRows {
+		s.scrollback = append(s.scrollback, h)
+		return
+	}
+	s.scrollback[s.sbStart] = h
+	s.sbStart = (s.sbStart + 1) % ScrollbackRows
+}
+
+// clearScrollback drops all scrollback rows (ED 3, full reset). Caller
+// holds s.mu.
+func (s *Screen) clearScrollback() {
+	s.scrollback = nil
+	s.sbStart = 0
+	s.sbJoin = false
+}
+
+// Export returns the scrollback followed by the screen as text, one
+// logical line per line (soft-wrapped rows joined), so the output rewraps
+// in whatever it is pasted into. With ansi, colours and attributes are kept
+// as SGR sequences like Render. Trailing blank lines are dropped. Only the
+// last ScrollbackRows rows above the screen are included.
+func (s *Screen) Export(ansi bool) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rows := make([]historyRow, 0, len(s.scrollback)+s.rows)
+	for i := range s.scrollback {
+		rows = append(rows, s.scrollback[(s.sbStart+i)%len(s.scrollback)])
+	}
+	for r := 0; r < s.rows; r++ {
+		wrapped := s.wrapped[r] || r == 0 && s.sbJoin && len(s.scrollback) > 0
+		rows = append(rows, historyRow{cells: s.cells[r], width: s.cols, wrapped: wrapped})
+	}
+
+	var lines []string
+	var line []Cell
+	for i, row := range rows {
+		if i > 0 && !row.wrapped {
+			lines = append(lines, s.exportLine(line, ansi))
+			line = line[:0]
+		} else if i > 0 {
+			// Pad the row this one continues back to its full width
+			prev := rows[i-1]
+			for k := len(prev.cells); k < prev.width; k++ {
+				line = append(line, Cell{Char: ' '})
+			}
+		}
+		line = append(line, row.cells...)
+	}
+	lines = append(lines, s.exportLine(line, ansi))
+
+	end := len(lines)
+	for end > 0 && lines[end-1] == "" {
+		end--
+	}
+	return strings.Join(lines[:end], "\n")
+}
+
+// exportLine renders one logical line without trailing blanks.
+func (s *Screen) exportLine(cells []Cell, ansi bool) string {
+	n := len(cells)
+	for n > 0 && (cells[n-1].Char == ' ' || cells[n-1].Char == 0) && (!ansi || isBlankCell(cells[n-1])) {
+		n--
+	}
+	var b strings.Builder
+	prev := CellStyle{}
+	for _, c := range cells[:n] {
+		if st := s.shown(c.Style); ansi && st != prev {
+			b.WriteString(sgrSequence(st))
+			prev = st
+		}
+		ch := c.Char
+		if ch == 0 {
+			ch = ' '
+		}
+		b.WriteRune(ch)
+	}
+	if ansi && prev != (CellStyle{}) {
+		b.WriteString("\x1b[0m")
+	}
+	return b.String()
+}
@@ -0,0 +1,98 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// Scrollback and Export
+// ---------------------------------------------------------------------------
+
+func TestExport_IncludesScrolledOffRows(t *testing.T) {
+	s := NewScreen(3, 10)
+	s.Write([]byte("one\r\ntwo\r\nthree\r\nfour\r\nfive"))
+	want := "one\ntwo\nthree\nfour\nfive"
+	if got := s.Export(false); got != want {
+		t.Errorf("Export = %q, want %q", got, want)
+	}
+}
+
+func TestExport_JoinsWrappedLinesAcrossScrollback(t *testing.T) {
+	s := NewScreen(2, 4)
+	s.Write([]byte("abcd efgh ij\r\nnext"))
+	// "abcd", " efg", "h ij" wrapped; the first two rows scrolled off
+	want := "abcd efgh ij\nnext"
+	if got := s.Export(false); got != want {
+		t.Errorf("Export = %q, want %q", got, want)
+	}
+}
+
+func TestExport_KeepsSpaceAtWrapBoundary(t *testing.T) {
+	s := NewScreen(2, 4)
+	s.Write([]byte("abc def\r\nx\r\ny"))
+	if got := s.Export(false); !strings.HasPrefix(got, "abc def\n") {
+		t.Errorf("Export = %q, want the space before 'def' kept", got)
+	}
+}
+
+func TestExport_ANSI(t *testing.T) {
+	s := NewScreen(2, 10)
+	s.Write([]byte("\x1b[31mred\x1b[0m ok\r\nplain\r\nlast"))
+	got := s.Export(true)
+	lines := strings.Split(got, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Export(true) = %q, want 3 lines", got)
+	}
+	if lines[0] != "\x1b[0;31mred\x1b[0m ok" {
+		t.Errorf("line 0 = %q, want red 'red' then plain ' ok'", lines[0])
+	}
+	if lines[1] != "plain" {
+		t.Errorf("line 1 = %q, want unstyled 'plain'", lines[1])
+	}
+}
+
+func TestExport_ScrollbackIsBounded(t *testing.T) {
+	s := NewScreen(2, 8)
+	for i := 0; i < ScrollbackRows+50; i++ {
+		fmt.Fprintf(s, "l%d\r\n", i)
+	}
+	lines := strings.Split(s.Export(false), "\n")
+	if len(lines) != ScrollbackRows+1 {
+		t.Fatalf("got %d lines, want %d", len(lines), ScrollbackRows+1)
+	}
+	if lines[0] != "l49" {
+		t.Errorf("oldest line = %q, want l49", lines[0])
+	}
+}
+
+func TestExport_ClearedByED3AndReset(t *testing.T) {
+	s := NewScreen(2, 8)
+	s.Write([]byte("a\r\nb\r\nc\x1b[3J"))
+	if got := s.Export(false); got != "" {
+		t.Errorf("after ED 3, Export = %q, want empty", got)
+	}
+	s.Write([]byte("a\r\nb\r\nc"))
+	s.Reset()
+	if got := s.Export(false); got != "" {
+		t.Errorf("after Reset, Export = %q, want empty", got)
+	}
+}
+
+func TestExport_ScrollRegionBelowTopKeepsNoHistory(t *testing.T) {
+	s := NewScreen(3, 8)
+	s.Write([]byte("head\x1b[2;3r\x1b[2;1Hx\r\ny\r\nz"))
+	if got := s.Export(false); got != "head\ny\nz" {
+		t.Errorf("Export = %q, want rows scrolled inside the region discarded", got)
+	}
+}
+
+func TestReflow_OverflowMovesRowsToScrollback(t *testing.T) {
+	s := newReflowScreen(2, 8)
+	s.Write([]byte("abcdefgh\r\n$ "))
+	s.Resize(2, 4)
+	if got := s.Export(false); got != "abcdefgh\n$" {
+		t.Errorf("Export = %q, want the reflowed line kept whole", got)
+	}
+}
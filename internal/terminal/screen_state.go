@@ -0,0 +1,181 @@
+package terminal
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ---------------------------------------------------------------------------
+// Screen state – everything a Screen shows, for Session.ExportState
+// ---------------------------------------------------------------------------
+
+// maxStateSize bounds the rows and columns an imported state may claim.
+const maxStateSize = 2000
+
+// stateRun is a stretch of cells sharing one style: one cell per rune of
+// Text. Style is nil for the default style, which most cells have.
+type stateRun struct {
+	Text  string     `json:"t"`
+	Style *CellStyle `json:"s,omitempty"`
+}
+
+// stateRow is one screen or scrollback row as runs. Width is the screen
+// width of a scrollback row (its trailing blanks are trimmed).
+type stateRow struct {
+	Runs    []stateRun `json:"r,omitempty"`
+	Width   int        `json:"w,omitempty"`
+	Wrapped bool       `json:"wr,omitempty"`
+}
+
+// screenState is the serialised form of a Screen. The parser's position
+// inside an unfinished escape sequence or UTF-8 character is not kept:
+// an imported screen starts between sequences.
+type screenState struct {
+	Rows       int        `json:"rows"`
+	Cols       int        `json:"cols"`
+	Cells      []stateRow `json:"cells"`
+	CursorRow  int        `json:"cursor_row"`
+	CursorCol  int        `json:"cursor_col"`
+	Style      CellStyle  `json:"style"`
+	SavedRow   int        `json:"saved_row"`
+	SavedCol   int        `json:"saved_col"`
+	SavedStyle CellStyle  `json:"saved_style"`
+	ScrollTop  int        `json:"scroll_top"`
+	ScrollBot  int        `json:"scroll_bottom"`
+	Title      string     `json:"title,omitempty"`
+	Dir        string     `json:"dir,omitempty"`
+
+	FocusReporting bool  `json:"focus_reporting,omitempty"`
+	BracketedPaste bool  `json:"bracketed_paste,omitempty"`
+	KittyFlags     []int `json:"kitty_flags,omitempty"`
+
+	Progress    ProgressState `json:"progress,omitempty"`
+	ProgressPct int           `json:"progress_pct,omitempty"`
+
+	Scrollback []stateRow `json:"scrollback,omitempty"` // oldest first
+	SbJoin     bool       `json:"sb_join,omitempty"`
+
+	CmdMark  bool     `json:"cmd_mark,omitempty"`
+	CmdRow   int      `json:"cmd_row,omitempty"`
+	CmdCol   int      `json:"cmd_col,omitempty"`
+	Commands []string `json:"commands,omitempty"`
+}
+
+// encodeRow turns cells into runs of equal style.
+func encodeRow(cells []Cell) []stateRun {
+	var runs []stateRun
+	var text []rune
+	style := CellStyle{}
+	flush := func() {
+		if len(text) == 0 {
+			return
+		}
+		run := stateRun{Text: string(text)}
+		if style != (CellStyle{}) {
+			st := style
+			run.Style = &st
+		}
+		runs = append(runs, run)
+		text = text[:0]
+	}
+	for _, c := range cells {
+		if c.Style != style {
+			flush()
+			style = c.Style
+		}
+		text = append(text, c.Char)
+	}
+	flush()
+	return runs
+}
+
+// decodeRow turns runs back into cells.
+func decodeRow(runs []stateRun) []Cell {
+	var cells []Cell
+	for _, run := range runs {
+		style := CellStyle{}
+		if run.Style != nil {
+			style = *run.Style
+		}
+		for _, r := range run.Text {
+			cells = append(cells, Cell{Char: r, Style: style})
+		}
+	}
+	return cells
+}
+
+// snapshotState captures the screen as a screenState.
+func (s *Screen) snapshotState() screenState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := screenState{
+		Rows: s.rows, Cols: s.cols,
+		CursorRow: s.curRow, CursorCol: s.curCol, Style: s.style,
+		SavedRow: s.savedRow, SavedCol: s.savedCol, SavedStyle: s.savedStyle,
+		ScrollTop: s.scrollTop, ScrollBot: s.scrollBottom,
+		Title: s.Title, Dir: s.reportedDir,
+		FocusReporting: s.focusReporting, BracketedPaste: s.bracketedPaste,
+		KittyFlags: append([]int(nil), s.kittyFlags...),
+		Progress:   s.progress, ProgressPct: s.progressPct,
+		SbJoin:  s.sbJoin,
+		CmdMark: s.cmdMark, CmdRow: s.cmdRow, CmdCol: s.cmdCol,
+		Commands: append([]string(nil), s.commands...),
+	}
+	st.Cells = make([]stateRow, s.rows)
+	for r := range st.Cells {
+		st.Cells[r] = stateRow{Runs: encodeRow(s.cells[r]), Wrapped: s.wrapped[r]}
+	}
+	for i := range s.scrollback {
+		h := s.scrollback[(s.sbStart+i)%len(s.scrollback)]
+		st.Scrollback = append(st.Scrollback, stateRow{Runs: encodeRow(h.cells), Width: h.width, Wrapped: h.wrapped})
+	}
+	return st
+}
+
+// screenFromState rebuilds a Screen from st, rejecting states whose
+// dimensions, rows or cursor do not fit together.
+func screenFromState(st screenState) (*Screen, error) {
+	if st.Rows <= 0 || st.Cols <= 0 || st.Rows > maxStateSize || st.Cols > maxStateSize {
+		return nil, fmt.Errorf("invalid screen size %dx%d", st.Cols, st.Rows)
+	}
+	if len(st.Cells) != st.Rows {
+		return nil, fmt.Errorf("%d screen rows, want %d", len(st.Cells), st.Rows)
+	}
+	if len(st.Scrollback) > ScrollbackRows {
+		return nil, fmt.Errorf("%d scrollback rows, at most %d", len(st.Scrollback), ScrollbackRows)
+	}
+	inside := func(row, col int) bool {
+		return row >= 0 && row < st.Rows && col >= 0 && col <= st.Cols
+	}
+	if !inside(st.CursorRow, st.CursorCol) || !inside(st.SavedRow, st.SavedCol) || !inside(st.CmdRow, st.CmdCol) {
+		return nil, errors.New("cursor outside the screen")
+	}
+	if st.ScrollTop < 0 || st.ScrollBot < 0 || st.ScrollTop > st.Rows || st.ScrollBot > st.Rows {
+		return nil, errors.New("scroll region outside the screen")
+	}
+
+	s := NewScreen(st.Rows, st.Cols)
+	for r, row := range st.Cells {
+		cells := decodeRow(row.Runs)
+		if len(cells) != st.Cols {
+			return nil, fmt.Errorf("screen row %d has %d cells, want %d", r, len(cells), st.Cols)
+		}
+		s.cells[r] = cells
+		s.wrapped[r] = row.Wrapped
+	}
+	for _, row := range st.Scrollback {
+		s.scrollback = append(s.scrollback, historyRow{cells: decodeRow(row.Runs), width: row.Width, wrapped: row.Wrapped})
+	}
+	s.curRow, s.curCol, s.style = st.CursorRow, st.CursorCol, st.Style
+	s.savedRow, s.savedCol, s.savedStyle = st.SavedRow, st.SavedCol, st.SavedStyle
+	s.scrollTop, s.scrollBottom = st.ScrollTop, st.ScrollBot
+	s.Title, s.reportedDir = st.Title, st.Dir
+	s.focusReporting, s.bracketedPaste = st.FocusReporting, st.BracketedPaste
+	s.kittyFlags = st.KittyFlags
+	s.progress, s.progressPct = st.Progress, st.ProgressPct
+	s.sbJoin = st.SbJoin
+	s.cmdMark, s.cmdRow, s.cmdCol = st.CmdMark, st.CmdRow, st.CmdCol
+	s.commands = st.Commands
+	return s, nil
+}
@@ -102,28 +102,17 @@ func TestWrite_LineFeed(t *testing.T) {
 }
 
 func TestWrite_CarriageReturn(t *testing.T) {
-	s := NewScreen(3, 10)
-	s.Write([]byte("AAAA\rBB"))
-
+	h := NewScreenHarness(t, 3, 10)
 	// \r moves cursor to col 0, then BB overwrites first 2 chars
-	r0 := s.PlainTextRow(0)
-	if !strings.HasPrefix(r0, "BBAA") {
-		t.Errorf("Row 0 = %q, want prefix 'BBAA'", r0)
-	}
+	h.Feed("AAAA\rBB")
+	h.AssertRow(0, "BBAA")
+	h.AssertCursor(0, 2)
 }
 
 func TestWrite_CRLF(t *testing.T) {
-	s := NewScreen(5, 20)
-	s.Write([]byte("Line1\r\nLine2"))
-
-	r0 := s.PlainTextRow(0)
-	r1 := s.PlainTextRow(1)
-	if !strings.HasPrefix(r0, "Line1") {
-		t.Errorf("Row 0 = %q, want 'Line1'", r0)
-	}
-	if !strings.HasPrefix(r1, "Line2") {
-		t.Errorf("Row 1 = %q, want 'Line2'", r1)
-	}
+	h := NewScreenHarness(t, 5, 20)
+	h.Feed("Line1\r\nLine2")
+	h.AssertRows("Line1", "Line2")
 }
 
 // ---------------------------------------------------------------------------
@@ -131,14 +120,10 @@ func TestWrite_CRLF(t *testing.T) {
 // ---------------------------------------------------------------------------
 
 func TestWrite_Backspace(t *testing.T) {
-	s := NewScreen(3, 10)
-	s.Write([]byte("ABC\bX"))
-
+	h := NewScreenHarness(t, 3, 10)
 	// \b moves cursor back one, then X overwrites C
-	r0 := s.PlainTextRow(0)
-	if !strings.HasPrefix(r0, "ABX") {
-		t.Errorf("Row 0 after backspace = %q, want prefix 'ABX'", r0)
-	}
+	h.Feed("ABC\bX")
+	h.AssertRow(0, "ABX")
 }
 
 func TestWrite_BackspaceAtCol0(t *testing.T) {
@@ -176,17 +161,9 @@ func TestWrite_TabClampsToEdge(t *testing.T) {
 // ---------------------------------------------------------------------------
 
 func TestWrite_LineWrap(t *testing.T) {
-	s := NewScreen(5, 5)
-	s.Write([]byte("ABCDEFGH"))
-
-	r0 := s.PlainTextRow(0)
-	r1 := s.PlainTextRow(1)
-	if r0 != "ABCDE" {
-		t.Errorf("Row 0 = %q, want 'ABCDE'", r0)
-	}
-	if !strings.HasPrefix(r1, "FGH") {
-		t.Errorf("Row 1 = %q, want prefix 'FGH'", r1)
-	}
+	h := NewScreenHarness(t, 5, 5)
+	h.Feed("ABCDEFGH")
+	h.AssertRows("ABCDE", "FGH")
 }
 
 // ---------------------------------------------------------------------------
@@ -335,6 +312,35 @@ func TestOSC_IgnoresUnknown(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// C1 controls: 8-bit CSI (0x9B), OSC (0x9D) and ST (0x9C)
+// ---------------------------------------------------------------------------
+
+func TestC1_CSI(t *testing.T) {
+	h := NewScreenHarness(t, 5, 20)
+	h.Feed("\x9b3;5HX\x9b31mR")
+	h.AssertRow(2, "    XR")
+	if fg := h.Screen.CellAt(2, 5).Style.FG; fg != 2 {
+		t.Errorf("FG = %d, want red R", fg)
+	}
+}
+
+func TestC1_OSCWithST(t *testing.T) {
+	h := NewScreenHarness(t, 5, 20)
+	h.Feed("\x9d0;C1 title\x9cok")
+	h.AssertTitle("C1 title")
+	h.AssertRow(0, "ok")
+}
+
+func TestC1_ContinuationBytesAreNotControls(t *testing.T) {
+	h := NewScreenHarness(t, 5, 20)
+	// "Ŝ" is C5 9C and "ě" is C4 9B: the second bytes must not act as
+	// ST or CSI
+	h.Feed("\x1b]0;\xc5\x9c\x07\xc4\x9b3")
+	h.AssertTitle("Ŝ")
+	h.AssertRow(0, "ě3")
+}
+
 // ---------------------------------------------------------------------------
 // Write implements io.Writer
 // ---------------------------------------------------------------------------
@@ -0,0 +1,101 @@
+package terminal
+
+import "strings"
+
+// ---------------------------------------------------------------------------
+// Soft-wrap tracking – which rows continue the row above after an auto-wrap
+// ---------------------------------------------------------------------------
+
+// setWrapped records whether row r was reached by auto-wrapping (true) or
+// by an explicit line feed (false).
+func (s *Screen) setWrapped(r int, on bool) {
+	if r >= 0 && r < len(s.wrapped) {
+		s.wrapped[r] = on
+	}
+}
+
+// clearWrapped marks rows from..to (inclusive) as starting a new line.
+func (s *Screen) clearWrapped(from, to int) {
+	if from <= 0 {
+		s.sbJoin = false
+	}
+	for r := max(from, 0); r <= to && r < len(s.wrapped); r++ {
+		s.wrapped[r] = false
+	}
+}
+
+// shiftWrappedUp moves the flags of rows top+1..bottom up by one, as
+// scrollUp and deleteLines do with the rows themselves.
+func (s *Screen) shiftWrappedUp(top, bottom int) {
+	if top < 0 || bottom >= len(s.wrapped) || top > bottom {
+		return
+	}
+	copy(s.wrapped[top:bottom], s.wrapped[top+1:bottom+1])
+	s.wrapped[bottom] = false
+	// The row now at the top no longer sits below the row it continued
+	s.wrapped[top] = false
+}
+
+// shiftWrappedDown moves the flags of rows top..bottom-1 down by one, as
+// scrollDown and insertLines do with the rows themselves.
+func (s *Screen) shiftWrappedDown(top, bottom int) {
+	if top < 0 || bottom >= len(s.wrapped) || top > bottom {
+		return
+	}
+	copy(s.wrapped[top+1:bottom+1], s.wrapped[top:bottom])
+	s.wrapped[top] = false
+	if top == 0 {
+		s.sbJoin = false
+	}
+	if top+1 <= bottom {
+		// The pushed-down row no longer sits below the row it continued
+		s.wrapped[top+1] = false
+	}
+}
+
+// logicalLines returns rows [startRow, endRow) as plain text with
+// soft-wrapped rows joined to the line they continue. startRow is moved up
+// to the first row of the logical line it belongs to. Caller holds s.mu.
+func (s *Screen) logicalLines(startRow, endRow int) []string {
+	startRow = max(startRow, 0)
+	endRow = min(endRow, s.rows)
+	for startRow > 0 && startRow < endRow && s.wrapped[startRow] {
+		startRow--
+	}
+	var lines []string
+	var b strings.Builder
+	for r := startRow; r < endRow; r++ {
+		if r > startRow && !s.wrapped[r] {
+			lines = append(lines, strings.TrimRight(b.String(), " "))
+			b.Reset()
+		}
+		for _, c := range s.cells[r] {
+			ch := c.Char
+			if ch == 0 {
+				ch = ' '
+			}
+			b.WriteRune(ch)
+		}
+	}
+	if endRow > startRow {
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+	}
+	return lines
+}
+
+// PlainTextLogical returns the screen as plain text like PlainText, but
+// rows that auto-wrapped are joined into one line, so a long command or
+// cost line reads as written. Trailing spaces of each line are trimmed.
+func (s *Screen) PlainTextLogical() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return strings.Join(s.logicalLines(0, s.rows), "\n")
+}
+
+// PlainTextLogicalRows is PlainTextRows with soft-wrapped rows joined (see
+// PlainTextLogical). A line that starts above startRow is returned whole.
+func (s *Screen) PlainTextLogicalRows(startRow, endRow int) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.logicalLines(startRow, endRow)
+}
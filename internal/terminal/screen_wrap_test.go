@@ -0,0 +1,96 @@
+package terminal
+
+import (
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// PlainTextLogical – soft-wrapped rows read back as one line
+// ---------------------------------------------------------------------------
+
+func TestPlainTextLogical_JoinsAutoWrappedRows(t *testing.T) {
+	s := NewScreen(4, 8)
+	s.Write([]byte("abcdefghijkl\r\nnext"))
+
+	if got := s.PlainText(); !strings.HasPrefix(got, "abcdefgh\nijkl") {
+		t.Fatalf("PlainText = %q, want hard rows", got)
+	}
+	want := "abcdefghijkl\nnext\n"
+	if got := s.PlainTextLogical(); got != want {
+		t.Errorf("PlainTextLogical = %q, want %q", got, want)
+	}
+}
+
+func TestPlainTextLogical_ExplicitNewlineAtWidthIsNotJoined(t *testing.T) {
+	s := NewScreen(3, 4)
+	s.Write([]byte("abcd\r\nefgh"))
+	if got := s.PlainTextLogical(); got != "abcd\nefgh\n" {
+		t.Errorf("PlainTextLogical = %q, want two separate lines", got)
+	}
+}
+
+func TestPlainTextLogical_WrapSurvivesScroll(t *testing.T) {
+	s := NewScreen(3, 5)
+	s.Write([]byte("one\r\ntwo\r\n0123456789xy"))
+	// "0123456789xy" needs three rows; the screen scrolled twice
+	if got := s.PlainTextLogical(); got != "0123456789xy" {
+		t.Errorf("PlainTextLogical = %q, want the wrapped line only", got)
+	}
+}
+
+func TestPlainTextLogical_EraseDisplayClearsWraps(t *testing.T) {
+	s := NewScreen(3, 4)
+	s.Write([]byte("abcdefgh\x1b[2J\x1b[Hxy\x1b[2;1Hzz"))
+	if got := s.PlainTextLogical(); got != "xy\nzz\n" {
+		t.Errorf("PlainTextLogical = %q, want unjoined rows after ED 2", got)
+	}
+}
+
+func TestPlainTextLogical_ResizeWidthDropsWraps(t *testing.T) {
+	s := NewScreen(3, 4)
+	s.Write([]byte("abcdef"))
+	s.Resize(3, 10)
+	if got := s.PlainTextLogicalRows(0, 2); len(got) != 2 {
+		t.Errorf("rows after width change = %q, want 2 separate lines", got)
+	}
+}
+
+func TestPlainTextLogicalRows_ExtendsToLineStart(t *testing.T) {
+	s := NewScreen(4, 5)
+	s.Write([]byte("hello world!"))
+	got := s.PlainTextLogicalRows(2, 3)
+	if len(got) != 1 || got[0] != "hello world!" {
+		t.Errorf("PlainTextLogicalRows(2,3) = %q, want the whole line", got)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// ScanTokens – cost and token lines that wrap on a narrow pane
+// ---------------------------------------------------------------------------
+
+func TestScanTokens_WrappedCostLine(t *testing.T) {
+	sess := NewSession(1, 6, 14)
+	// "$1.23" is split across rows 0 and 1 at width 14
+	sess.Screen.Write([]byte("Total cost: $1.23 today"))
+	if row := sess.Screen.PlainTextRow(0); row != "Total cost: $1" {
+		t.Fatalf("row 0 = %q, test needs the cost split across rows", row)
+	}
+
+	sess.ScanTokens()
+	if got := sess.GetTokens().TotalCost; got != 1.23 {
+		t.Errorf("TotalCost = %v, want 1.23", got)
+	}
+}
+
+func TestScanTokens_WrappedTokenCount(t *testing.T) {
+	sess := NewSession(1, 6, 10)
+	// "3.8k" is split across rows 1 and 2 at width 10
+	sess.Screen.Write([]byte("used 15.2k input, 3.8k output"))
+
+	sess.ScanTokens()
+	tok := sess.GetTokens()
+	if tok.InputTokens != 15200 || tok.OutputTokens != 3800 {
+		t.Errorf("tokens = %+v, want 15200 in / 3800 out", tok)
+	}
+}
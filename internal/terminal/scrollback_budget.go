@@ -0,0 +1,126 @@
+package terminal
+
+import (
+	"slices"
+	"sort"
+	"time"
+	"unsafe"
+)
+
+// ---------------------------------------------------------------------------
+// Scrollback budget – one memory cap for the scrollback of all sessions
+// ---------------------------------------------------------------------------
+
+// Approximate memory of a scrollback row: the row itself plus its cells.
+var (
+	historyRowBytes = int(unsafe.Sizeof(historyRow{}))
+	cellBytes       = int(unsafe.Sizeof(Cell{}))
+)
+
+// ScrollbackUsage is the approximate memory the scrollback of a set of
+// sessions takes.
+type ScrollbackUsage struct {
+	Rows    int // scrollback rows kept
+	Bytes   int // their approximate memory
+	Evicted int // rows EnforceScrollbackBudget dropped to get there
+}
+
+// ScrollbackUsage returns how many scrollback rows the screen keeps and
+// their approximate memory.
+func (s *Screen) ScrollbackUsage() (rows, bytes int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, h := range s.scrollback {
+		bytes += historyRowBytes + cap(h.cells)*cellBytes
+	}
+	return len(s.scrollback), bytes
+}
+
+// dropOldestScrollback drops the oldest scrollback rows until at least
+// bytes are freed or none are left, and returns what it freed. The visible
+// screen is never touched. The ring is compacted in place, so nothing is
+// allocated under the lock.
+func (s *Screen) dropOldestScrollback(bytes int) (rows, freed int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sb := s.scrollback
+	n := len(sb)
+	for rows < n && freed < bytes {
+		h := sb[(s.sbStart+rows)%n]
+		freed += historyRowBytes + cap(h.cells)*cellBytes
+		rows++
+	}
+	if rows == 0 {
+		return 0, 0
+	}
+	// Rotate the oldest row to index 0, then shift the kept rows down and
+	// release the dropped rows' cells
+	slices.Reverse(sb[:s.sbStart])
+	slices.Reverse(sb[s.sbStart:])
+	slices.Reverse(sb)
+	copy(sb, sb[rows:])
+	clear(sb[n-rows:])
+	s.scrollback, s.sbStart = sb[:n-rows], 0
+	if n == rows {
+		s.sbJoin = false
+	}
+	return rows, freed
+}
+
+// MeasureScrollback returns the scrollback rows of sessions and their
+// approximate memory. Mirrors share their source's screen and are skipped.
+func MeasureScrollback(sessions []*Session) ScrollbackUsage {
+	var usage ScrollbackUsage
+	for _, sess := range sessions {
+		if sess.IsMirror() {
+			continue
+		}
+		rows, bytes := sess.Screen.ScrollbackUsage()
+		usage.Rows += rows
+		usage.Bytes += bytes
+	}
+	return usage
+}
+
+// EnforceScrollbackBudget measures the scrollback of sessions and, while it
+// takes more than budget bytes, drops the oldest rows of the least recently
+// active session (by output or input), then of the next one. Only rows that
+// scrolled off the screen are dropped; what the panes show stays. Mirrors
+// share their source's screen and are skipped. A budget <= 0 only measures
+// (see MeasureScrollback).
+func EnforceScrollbackBudget(sessions []*Session, budget int) ScrollbackUsage {
+	type entry struct {
+		sess   *Session
+		active time.Time
+		bytes  int
+	}
+	var usage ScrollbackUsage
+	entries := make([]entry, 0, len(sessions))
+	for _, sess := range sessions {
+		if sess.IsMirror() {
+			continue
+		}
+		rows, bytes := sess.Screen.ScrollbackUsage()
+		usage.Rows += rows
+		usage.Bytes += bytes
+		entries = append(entries, entry{sess, sess.lastActive(), bytes})
+	}
+	if budget <= 0 || usage.Bytes <= budget {
+		return usage
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].active.Before(entries[j].active) })
+	for _, e := range entries {
+		if usage.Bytes <= budget {
+			break
+		}
+		if e.bytes == 0 {
+			continue
+		}
+		rows, freed := e.sess.Screen.dropOldestScrollback(usage.Bytes - budget)
+		usage.Rows -= rows
+		usage.Bytes -= freed
+		usage.Evicted += rows
+	}
+	return usage
+}
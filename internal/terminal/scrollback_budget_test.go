@@ -0,0 +1,107 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// historySession returns a session whose screen scrolled lines lines off
+// the top, last active at active.
+func historySession(id int, name string, lines int, active time.Time) *Session {
+	s := NewSession(id, 3, 12)
+	for i := 0; i < lines; i++ {
+		fmt.Fprintf(s.Screen, "%s-%d\r\n", name, i)
+	}
+	s.LastOutputAt = active
+	return s
+}
+
+func TestEnforceScrollbackBudget_EvictsLeastRecentlyActiveFirst(t *testing.T) {
+	now := time.Now()
+	oldest := historySession(1, "a", 40, now.Add(-time.Hour))
+	middle := historySession(2, "b", 40, now.Add(-time.Minute))
+	newest := historySession(3, "c", 40, now)
+	sessions := []*Session{newest, oldest, middle}
+	screens := map[*Session]string{}
+	for _, s := range sessions {
+		screens[s] = s.Screen.PlainText()
+	}
+
+	before := EnforceScrollbackBudget(sessions, 0)
+	_, bytesA := oldest.Screen.ScrollbackUsage()
+	_, bytesB := middle.Screen.ScrollbackUsage()
+	budget := before.Bytes - bytesA - bytesB/2
+	after := EnforceScrollbackBudget(sessions, budget)
+
+	if after.Bytes > budget || after.Evicted == 0 {
+		t.Fatalf("usage after = %+v, want at most %d bytes", after, budget)
+	}
+	if rows, _ := oldest.Screen.ScrollbackUsage(); rows != 0 {
+		t.Errorf("least recently active session kept %d rows, want none", rows)
+	}
+	rowsB, _ := middle.Screen.ScrollbackUsage()
+	if rowsB == 0 || rowsB >= 38 {
+		t.Errorf("middle session kept %d rows, want part of its 38", rowsB)
+	}
+	if text := middle.Screen.Export(false); strings.Contains(text, "b-0\n") || !strings.Contains(text, "b-37") {
+		t.Errorf("middle session lost the wrong rows:\n%s", text)
+	}
+	if rows, _ := newest.Screen.ScrollbackUsage(); rows != 38 {
+		t.Errorf("most recently active session kept %d rows, want all 38", rows)
+	}
+	for _, s := range sessions {
+		if got := s.Screen.PlainText(); got != screens[s] {
+			t.Errorf("session %d screen changed: %q, want %q", s.ID, got, screens[s])
+		}
+	}
+	if after.Rows != before.Rows-after.Evicted {
+		t.Errorf("rows %d after evicting %d of %d", after.Rows, after.Evicted, before.Rows)
+	}
+}
+
+func TestEnforceScrollbackBudget_ScreensAreNeverEvicted(t *testing.T) {
+	s := historySession(1, "x", 10, time.Now())
+	screen := s.Screen.PlainText()
+
+	usage := EnforceScrollbackBudget([]*Session{s}, 1)
+	if usage.Rows != 0 || usage.Bytes != 0 || usage.Evicted != 8 {
+		t.Errorf("usage = %+v, want all 8 scrollback rows evicted", usage)
+	}
+	if got := s.Screen.PlainText(); got != screen {
+		t.Errorf("screen = %q, want %q", got, screen)
+	}
+	// The trimmed scrollback keeps working as a ring
+	fmt.Fprint(s.Screen, "more\r\n")
+	if rows, _ := s.Screen.ScrollbackUsage(); rows != 1 {
+		t.Errorf("%d scrollback rows after one more line, want 1", rows)
+	}
+}
+
+func TestDropOldestScrollback_WrappedRing(t *testing.T) {
+	s := historySession(1, "w", ScrollbackRows+52, time.Now())
+	rows, freed := s.Screen.dropOldestScrollback(1)
+	if rows != 1 || freed == 0 {
+		t.Fatalf("dropped %d rows (%d bytes), want 1", rows, freed)
+	}
+	text := s.Screen.Export(false)
+	// The full ring held w-50 … w-1049; w-50 was dropped
+	if !strings.HasPrefix(text, "w-51\n") || !strings.Contains(text, "w-1049\nw-1050\n") {
+		t.Errorf("scrollback after dropping the oldest row starts %q", text[:20])
+	}
+	if n, _ := s.Screen.ScrollbackUsage(); n != ScrollbackRows-1 {
+		t.Errorf("%d rows kept, want %d", n, ScrollbackRows-1)
+	}
+}
+
+func TestMeasureScrollback(t *testing.T) {
+	a := historySession(1, "a", 10, time.Now())
+	b := historySession(2, "b", 5, time.Now())
+	usage := MeasureScrollback([]*Session{a, b})
+	_, bytesA := a.Screen.ScrollbackUsage()
+	_, bytesB := b.Screen.ScrollbackUsage()
+	if usage.Rows != 8+3 || usage.Bytes != bytesA+bytesB || usage.Evicted != 0 {
+		t.Errorf("usage = %+v", usage)
+	}
+}
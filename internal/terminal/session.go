@@ -8,8 +8,8 @@ package terminal
 import (
 	"io"
 	"os"
+	"regexp"
 	"runtime"
-	"strings"
 	"sync"
 	"time"
 
@@ -33,54 +33,117 @@ type Session struct {
 	ID     int           // unique session identifier
 	Screen *Screen       // VT100 virtual screen buffer
 	Status SessionStatus // current lifecycle status
-	Title  string        // derived from OSC or user-set
+	Title  string        // last title reported via OSC 0/2
+
+	manualName string // user-chosen pane name; overrides Title when set
+	readOnly   bool   // user lock against typed input (SetReadOnly)
+
+	highlight *regexp.Regexp // output highlight pattern (SetHighlight), nil = none
 
 	p   gopty.Pty  // cross-platform PTY (Unix PTY or Windows ConPTY)
 	cmd *gopty.Cmd // the spawned child process
 
-	done chan struct{}
+	done     chan struct{} // closed when the process exits
+	readDone chan struct{} // closed when readLoop returns
+	failed   chan struct{} // closed on StatusError (see readEnded)
 
-	// OutputCh receives a signal each time new data is written to Screen.
-	OutputCh chan struct{}
+	// Launch parameters, kept so an exited session can be restarted.
+	argv       []string
+	dir        string
+	env        []string
+	restarting bool
+
+	// resizeMu keeps a resize of Screen and PTY from interleaving with
+	// parsing a chunk of output (see writeScreen and Resize).
+	resizeMu sync.Mutex
 
-	// RawOutputCh carries raw PTY output bytes for the GUI frontend (xterm.js).
-	// Each message is a copy of the bytes read from the PTY.
-	RawOutputCh chan []byte
+	cwd   string // cached result of CurrentDir
+	cwdAt time.Time
+	usage usageState // latest SampleUsage result
+
+	// OutputCh receives a signal each time new data is written to Screen,
+	// at most once per SetOutputThrottle interval.
+	OutputCh chan struct{}
+	signal   outputSignal
 
 	// ExitCode is set when the process terminates.
 	ExitCode int
 
+	// ExitReason tells how the process ended; ExitSignal names the signal
+	// for Signaled (e.g. "SIGSEGV").
+	ExitReason ExitReason
+	ExitSignal string
+	closing    bool   // Close was called; the exit is reported as Killed
+	LastError  string // why the session is in StatusError, or Wait failed
+
+	// StartedAt records when Start (or Restart) launched the process.
+	StartedAt time.Time
+
 	// LastOutputAt records when the last PTY output was received.
 	LastOutputAt time.Time
 
+	// LastInputAt records when input was last written to the PTY (Write)
+	// or the session was kept open (KeepAlive).
+	LastInputAt time.Time
+
 	// Activity tracks the current activity state for Claude panes.
 	Activity ActivityState
 
 	// Tokens holds parsed token usage / cost information.
-	Tokens TokenInfo
+	Tokens     TokenInfo
+	LastResult CommandResult // outcome of the last finished command (ScanResult)
+
+	// Screen generations seen by the last ScanTokens and classification,
+	// so polling an unchanged screen does not walk its rows again.
+	tokensGen      uint64
+	classifiedGen  uint64
+	classified     ActivityState
+	classifiedLine string       // line that decided classified (see ActivityLine)
+	menus          MenuPatterns // approval menu patterns; nil = DefaultMenuPatterns
+
+	// Output subscriptions (see Subscribe) and read-only mirrors of this
+	// session (see Mirror), guarded by mirrorMu (a mirror's by its source's).
+	mirrorMu   sync.Mutex
+	subs       []*subscriber
+	mirrors    []*Session
+	streaming  bool      // readLoop is running (on a mirror: attached to it)
+	outputSeen bool      // readLoop has written output to Screen
+	source     *Session  // set on a mirror: the session it shows
+	doneOnce   sync.Once // a mirror's done is closed by Close or the source
 }
 
 // NewSession creates a Session with the given screen dimensions but does not
 // start any process yet. Call Start to spawn the shell.
 func NewSession(id, rows, cols int) *Session {
-	return &Session{
-		ID:          id,
-		Screen:      NewScreen(rows, cols),
-		Status:      StatusRunning,
-		OutputCh:    make(chan struct{}, 1),
-		RawOutputCh: make(chan []byte, 256),
-		done:        make(chan struct{}),
+	s := &Session{
+		ID:       id,
+		Screen:   NewScreen(rows, cols),
+		Status:   StatusRunning,
+		OutputCh: make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		readDone: make(chan struct{}),
+		failed:   make(chan struct{}),
 	}
+	s.signal.ch = s.OutputCh
+	// The screen answers queries like CSI 6n as soon as it parses them;
+	// the replies go straight back to the process.
+	s.Screen.SetResponder(func(reply []byte) { _, _ = s.Write(reply) })
+	return s
 }
 
 // Start launches the given command inside a new PTY.
 // argv is the command + arguments (e.g. []string{"bash"} or
 // []string{"claude", "--dangerously-skip-permissions"}).
-// dir is the working directory; env holds additional environment variables.
+// dir is the working directory; env holds additional "KEY=value" variables,
+// which override inherited ones and the TERM defaults.
 func (s *Session) Start(argv []string, dir string, env []string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	s.argv = append([]string(nil), argv...)
+	s.dir = dir
+	s.env = append([]string(nil), env...)
+
 	if len(argv) == 0 {
 		argv = defaultShell()
 	} else if runtime.GOOS == "windows" {
@@ -94,20 +157,7 @@ func (s *Session) Start(argv []string, dir string, env []string) error {
 		argv = append([]string{shell, "/c"}, argv...)
 	}
 
-	// Build environment: inherit from parent but strip variables that would
-	// prevent Claude Code from launching inside our terminal panes.
-	parentEnv := os.Environ()
-	fullEnv := make([]string, 0, len(parentEnv)+len(env)+2)
-	for _, e := range parentEnv {
-		// CLAUDECODE is set by Claude Code sessions; remove it so nested
-		// Claude instances don't refuse to start.
-		if strings.HasPrefix(e, "CLAUDECODE=") {
-			continue
-		}
-		fullEnv = append(fullEnv, e)
-	}
-	fullEnv = append(fullEnv, "TERM=xterm-256color", "COLORTERM=truecolor")
-	fullEnv = append(fullEnv, env...)
+	fullEnv := buildEnv(os.Environ(), env)
 
 	rows := s.Screen.Rows()
 	cols := s.Screen.Cols()
@@ -140,69 +190,49 @@ func (s *Session) Start(argv []string, dir string, env []string) error {
 
 	s.p = p
 	s.cmd = cmd
+	s.StartedAt = time.Now()
 
-	go s.readLoop()
-	go s.waitLoop()
+	s.setStreaming(true)
+	go s.readLoop(p, s.done, s.readDone)
+	go s.waitLoop(cmd, s.done)
 
 	return nil
 }
 
 // readLoop continuously reads from the PTY and writes to the Screen.
-func (s *Session) readLoop() {
+// The channels are passed in so a restarted session never mixes the
+// goroutines of its previous process with the new ones.
+func (s *Session) readLoop(p io.Reader, done <-chan struct{}, readDone chan<- struct{}) {
+	defer close(readDone)
 	buf := make([]byte, 65536)
 	for {
-		n, err := s.p.Read(buf)
+		n, err := p.Read(buf)
 		if n > 0 {
 			chunk := make([]byte, n)
 			copy(chunk, buf[:n])
 
-			s.Screen.Write(chunk)
+			s.writeScreen(chunk)
 
 			// Update title and timestamps
+			now := time.Now()
 			s.mu.Lock()
 			if s.Screen.Title != "" {
 				s.Title = s.Screen.Title
 			}
-			s.LastOutputAt = time.Now()
+			s.LastOutputAt = now
 			s.Activity = ActivityActive
 			s.mu.Unlock()
 
-			// Send raw bytes to GUI frontend (blocking with done-guard)
-			select {
-			case s.RawOutputCh <- chunk:
-			case <-s.done:
-			}
-
-			// Signal for legacy TUI consumers (non-blocking)
-			select {
-			case s.OutputCh <- struct{}{}:
-			default:
-			}
+			// Signal for legacy TUI consumers (non-blocking, throttled)
+			s.signal.notify(now)
 		}
 		if err != nil {
+			s.readEnded(err, done)
 			break
 		}
 	}
-	// Sender closes the channel so receivers (streamOutput) detect completion.
-	close(s.RawOutputCh)
-}
-
-// waitLoop waits for the process to exit and updates the session status.
-func (s *Session) waitLoop() {
-	err := s.cmd.Wait()
-	s.mu.Lock()
-	if err != nil {
-		if s.cmd.ProcessState != nil {
-			s.ExitCode = s.cmd.ProcessState.ExitCode()
-		} else {
-			s.ExitCode = 1
-		}
-	} else {
-		s.ExitCode = 0
-	}
-	s.Status = StatusExited
-	s.mu.Unlock()
-	close(s.done)
+	// Close the subscriptions so consumers (streamOutput) detect completion
+	s.endOutput(done)
 }
 
 // Write sends raw bytes to the PTY (i.e. keyboard input from the user).
@@ -210,8 +240,14 @@ func (s *Session) waitLoop() {
 // buffer (especially on Windows ConPTY). Partial writes are retried until
 // all bytes have been delivered.
 func (s *Session) Write(p []byte) (int, error) {
+	if s.source != nil {
+		return 0, ErrReadOnly
+	}
 	s.mu.Lock()
 	pty := s.p
+	if pty != nil {
+		s.LastInputAt = time.Now()
+	}
 	s.mu.Unlock()
 	if pty == nil {
 		return 0, io.ErrClosedPipe
@@ -244,54 +280,15 @@ func (s *Session) Write(p []byte) (int, error) {
 	return total, nil
 }
 
-// Resize updates the PTY and Screen dimensions.
+// Resize updates the PTY and Screen dimensions. No output is parsed in
+// between, so a chunk never starts at one size and ends at the other, and
+// the program's redraw after SIGWINCH lands on a screen of its new size.
 func (s *Session) Resize(rows, cols int) {
-	s.Screen.Resize(rows, cols)
-	s.mu.Lock()
-	pty := s.p
-	s.mu.Unlock()
-	if pty != nil {
-		// go-pty uses (width, height) = (cols, rows)
-		_ = pty.Resize(cols, rows)
-	}
-}
-
-// Close terminates the session: kills the process and closes the PTY.
-func (s *Session) Close() {
-	s.mu.Lock()
-	cmd := s.cmd
-	pty := s.p
-	s.mu.Unlock()
-
-	// Kill the process first
-	if cmd != nil && cmd.Process != nil {
-		_ = cmd.Process.Kill()
-	}
-	// Close the PTY (also kills on Windows via ConPTY)
-	if pty != nil {
-		pty.Close()
+	if s.source != nil {
+		return // the shared screen follows the source's size
 	}
-
-	// Wait for the process to actually finish
-	<-s.done
-}
-
-// Done returns a channel that is closed when the session exits.
-func (s *Session) Done() <-chan struct{} {
-	return s.done
-}
-
-// IsRunning reports whether the process is still alive.
-func (s *Session) IsRunning() bool {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	return s.Status == StatusRunning
-}
-
-// GetTokens returns a snapshot of the token/cost info.
-func (s *Session) GetTokens() TokenInfo {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	return s.Tokens
+	s.resizeMu.Lock()
+	defer s.resizeMu.Unlock()
+	s.Screen.Resize(rows, cols)
+	s.resizePTY(rows, cols)
 }
-
@@ -0,0 +1,63 @@
+package terminal
+
+import (
+	"errors"
+	"time"
+)
+
+// errNoGracefulStop is returned by terminateProcess on platforms where the
+// process cannot be asked to exit (Windows: ConPTY close is the signal).
+var errNoGracefulStop = errors.New("graceful stop not supported")
+
+// Close terminates the session: kills the process and closes the PTY.
+// Closing a mirror only detaches it; the source keeps running.
+func (s *Session) Close() {
+	if s.source != nil {
+		s.source.detachMirror(s)
+		return
+	}
+	s.mu.Lock()
+	s.closing = true
+	cmd := s.cmd
+	pty := s.p
+	done := s.done
+	s.mu.Unlock()
+
+	// Kill the process first
+	if cmd != nil && cmd.Process != nil {
+		_ = cmd.Process.Kill()
+	}
+	// Close the PTY (also kills on Windows via ConPTY)
+	if pty != nil {
+		pty.Close()
+	}
+
+	// Wait for the process to actually finish
+	<-done
+}
+
+// CloseGraceful asks the process to exit so shells and Claude can save
+// history and flush files, and waits up to timeout for it to do so. If the
+// process is still running after timeout (or cannot be signalled), it falls
+// back to Close, which kills it.
+func (s *Session) CloseGraceful(timeout time.Duration) {
+	if s.source != nil {
+		s.source.detachMirror(s)
+		return
+	}
+	s.mu.Lock()
+	s.closing = true
+	cmd := s.cmd
+	done := s.done
+	s.mu.Unlock()
+
+	if cmd != nil && cmd.Process != nil {
+		if err := terminateProcess(cmd.Process); err == nil {
+			select {
+			case <-done:
+			case <-time.After(timeout):
+			}
+		}
+	}
+	s.Close()
+}
@@ -0,0 +1,45 @@
+package terminal
+
+import "time"
+
+// ReportedDir returns the working directory the shell last reported via
+// OSC 7. It works on every platform but only for shells configured to emit
+// it; "" means no report has been seen.
+func (s *Session) ReportedDir() string {
+	return s.Screen.ReportedDir()
+}
+
+// cwdCacheTTL bounds how often CurrentDir queries the OS; lookups on macOS
+// spawn lsof, and the frontend polls every focused pane.
+const cwdCacheTTL = time.Second
+
+// CurrentDir returns the working directory of the session's process, which
+// follows `cd` in a shell. It falls back to the directory the session was
+// started in when the lookup is not supported (Windows) or fails, e.g.
+// because the process has exited.
+func (s *Session) CurrentDir() string {
+	s.mu.Lock()
+	if s.cwd != "" && time.Since(s.cwdAt) < cwdCacheTTL {
+		cwd := s.cwd
+		s.mu.Unlock()
+		return cwd
+	}
+	pid := 0
+	if s.cmd != nil && s.cmd.Process != nil && s.Status == StatusRunning {
+		pid = s.cmd.Process.Pid
+	}
+	dir := s.dir
+	s.mu.Unlock()
+
+	if pid > 0 {
+		if cwd, err := processCwd(pid); err == nil && cwd != "" {
+			dir = cwd
+		}
+	}
+
+	s.mu.Lock()
+	s.cwd = dir
+	s.cwdAt = time.Now()
+	s.mu.Unlock()
+	return dir
+}
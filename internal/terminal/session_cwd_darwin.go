@@ -0,0 +1,23 @@
+package terminal
+
+import (
+	"errors"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// processCwd asks lsof for the working directory of pid. With -Fn, lsof
+// prints one field per line; the path is on the line prefixed with "n".
+func processCwd(pid int) (string, error) {
+	out, err := exec.Command("lsof", "-a", "-p", strconv.Itoa(pid), "-d", "cwd", "-Fn").Output()
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(line, "n") {
+			return line[1:], nil
+		}
+	}
+	return "", errors.New("lsof: no cwd for pid " + strconv.Itoa(pid))
+}
@@ -0,0 +1,11 @@
+package terminal
+
+import (
+	"os"
+	"strconv"
+)
+
+// processCwd reads the working directory of pid from /proc.
+func processCwd(pid int) (string, error) {
+	return os.Readlink("/proc/" + strconv.Itoa(pid) + "/cwd")
+}
@@ -0,0 +1,11 @@
+//go:build !linux && !darwin
+
+package terminal
+
+import "errors"
+
+// processCwd is not supported on this platform; CurrentDir falls back to
+// the session's start directory.
+func processCwd(_ int) (string, error) {
+	return "", errors.New("process cwd lookup not supported")
+}
@@ -0,0 +1,42 @@
+package terminal
+
+import (
+	"runtime"
+	"strings"
+)
+
+// buildEnv returns the environment for a session process: parent without
+// variables that would break nested Claude sessions, the terminal defaults,
+// then extra. A later entry replaces an earlier one with the same key, so
+// extra (per-pane overrides) wins over both parent and TERM/COLORTERM.
+func buildEnv(parent, extra []string) []string {
+	env := make([]string, 0, len(parent)+len(extra)+2)
+	index := make(map[string]int, cap(env))
+	add := func(kv string) {
+		key, _, _ := strings.Cut(kv, "=")
+		if runtime.GOOS == "windows" {
+			key = strings.ToUpper(key)
+		}
+		if i, ok := index[key]; ok {
+			env[i] = kv
+			return
+		}
+		index[key] = len(env)
+		env = append(env, kv)
+	}
+
+	for _, e := range parent {
+		// CLAUDECODE is set by Claude Code sessions; remove it so nested
+		// Claude instances don't refuse to start.
+		if strings.HasPrefix(e, "CLAUDECODE=") {
+			continue
+		}
+		add(e)
+	}
+	add("TERM=xterm-256color")
+	add("COLORTERM=truecolor")
+	for _, e := range extra {
+		add(e)
+	}
+	return env
+}
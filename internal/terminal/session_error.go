@@ -0,0 +1,70 @@
+package terminal
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Broken sessions – PTY output ended but the process did not exit
+// ---------------------------------------------------------------------------
+
+// brokenGrace is how long a process may take to exit after its PTY stopped
+// delivering output. A Unix PTY reports EIO or EOF as the child exits, so
+// the read loop normally ends just before Wait returns; a process still
+// running after this long is cut off from its terminal (a failed ConPTY
+// pipe, a PTY closed underneath it).
+var brokenGrace = 3 * time.Second
+
+// readEnded is called by readLoop when the PTY read fails with err. If the
+// process has not exited within brokenGrace, the session moves to
+// StatusError with LastError describing err, and Failed is closed.
+func (s *Session) readEnded(err error, done <-chan struct{}) {
+	select {
+	case <-done:
+		return // the process exited; waitLoop reports it
+	default:
+	}
+	timeout := time.After(brokenGrace)
+	go func() {
+		select {
+		case <-done:
+			return
+		case <-timeout:
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.closing || s.Status != StatusRunning {
+			return
+		}
+		s.Status = StatusError
+		s.LastError = readErrorMessage(err)
+		if s.failed != nil {
+			close(s.failed)
+		}
+	}()
+}
+
+// readErrorMessage explains why the terminal stopped working.
+func readErrorMessage(err error) string {
+	if err == nil || errors.Is(err, io.EOF) {
+		return "PTY unexpectedly closed while the process is still running"
+	}
+	return "PTY read failed while the process is still running: " + err.Error()
+}
+
+// Failed is closed when the session moves to StatusError (see LastError).
+// Restart replaces it, like Done.
+func (s *Session) Failed() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.failed
+}
+
+// Err returns the reason the session failed, or "" while it works.
+func (s *Session) Err() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.LastError
+}
@@ -0,0 +1,78 @@
+package terminal
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// breakReadLoop runs readLoop on a pipe that fails with err while done
+// stays open, as when the PTY dies under a live process.
+func breakReadLoop(t *testing.T, sess *Session, err error, done chan struct{}) {
+	t.Helper()
+	old := brokenGrace
+	brokenGrace = 20 * time.Millisecond
+	t.Cleanup(func() { brokenGrace = old })
+
+	r, w := io.Pipe()
+	readDone := make(chan struct{})
+	go sess.readLoop(r, done, readDone)
+	w.CloseWithError(err)
+	<-readDone
+}
+
+func TestReadLoop_BrokenPTYSetsError(t *testing.T) {
+	sess := NewSession(1, 5, 20)
+	breakReadLoop(t, sess, errors.New("pipe has been ended"), make(chan struct{}))
+
+	select {
+	case <-sess.Failed():
+	case <-time.After(time.Second):
+		t.Fatal("Failed was not closed")
+	}
+	if sess.IsRunning() {
+		t.Fatal("a failed session must not report running")
+	}
+	if msg := sess.Err(); !strings.Contains(msg, "pipe has been ended") {
+		t.Fatalf("Err() = %q, want the read error", msg)
+	}
+}
+
+func TestReadLoop_EOFWithLiveProcessSetsError(t *testing.T) {
+	sess := NewSession(1, 5, 20)
+	breakReadLoop(t, sess, io.EOF, make(chan struct{}))
+
+	select {
+	case <-sess.Failed():
+	case <-time.After(time.Second):
+		t.Fatal("Failed was not closed")
+	}
+	if msg := sess.Err(); !strings.Contains(msg, "unexpectedly closed") {
+		t.Fatalf("Err() = %q", msg)
+	}
+}
+
+func TestReadLoop_ExitWithinGraceIsNotAnError(t *testing.T) {
+	sess := NewSession(1, 5, 20)
+	done := make(chan struct{})
+	breakReadLoop(t, sess, errors.New("input/output error"), done)
+	close(done) // the process exits right after its PTY closed
+
+	time.Sleep(3 * brokenGrace)
+	if !sess.IsRunning() || sess.Err() != "" {
+		t.Fatalf("normal exit reported as failure: err %q", sess.Err())
+	}
+}
+
+func TestReadLoop_ClosingIsNotAnError(t *testing.T) {
+	sess := NewSession(1, 5, 20)
+	sess.closing = true
+	breakReadLoop(t, sess, errors.New("file already closed"), make(chan struct{}))
+
+	time.Sleep(3 * brokenGrace)
+	if sess.Err() != "" {
+		t.Fatalf("Close reported as failure: %q", sess.Err())
+	}
+}
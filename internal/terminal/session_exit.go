@@ -0,0 +1,88 @@
+package terminal
+
+import (
+	"os"
+
+	gopty "github.com/aymanbagabas/go-pty"
+)
+
+// ExitReason describes how a session's process ended.
+type ExitReason int
+
+const (
+	// ExitRunning means the process has not exited yet.
+	ExitRunning ExitReason = iota
+	// ExitedNormally means the process exited with code 0.
+	ExitedNormally
+	// ExitedWithError means the process exited with a nonzero code.
+	ExitedWithError
+	// Killed means the session was closed and the process was stopped by
+	// Close or CloseGraceful.
+	Killed
+	// Signaled means the process died from a signal nobody in this app
+	// sent (e.g. SIGSEGV, or kill from another terminal); see ExitSignal.
+	Signaled
+)
+
+// String returns the name used in the terminal:exit event.
+func (r ExitReason) String() string {
+	switch r {
+	case ExitedNormally:
+		return "normal"
+	case ExitedWithError:
+		return "error"
+	case Killed:
+		return "killed"
+	case Signaled:
+		return "signaled"
+	default:
+		return "running"
+	}
+}
+
+// exitReason classifies a finished process. closing is true when the
+// session asked the process to stop; ps may be nil if Wait failed before
+// the process ran.
+func exitReason(ps *os.ProcessState, closing bool) (ExitReason, string) {
+	if closing {
+		return Killed, ""
+	}
+	if ps == nil {
+		return ExitedWithError, ""
+	}
+	if sig, ok := exitSignal(ps); ok {
+		return Signaled, sig
+	}
+	if ps.ExitCode() == 0 {
+		return ExitedNormally, ""
+	}
+	return ExitedWithError, ""
+}
+
+// ExitInfo returns how the process ended and, for Signaled, the signal name.
+func (s *Session) ExitInfo() (ExitReason, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ExitReason, s.ExitSignal
+}
+
+// waitLoop waits for the process to exit and updates the session status.
+// A Wait that fails without a process state is kept in LastError.
+func (s *Session) waitLoop(cmd *gopty.Cmd, done chan struct{}) {
+	err := cmd.Wait()
+	s.mu.Lock()
+	if err != nil {
+		if cmd.ProcessState != nil {
+			s.ExitCode = cmd.ProcessState.ExitCode()
+		} else {
+			s.ExitCode = 1
+			s.LastError = "waiting for the process failed: " + err.Error()
+		}
+	} else {
+		s.ExitCode = 0
+	}
+	s.ExitReason, s.ExitSignal = exitReason(cmd.ProcessState, s.closing)
+	s.Status = StatusExited
+	s.mu.Unlock()
+	close(done)
+}
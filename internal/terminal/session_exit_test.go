@@ -0,0 +1,48 @@
+package terminal
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"testing"
+)
+
+// runState runs a shell snippet and returns its process state.
+func runState(t *testing.T, script string) *os.ProcessState {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("needs sh")
+	}
+	cmd := exec.Command("sh", "-c", script)
+	_ = cmd.Run()
+	return cmd.ProcessState
+}
+
+func TestExitReason_CodeZeroIsNormal(t *testing.T) {
+	if r, _ := exitReason(runState(t, "exit 0"), false); r != ExitedNormally {
+		t.Errorf("reason = %v, want normal", r)
+	}
+}
+
+func TestExitReason_NonzeroCodeIsError(t *testing.T) {
+	if r, _ := exitReason(runState(t, "exit 3"), false); r != ExitedWithError {
+		t.Errorf("reason = %v, want error", r)
+	}
+	if r, _ := exitReason(nil, false); r != ExitedWithError {
+		t.Errorf("reason without process state = %v, want error", r)
+	}
+}
+
+func TestExitReason_SignalIsNamed(t *testing.T) {
+	r, sig := exitReason(runState(t, "kill -SEGV $$"), false)
+	if r != Signaled || sig != "SIGSEGV" {
+		t.Errorf("exitReason = %v %q, want signaled SIGSEGV", r, sig)
+	}
+}
+
+func TestExitReason_ClosingWinsOverSignal(t *testing.T) {
+	r, sig := exitReason(runState(t, "kill -KILL $$"), true)
+	if r != Killed || sig != "" {
+		t.Errorf("exitReason = %v %q, want killed", r, sig)
+	}
+}
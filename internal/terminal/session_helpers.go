@@ -2,7 +2,10 @@ package terminal
 
 import (
 	"os"
+	"regexp"
 	"runtime"
+	"strings"
+	"time"
 )
 
 // defaultShell returns the default shell command for the current OS.
@@ -42,3 +45,141 @@ func (s *Session) DisableKittyKeyboard() {
 		pty.Write([]byte("\x1b[<1u"))
 	}
 }
+
+// UnhandledSequences reports escape sequences the screen parser dropped,
+// keyed by kind and final byte (e.g. "ESC (" → 3, "CSI t" → 1). Useful for
+// triaging rendering bugs caused by unsupported terminal features.
+func (s *Session) UnhandledSequences() map[string]int {
+	return s.Screen.UnhandledSequences()
+}
+
+// Done returns a channel that is closed when the session exits.
+func (s *Session) Done() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.done
+}
+
+// IsRunning reports whether the process is still alive.
+func (s *Session) IsRunning() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Status == StatusRunning
+}
+
+// SetManualName sets a user-chosen pane name that takes precedence over
+// titles the program reports via OSC 0/2. An empty name switches the pane
+// back to following those titles.
+func (s *Session) SetManualName(name string) {
+	s.mu.Lock()
+	s.manualName = name
+	s.mu.Unlock()
+}
+
+// DisplayTitle returns the title to show for the pane and whether it is a
+// manual name. Without a manual name it is the last OSC title, or "" if
+// the program never set one.
+func (s *Session) DisplayTitle() (title string, manual bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.manualName != "" {
+		return s.manualName, true
+	}
+	return s.Title, false
+}
+
+// SetReadOnly locks (true) or unlocks the pane against typed input. The
+// lock is enforced by the caller writing user input (App.WriteToSession);
+// Write itself does not check it.
+func (s *Session) SetReadOnly(ro bool) {
+	s.mu.Lock()
+	s.readOnly = ro
+	s.mu.Unlock()
+}
+
+// ReadOnly reports whether the pane is locked against typed input.
+func (s *Session) ReadOnly() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.readOnly
+}
+
+// SetHighlight sets the pattern whose matches the pane marks in its
+// output; nil clears it. Compile patterns with CompileHighlight.
+func (s *Session) SetHighlight(re *regexp.Regexp) {
+	s.mu.Lock()
+	s.highlight = re
+	s.mu.Unlock()
+}
+
+// Highlight returns the pane's highlight pattern, or nil.
+func (s *Session) Highlight() *regexp.Regexp {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.highlight
+}
+
+// LastOutput returns when the process last produced output.
+func (s *Session) LastOutput() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.LastOutputAt
+}
+
+// GetTokens returns a snapshot of the token/cost info.
+func (s *Session) GetTokens() TokenInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Tokens
+}
+
+// WaitIdle blocks until the session has produced output and then stayed
+// quiet for the given period — typically the shell printing its first
+// prompt — or until max has elapsed. It returns false if the process exited
+// first.
+func (s *Session) WaitIdle(quiet, max time.Duration) bool {
+	deadline := time.Now().Add(max)
+	done := s.Done()
+	ticker := time.NewTicker(quiet / 4)
+	defer ticker.Stop()
+	for {
+		s.mu.Lock()
+		last := s.LastOutputAt
+		s.mu.Unlock()
+		now := time.Now()
+		if (!last.IsZero() && now.Sub(last) >= quiet) || now.After(deadline) {
+			return true
+		}
+		select {
+		case <-done:
+			return false
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReportFocus sends CSI I (focused) or CSI O (unfocused) to the process if
+// it enabled focus reporting; otherwise it does nothing.
+func (s *Session) ReportFocus(focused bool) {
+	if !s.Screen.FocusReporting() {
+		return
+	}
+	seq := "\x1b[O"
+	if focused {
+		seq = "\x1b[I"
+	}
+	_, _ = s.Write([]byte(seq))
+}
+
+// CommandHistory returns the commands the user ran in this session, oldest
+// first, as marked by the shell's OSC 133 prompt sequences.
+func (s *Session) CommandHistory() []string {
+	return s.Screen.CommandHistory()
+}
+
+// AwaitingPassword reports whether the cursor sits on a password prompt
+// (sudo, ssh, gpg), so input typed now is a secret.
+func (s *Session) AwaitingPassword() bool {
+	row, _ := s.Screen.Cursor()
+	return passwordPromptPattern.MatchString(strings.TrimSpace(s.Screen.PlainTextRow(row)))
+}
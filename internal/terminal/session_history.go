@@ -0,0 +1,39 @@
+package terminal
+
+import "strings"
+
+// HistoryBytes turns saved plain-text scrollback into bytes a terminal
+// renders literally: control characters are dropped, so nothing in the
+// text can change modes or be answered, and line breaks become CRLF. A
+// final line break leaves the cursor at the start of a fresh line.
+func HistoryBytes(text string) []byte {
+	text = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if r < 0x20 || r == 0x7f || (r >= 0x80 && r < 0xa0) {
+			return -1
+		}
+		return r
+	}, text)
+	if text == "" {
+		return nil
+	}
+	return []byte(strings.ReplaceAll(text, "\n", "\r\n") + "\r\n")
+}
+
+// RestoreHistory writes saved output into the screen before Start, so the
+// new process continues below it. The text is display-only: it never
+// reaches the process, and cost figures in it are not picked up by
+// ScanTokens.
+func (s *Session) RestoreHistory(text string) {
+	b := HistoryBytes(text)
+	if len(b) == 0 {
+		return
+	}
+	s.Screen.Write(b)
+	gen := s.Screen.Generation()
+	s.mu.Lock()
+	s.tokensGen = gen
+	s.mu.Unlock()
+}
@@ -0,0 +1,113 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KittyKeyboard reports whether the program asked for kitty keyboard
+// encoding (CSI > 1 u), so keys should go through KeyToKittyBytes.
+func (s *Session) KittyKeyboard() bool {
+	return s.Screen.KittyKeyboardFlags()&1 != 0
+}
+
+// Kitty modifier bits; the encoded parameter is 1 + their sum.
+const (
+	kittyShift = 1
+	kittyAlt   = 2
+	kittyCtrl  = 4
+	kittySuper = 8
+)
+
+// kittyFunctional maps keys with a CSI <number> u code point.
+var kittyFunctional = map[string]int{
+	"escape":    27,
+	"enter":     13,
+	"tab":       9,
+	"backspace": 127,
+	"space":     32,
+}
+
+// kittyLetter maps keys encoded as CSI 1 ; <mod> <letter>.
+var kittyLetter = map[string]byte{
+	"arrowup":    'A',
+	"arrowdown":  'B',
+	"arrowright": 'C',
+	"arrowleft":  'D',
+	"home":       'H',
+	"end":        'F',
+	"f1":         'P',
+	"f2":         'Q',
+	"f4":         'S',
+}
+
+// kittyTilde maps keys encoded as CSI <number> ; <mod> ~. F3 is here
+// because CSI R would be mistaken for a cursor position report.
+var kittyTilde = map[string]int{
+	"insert":   2,
+	"delete":   3,
+	"pageup":   5,
+	"pagedown": 6,
+	"f3":       13,
+	"f5":       15,
+	"f6":       17,
+	"f7":       18,
+	"f8":       19,
+	"f9":       20,
+	"f10":      21,
+	"f11":      23,
+	"f12":      24,
+}
+
+// KeyToKittyBytes encodes a key spec such as "ctrl+enter" or "shift+tab"
+// (see keySpec in the frontend) for the kitty keyboard protocol with the
+// disambiguate flag. ok is false when the legacy bytes are already correct:
+// unmodified keys other than Escape, and text typed with at most Shift.
+func KeyToKittyBytes(spec string) (seq []byte, ok bool) {
+	mod, key := parseKeySpec(spec)
+	if key == "" {
+		return nil, false
+	}
+	param := ""
+	if mod > 0 {
+		param = fmt.Sprintf(";%d", mod+1)
+	}
+	if code, found := kittyFunctional[key]; found {
+		if mod == 0 && key != "escape" {
+			return nil, false
+		}
+		if key == "space" && mod == kittyShift {
+			return nil, false
+		}
+		return fmt.Appendf(nil, "\x1b[%d%su", code, param), true
+	}
+	if mod == 0 {
+		return nil, false
+	}
+	if final, found := kittyLetter[key]; found {
+		return fmt.Appendf(nil, "\x1b[1%s%c", param, final), true
+	}
+	if code, found := kittyTilde[key]; found {
+		return fmt.Appendf(nil, "\x1b[%d%s~", code, param), true
+	}
+	if len(key) == 1 && key[0] > ' ' && key[0] < 0x7f && mod != kittyShift {
+		return fmt.Appendf(nil, "\x1b[%d%su", key[0], param), true
+	}
+	return nil, false
+}
+
+// parseKeySpec splits "ctrl+alt+shift+meta+<key>" into modifier bits and
+// the key name. A bare "+" key is kept ("ctrl++").
+func parseKeySpec(spec string) (mod int, key string) {
+	prefixes := []struct {
+		name string
+		bit  int
+	}{{"ctrl+", kittyCtrl}, {"alt+", kittyAlt}, {"shift+", kittyShift}, {"meta+", kittySuper}}
+	for _, p := range prefixes {
+		if len(spec) > len(p.name) && strings.HasPrefix(spec, p.name) {
+			mod |= p.bit
+			spec = spec[len(p.name):]
+		}
+	}
+	return mod, spec
+}
@@ -0,0 +1,62 @@
+package terminal
+
+import "testing"
+
+func TestKeyToKittyBytes(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"escape", "\x1b[27u"},
+		{"shift+enter", "\x1b[13;2u"},
+		{"ctrl+enter", "\x1b[13;5u"},
+		{"alt+enter", "\x1b[13;3u"},
+		{"shift+tab", "\x1b[9;2u"},
+		{"ctrl+backspace", "\x1b[127;5u"},
+		{"ctrl+space", "\x1b[32;5u"},
+		{"ctrl+a", "\x1b[97;5u"},
+		{"ctrl+shift+a", "\x1b[97;6u"},
+		{"alt+b", "\x1b[98;3u"},
+		{"meta+x", "\x1b[120;9u"},
+		{"ctrl++", "\x1b[43;5u"},
+		{"ctrl+arrowleft", "\x1b[1;5D"},
+		{"alt+arrowright", "\x1b[1;3C"},
+		{"shift+home", "\x1b[1;2H"},
+		{"ctrl+f1", "\x1b[1;5P"},
+		{"shift+f3", "\x1b[13;2~"},
+		{"ctrl+f5", "\x1b[15;5~"},
+		{"ctrl+alt+f12", "\x1b[24;7~"},
+		{"ctrl+delete", "\x1b[3;5~"},
+		{"shift+pageup", "\x1b[5;2~"},
+	}
+	for _, tt := range tests {
+		got, ok := KeyToKittyBytes(tt.spec)
+		if !ok || string(got) != tt.want {
+			t.Errorf("KeyToKittyBytes(%q) = %q, %v; want %q", tt.spec, got, ok, tt.want)
+		}
+	}
+}
+
+func TestKeyToKittyBytes_LegacyKeys(t *testing.T) {
+	// Plain keys and shifted text keep the bytes xterm.js produced
+	for _, spec := range []string{"", "enter", "tab", "a", "shift+a", "shift+space", "arrowup", "f5", "shift+shift", "ctrl+unidentified"} {
+		if got, ok := KeyToKittyBytes(spec); ok {
+			t.Errorf("KeyToKittyBytes(%q) = %q, want legacy encoding", spec, got)
+		}
+	}
+}
+
+func TestSession_KittyKeyboard(t *testing.T) {
+	sess := NewSession(1, 5, 20)
+	if sess.KittyKeyboard() {
+		t.Fatal("kitty keyboard on before the program asked for it")
+	}
+	sess.Screen.Write([]byte("\x1b[>1u"))
+	if !sess.KittyKeyboard() {
+		t.Error("CSI > 1 u did not enable kitty keyboard")
+	}
+	sess.Screen.Write([]byte("\x1b[<u"))
+	if sess.KittyKeyboard() {
+		t.Error("CSI < u did not disable kitty keyboard")
+	}
+}
@@ -0,0 +1,153 @@
+package terminal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ---------------------------------------------------------------------------
+// Mirrors – read-only views of a running session
+// ---------------------------------------------------------------------------
+
+// ErrReadOnly is returned when a mirror is written to or restarted.
+var ErrReadOnly = errors.New("session is a read-only mirror")
+
+// Mirror returns a read-only view of s with its own id, or nil if s has no
+// running process. The mirror shares s.Screen; its Subscribe channels
+// start with a frame of the current screen and then carry every chunk s
+// reads.
+//
+// Input written to the mirror fails with ErrReadOnly and Resize is ignored,
+// so the mirror never changes the process or the shared screen. Closing it
+// detaches it without touching s; when s's process exits the mirror ends
+// with the same exit code.
+func (s *Session) Mirror(id int) *Session {
+	m := &Session{
+		ID:        id,
+		Screen:    s.Screen,
+		Status:    StatusRunning,
+		OutputCh:  make(chan struct{}, 1),
+		done:      make(chan struct{}),
+		readDone:  make(chan struct{}),
+		source:    s,
+		streaming: true, // until detached or the source's output ends
+	}
+	m.signal.ch = m.OutputCh
+	close(m.readDone) // a mirror has no read loop of its own
+
+	s.mirrorMu.Lock()
+	defer s.mirrorMu.Unlock()
+	if !s.streaming {
+		return nil
+	}
+	s.mirrors = append(s.mirrors, m)
+	return m
+}
+
+// IsMirror reports whether the session is a read-only view of another one.
+func (s *Session) IsMirror() bool {
+	return s.source != nil
+}
+
+// screenFrame returns output that repaints sc in an empty terminal: the
+// visible rows with their attributes, then the cursor position.
+func screenFrame(sc *Screen) []byte {
+	rows := strings.ReplaceAll(sc.Render(), "\n", "\r\n")
+	r, c := sc.Cursor()
+	return []byte(fmt.Sprintf("\x1b[H\x1b[2J%s\x1b[%d;%dH", rows, r+1, c+1))
+}
+
+// writeScreen feeds PTY output to the screen, the subscriptions and all
+// mirrors. mirrorMu is held throughout so a new subscription's first frame
+// lines up with the chunks that follow it.
+func (s *Session) writeScreen(chunk []byte) {
+	s.mirrorMu.Lock()
+	defer s.mirrorMu.Unlock()
+	s.resizeMu.Lock()
+	s.Screen.Write(chunk)
+	s.resizeMu.Unlock()
+	s.outputSeen = true
+	s.fanOut(chunk)
+}
+
+// setStreaming records whether a read loop is running, which Mirror and
+// Subscribe need. A new read loop has not read any output yet.
+func (s *Session) setStreaming(on bool) {
+	s.mirrorMu.Lock()
+	s.streaming = on
+	if on {
+		s.outputSeen = false
+	}
+	s.mirrorMu.Unlock()
+}
+
+// fanOut passes chunk to the session's subscriptions and those of every
+// mirror without waiting: the caller holds mirrorMu inside the read loop,
+// and a consumer nobody reads (a mirror not yet attached in the frontend,
+// or a stalled one) must not hold up the source.
+func (s *Session) fanOut(chunk []byte) {
+	s.deliver(chunk, s.Screen)
+	for _, m := range s.mirrors {
+		m.deliver(chunk, s.Screen)
+	}
+}
+
+// detachMirror removes m from s and ends it as Killed.
+func (s *Session) detachMirror(m *Session) {
+	m.finish(0, Killed, "")
+	s.mirrorMu.Lock()
+	defer s.mirrorMu.Unlock()
+	for i, x := range s.mirrors {
+		if x == m {
+			s.mirrors = append(s.mirrors[:i], s.mirrors[i+1:]...)
+			m.endSubscriptions()
+			return
+		}
+	}
+}
+
+// endOutput closes the subscriptions of the session and all mirrors once
+// the read loop of the process with done stops, and ends the mirrors with
+// the source's exit status when done is closed.
+func (s *Session) endOutput(done <-chan struct{}) {
+	s.mu.Lock()
+	stale := s.done != done // Restart gave up waiting for this read loop
+	s.mu.Unlock()
+	if stale {
+		return
+	}
+	s.mirrorMu.Lock()
+	mirrors := s.mirrors
+	s.mirrors = nil
+	s.endSubscriptions()
+	for _, m := range mirrors {
+		m.endSubscriptions()
+	}
+	s.mirrorMu.Unlock()
+	if len(mirrors) == 0 {
+		return
+	}
+	go func() {
+		<-done
+		s.mu.Lock()
+		code, reason, sig := s.ExitCode, s.ExitReason, s.ExitSignal
+		s.mu.Unlock()
+		for _, m := range mirrors {
+			m.finish(code, reason, sig)
+		}
+	}()
+}
+
+// finish marks the mirror as exited and closes its done channel; only the
+// first call has an effect.
+func (s *Session) finish(code int, reason ExitReason, sig string) {
+	s.doneOnce.Do(func() {
+		s.mu.Lock()
+		s.Status = StatusExited
+		s.ExitCode = code
+		s.ExitReason, s.ExitSignal = reason, sig
+		s.mu.Unlock()
+		close(s.done)
+	})
+}
@@ -0,0 +1,202 @@
+package terminal
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// pipedSession runs readLoop on a pipe instead of a PTY. Writes to the
+// returned writer act as process output; closing done ends the "process".
+// src is a subscription to the session's output.
+func pipedSession(t *testing.T) (s *Session, out *io.PipeWriter, done chan struct{}, src <-chan []byte) {
+	t.Helper()
+	s = NewSession(1, 3, 20)
+	r, w := io.Pipe()
+	s.setStreaming(true)
+	src, _ = s.Subscribe()
+	go s.readLoop(r, s.done, s.readDone)
+	t.Cleanup(func() { w.Close() })
+	return s, w, s.done, src
+}
+
+// subscribe subscribes to s and cancels the subscription when the test ends.
+func subscribe(t *testing.T, s *Session) <-chan []byte {
+	t.Helper()
+	ch, cancel := s.Subscribe()
+	t.Cleanup(cancel)
+	return ch
+}
+
+// recv returns the next chunk from ch, failing the test after a second.
+func recv(t *testing.T, ch <-chan []byte) ([]byte, bool) {
+	t.Helper()
+	select {
+	case b, ok := <-ch:
+		return b, ok
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for output")
+		return nil, false
+	}
+}
+
+func TestMirror_FrameThenFollowsSource(t *testing.T) {
+	s, out, _, src := pipedSession(t)
+	out.Write([]byte("hello"))
+	recv(t, src)
+
+	m := s.Mirror(2)
+	if m == nil || m.Screen != s.Screen || !m.IsMirror() {
+		t.Fatalf("Mirror = %+v, want a mirror sharing the screen", m)
+	}
+	mo := subscribe(t, m)
+	frame, _ := recv(t, mo)
+	if !strings.Contains(string(frame), "hello") || !strings.HasSuffix(string(frame), "\x1b[1;6H") {
+		t.Errorf("frame = %q, want the screen and the cursor at 1;6", frame)
+	}
+
+	go out.Write([]byte(" world"))
+	recv(t, src)
+	if b, _ := recv(t, mo); string(b) != " world" {
+		t.Errorf("mirror chunk = %q, want %q", b, " world")
+	}
+}
+
+func TestMirror_IgnoresInputAndResize(t *testing.T) {
+	s, _, _, _ := pipedSession(t)
+	m := s.Mirror(2)
+
+	if _, err := m.Write([]byte("rm -rf ~\r")); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Write error = %v, want ErrReadOnly", err)
+	}
+	m.Resize(10, 40)
+	if s.Screen.Rows() != 3 || s.Screen.Cols() != 20 {
+		t.Errorf("mirror resized the shared screen to %dx%d", s.Screen.Rows(), s.Screen.Cols())
+	}
+	if err := m.Restart(); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Restart error = %v, want ErrReadOnly", err)
+	}
+}
+
+func TestMirror_CloseKeepsSourceRunning(t *testing.T) {
+	s, out, _, src := pipedSession(t)
+	m := s.Mirror(2)
+	mo := subscribe(t, m)
+	recv(t, mo) // frame
+
+	m.Close()
+	if _, ok := recv(t, mo); ok {
+		t.Error("mirror output still open after Close")
+	}
+	select {
+	case <-m.Done():
+	default:
+		t.Error("mirror Done not closed after Close")
+	}
+
+	go out.Write([]byte("still here"))
+	if b, _ := recv(t, src); string(b) != "still here" {
+		t.Errorf("source chunk = %q after closing the mirror", b)
+	}
+	if !s.IsRunning() {
+		t.Error("closing the mirror stopped the source")
+	}
+}
+
+func TestMirror_EndsWithSource(t *testing.T) {
+	s, out, done, src := pipedSession(t)
+	m := s.Mirror(2)
+	mo := subscribe(t, m)
+	recv(t, mo) // frame
+
+	out.Close()
+	recv(t, src) // source channel closed
+	if _, ok := recv(t, mo); ok {
+		t.Error("mirror output still open after the source ended")
+	}
+	s.mu.Lock()
+	s.ExitCode, s.ExitReason = 3, ExitedWithError
+	s.mu.Unlock()
+	close(done)
+
+	select {
+	case <-m.Done():
+	case <-time.After(time.Second):
+		t.Fatal("mirror Done not closed after the source exited")
+	}
+	if reason, _ := m.ExitInfo(); m.ExitCode != 3 || reason != ExitedWithError {
+		t.Errorf("mirror exit = %d %v, want the source's 3 error", m.ExitCode, reason)
+	}
+	if s.Mirror(3) != nil {
+		t.Error("Mirror of an ended session should be nil")
+	}
+}
+
+func TestMirror_NotStarted(t *testing.T) {
+	if m := NewSession(1, 3, 20).Mirror(2); m != nil {
+		t.Error("Mirror of a session without a process should be nil")
+	}
+}
+
+func TestMirror_UnreadMirrorDoesNotBlockSource(t *testing.T) {
+	old := subscriberBacklog
+	subscriberBacklog = 64
+	t.Cleanup(func() { subscriberBacklog = old })
+
+	s, out, _, src := pipedSession(t)
+	m := s.Mirror(2)
+	mo := subscribe(t, m)
+	go func() {
+		for range src {
+		}
+	}()
+
+	// Nobody reads the mirror: far more output than its backlog holds
+	// must still reach the source's screen.
+	written := make(chan struct{})
+	go func() {
+		for i := 0; i < 4*subscriberBacklog; i++ {
+			out.Write([]byte("x"))
+		}
+		out.Write([]byte("\r\nlast"))
+		close(written)
+	}()
+	select {
+	case <-written:
+	case <-time.After(2 * time.Second):
+		t.Fatal("source blocked on a mirror nobody reads")
+	}
+
+	// Wait until the last chunk has been fanned out (the screen is written
+	// under mirrorMu), then let the mirror catch up.
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		s.mirrorMu.Lock()
+		done := strings.Contains(s.Screen.Render(), "last")
+		s.mirrorMu.Unlock()
+		if done {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("last chunk never reached the screen")
+		}
+	}
+	go out.Write([]byte("!"))
+
+	// The dropped output was replaced by frames: replaying what the mirror
+	// received paints the same screen as the source.
+	replay := NewScreen(3, 20)
+	total := 0
+	for !strings.Contains(replay.Render(), "last!") {
+		b, _ := recv(t, mo)
+		total += len(b)
+		replay.Write(b)
+	}
+	if total >= 4*subscriberBacklog {
+		t.Errorf("mirror received %d bytes, want the backlog dropped", total)
+	}
+	if got, want := replay.Render(), s.Screen.Render(); got != want {
+		t.Errorf("replayed mirror output = %q, want %q", got, want)
+	}
+}
@@ -2,7 +2,33 @@
 
 package terminal
 
-import gopty "github.com/aymanbagabas/go-pty"
+import (
+	"os"
+	"syscall"
+
+	gopty "github.com/aymanbagabas/go-pty"
+	"golang.org/x/sys/unix"
+)
 
 // hidePTYConsole is a no-op on non-Windows platforms.
 func hidePTYConsole(_ *gopty.Cmd) {}
+
+// terminateProcess sends SIGHUP, as a terminal emulator does when its window
+// closes (interactive shells ignore SIGTERM but save history on SIGHUP),
+// followed by SIGTERM for programs that ignore hangups.
+func terminateProcess(p *os.Process) error {
+	if err := p.Signal(syscall.SIGHUP); err != nil {
+		return err
+	}
+	_ = p.Signal(syscall.SIGTERM)
+	return nil
+}
+
+// exitSignal reports the signal that terminated the process, if any.
+func exitSignal(ps *os.ProcessState) (string, bool) {
+	ws, ok := ps.Sys().(syscall.WaitStatus)
+	if !ok || !ws.Signaled() {
+		return "", false
+	}
+	return unix.SignalName(ws.Signal()), true
+}
@@ -0,0 +1,60 @@
+package terminal
+
+import (
+	"sync"
+	"time"
+)
+
+// outputSignal rate-limits the OutputCh notification. Every byte read from
+// the PTY is still parsed into the Screen right away; only the "screen
+// changed" signals are merged, so a flood of output (e.g. `yes`) triggers
+// at most one redraw per interval instead of one per read.
+type outputSignal struct {
+	mu       sync.Mutex
+	ch       chan struct{}
+	interval time.Duration
+	last     time.Time
+	timer    *time.Timer // pending trailing signal, nil if none
+}
+
+// notify reports new output at now. Within interval of the previous signal
+// it schedules a single trailing signal instead, so the final state of a
+// burst is always announced.
+func (o *outputSignal) notify(now time.Time) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.timer != nil {
+		return
+	}
+	if wait := o.interval - now.Sub(o.last); wait > 0 {
+		o.timer = time.AfterFunc(wait, o.flush)
+		return
+	}
+	o.last = now
+	o.send()
+}
+
+// flush delivers the trailing signal of a throttled burst.
+func (o *outputSignal) flush() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.timer = nil
+	o.last = time.Now()
+	o.send()
+}
+
+// send signals ch without blocking; an unread signal already covers this one.
+func (o *outputSignal) send() {
+	select {
+	case o.ch <- struct{}{}:
+	default:
+	}
+}
+
+// SetOutputThrottle sets the minimum time between OutputCh signals while
+// output keeps arriving; 0 signals after every read.
+func (s *Session) SetOutputThrottle(d time.Duration) {
+	s.signal.mu.Lock()
+	s.signal.interval = max(d, 0)
+	s.signal.mu.Unlock()
+}
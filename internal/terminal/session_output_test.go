@@ -0,0 +1,108 @@
+package terminal
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Output throttling – every byte is processed, only signals are merged
+// ---------------------------------------------------------------------------
+
+// runReadLoop feeds data through readLoop in 4 KB writes and returns what
+// arrived on a subscription plus the number of OutputCh signals.
+func runReadLoop(t *testing.T, sess *Session, data []byte) ([]byte, int) {
+	t.Helper()
+	r, w := io.Pipe()
+	sess.setStreaming(true)
+	rawOut, _ := sess.Subscribe()
+	go sess.readLoop(r, sess.done, sess.readDone)
+
+	go func() {
+		for off := 0; off < len(data); off += 4096 {
+			_, _ = w.Write(data[off:min(off+4096, len(data))])
+		}
+		w.Close()
+	}()
+
+	var got bytes.Buffer
+	signals := 0
+	for chunk := range rawOut {
+		got.Write(chunk)
+		select {
+		case <-sess.OutputCh:
+			signals++
+		default:
+		}
+	}
+	<-sess.readDone
+	close(sess.done)
+	return got.Bytes(), signals
+}
+
+func floodOutput() []byte {
+	var b bytes.Buffer
+	for i := 0; i < 100000; i++ {
+		fmt.Fprintf(&b, "y %d\r\n", i)
+	}
+	b.WriteString("END")
+	return b.Bytes()
+}
+
+func TestReadLoop_ThrottledFloodKeepsEveryByte(t *testing.T) {
+	sess := NewSession(1, 5, 40)
+	sess.SetOutputThrottle(50 * time.Millisecond)
+	data := floodOutput()
+
+	start := time.Now()
+	got, signals := runReadLoop(t, sess, data)
+	elapsed := time.Since(start)
+
+	if !bytes.Equal(got, data) {
+		t.Fatalf("raw output differs: got %d bytes, want %d", len(got), len(data))
+	}
+	if row := sess.Screen.PlainTextRow(4); row != "END" {
+		t.Errorf("last row = %q, want \"END\"", row)
+	}
+	if limit := int(elapsed/(50*time.Millisecond)) + 2; signals > limit {
+		t.Errorf("%d signals in %v, want at most %d", signals, elapsed, limit)
+	}
+}
+
+func TestOutputSignal_TrailingSignalAfterBurst(t *testing.T) {
+	sess := NewSession(1, 5, 40)
+	sess.SetOutputThrottle(20 * time.Millisecond)
+
+	now := time.Now()
+	sess.signal.notify(now)
+	<-sess.OutputCh
+	sess.signal.notify(now.Add(time.Millisecond))
+	sess.signal.notify(now.Add(2 * time.Millisecond))
+
+	select {
+	case <-sess.OutputCh:
+		t.Fatal("throttled output signalled immediately")
+	default:
+	}
+	select {
+	case <-sess.OutputCh:
+	case <-time.After(time.Second):
+		t.Fatal("no trailing signal after the burst")
+	}
+}
+
+func TestOutputSignal_UnthrottledSignalsEveryRead(t *testing.T) {
+	sess := NewSession(1, 5, 40)
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		sess.signal.notify(now)
+		select {
+		case <-sess.OutputCh:
+		default:
+			t.Fatalf("read %d: no signal without throttle", i)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package terminal
+
+import gopty "github.com/aymanbagabas/go-pty"
+
+// SetCellPixels records the rendered cell size for the XTWINOPS pixel
+// reports and the PTY's window size, which image tools read with
+// TIOCGWINSZ. Unchanged sizes are ignored, so calling it on every fit does
+// not send the program SIGWINCH.
+func (s *Session) SetCellPixels(width, height int) {
+	if s.source != nil {
+		return // the shared screen belongs to the source
+	}
+	s.resizeMu.Lock()
+	defer s.resizeMu.Unlock()
+	if w, h, ok := s.Screen.CellPixels(); ok && w == width && h == height {
+		return
+	}
+	s.Screen.SetCellPixels(width, height)
+	s.resizePTY(s.Screen.Rows(), s.Screen.Cols())
+}
+
+// resizePTY sets the PTY size to rows x cols. Unix PTYs also carry the
+// text area in pixels once the cell size is known; ConPTY has no field for
+// it. Must hold resizeMu.
+func (s *Session) resizePTY(rows, cols int) {
+	s.mu.Lock()
+	pty := s.p
+	s.mu.Unlock()
+	if pty == nil {
+		return
+	}
+	if up, ok := pty.(gopty.UnixPty); ok {
+		ws := &gopty.Winsize{Row: uint16(rows), Col: uint16(cols)}
+		if w, h, known := s.Screen.CellPixels(); known {
+			ws.Xpixel, ws.Ypixel = uint16(min(cols*w, 0xffff)), uint16(min(rows*h, 0xffff))
+		}
+		_ = up.SetWinsize(ws)
+		return
+	}
+	// go-pty uses (width, height) = (cols, rows)
+	_ = pty.Resize(cols, rows)
+}
@@ -0,0 +1,43 @@
+//go:build !windows
+
+package terminal
+
+import (
+	"os"
+	"testing"
+
+	gopty "github.com/aymanbagabas/go-pty"
+	"golang.org/x/sys/unix"
+)
+
+func TestSession_CellPixelsInWinsize(t *testing.T) {
+	sess := NewSession(1, 24, 80)
+	if err := sess.Start([]string{"sh", "-c", "sleep 5"}, os.TempDir(), nil); err != nil {
+		t.Skipf("cannot start a PTY: %v", err)
+	}
+	defer sess.Close()
+	up, ok := sess.p.(gopty.UnixPty)
+	if !ok {
+		t.Skip("not a Unix PTY")
+	}
+	winsize := func() *unix.Winsize {
+		ws, err := unix.IoctlGetWinsize(int(up.Fd()), unix.TIOCGWINSZ)
+		if err != nil {
+			t.Fatalf("TIOCGWINSZ: %v", err)
+		}
+		return ws
+	}
+
+	sess.Resize(10, 40)
+	if ws := winsize(); ws.Row != 10 || ws.Col != 40 || ws.Xpixel != 0 {
+		t.Errorf("before SetCellPixels: winsize = %+v, want 10x40 without pixels", *ws)
+	}
+	sess.SetCellPixels(9, 18)
+	if ws := winsize(); ws.Xpixel != 360 || ws.Ypixel != 180 {
+		t.Errorf("after SetCellPixels: pixels = %dx%d, want 360x180", ws.Xpixel, ws.Ypixel)
+	}
+	sess.Resize(20, 50)
+	if ws := winsize(); ws.Row != 20 || ws.Xpixel != 450 || ws.Ypixel != 360 {
+		t.Errorf("after Resize: winsize = %+v, want 20x50 at 450x360 px", *ws)
+	}
+}
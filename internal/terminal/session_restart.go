@@ -0,0 +1,100 @@
+package terminal
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNotExited is returned by Restart when the session's process is still
+// running (or a restart is already in progress).
+var ErrNotExited = errors.New("session has not exited")
+
+// restartDrainTimeout bounds how long Restart waits for the previous
+// process's read loop to finish after its PTY has been closed.
+const restartDrainTimeout = 2 * time.Second
+
+// Restart spawns a fresh process with the same argv, dir and env into this
+// session after the previous one exited. The screen is cleared, the old
+// output subscriptions are closed and a new done channel is created;
+// consumers must re-subscribe via Done() and Subscribe after Restart
+// returns. Mirrors cannot be restarted.
+func (s *Session) Restart() error {
+	if s.source != nil {
+		return ErrReadOnly
+	}
+	s.mu.Lock()
+	if s.Status != StatusExited || s.restarting {
+		s.mu.Unlock()
+		return ErrNotExited
+	}
+	s.restarting = true
+	oldPty := s.p
+	readDone := s.readDone
+	argv, dir, env := s.argv, s.dir, s.env
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.restarting = false
+		s.mu.Unlock()
+	}()
+
+	// Closing the old PTY unblocks its read loop; wait for it so the old
+	// subscriptions are closed before the new process starts.
+	if oldPty != nil {
+		oldPty.Close()
+	}
+	select {
+	case <-readDone:
+	case <-time.After(restartDrainTimeout):
+	}
+	// A read loop that did not finish in time no longer feeds them
+	s.mirrorMu.Lock()
+	s.endSubscriptions()
+	s.mirrorMu.Unlock()
+
+	s.Screen.Reset()
+
+	s.mu.Lock()
+	s.p = nil
+	s.cmd = nil
+	s.done = make(chan struct{})
+	s.readDone = make(chan struct{})
+	s.failed = make(chan struct{})
+	s.Status = StatusRunning
+	s.ExitCode = 0
+	s.ExitReason, s.ExitSignal = ExitRunning, ""
+	s.closing = false
+	s.LastError = ""
+	s.Title = ""
+	s.Activity = ActivityIdle
+	s.Tokens = TokenInfo{}
+	s.LastResult = ResultUnknown
+	s.tokensGen, s.classifiedGen = 0, 0
+	s.classifiedLine = ""
+	s.mu.Unlock()
+
+	if err := s.Start(argv, dir, env); err != nil {
+		s.abortStart()
+		return err
+	}
+	return nil
+}
+
+// abortStart closes the channels Restart created when the new process
+// could not be started, so Close, Done and output consumers do not wait
+// for a process that never ran.
+func (s *Session) abortStart() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	close(s.done)
+	close(s.readDone)
+	close(s.failed)
+}
+
+// Argv returns the command the session was started with (empty = default shell).
+func (s *Session) Argv() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.argv...)
+}
@@ -0,0 +1,91 @@
+package terminal
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// StateVersion is the version of the ExportState format. ImportState
+// rejects other versions; bump it when the format changes incompatibly.
+const StateVersion = 1
+
+// sessionState is the JSON document written by ExportState.
+type sessionState struct {
+	Version    int         `json:"version"`
+	ExportedAt time.Time   `json:"exported_at"`
+	ID         int         `json:"id"`
+	Title      string      `json:"title,omitempty"`
+	Name       string      `json:"name,omitempty"` // manual pane name
+	Argv       []string    `json:"argv,omitempty"`
+	Dir        string      `json:"dir,omitempty"`
+	Running    bool        `json:"running"`
+	ExitCode   int         `json:"exit_code"`
+	ExitReason ExitReason  `json:"exit_reason"`
+	ExitSignal string      `json:"exit_signal,omitempty"`
+	StartedAt  time.Time   `json:"started_at"`
+	LastOutput time.Time   `json:"last_output_at"`
+	Tokens     TokenInfo   `json:"tokens"`
+	Screen     screenState `json:"screen"`
+}
+
+// ExportState serialises the session's complete terminal state – every
+// screen cell with its style, the cursor, drawing style, scroll region,
+// modes and scrollback – together with its command, directory, exit
+// status and token counts, as versioned JSON. The environment is left out
+// because it may hold API keys. ImportState turns the result back into
+// an inert session, e.g. to move a session between machines or to replay
+// a state attached to a bug report.
+func (s *Session) ExportState() ([]byte, error) {
+	s.mu.Lock()
+	st := sessionState{
+		Version:    StateVersion,
+		ExportedAt: time.Now(),
+		ID:         s.ID,
+		Title:      s.Title,
+		Name:       s.manualName,
+		Argv:       append([]string(nil), s.argv...),
+		Dir:        s.dir,
+		Running:    s.Status == StatusRunning,
+		ExitCode:   s.ExitCode,
+		ExitReason: s.ExitReason,
+		ExitSignal: s.ExitSignal,
+		StartedAt:  s.StartedAt,
+		LastOutput: s.LastOutputAt,
+		Tokens:     s.Tokens,
+	}
+	s.mu.Unlock()
+	st.Screen = s.Screen.snapshotState()
+	return json.Marshal(st)
+}
+
+// ImportState rebuilds a session from ExportState output. The session
+// shows the saved screen and scrollback but runs no process: it counts as
+// exited, writes fail, and Restart starts the saved command afresh.
+func ImportState(data []byte) (*Session, error) {
+	var st sessionState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("session state: %w", err)
+	}
+	if st.Version != StateVersion {
+		return nil, fmt.Errorf("session state: version %d, want %d", st.Version, StateVersion)
+	}
+	screen, err := screenFromState(st.Screen)
+	if err != nil {
+		return nil, fmt.Errorf("session state: %w", err)
+	}
+
+	s := NewSession(st.ID, st.Screen.Rows, st.Screen.Cols)
+	screen.SetResponder(func(reply []byte) { _, _ = s.Write(reply) })
+	s.Screen = screen
+	s.Status = StatusExited
+	s.Title, s.manualName = st.Title, st.Name
+	s.argv, s.dir = st.Argv, st.Dir
+	s.ExitCode, s.ExitReason, s.ExitSignal = st.ExitCode, st.ExitReason, st.ExitSignal
+	s.StartedAt, s.LastOutputAt = st.StartedAt, st.LastOutput
+	s.Tokens = st.Tokens
+	// Nothing runs, so Close and Done must not wait for a process
+	close(s.done)
+	close(s.readDone)
+	return s, nil
+}
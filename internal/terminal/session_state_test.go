@@ -0,0 +1,120 @@
+package terminal
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// busyScreen fills a session with styled text, wide characters, a title,
+// modes, a scroll region and scrollback.
+func busyScreen(t *testing.T) *Session {
+	t.Helper()
+	sess := NewSession(7, 6, 20)
+	sess.Screen.SetReflow(true)
+	for i := 0; i < 12; i++ {
+		sess.Screen.Write([]byte("line " + strings.Repeat("x", i) + "\r\n"))
+	}
+	sess.Screen.Write([]byte(
+		"\x1b]0;build\x07" + // title
+			"\x1b[1;31mred bold\x1b[0m \x1b[38;2;10;20;30;48;5;200mtrue\x1b[0m\r\n" +
+			"wide: 日本語 and a row long enough to wrap around\r\n" +
+			"\x1b[?2004h\x1b[?1004h" + // bracketed paste, focus reports
+			"\x1b[2;5r" + // scroll region
+			"\x1b[4;3H\x1b[4munderlined", // cursor and drawing style
+	))
+	sess.mu.Lock()
+	sess.argv, sess.dir = []string{"bash", "-l"}, "/home/user/project"
+	sess.Title, sess.manualName = "build", "Server"
+	sess.ExitCode, sess.ExitReason = 2, ExitedWithError
+	sess.Tokens = TokenInfo{TotalCost: 1.25, InputTokens: 1500, OutputTokens: 300}
+	sess.mu.Unlock()
+	return sess
+}
+
+func TestExportState_RoundTrip(t *testing.T) {
+	orig := busyScreen(t)
+	data, err := orig.ExportState()
+	if err != nil {
+		t.Fatalf("ExportState: %v", err)
+	}
+	got, err := ImportState(data)
+	if err != nil {
+		t.Fatalf("ImportState: %v", err)
+	}
+
+	rows, cols := orig.Screen.Rows(), orig.Screen.Cols()
+	if got.Screen.Rows() != rows || got.Screen.Cols() != cols {
+		t.Fatalf("size = %dx%d, want %dx%d", got.Screen.Cols(), got.Screen.Rows(), cols, rows)
+	}
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			if a, b := got.Screen.CellAt(r, c), orig.Screen.CellAt(r, c); a != b {
+				t.Fatalf("cell %d,%d = %+v, want %+v", r, c, a, b)
+			}
+		}
+	}
+	if !reflect.DeepEqual(got.Screen.snapshotState(), orig.Screen.snapshotState()) {
+		t.Errorf("screen state differs after the round trip")
+	}
+	if a, b := got.Screen.Export(true), orig.Screen.Export(true); a != b {
+		t.Errorf("Export with scrollback = %q, want %q", a, b)
+	}
+	if !got.Screen.BracketedPaste() || !got.Screen.FocusReporting() {
+		t.Error("modes lost")
+	}
+	name, _ := got.DisplayTitle()
+	if got.ID != 7 || got.Title != "build" || name != "Server" ||
+		!reflect.DeepEqual(got.Argv(), []string{"bash", "-l"}) || got.Tokens.InputTokens != 1500 {
+		t.Errorf("metadata = id %d title %q name %q argv %v tokens %+v", got.ID, got.Title, name, got.Argv(), got.Tokens)
+	}
+
+	// Output after the import continues where the original would
+	orig.Screen.Write([]byte("\x1b[Hmore"))
+	got.Screen.Write([]byte("\x1b[Hmore"))
+	if a, b := got.Screen.Export(true), orig.Screen.Export(true); a != b {
+		t.Errorf("after further output = %q, want %q", a, b)
+	}
+}
+
+func TestImportState_IsInert(t *testing.T) {
+	data, err := busyScreen(t).ExportState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sess, err := ImportState(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sess.IsRunning() {
+		t.Error("imported session counts as running")
+	}
+	if _, err := sess.Write([]byte("ls\r")); !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("Write error = %v, want io.ErrClosedPipe", err)
+	}
+	sess.Close() // must not wait for a process
+}
+
+func TestImportState_Rejects(t *testing.T) {
+	good, err := NewSession(1, 3, 10).ExportState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]string{
+		"not json":      "{",
+		"other version": strings.Replace(string(good), `"version":1`, `"version":99`, 1),
+		"short row":     strings.Replace(string(good), `"t":"          "`, `"t":"   "`, 1),
+		"bad size":      strings.Replace(string(good), `"rows":3`, `"rows":0`, 1),
+		"cursor":        strings.Replace(string(good), `"cursor_row":0`, `"cursor_row":5`, 1),
+	}
+	for name, data := range tests {
+		if data == string(good) {
+			t.Fatalf("%s: test input unchanged", name)
+		}
+		if _, err := ImportState([]byte(data)); err == nil {
+			t.Errorf("%s: ImportState succeeded, want an error", name)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package terminal
+
+import "time"
+
+// Uptime returns how long ago the session's process was started, or 0
+// if it never was (e.g. a mirror).
+func (s *Session) Uptime() time.Duration {
+	s.mu.Lock()
+	started := s.StartedAt
+	s.mu.Unlock()
+	if started.IsZero() {
+		return 0
+	}
+	return time.Since(started)
+}
+
+// IdleFor returns how long the session has produced no output: since its
+// last output, or since the start if there was none yet. It is 0 for a
+// session that was never started.
+func (s *Session) IdleFor() time.Duration {
+	s.mu.Lock()
+	since := s.LastOutputAt
+	if since.IsZero() {
+		since = s.StartedAt
+	}
+	s.mu.Unlock()
+	if since.IsZero() {
+		return 0
+	}
+	return time.Since(since)
+}
+
+// InputIdleFor returns how long no input was written to the session: since
+// the last Write or KeepAlive, or since the start if there was none yet.
+// It is 0 for a session that was never started.
+func (s *Session) InputIdleFor() time.Duration {
+	s.mu.Lock()
+	since := s.LastInputAt
+	if since.IsZero() {
+		since = s.StartedAt
+	}
+	s.mu.Unlock()
+	if since.IsZero() {
+		return 0
+	}
+	return time.Since(since)
+}
+
+// KeepAlive counts as input for InputIdleFor without writing anything, e.g.
+// when the user dismisses an idle-timeout warning.
+func (s *Session) KeepAlive() {
+	s.mu.Lock()
+	s.LastInputAt = time.Now()
+	s.mu.Unlock()
+}
+
+// lastActive returns when the session last had output or input, or its
+// start if it had neither yet.
+func (s *Session) lastActive() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	last := s.StartedAt
+	if s.LastOutputAt.After(last) {
+		last = s.LastOutputAt
+	}
+	if s.LastInputAt.After(last) {
+		last = s.LastInputAt
+	}
+	return last
+}
@@ -0,0 +1,88 @@
+package terminal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionStats_NeverStarted(t *testing.T) {
+	sess := NewSession(1, 24, 80)
+	if u, i := sess.Uptime(), sess.IdleFor(); u != 0 || i != 0 {
+		t.Errorf("Uptime = %v, IdleFor = %v, want 0 before Start", u, i)
+	}
+}
+
+func TestSessionStats_Durations(t *testing.T) {
+	sess := NewSession(1, 24, 80)
+	now := time.Now()
+	sess.mu.Lock()
+	sess.StartedAt = now.Add(-10 * time.Minute)
+	sess.mu.Unlock()
+
+	// No output yet: idle since the start
+	if u := sess.Uptime(); u < 10*time.Minute || u > 11*time.Minute {
+		t.Errorf("Uptime = %v, want about 10m", u)
+	}
+	if i := sess.IdleFor(); i < 10*time.Minute || i > 11*time.Minute {
+		t.Errorf("IdleFor without output = %v, want about 10m", i)
+	}
+
+	sess.mu.Lock()
+	sess.LastOutputAt = now.Add(-30 * time.Second)
+	sess.mu.Unlock()
+	if i := sess.IdleFor(); i < 30*time.Second || i > time.Minute {
+		t.Errorf("IdleFor = %v, want about 30s", i)
+	}
+	if u := sess.Uptime(); u < 10*time.Minute {
+		t.Errorf("Uptime = %v, output must not reset it", u)
+	}
+}
+
+func TestSessionStats_StartSetsStartedAt(t *testing.T) {
+	sess := NewSession(1, 24, 80)
+	before := time.Now()
+	if err := sess.Start([]string{"sh", "-c", "sleep 5"}, t.TempDir(), nil); err != nil {
+		t.Skipf("cannot start a PTY: %v", err)
+	}
+	defer sess.Close()
+	sess.mu.Lock()
+	started := sess.StartedAt
+	sess.mu.Unlock()
+	if started.Before(before) || started.After(time.Now()) {
+		t.Errorf("StartedAt = %v, want the time of Start", started)
+	}
+}
+
+func TestSessionStats_InputIdleFor(t *testing.T) {
+	sess := NewSession(1, 24, 80)
+	if i := sess.InputIdleFor(); i != 0 {
+		t.Errorf("InputIdleFor before Start = %v, want 0", i)
+	}
+	sess.mu.Lock()
+	sess.StartedAt = time.Now().Add(-time.Hour)
+	sess.mu.Unlock()
+	if i := sess.InputIdleFor(); i < time.Hour {
+		t.Errorf("InputIdleFor without input = %v, want since the start", i)
+	}
+	sess.KeepAlive()
+	if i := sess.InputIdleFor(); i > time.Second {
+		t.Errorf("InputIdleFor after KeepAlive = %v, want about 0", i)
+	}
+}
+
+func TestSessionStats_WriteRecordsInput(t *testing.T) {
+	sess := NewSession(1, 24, 80)
+	if err := sess.Start([]string{"sh", "-c", "sleep 5"}, t.TempDir(), nil); err != nil {
+		t.Skipf("cannot start a PTY: %v", err)
+	}
+	defer sess.Close()
+	sess.mu.Lock()
+	sess.StartedAt = time.Now().Add(-time.Hour)
+	sess.mu.Unlock()
+	if _, err := sess.Write([]byte("x")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if i := sess.InputIdleFor(); i > time.Second {
+		t.Errorf("InputIdleFor after Write = %v, want about 0", i)
+	}
+}
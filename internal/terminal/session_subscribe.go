@@ -0,0 +1,157 @@
+package terminal
+
+import "sync"
+
+// ---------------------------------------------------------------------------
+// Output subscriptions – independent consumers of a session's raw output
+// ---------------------------------------------------------------------------
+
+// subscriberBacklog bounds the output a subscription holds for a consumer
+// that does not keep up (4 MB). A var so tests can shrink it.
+var subscriberBacklog = 4 << 20
+
+// subscriber is one Subscribe channel. The read loop appends to pending
+// and never waits; run hands pending to the consumer.
+type subscriber struct {
+	ch   chan []byte
+	wake chan struct{} // pending has data or the output ended
+	quit chan struct{} // closed by cancel
+	once sync.Once
+
+	mu      sync.Mutex
+	pending []byte
+	ended   bool
+}
+
+// Subscribe returns a channel that receives the raw output the session
+// reads from now on (for a mirror: the output of its source), and a cancel
+// func that ends the subscription. Any number of consumers may subscribe;
+// each gets its own channel.
+//
+// A subscription that joins after output has been read, and every
+// subscription of a mirror, starts with a frame of the current screen so
+// the consumer can paint what it missed. The read loop never waits for a
+// consumer: chunks a slow consumer has not taken yet are merged into one,
+// and once they exceed subscriberBacklog they are replaced by a frame of
+// the screen.
+//
+// The channel is closed after the last output when the process's output
+// ends (also through Close, and before Restart starts a new process, after
+// which consumers subscribe again), when a mirror is closed, or by cancel.
+// Subscribing to a session without a running read loop returns a closed
+// channel.
+func (s *Session) Subscribe() (<-chan []byte, func()) {
+	sub := &subscriber{
+		ch:   make(chan []byte),
+		wake: make(chan struct{}, 1),
+		quit: make(chan struct{}),
+	}
+	mu := s.outputMu()
+	mu.Lock()
+	defer mu.Unlock()
+	if !s.streaming {
+		close(sub.ch)
+		return sub.ch, func() {}
+	}
+	// No chunk can reach the screen while the lock is held, so the frame
+	// and the chunks delivered after it fit together exactly.
+	if s.source != nil || s.outputSeen {
+		sub.offer(screenFrame(s.Screen), s.Screen)
+	}
+	s.subs = append(s.subs, sub)
+	go sub.run()
+	return sub.ch, func() { s.unsubscribe(sub) }
+}
+
+// unsubscribe removes sub and stops it; its channel is closed without
+// delivering what is still pending.
+func (s *Session) unsubscribe(sub *subscriber) {
+	mu := s.outputMu()
+	mu.Lock()
+	for i, x := range s.subs {
+		if x == sub {
+			s.subs = append(s.subs[:i], s.subs[i+1:]...)
+			break
+		}
+	}
+	mu.Unlock()
+	sub.once.Do(func() { close(sub.quit) })
+}
+
+// outputMu returns the lock that guards the session's subscriptions: the
+// source's mirrorMu, which its read loop holds while it delivers a chunk.
+func (s *Session) outputMu() *sync.Mutex {
+	if s.source != nil {
+		return &s.source.mirrorMu
+	}
+	return &s.mirrorMu
+}
+
+// deliver passes chunk to every subscription without waiting, the caller
+// holding outputMu. screen is the screen chunk was written to.
+func (s *Session) deliver(chunk []byte, screen *Screen) {
+	for _, sub := range s.subs {
+		sub.offer(chunk, screen)
+	}
+}
+
+// endSubscriptions closes all subscriptions once they have delivered their
+// pending output and refuses new ones, the caller holding outputMu.
+func (s *Session) endSubscriptions() {
+	for _, sub := range s.subs {
+		sub.mu.Lock()
+		sub.ended = true
+		sub.mu.Unlock()
+		sub.notify()
+	}
+	s.subs = nil
+	s.streaming = false
+}
+
+// offer appends chunk to the pending output. If that grows past
+// subscriberBacklog, the pending output is replaced by a frame of screen,
+// which already shows chunk.
+func (sub *subscriber) offer(chunk []byte, screen *Screen) {
+	sub.mu.Lock()
+	if len(sub.pending)+len(chunk) > subscriberBacklog {
+		sub.pending = screenFrame(screen)
+	} else {
+		sub.pending = append(sub.pending, chunk...)
+	}
+	sub.mu.Unlock()
+	sub.notify()
+}
+
+func (sub *subscriber) notify() {
+	select {
+	case sub.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run sends the pending output to the consumer until the output ends or
+// the subscription is cancelled, then closes the channel.
+func (sub *subscriber) run() {
+	defer close(sub.ch)
+	for {
+		select {
+		case <-sub.wake:
+		case <-sub.quit:
+			return
+		}
+		sub.mu.Lock()
+		data, ended := sub.pending, sub.ended
+		sub.pending = nil
+		sub.mu.Unlock()
+		if len(data) > 0 {
+			select {
+			case sub.ch <- data:
+			case <-sub.quit:
+				return
+			}
+		}
+		if ended {
+			return
+		}
+	}
+}
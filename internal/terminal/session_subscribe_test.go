@@ -0,0 +1,74 @@
+package terminal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSubscribe_TwoConsumers(t *testing.T) {
+	s, out, _, first := pipedSession(t)
+	second, cancel := s.Subscribe()
+
+	go out.Write([]byte("hello"))
+	for i, ch := range []<-chan []byte{first, second} {
+		if b, _ := recv(t, ch); string(b) != "hello" {
+			t.Errorf("subscriber %d got %q, want %q", i+1, b, "hello")
+		}
+	}
+
+	cancel()
+	if _, ok := recv(t, second); ok {
+		t.Error("cancelled subscription still open")
+	}
+	go out.Write([]byte(" again"))
+	if b, _ := recv(t, first); string(b) != " again" {
+		t.Errorf("after cancelling the second subscriber the first got %q", b)
+	}
+
+	out.Close()
+	if _, ok := recv(t, first); ok {
+		t.Error("subscription still open after the output ended")
+	}
+	ch, _ := s.Subscribe()
+	if _, ok := recv(t, ch); ok {
+		t.Error("Subscribe after the output ended returned an open channel")
+	}
+}
+
+func TestSubscribe_LateSubscriberStartsWithFrame(t *testing.T) {
+	s, out, _, src := pipedSession(t)
+	go out.Write([]byte("hello"))
+	recv(t, src)
+
+	late := subscribe(t, s)
+	frame, _ := recv(t, late)
+	if !strings.HasPrefix(string(frame), "\x1b[H\x1b[2J") || !strings.Contains(string(frame), "hello") {
+		t.Errorf("late subscriber got %q, want a frame of the screen", frame)
+	}
+	go out.Write([]byte("!"))
+	if b, _ := recv(t, late); string(b) != "!" {
+		t.Errorf("late subscriber got %q after the frame, want %q", b, "!")
+	}
+}
+
+func TestSubscribe_SlowConsumerGetsMergedOutput(t *testing.T) {
+	s, out, _, src := pipedSession(t)
+	slow := subscribe(t, s)
+
+	// The fast consumer sees every write; the slow one, read afterwards,
+	// gets the same bytes in fewer, merged chunks.
+	var want string
+	for _, w := range []string{"a", "b", "c", "d"} {
+		go out.Write([]byte(w))
+		b, _ := recv(t, src)
+		want += string(b)
+	}
+	got := ""
+	for got != want {
+		b, _ := recv(t, slow)
+		got += string(b)
+		if !strings.HasPrefix(want, got) {
+			t.Fatalf("slow consumer got %q, want %q", got, want)
+		}
+	}
+}
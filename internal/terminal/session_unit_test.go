@@ -1,6 +1,10 @@
 package terminal
 
-import "testing"
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
 
 // ---------------------------------------------------------------------------
 // NewSession – construction tests (no PTY needed)
@@ -42,9 +46,6 @@ func TestNewSession_ChannelsCreated(t *testing.T) {
 	if sess.OutputCh == nil {
 		t.Fatal("OutputCh should not be nil")
 	}
-	if sess.RawOutputCh == nil {
-		t.Fatal("RawOutputCh should not be nil")
-	}
 	if sess.done == nil {
 		t.Fatal("done channel should not be nil")
 	}
@@ -165,3 +166,241 @@ func TestNewSession_LargeScreen(t *testing.T) {
 		t.Fatalf("expected 100x300, got %dx%d", sess.Screen.Rows(), sess.Screen.Cols())
 	}
 }
+
+// ---------------------------------------------------------------------------
+// Restart – state guard tests (no PTY needed)
+// ---------------------------------------------------------------------------
+
+func TestSession_RestartRunningRejected(t *testing.T) {
+	sess := NewSession(1, 10, 40)
+
+	if err := sess.Restart(); err != ErrNotExited {
+		t.Fatalf("expected ErrNotExited for running session, got %v", err)
+	}
+}
+
+func TestSession_RestartInProgressRejected(t *testing.T) {
+	sess := NewSession(1, 10, 40)
+	sess.Status = StatusExited
+	sess.restarting = true
+
+	if err := sess.Restart(); err != ErrNotExited {
+		t.Fatalf("expected ErrNotExited during restart, got %v", err)
+	}
+}
+
+func TestSession_RestartStartFailureDoesNotBlockClose(t *testing.T) {
+	sess := NewSession(1, 10, 40)
+	sess.Status = StatusExited
+	close(sess.readDone)
+	sess.argv = []string{filepath.Join(t.TempDir(), "no-such-program")}
+
+	if err := sess.Restart(); err == nil {
+		sess.Close()
+		t.Skip("platform started the missing program via a wrapper shell")
+	}
+	if sess.Status != StatusError {
+		t.Errorf("status = %v, want StatusError", sess.Status)
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		sess.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close blocked after a failed restart")
+	}
+	ch, _ := sess.Subscribe()
+	if _, ok := recv(t, ch); ok {
+		t.Error("Subscribe returned an open channel after a failed restart")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// WaitIdle – startup command readiness (no PTY needed)
+// ---------------------------------------------------------------------------
+
+func TestSession_WaitIdleAfterQuietOutput(t *testing.T) {
+	sess := NewSession(1, 10, 40)
+	sess.LastOutputAt = time.Now().Add(-time.Second)
+
+	start := time.Now()
+	if !sess.WaitIdle(100*time.Millisecond, 5*time.Second) {
+		t.Fatal("expected WaitIdle to report ready")
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("WaitIdle should return promptly once output has gone quiet")
+	}
+}
+
+func TestSession_WaitIdleTimesOutWithoutOutput(t *testing.T) {
+	sess := NewSession(1, 10, 40)
+
+	start := time.Now()
+	if !sess.WaitIdle(20*time.Millisecond, 100*time.Millisecond) {
+		t.Fatal("expected WaitIdle to give up waiting and report ready")
+	}
+	if time.Since(start) < 100*time.Millisecond {
+		t.Fatal("WaitIdle returned before max without any output")
+	}
+}
+
+func TestSession_WaitIdleExited(t *testing.T) {
+	sess := NewSession(1, 10, 40)
+	close(sess.done)
+
+	if sess.WaitIdle(20*time.Millisecond, time.Second) {
+		t.Fatal("expected WaitIdle to report false for an exited session")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// CurrentDir – falls back to the start directory without a process
+// ---------------------------------------------------------------------------
+
+func TestSession_CurrentDirFallsBackToStartDir(t *testing.T) {
+	sess := NewSession(1, 10, 40)
+	sess.dir = "/srv/project"
+
+	if got := sess.CurrentDir(); got != "/srv/project" {
+		t.Errorf("CurrentDir() = %q, want start dir %q", got, "/srv/project")
+	}
+}
+
+func TestSession_CurrentDirIsCached(t *testing.T) {
+	sess := NewSession(1, 10, 40)
+	sess.dir = "/srv/project"
+	sess.CurrentDir()
+
+	sess.dir = "/elsewhere"
+	if got := sess.CurrentDir(); got != "/srv/project" {
+		t.Errorf("CurrentDir() within TTL = %q, want cached %q", got, "/srv/project")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// buildEnv – per-pane overrides win over inherited variables and defaults
+// ---------------------------------------------------------------------------
+
+func TestBuildEnv_ExtraOverridesParentAndDefaults(t *testing.T) {
+	parent := []string{"PATH=/bin", "TERM=dumb", "CLAUDECODE=1", "NO_COLOR="}
+	env := buildEnv(parent, []string{"TERM=xterm", "NO_COLOR=1", "ANTHROPIC_API_KEY=sk-test"})
+
+	want := []string{"PATH=/bin", "TERM=xterm", "NO_COLOR=1", "COLORTERM=truecolor", "ANTHROPIC_API_KEY=sk-test"}
+	if len(env) != len(want) {
+		t.Fatalf("env = %v, want %v", env, want)
+	}
+	for i := range want {
+		if env[i] != want[i] {
+			t.Errorf("env[%d] = %q, want %q", i, env[i], want[i])
+		}
+	}
+}
+
+func TestBuildEnv_DefaultsWithoutExtra(t *testing.T) {
+	env := buildEnv([]string{"TERM=dumb"}, nil)
+	if len(env) != 2 || env[0] != "TERM=xterm-256color" || env[1] != "COLORTERM=truecolor" {
+		t.Errorf("env = %v, want TERM default to replace the inherited value", env)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// DisplayTitle – manual names take precedence over OSC titles
+// ---------------------------------------------------------------------------
+
+func TestDisplayTitle_ManualOverridesOSC(t *testing.T) {
+	s := NewSession(1, 5, 20)
+	if title, manual := s.DisplayTitle(); title != "" || manual {
+		t.Fatalf("new session title = %q (manual %v), want empty", title, manual)
+	}
+
+	s.mu.Lock()
+	s.Title = "vim main.go"
+	s.mu.Unlock()
+	if title, manual := s.DisplayTitle(); title != "vim main.go" || manual {
+		t.Errorf("OSC title = %q (manual %v), want \"vim main.go\"", title, manual)
+	}
+
+	s.SetManualName("Editor")
+	if title, manual := s.DisplayTitle(); title != "Editor" || !manual {
+		t.Errorf("manual title = %q (manual %v), want \"Editor\"", title, manual)
+	}
+
+	s.SetManualName("")
+	if title, manual := s.DisplayTitle(); title != "vim main.go" || manual {
+		t.Errorf("after clearing = %q (manual %v), want the OSC title back", title, manual)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// RestoreHistory – saved scrollback is inert display text
+// ---------------------------------------------------------------------------
+
+func TestRestoreHistory_WritesLinesAndMovesCursorBelow(t *testing.T) {
+	s := NewSession(1, 5, 20)
+	s.RestoreHistory("$ ls\nmain.go")
+
+	if got := s.Screen.PlainTextRow(0); got != "$ ls" {
+		t.Errorf("row 0 = %q, want \"$ ls\"", got)
+	}
+	if got := s.Screen.PlainTextRow(1); got != "main.go" {
+		t.Errorf("row 1 = %q, want \"main.go\"", got)
+	}
+	if row, col := s.Screen.Cursor(); row != 2 || col != 0 {
+		t.Errorf("cursor = (%d,%d), want (2,0)", row, col)
+	}
+}
+
+func TestRestoreHistory_IgnoresEscapeSequences(t *testing.T) {
+	s := NewSession(1, 5, 20)
+	s.RestoreHistory("\x1b[?1049h\x1b[6nhi")
+
+	if got := s.Screen.PlainTextRow(0); got != "[?1049h[6nhi" {
+		t.Errorf("row 0 = %q, want the sequence as literal text", got)
+	}
+	if replies, _ := s.Screen.takeReplies(); len(replies) != 0 {
+		t.Errorf("history produced query replies %q", replies)
+	}
+}
+
+func TestRestoreHistory_CostsAreNotScanned(t *testing.T) {
+	s := NewSession(1, 5, 40)
+	s.RestoreHistory("Total cost: $4.20")
+	s.ScanTokens()
+	if tok := s.GetTokens(); tok.TotalCost != 0 {
+		t.Errorf("restored cost counted: %+v", tok)
+	}
+}
+
+// Resize racing with PTY output must neither race (run with -race) nor
+// leave the screen at a size other than the last one requested.
+func TestSession_ResizeDuringOutput(t *testing.T) {
+	sess := NewSession(1, 10, 40)
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		line := []byte("\x1b[2J\x1b[Hhello world, a line long enough to wrap at small sizes\r\n")
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				sess.writeScreen(line)
+			}
+		}
+	}()
+	for i := 0; i < 200; i++ {
+		sess.Resize(5+i%20, 20+i%60)
+	}
+	sess.Resize(12, 50)
+	close(stop)
+	<-done
+
+	if rows, cols := sess.Screen.Rows(), sess.Screen.Cols(); rows != 12 || cols != 50 {
+		t.Errorf("screen size = %dx%d, want 12x50", rows, cols)
+	}
+}
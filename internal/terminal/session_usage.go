@@ -0,0 +1,138 @@
+package terminal
+
+import (
+	"sync"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Resource usage – CPU and memory of a session's process tree
+// ---------------------------------------------------------------------------
+
+// usageMinInterval bounds how often SampleUsage asks the OS; on macOS each
+// sample runs ps, and the scan loop ticks every 200 ms while panes are busy.
+const usageMinInterval = time.Second
+
+// procStat is one process from the OS process table. cpu is the CPU time
+// (user + system) it used so far.
+type procStat struct {
+	pid  int
+	ppid int
+	cpu  time.Duration
+	rss  int64
+}
+
+// usageState holds the latest sample of a session's resource usage.
+type usageState struct {
+	mu      sync.Mutex
+	cpu     float64       // percent of one core since the previous sample
+	rss     int64         // resident memory in bytes
+	lastCPU time.Duration // summed CPU time at the last sample
+	lastAt  time.Time
+}
+
+// SampleUsage measures the session's process and all of its descendants,
+// so jobs a shell started in the background count too. The CPU share is
+// averaged since the previous sample and may exceed 100 for programs
+// keeping several cores busy. Calls within usageMinInterval of the last
+// sample, and platforms without support, leave the last values in place.
+func (s *Session) SampleUsage(now time.Time) {
+	s.mu.Lock()
+	pid := 0
+	if s.cmd != nil && s.cmd.Process != nil && s.Status == StatusRunning {
+		pid = s.cmd.Process.Pid
+	}
+	s.mu.Unlock()
+
+	u := &s.usage
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if pid == 0 {
+		u.cpu, u.rss, u.lastCPU, u.lastAt = 0, 0, 0, time.Time{}
+		return
+	}
+	if !u.lastAt.IsZero() && now.Sub(u.lastAt) < usageMinInterval {
+		return
+	}
+	procs, err := listProcesses()
+	if err != nil {
+		return
+	}
+	cpu, rss := treeUsage(pid, procs)
+	u.cpu = 0
+	if elapsed := now.Sub(u.lastAt); !u.lastAt.IsZero() && elapsed > 0 && cpu >= u.lastCPU {
+		u.cpu = float64(cpu-u.lastCPU) / float64(elapsed) * 100
+	}
+	u.rss, u.lastCPU, u.lastAt = rss, cpu, now
+}
+
+// ResourceUsage returns the latest SampleUsage result: CPU percent and
+// resident memory in bytes. Both are zero before the first sample, once
+// the process exited, and where the platform offers no process table.
+func (s *Session) ResourceUsage() (cpuPercent float64, rssBytes int64) {
+	s.usage.mu.Lock()
+	defer s.usage.mu.Unlock()
+	return s.usage.cpu, s.usage.rss
+}
+
+// HasChildProcesses reports whether the session's process has started
+// other processes that still run, such as an editor or a build in a shell.
+// It is false once the process exited and where the platform offers no
+// process table.
+func (s *Session) HasChildProcesses() bool {
+	s.mu.Lock()
+	pid := 0
+	if s.cmd != nil && s.cmd.Process != nil && s.Status == StatusRunning {
+		pid = s.cmd.Process.Pid
+	}
+	s.mu.Unlock()
+	if pid == 0 {
+		return false
+	}
+	procs, err := listProcesses()
+	if err != nil {
+		return false
+	}
+	return hasChild(pid, procs)
+}
+
+// hasChild reports whether any process in procs has parent as its parent.
+func hasChild(parent int, procs []procStat) bool {
+	for _, p := range procs {
+		if p.ppid == parent {
+			return true
+		}
+	}
+	return false
+}
+
+// treeUsage sums CPU time and memory of root and its descendants in procs.
+// A root missing from procs (it just exited) yields zeros.
+func treeUsage(root int, procs []procStat) (cpu time.Duration, rss int64) {
+	children := make(map[int][]int, len(procs))
+	byPID := make(map[int]procStat, len(procs))
+	for _, p := range procs {
+		byPID[p.pid] = p
+		if p.pid != p.ppid {
+			children[p.ppid] = append(children[p.ppid], p.pid)
+		}
+	}
+	if _, ok := byPID[root]; !ok {
+		return 0, 0
+	}
+	seen := map[int]bool{root: true}
+	queue := []int{root}
+	for len(queue) > 0 {
+		p := byPID[queue[0]]
+		queue = queue[1:]
+		cpu += p.cpu
+		rss += p.rss
+		for _, c := range children[p.pid] {
+			if !seen[c] {
+				seen[c] = true
+				queue = append(queue, c)
+			}
+		}
+	}
+	return cpu, rss
+}
@@ -0,0 +1,13 @@
+package terminal
+
+import "os/exec"
+
+// listProcesses reads the process table from ps. proc_pidinfo would avoid
+// the extra process but needs cgo, which the release builds do without.
+func listProcesses() ([]procStat, error) {
+	out, err := exec.Command("ps", "-A", "-o", "pid=,ppid=,rss=,time=").Output()
+	if err != nil {
+		return nil, err
+	}
+	return parsePSOutput(string(out)), nil
+}
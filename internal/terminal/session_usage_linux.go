@@ -0,0 +1,60 @@
+package terminal
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// clockTicks is USER_HZ, the unit of the CPU times in /proc/<pid>/stat. It
+// is 100 on every Linux architecture Go supports.
+const clockTicks = 100
+
+// listProcesses reads the process table from /proc.
+func listProcesses() ([]procStat, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+	pageSize := int64(os.Getpagesize())
+	procs := make([]procStat, 0, len(entries))
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile("/proc/" + e.Name() + "/stat")
+		if err != nil {
+			continue // exited since ReadDir
+		}
+		if p, ok := parseProcStat(pid, string(data), pageSize); ok {
+			procs = append(procs, p)
+		}
+	}
+	return procs, nil
+}
+
+// parseProcStat parses a /proc/<pid>/stat line. The command name in
+// parentheses may contain spaces, so fields are counted from the last ")".
+func parseProcStat(pid int, line string, pageSize int64) (procStat, bool) {
+	end := strings.LastIndexByte(line, ')')
+	if end < 0 {
+		return procStat{}, false
+	}
+	// fields[0] is the state (field 3 in proc(5))
+	fields := strings.Fields(line[end+1:])
+	if len(fields) < 22 {
+		return procStat{}, false
+	}
+	ppid, _ := strconv.Atoi(fields[1])
+	utime, _ := strconv.ParseInt(fields[11], 10, 64)
+	stime, _ := strconv.ParseInt(fields[12], 10, 64)
+	rss, _ := strconv.ParseInt(fields[21], 10, 64)
+	return procStat{
+		pid:  pid,
+		ppid: ppid,
+		cpu:  time.Duration(utime+stime) * time.Second / clockTicks,
+		rss:  rss * pageSize,
+	}, true
+}
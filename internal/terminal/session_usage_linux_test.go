@@ -0,0 +1,21 @@
+package terminal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseProcStat(t *testing.T) {
+	// The command name may contain spaces and parentheses
+	line := "4242 (my (odd) cmd) S 4000 4242 4242 34816 4242 4194304 100 0 0 0 250 50 0 0 20 0 1 0 12345 10000000 300 18446744073709551615"
+	p, ok := parseProcStat(4242, line, 4096)
+	if !ok {
+		t.Fatal("parseProcStat failed")
+	}
+	if p.ppid != 4000 || p.cpu != 3*time.Second || p.rss != 300*4096 {
+		t.Fatalf("parseProcStat = %+v", p)
+	}
+	if _, ok := parseProcStat(1, "1 (init) S 0", 4096); ok {
+		t.Fatal("expected a short line to be rejected")
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !linux && !darwin && !windows
+
+package terminal
+
+import "errors"
+
+// listProcesses is not supported on this platform; ResourceUsage stays at
+// zero.
+func listProcesses() ([]procStat, error) {
+	return nil, errors.New("process table not supported")
+}
@@ -0,0 +1,58 @@
+package terminal
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parsePSOutput parses `ps -A -o pid=,ppid=,rss=,time=` as printed on
+// macOS: rss in KiB, CPU time as [[dd-]hh:]mm:ss.cc. Malformed lines are
+// skipped.
+func parsePSOutput(out string) []procStat {
+	var procs []procStat
+	for _, line := range strings.Split(out, "\n") {
+		f := strings.Fields(line)
+		if len(f) != 4 {
+			continue
+		}
+		pid, err1 := strconv.Atoi(f[0])
+		ppid, err2 := strconv.Atoi(f[1])
+		rss, err3 := strconv.ParseInt(f[2], 10, 64)
+		cpu, ok := parseCPUTime(f[3])
+		if err1 != nil || err2 != nil || err3 != nil || !ok {
+			continue
+		}
+		procs = append(procs, procStat{pid: pid, ppid: ppid, cpu: cpu, rss: rss << 10})
+	}
+	return procs
+}
+
+// parseCPUTime parses ps CPU times like "0:01.25", "72:03.10" or
+// "1-02:03:04.50".
+func parseCPUTime(s string) (time.Duration, bool) {
+	var days int64
+	if d, rest, ok := strings.Cut(s, "-"); ok {
+		n, err := strconv.ParseInt(d, 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		days, s = n, rest
+	}
+	parts := strings.Split(s, ":")
+	secs, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+	if err != nil || len(parts) > 3 {
+		return 0, false
+	}
+	total := time.Duration(secs * float64(time.Second))
+	unit := time.Minute
+	for i := len(parts) - 2; i >= 0; i-- {
+		n, err := strconv.ParseInt(parts[i], 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		total += time.Duration(n) * unit
+		unit *= 60
+	}
+	return total + time.Duration(days)*24*time.Hour, true
+}
@@ -0,0 +1,98 @@
+package terminal
+
+import (
+	"os"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestTreeUsage_SumsDescendants(t *testing.T) {
+	procs := []procStat{
+		{pid: 1, ppid: 0, cpu: time.Hour, rss: 1 << 30}, // init, not ours
+		{pid: 10, ppid: 1, cpu: time.Second, rss: 100},  // shell
+		{pid: 11, ppid: 10, cpu: 2 * time.Second, rss: 200},
+		{pid: 12, ppid: 11, cpu: 3 * time.Second, rss: 300}, // grandchild
+		{pid: 20, ppid: 1, cpu: time.Minute, rss: 5000},     // sibling
+	}
+	cpu, rss := treeUsage(10, procs)
+	if cpu != 6*time.Second || rss != 600 {
+		t.Fatalf("treeUsage = %v, %d; want 6s, 600", cpu, rss)
+	}
+	if cpu, rss := treeUsage(99, procs); cpu != 0 || rss != 0 {
+		t.Fatalf("missing root = %v, %d; want zeros", cpu, rss)
+	}
+}
+
+func TestHasChild(t *testing.T) {
+	procs := []procStat{
+		{pid: 1, ppid: 0},
+		{pid: 10, ppid: 1},  // shell
+		{pid: 11, ppid: 10}, // vim started by the shell
+		{pid: 20, ppid: 1},  // idle shell
+	}
+	if !hasChild(10, procs) {
+		t.Error("shell running vim: hasChild = false")
+	}
+	if hasChild(20, procs) || hasChild(11, procs) {
+		t.Error("process without children: hasChild = true")
+	}
+}
+
+func TestParsePSOutput(t *testing.T) {
+	out := "  501   1  2048   0:01.50\n" +
+		"  502 501  1024  72:03.10\n" +
+		"  503 501   512 1-02:03:04.00\n" +
+		"garbage line\n"
+	procs := parsePSOutput(out)
+	if len(procs) != 3 {
+		t.Fatalf("parsed %d processes, want 3: %+v", len(procs), procs)
+	}
+	if procs[0].rss != 2048<<10 || procs[0].cpu != 1500*time.Millisecond {
+		t.Errorf("first = %+v", procs[0])
+	}
+	if procs[1].cpu != 72*time.Minute+3100*time.Millisecond {
+		t.Errorf("minutes over an hour = %v", procs[1].cpu)
+	}
+	if want := 26*time.Hour + 3*time.Minute + 4*time.Second; procs[2].cpu != want {
+		t.Errorf("with days = %v, want %v", procs[2].cpu, want)
+	}
+}
+
+func TestSampleUsage_NoProcessIsZero(t *testing.T) {
+	sess := NewSession(1, 5, 20)
+	sess.SampleUsage(time.Now())
+	if cpu, rss := sess.ResourceUsage(); cpu != 0 || rss != 0 {
+		t.Fatalf("ResourceUsage without a process = %v, %d", cpu, rss)
+	}
+}
+
+func TestSampleUsage_RunningSession(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("reads /proc")
+	}
+	sess := NewSession(1, 24, 80)
+	if err := sess.Start([]string{"sh", "-c", "sleep 5"}, os.TempDir(), nil); err != nil {
+		t.Skipf("cannot start a PTY: %v", err)
+	}
+	defer sess.Close()
+
+	// A process that just exec'd can briefly report no resident memory
+	now := time.Now()
+	for i := 0; i < 50; i++ {
+		now = now.Add(usageMinInterval)
+		sess.SampleUsage(now)
+		if _, rss := sess.ResourceUsage(); rss > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, rss := sess.ResourceUsage(); rss <= 0 {
+		t.Fatalf("rss = %d, want the shell's memory", rss)
+	}
+	sess.usage.rss = -1
+	sess.SampleUsage(now.Add(usageMinInterval / 2))
+	if _, rss := sess.ResourceUsage(); rss != -1 {
+		t.Fatal("sample within usageMinInterval should be skipped")
+	}
+}
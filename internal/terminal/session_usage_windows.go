@@ -0,0 +1,71 @@
+package terminal
+
+import (
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var procGetProcessMemoryInfo = windows.NewLazySystemDLL("psapi.dll").NewProc("GetProcessMemoryInfo")
+
+// processMemoryCounters is PROCESS_MEMORY_COUNTERS from psapi.h.
+type processMemoryCounters struct {
+	cb                         uint32
+	PageFaultCount             uint32
+	PeakWorkingSetSize         uintptr
+	WorkingSetSize             uintptr
+	QuotaPeakPagedPoolUsage    uintptr
+	QuotaPagedPoolUsage        uintptr
+	QuotaPeakNonPagedPoolUsage uintptr
+	QuotaNonPagedPoolUsage     uintptr
+	PagefileUsage              uintptr
+	PeakPagefileUsage          uintptr
+}
+
+// listProcesses walks a Toolhelp snapshot for the process tree and reads
+// CPU times and the working set of each process. Processes that cannot be
+// opened (other users, already exited) count with zero usage.
+func listProcesses() ([]procStat, error) {
+	snap, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer windows.CloseHandle(snap)
+
+	var entry windows.ProcessEntry32
+	entry.Size = uint32(unsafe.Sizeof(entry))
+	var procs []procStat
+	for err = windows.Process32First(snap, &entry); err == nil; err = windows.Process32Next(snap, &entry) {
+		p := procStat{pid: int(entry.ProcessID), ppid: int(entry.ParentProcessID)}
+		p.cpu, p.rss = processUsage(entry.ProcessID)
+		procs = append(procs, p)
+	}
+	return procs, nil
+}
+
+// processUsage returns the CPU time and working set of one process.
+func processUsage(pid uint32) (time.Duration, int64) {
+	h, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, pid)
+	if err != nil {
+		return 0, 0
+	}
+	defer windows.CloseHandle(h)
+
+	var cpu time.Duration
+	var created, exited, kernel, user windows.Filetime
+	if windows.GetProcessTimes(h, &created, &exited, &kernel, &user) == nil {
+		// FILETIME durations count 100 ns intervals
+		cpu = time.Duration(filetimeTicks(kernel)+filetimeTicks(user)) * 100
+	}
+	var mem processMemoryCounters
+	mem.cb = uint32(unsafe.Sizeof(mem))
+	if r, _, _ := procGetProcessMemoryInfo.Call(uintptr(h), uintptr(unsafe.Pointer(&mem)), uintptr(mem.cb)); r == 0 {
+		return cpu, 0
+	}
+	return cpu, int64(mem.WorkingSetSize)
+}
+
+func filetimeTicks(ft windows.Filetime) int64 {
+	return int64(ft.HighDateTime)<<32 | int64(ft.LowDateTime)
+}
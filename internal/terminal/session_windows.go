@@ -2,10 +2,26 @@
 
 package terminal
 
-import gopty "github.com/aymanbagabas/go-pty"
+import (
+	"os"
+
+	gopty "github.com/aymanbagabas/go-pty"
+)
 
 // hidePTYConsole is intentionally a no-op on Windows.
 // ConPTY already creates a pseudo-console for the child process; setting
 // CREATE_NO_WINDOW would prevent the process from attaching to it and
 // break terminal I/O entirely.
 func hidePTYConsole(_ *gopty.Cmd) {}
+
+// terminateProcess is not supported on Windows; there is no SIGTERM, and
+// closing the ConPTY (done by Close) is how console programs learn to exit.
+func terminateProcess(_ *os.Process) error {
+	return errNoGracefulStop
+}
+
+// exitSignal always reports false on Windows, where processes end with an
+// exit code only.
+func exitSignal(_ *os.ProcessState) (string, bool) {
+	return "", false
+}
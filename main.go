@@ -5,9 +5,11 @@ package main
 
 import (
 	"embed"
+	"io"
 	"log"
 	"net"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -23,12 +25,15 @@ import (
 //go:embed all:frontend/dist
 var assets embed.FS
 
+// maxPipedInput bounds what --stdin reads; a prompt is never this long.
+const maxPipedInput = 1 << 20
+
 func main() {
 	// If launched via multiterminal: protocol (notification click),
 	// signal the running instance to focus and exit immediately.
 	for _, arg := range os.Args[1:] {
 		if strings.HasPrefix(arg, "multiterminal:") {
-			signalFocus()
+			signalFocus(arg, config.Load().ControlPort)
 			return
 		}
 	}
@@ -41,7 +46,7 @@ func main() {
 	// Enable file logging if configured (persistent or auto-enabled after crashes)
 	backend.InitLoggingFromConfig(cfg)
 
-	app := backend.NewApp(cfg)
+	app := backend.NewApp(cfg, readPipedInput(os.Args[1:]))
 	log.Println("App created, starting Wails...")
 
 	err := wails.Run(&options.App{
@@ -72,12 +77,37 @@ func main() {
 	log.Println("Multiterminal UI exited")
 }
 
-// signalFocus connects to the running instance's focus listener
-// to bring the window to the foreground.
-func signalFocus() {
-	conn, err := net.DialTimeout("tcp", "127.0.0.1:41987", 2*time.Second)
+// signalFocus connects to the running instance's focus listener on port
+// (control_port) to bring the window to the foreground. The URI is sent
+// along so the instance can select the pane it names
+// (multiterminal://focus/session/<id>).
+func signalFocus(uri string, port int) {
+	conn, err := net.DialTimeout("tcp", backend.ControlAddr(port), 2*time.Second)
 	if err != nil {
 		return
 	}
+	_ = conn.SetWriteDeadline(time.Now().Add(2 * time.Second))
+	_, _ = conn.Write([]byte(uri + "\n"))
 	conn.Close()
 }
+
+// readPipedInput returns what was piped into `mtui --stdin`, e.g.
+// `cat prompt.txt | mtui --stdin`; the first pane the user opens gets it
+// typed in. Without
+// the flag, or when stdin is a terminal, stdin is left alone.
+func readPipedInput(args []string) string {
+	if !slices.Contains(args, "--stdin") {
+		return ""
+	}
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice != 0 {
+		log.Println("--stdin: stdin is not a pipe, ignoring it")
+		return ""
+	}
+	data, err := io.ReadAll(io.LimitReader(os.Stdin, maxPipedInput))
+	if err != nil {
+		log.Printf("--stdin: %v", err)
+	}
+	log.Printf("--stdin: read %d bytes for the first pane", len(data))
+	return string(data)
+}